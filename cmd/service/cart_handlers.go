@@ -0,0 +1,255 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	cart "example.com/myapp/cart/application"
+	cartDomain "example.com/myapp/cart/domain"
+	"example.com/myapp/shared/log"
+	"github.com/labstack/echo/v4"
+)
+
+// cartResponse는 가격이 계산된 장바구니를 API 응답 형태로 변환합니다.
+func cartResponse(priced *cart.PricedCart) map[string]interface{} {
+	lines := make([]map[string]interface{}, len(priced.Lines))
+	for i, line := range priced.Lines {
+		lines[i] = map[string]interface{}{
+			"productId": line.Line.ProductID(),
+			"skuId":     line.Line.SKUID(),
+			"name":      line.Name,
+			"quantity":  line.Line.Quantity(),
+			"unitPrice": line.UnitPrice,
+			"subtotal":  line.Subtotal,
+			"available": line.Available,
+		}
+	}
+
+	return map[string]interface{}{
+		"id":       priced.Cart.ID(),
+		"memberId": priced.Cart.MemberID(),
+		"status":   string(priced.Cart.Status()),
+		"orderId":  priced.Cart.OrderID(),
+		"lines":    lines,
+		"total":    priced.Total,
+	}
+}
+
+// cartErrorStatus는 장바구니 오류에 대응하는 HTTP 상태 코드를 반환합니다.
+// 결제 중 주문 모듈에서 발생한 오류는 orderErrorStatus로 판단합니다.
+func cartErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, cartDomain.ErrCartNotFound), errors.Is(err, cartDomain.ErrLineNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, cartDomain.ErrCartNotActive),
+		errors.Is(err, cartDomain.ErrCartConflict),
+		errors.Is(err, cartDomain.ErrCheckoutInProgress):
+		return http.StatusConflict
+	case errors.Is(err, cartDomain.ErrInvalidQuantity),
+		errors.Is(err, cartDomain.ErrInvalidMemberID),
+		errors.Is(err, cartDomain.ErrNotGuestCart),
+		errors.Is(err, cartDomain.ErrCartEmpty),
+		errors.Is(err, cart.ErrInvalidCartID),
+		errors.Is(err, cart.ErrProductNotFound),
+		errors.Is(err, cart.ErrProductUnavailable),
+		errors.Is(err, cart.ErrMemberRequired):
+		return http.StatusBadRequest
+	default:
+		return orderErrorStatus(err)
+	}
+}
+
+// API 핸들러 함수들 - 장바구니
+func createCartHandler(uc cart.CartService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		type request struct {
+			MemberID string `json:"memberId"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		created, err := uc.CreateCart(c.Request().Context(), req.MemberID)
+		if err != nil {
+			logger.Errorw("장바구니 생성 실패", "error", err, "memberId", req.MemberID)
+			return c.JSON(cartErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		priced, err := uc.GetCart(c.Request().Context(), created.ID())
+		if err != nil {
+			logger.Errorw("장바구니 조회 실패", "error", err, "id", created.ID())
+			return c.JSON(cartErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusCreated, cartResponse(priced))
+	}
+}
+
+func getCartHandler(uc cart.CartService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing cart ID"})
+		}
+
+		priced, err := uc.GetCart(c.Request().Context(), id)
+		if err != nil {
+			logger.Errorw("장바구니 조회 실패", "error", err, "id", id)
+			return c.JSON(cartErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, cartResponse(priced))
+	}
+}
+
+func getMemberCartHandler(uc cart.CartService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		memberID := c.Param("memberId")
+		if memberID == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing member ID"})
+		}
+
+		priced, err := uc.GetMemberCart(c.Request().Context(), memberID)
+		if err != nil {
+			logger.Errorw("회원 장바구니 조회 실패", "error", err, "memberId", memberID)
+			return c.JSON(cartErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, cartResponse(priced))
+	}
+}
+
+func addCartItemHandler(uc cart.CartService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing cart ID"})
+		}
+
+		type request struct {
+			ProductID string `json:"productId"`
+			SKUID     string `json:"skuId"`
+			Quantity  int    `json:"quantity"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		priced, err := uc.AddItem(c.Request().Context(), id, cart.AddItemRequest{
+			ProductID: req.ProductID,
+			SKUID:     req.SKUID,
+			Quantity:  req.Quantity,
+		})
+		if err != nil {
+			logger.Errorw("장바구니 담기 실패", "error", err, "id", id, "productId", req.ProductID)
+			return c.JSON(cartErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, cartResponse(priced))
+	}
+}
+
+func updateCartItemHandler(uc cart.CartService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		skuID := c.Param("skuId")
+		if id == "" || skuID == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing cart ID or SKU ID"})
+		}
+
+		type request struct {
+			Quantity int `json:"quantity"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		priced, err := uc.UpdateItem(c.Request().Context(), id, skuID, req.Quantity)
+		if err != nil {
+			logger.Errorw("장바구니 수량 변경 실패", "error", err, "id", id, "skuId", skuID)
+			return c.JSON(cartErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, cartResponse(priced))
+	}
+}
+
+func removeCartItemHandler(uc cart.CartService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		skuID := c.Param("skuId")
+		if id == "" || skuID == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing cart ID or SKU ID"})
+		}
+
+		priced, err := uc.RemoveItem(c.Request().Context(), id, skuID)
+		if err != nil {
+			logger.Errorw("장바구니 상품 제거 실패", "error", err, "id", id, "skuId", skuID)
+			return c.JSON(cartErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, cartResponse(priced))
+	}
+}
+
+func mergeCartHandler(uc cart.CartService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing cart ID"})
+		}
+
+		type request struct {
+			MemberID string `json:"memberId"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		priced, err := uc.MergeGuestCart(c.Request().Context(), id, req.MemberID)
+		if err != nil {
+			logger.Errorw("장바구니 병합 실패", "error", err, "id", id, "memberId", req.MemberID)
+			return c.JSON(cartErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, cartResponse(priced))
+	}
+}
+
+func checkoutCartHandler(uc cart.CartService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing cart ID"})
+		}
+
+		type request struct {
+			CouponCodes []string `json:"couponCodes"`
+		}
+
+		// 본문이 없으면 쿠폰 없이 주문합니다
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		orderID, err := uc.Checkout(c.Request().Context(), id, req.CouponCodes)
+		if err != nil {
+			logger.Errorw("장바구니 결제 실패", "error", err, "id", id)
+			return c.JSON(cartErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"cartId":  id,
+			"orderId": orderID,
+		})
+	}
+}
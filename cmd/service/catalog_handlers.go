@@ -0,0 +1,299 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	catalog "example.com/myapp/catalog/application"
+	catalogDomain "example.com/myapp/catalog/domain"
+	"example.com/myapp/shared/log"
+	"github.com/labstack/echo/v4"
+)
+
+type skuRequest struct {
+	Code  string  `json:"code"`
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+// productResponse는 상품 엔티티를 API 응답 형태로 변환합니다.
+func productResponse(product *catalogDomain.Product) map[string]interface{} {
+	skus := make([]map[string]interface{}, len(product.SKUs()))
+	for i, sku := range product.SKUs() {
+		skus[i] = map[string]interface{}{
+			"id":    sku.ID(),
+			"code":  sku.Code(),
+			"name":  sku.Name(),
+			"price": sku.Price(),
+		}
+	}
+
+	return map[string]interface{}{
+		"id":          product.ID(),
+		"name":        product.Name(),
+		"description": product.Description(),
+		"category":    product.Category(),
+		"taxCategory": product.TaxCategory(),
+		"status":      string(product.Status()),
+		"skus":        skus,
+	}
+}
+
+// catalogErrorStatus는 카탈로그 오류에 대응하는 HTTP 상태 코드를 반환합니다.
+func catalogErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, catalogDomain.ErrProductNotFound), errors.Is(err, catalogDomain.ErrSKUNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, catalogDomain.ErrInvalidProductName),
+		errors.Is(err, catalogDomain.ErrInvalidSKUCode),
+		errors.Is(err, catalogDomain.ErrInvalidPrice),
+		errors.Is(err, catalogDomain.ErrDuplicateSKUCode),
+		errors.Is(err, catalogDomain.ErrInvalidProductState),
+		errors.Is(err, catalog.ErrProductNoSKU):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// API 핸들러 함수들 - 상품 카탈로그
+func createProductHandler(uc catalog.ProductService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		type request struct {
+			Name        string       `json:"name"`
+			Description string       `json:"description"`
+			Category    string       `json:"category"`
+			TaxCategory string       `json:"taxCategory"`
+			SKUs        []skuRequest `json:"skus"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		skus := make([]catalog.SKURequest, len(req.SKUs))
+		for i, sku := range req.SKUs {
+			skus[i] = catalog.SKURequest{
+				Code:  sku.Code,
+				Name:  sku.Name,
+				Price: sku.Price,
+			}
+		}
+
+		product, err := uc.CreateProduct(c.Request().Context(), catalog.CreateProductRequest{
+			Name:        req.Name,
+			Description: req.Description,
+			Category:    req.Category,
+			TaxCategory: req.TaxCategory,
+			SKUs:        skus,
+		})
+		if err != nil {
+			logger.Errorw("상품 생성 실패", "error", err)
+			return c.JSON(catalogErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusCreated, productResponse(product))
+	}
+}
+
+func searchProductsHandler(uc catalog.ProductService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		criteria := catalog.ProductSearchCriteria{
+			Query:    c.QueryParam("q"),
+			Category: c.QueryParam("category"),
+			Status:   catalogDomain.ProductStatus(c.QueryParam("status")),
+		}
+
+		var err error
+		if v := c.QueryParam("minPrice"); v != "" {
+			if criteria.MinPrice, err = strconv.ParseFloat(v, 64); err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid minPrice"})
+			}
+		}
+		if v := c.QueryParam("maxPrice"); v != "" {
+			if criteria.MaxPrice, err = strconv.ParseFloat(v, 64); err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid maxPrice"})
+			}
+		}
+		if v := c.QueryParam("limit"); v != "" {
+			if criteria.Limit, err = strconv.Atoi(v); err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid limit"})
+			}
+		}
+		if v := c.QueryParam("offset"); v != "" {
+			if criteria.Offset, err = strconv.Atoi(v); err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid offset"})
+			}
+		}
+
+		products, err := uc.SearchProducts(c.Request().Context(), criteria)
+		if err != nil {
+			logger.Errorw("상품 검색 실패", "error", err)
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+
+		response := make([]map[string]interface{}, len(products))
+		for i, product := range products {
+			response[i] = productResponse(product)
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+func getProductHandler(uc catalog.ProductService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
+		}
+
+		product, err := uc.GetProduct(c.Request().Context(), id)
+		if err != nil {
+			logger.Errorw("상품 조회 실패", "error", err, "id", id)
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Product not found"})
+		}
+
+		return c.JSON(http.StatusOK, productResponse(product))
+	}
+}
+
+func updateProductHandler(uc catalog.ProductService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
+		}
+
+		type request struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			Category    string `json:"category"`
+			TaxCategory string `json:"taxCategory"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		product, err := uc.UpdateProduct(c.Request().Context(), id, catalog.UpdateProductRequest{
+			Name:        req.Name,
+			Description: req.Description,
+			Category:    req.Category,
+			TaxCategory: req.TaxCategory,
+		})
+		if err != nil {
+			logger.Errorw("상품 업데이트 실패", "error", err, "id", id)
+			return c.JSON(catalogErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, productResponse(product))
+	}
+}
+
+func deleteProductHandler(uc catalog.ProductService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
+		}
+
+		if err := uc.DeleteProduct(c.Request().Context(), id); err != nil {
+			logger.Errorw("상품 삭제 실패", "error", err, "id", id)
+			return c.JSON(catalogErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func archiveProductHandler(uc catalog.ProductService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
+		}
+
+		product, err := uc.ArchiveProduct(c.Request().Context(), id)
+		if err != nil {
+			logger.Errorw("상품 보관 실패", "error", err, "id", id)
+			return c.JSON(catalogErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, productResponse(product))
+	}
+}
+
+func activateProductHandler(uc catalog.ProductService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
+		}
+
+		product, err := uc.ActivateProduct(c.Request().Context(), id)
+		if err != nil {
+			logger.Errorw("상품 판매 재개 실패", "error", err, "id", id)
+			return c.JSON(catalogErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, productResponse(product))
+	}
+}
+
+func addSKUHandler(uc catalog.ProductService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
+		}
+
+		var req skuRequest
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		product, err := uc.AddSKU(c.Request().Context(), id, catalog.SKURequest{
+			Code:  req.Code,
+			Name:  req.Name,
+			Price: req.Price,
+		})
+		if err != nil {
+			logger.Errorw("SKU 추가 실패", "error", err, "id", id)
+			return c.JSON(catalogErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusCreated, productResponse(product))
+	}
+}
+
+func updateSKUHandler(uc catalog.ProductService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		skuID := c.Param("skuId")
+		if id == "" || skuID == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
+		}
+
+		type request struct {
+			Name  string  `json:"name"`
+			Price float64 `json:"price"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		product, err := uc.UpdateSKU(c.Request().Context(), id, skuID, req.Name, req.Price)
+		if err != nil {
+			logger.Errorw("SKU 업데이트 실패", "error", err, "id", id, "skuId", skuID)
+			return c.JSON(catalogErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, productResponse(product))
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	order "example.com/myapp/order/application"
+	"example.com/myapp/shared/log"
+)
+
+// runImportOrdersCommand는 CSV 파일의 주문을 가져오고 결과 보고서를 JSON으로 표준 출력에 씁니다.
+// 가져오지 못한 주문이 있거나 오류가 나면 0이 아닌 종료 코드를 반환합니다.
+//
+//	service import-orders -file orders.csv [-dry-run] [-batch-size 100]
+func runImportOrdersCommand(uc order.OrderService, args []string, logger *log.Logger) int {
+	flags := flag.NewFlagSet("import-orders", flag.ContinueOnError)
+	path := flags.String("file", "", "가져올 주문 CSV 파일 경로")
+	dryRun := flags.Bool("dry-run", false, "검증만 하고 주문을 저장하지 않음")
+	batchSize := flags.Int("batch-size", 0, "한 트랜잭션으로 저장할 주문 수 (기본 100)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *path == "" {
+		fmt.Fprintln(os.Stderr, "import-orders: -file is required")
+		flags.Usage()
+		return 2
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		logger.Errorw("주문 가져오기 파일 열기 실패", "error", err, "file", *path)
+		return 1
+	}
+	defer file.Close()
+
+	result, err := uc.ImportOrders(context.Background(), file, order.ImportOptions{DryRun: *dryRun, BatchSize: *batchSize})
+	if result != nil {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(importResultResponse(result)); encodeErr != nil {
+			logger.Errorw("주문 가져오기 결과 출력 실패", "error", encodeErr)
+		}
+	}
+	if err != nil {
+		logger.Errorw("주문 가져오기 실패", "error", err, "file", *path)
+		return 1
+	}
+
+	logger.Infow("주문 가져오기 완료", "file", *path, "dryRun", *dryRun,
+		"created", result.Created, "valid", result.Valid, "skipped", result.Skipped, "failed", result.Failed)
+	if result.Failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	inventory "example.com/myapp/inventory/application"
+	inventoryDomain "example.com/myapp/inventory/domain"
+	"example.com/myapp/shared/log"
+	"github.com/labstack/echo/v4"
+)
+
+// stockResponse는 재고 엔티티를 API 응답 형태로 변환합니다.
+func stockResponse(item *inventoryDomain.StockItem) map[string]interface{} {
+	return map[string]interface{}{
+		"skuId":       item.SKUID(),
+		"warehouseId": item.WarehouseID(),
+		"onHand":      item.OnHand(),
+		"reserved":    item.Reserved(),
+		"available":   item.Available(),
+	}
+}
+
+// reservationResponse는 재고 예약 엔티티를 API 응답 형태로 변환합니다.
+func reservationResponse(reservation *inventoryDomain.Reservation) map[string]interface{} {
+	lines := make([]map[string]interface{}, len(reservation.Lines()))
+	for i, line := range reservation.Lines() {
+		lines[i] = map[string]interface{}{
+			"skuId":       line.SKUID(),
+			"warehouseId": line.WarehouseID(),
+			"quantity":    line.Quantity(),
+		}
+	}
+
+	return map[string]interface{}{
+		"id":        reservation.ID(),
+		"orderId":   reservation.OrderID(),
+		"status":    string(reservation.Status()),
+		"expiresAt": reservation.ExpiresAt(),
+		"lines":     lines,
+	}
+}
+
+// API 핸들러 함수들 - 재고
+func adjustStockHandler(uc inventory.InventoryService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		skuID := c.Param("skuId")
+		if skuID == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing SKU ID"})
+		}
+
+		type request struct {
+			WarehouseID string `json:"warehouseId"`
+			Delta       int    `json:"delta"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		item, err := uc.AdjustStock(c.Request().Context(), skuID, req.WarehouseID, req.Delta)
+		if err != nil {
+			logger.Errorw("재고 조정 실패", "error", err, "skuId", skuID, "warehouseId", req.WarehouseID)
+			status := http.StatusInternalServerError
+			switch {
+			case errors.Is(err, inventoryDomain.ErrInsufficientStock):
+				status = http.StatusConflict
+			case errors.Is(err, inventoryDomain.ErrInvalidWarehouse), errors.Is(err, inventory.ErrInvalidDelta):
+				status = http.StatusBadRequest
+			}
+			return c.JSON(status, map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, stockResponse(item))
+	}
+}
+
+func getStockHandler(uc inventory.InventoryService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		skuID := c.Param("skuId")
+		if skuID == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing SKU ID"})
+		}
+
+		items, err := uc.GetStock(c.Request().Context(), skuID)
+		if err != nil {
+			logger.Errorw("재고 조회 실패", "error", err, "skuId", skuID)
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+
+		response := make([]map[string]interface{}, len(items))
+		for i, item := range items {
+			response[i] = stockResponse(item)
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+func getReservationHandler(uc inventory.InventoryService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		orderID := c.Param("orderId")
+		if orderID == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing order ID"})
+		}
+
+		reservation, err := uc.GetReservation(c.Request().Context(), orderID)
+		if err != nil {
+			logger.Errorw("재고 예약 조회 실패", "error", err, "orderId", orderID)
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Reservation not found"})
+		}
+
+		return c.JSON(http.StatusOK, reservationResponse(reservation))
+	}
+}
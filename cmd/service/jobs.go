@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"expvar"
+	"time"
+
+	inventory "example.com/myapp/inventory/application"
+	order "example.com/myapp/order/application"
+	payment "example.com/myapp/payment/application"
+	saga "example.com/myapp/saga/application"
+	"example.com/myapp/shared/idempotency"
+	"example.com/myapp/shared/log"
+)
+
+// orderExpiryMetrics는 결제 대기 주문 만료 작업의 누적 지표이며 /api/v1/metrics에서 조회할 수 있습니다.
+var orderExpiryMetrics = expvar.NewMap("order_expiry")
+
+// sagaMetrics는 주문·결제 사가 작업의 누적 지표이며 /api/v1/metrics에서 조회할 수 있습니다.
+var sagaMetrics = expvar.NewMap("sagas")
+
+// runPeriodically는 ctx가 취소될 때까지 interval마다 job을 실행합니다.
+func runPeriodically(ctx context.Context, interval time.Duration, job func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			job(ctx)
+		}
+	}
+}
+
+// releaseExpiredReservationsJob은 결제되지 않은 채 만료된 재고 예약을 해제합니다.
+func releaseExpiredReservationsJob(uc inventory.InventoryService, logger *log.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		released, err := uc.ReleaseExpiredReservations(ctx, time.Now())
+		if err != nil {
+			logger.Errorw("만료 재고 예약 해제 실패", "error", err)
+			return
+		}
+		if released > 0 {
+			logger.Infow("만료 재고 예약 해제", "count", released)
+		}
+	}
+}
+
+// expirePendingOrdersJob은 ttl이 지나도록 결제되지 않은 주문을 취소합니다.
+func expirePendingOrdersJob(uc order.OrderService, ttl time.Duration, logger *log.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		orderExpiryMetrics.Add("runs_total", 1)
+		expired, err := uc.ExpirePendingOrders(ctx, time.Now().Add(-ttl))
+		orderExpiryMetrics.Add("expired_total", int64(len(expired)))
+		if err != nil {
+			orderExpiryMetrics.Add("errors_total", 1)
+			logger.Errorw("결제 대기 주문 만료 처리 실패", "error", err, "expired", len(expired))
+			return
+		}
+		if len(expired) > 0 {
+			logger.Infow("결제 대기 주문 만료", "count", len(expired))
+		}
+	}
+}
+
+// voidExpiredAuthorizationsJob은 기한 안에 매입되지 않은 카드 가승인을 취소합니다.
+// 사가로 감싼 결제 서비스를 받으면 결제 금액이 빈 결제 완료 주문은 사가가 취소합니다.
+func voidExpiredAuthorizationsJob(uc payment.PaymentService, logger *log.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		voided, err := uc.VoidExpiredAuthorizations(ctx, time.Now())
+		if err != nil {
+			logger.Errorw("만료 가승인 취소 실패", "error", err, "voided", len(voided))
+			return
+		}
+		if len(voided) > 0 {
+			logger.Infow("만료 가승인 취소", "count", len(voided))
+		}
+	}
+}
+
+// retryFailedWebhooksJob은 결제에 반영하지 못한 게이트웨이 웹훅 이벤트를 다시 처리합니다.
+func retryFailedWebhooksJob(uc payment.WebhookService, logger *log.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		processed, err := uc.RetryFailedWebhooks(ctx, time.Now())
+		if err != nil {
+			logger.Errorw("결제 웹훅 재처리 실패", "error", err, "processed", processed)
+			return
+		}
+		if processed > 0 {
+			logger.Infow("결제 웹훅 재처리", "count", processed)
+		}
+	}
+}
+
+// reconcileProcessingPaymentsJob은 stuckAfter가 지나도록 처리 중인 결제를 게이트웨이에 조회해 마무리합니다.
+func reconcileProcessingPaymentsJob(uc payment.PaymentService, stuckAfter time.Duration, logger *log.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		reconciled, err := uc.ReconcileProcessingPayments(ctx, time.Now().Add(-stuckAfter))
+		if err != nil {
+			logger.Errorw("처리 중 결제 마무리 실패", "error", err, "reconciled", reconciled)
+			return
+		}
+		if reconciled > 0 {
+			logger.Infow("처리 중 결제 마무리", "count", reconciled)
+		}
+	}
+}
+
+// reconcilePendingRefundsJob은 stuckAfter가 지나도록 진행 중인 환불을 게이트웨이에 조회해 마무리합니다.
+func reconcilePendingRefundsJob(uc payment.PaymentService, stuckAfter time.Duration, logger *log.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		reconciled, err := uc.ReconcilePendingRefunds(ctx, time.Now().Add(-stuckAfter))
+		if err != nil {
+			logger.Errorw("진행 중 환불 마무리 실패", "error", err, "reconciled", reconciled)
+			return
+		}
+		if reconciled > 0 {
+			logger.Infow("진행 중 환불 마무리", "count", reconciled)
+		}
+	}
+}
+
+// resumeSagasJob은 실패한 단계를 다시 실행할 시간이 되었거나 진행 도중 중단된 사가를 이어서 진행합니다.
+func resumeSagasJob(uc saga.SagaService, logger *log.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		sagaMetrics.Add("resume_runs_total", 1)
+		resumed, err := uc.ResumeSagas(ctx, time.Now())
+		sagaMetrics.Add("resumed_total", int64(resumed))
+		if err != nil {
+			sagaMetrics.Add("errors_total", 1)
+			logger.Errorw("사가 재개 실패", "error", err, "resumed", resumed)
+			return
+		}
+		if resumed > 0 {
+			logger.Infow("사가 재개", "count", resumed)
+		}
+	}
+}
+
+// sweepOrderSagasJob은 최근 window 동안의 주문을 확인하여 시작되지 못한 사가를 시작합니다.
+func sweepOrderSagasJob(uc saga.SagaService, window time.Duration, logger *log.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		sagaMetrics.Add("sweep_runs_total", 1)
+		started, err := uc.SweepOrders(ctx, time.Now().Add(-window))
+		sagaMetrics.Add("swept_total", int64(started))
+		if err != nil {
+			sagaMetrics.Add("errors_total", 1)
+			logger.Errorw("사가 점검 실패", "error", err, "started", started)
+			return
+		}
+		if started > 0 {
+			logger.Infow("놓친 사가 시작", "count", started)
+		}
+	}
+}
+
+// deleteExpiredIdempotencyKeysJob은 보관 기간이 지난 멱등성 기록을 지웁니다.
+func deleteExpiredIdempotencyKeysJob(store idempotency.Store, logger *log.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		deleted, err := store.DeleteExpired(ctx, time.Now())
+		if err != nil {
+			logger.Errorw("만료 멱등성 키 삭제 실패", "error", err)
+			return
+		}
+		if deleted > 0 {
+			logger.Infow("만료 멱등성 키 삭제", "count", deleted)
+		}
+	}
+}
@@ -2,38 +2,50 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"expvar"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	cart "example.com/myapp/cart/application"
+	cartInfra "example.com/myapp/cart/infrastructure"
+	catalog "example.com/myapp/catalog/application"
+	catalogInfra "example.com/myapp/catalog/infrastructure"
+	inventory "example.com/myapp/inventory/application"
+	inventoryInfra "example.com/myapp/inventory/infrastructure"
 	"example.com/myapp/member/application"
+	memberDomain "example.com/myapp/member/domain"
 	memberInfra "example.com/myapp/member/infrastructure"
 	"example.com/myapp/order/application"
+	orderDomain "example.com/myapp/order/domain"
 	orderInfra "example.com/myapp/order/infrastructure"
 	"example.com/myapp/payment/application"
 	paymentInfra "example.com/myapp/payment/infrastructure"
+	promotion "example.com/myapp/promotion/application"
+	promotionInfra "example.com/myapp/promotion/infrastructure"
+	returns "example.com/myapp/returns/application"
+	returnsInfra "example.com/myapp/returns/infrastructure"
+	saga "example.com/myapp/saga/application"
+	sagaInfra "example.com/myapp/saga/infrastructure"
 	"example.com/myapp/shared/db"
+	"example.com/myapp/shared/idempotency"
 	"example.com/myapp/shared/log"
+	shipping "example.com/myapp/shipping/application"
+	shippingInfra "example.com/myapp/shipping/infrastructure"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
 
-// 결제 게이트웨이 모의 구현
-type DummyPaymentGateway struct{}
-
-func (g *DummyPaymentGateway) ProcessPayment(ctx context.Context, payment *payment.domain.Payment) (string, error) {
-	// 실제 구현에서는 외부 결제 API를 호출합니다
-	return fmt.Sprintf("txn_%s", payment.ID()), nil
-}
-
-func (g *DummyPaymentGateway) RefundPayment(ctx context.Context, payment *payment.domain.Payment, reason string) error {
-	// 실제 구현에서는 외부 결제 API를 호출합니다
-	return nil
-}
-
 func main() {
 	// 로거 초기화
 	logger := log.NewLoggerFromEnv()
@@ -49,14 +61,109 @@ func main() {
 
 	// 저장소 초기화
 	memberRepo := memberInfra.NewPostgresMemberRepository(database)
+	productRepo := catalogInfra.NewPostgresProductRepository(database)
+	inventoryRepo := inventoryInfra.NewPostgresInventoryRepository(database)
+	cartRepo := cartInfra.NewPostgresCartRepository(database)
 	orderRepo := orderInfra.NewPostgresOrderRepository(database)
+	couponRepo := promotionInfra.NewPostgresCouponRepository(database)
+	shipmentRepo := shippingInfra.NewPostgresShipmentRepository(database)
+	returnRepo := returnsInfra.NewPostgresReturnRepository(database)
 	paymentRepo := paymentInfra.NewPostgresPaymentRepository(database)
-	paymentGateway := &DummyPaymentGateway{}
+	paymentGateway, err := newGatewaySimulator()
+	if err != nil {
+		logger.Fatalw("결제 게이트웨이 시뮬레이터 설정 오류", "error", err)
+	}
 
 	// 비즈니스 로직 유스케이스 초기화
 	memberUseCase := member.NewMemberUseCase(memberRepo)
-	orderUseCase := order.NewOrderUseCase(orderRepo)
-	paymentUseCase := payment.NewPaymentUseCase(paymentRepo, paymentGateway)
+	productUseCase := catalog.NewProductUseCase(productRepo)
+	// 재고 예약이 먼저 해제되면 결제된 주문의 예약 확정이 실패하므로, 결제 대기 주문은 예약보다 먼저 만료되어야 합니다
+	reservationTTL := getEnvDuration("INVENTORY_RESERVATION_TTL", 30*time.Minute)
+	orderPendingTTL := getEnvDuration("ORDER_PENDING_TTL", 25*time.Minute)
+	if orderPendingTTL > reservationTTL {
+		logger.Fatalw("결제 대기 주문 만료 시간은 재고 예약 유지 시간보다 길 수 없습니다", "pendingTTL", orderPendingTTL, "reservationTTL", reservationTTL)
+	}
+	inventoryUseCase := inventory.NewInventoryUseCase(inventoryRepo, reservationTTL)
+	taxCalculator, err := newTaxCalculator()
+	if err != nil {
+		logger.Fatalw("세율 설정 오류", "error", err)
+	}
+	shippingRater, err := newShippingRater()
+	if err != nil {
+		logger.Fatalw("배송비 설정 오류", "error", err)
+	}
+	promotionUseCase := promotion.NewPromotionUseCase(couponRepo, promotionInfra.NewMemberTierAdapter(memberUseCase))
+	orderUseCase := order.NewOrderUseCase(
+		orderRepo,
+		orderInfra.NewMemberCustomerAdapter(memberUseCase),
+		orderInfra.NewCatalogProductAdapter(productUseCase),
+		orderInfra.NewInventoryStockAdapter(inventoryUseCase),
+		orderInfra.NewPromotionDiscountAdapter(promotionUseCase),
+		taxCalculator,
+		shippingRater,
+		newQuoteSigner(logger),
+	)
+	cartUseCase := cart.NewCartUseCase(
+		cartRepo,
+		cartInfra.NewCatalogPriceAdapter(productUseCase),
+		cartInfra.NewOrderPlacerAdapter(orderUseCase),
+	)
+	paymentUseCase := payment.NewPaymentUseCase(
+		paymentRepo,
+		paymentInfra.NewOrderDirectoryAdapter(orderUseCase),
+		newResilientGateway(paymentGateway),
+		getEnvDuration("PAYMENT_AUTHORIZATION_TTL", 7*24*time.Hour),
+	)
+	// 결제 승인과 주문 취소 결과를 다른 모듈에 반영하는 사가
+	// API, 웹훅, 백그라운드 작업과 배송·반품 모듈은 사가를 시작하도록 감싼 서비스를 사용하고, 사가는 감싸지 않은 서비스로 단계를 실행합니다
+	sagaUseCase := saga.NewSagaUseCase(
+		sagaInfra.NewPostgresSagaRepository(database),
+		sagaInfra.NewOrderSagaAdapter(orderUseCase),
+		sagaInfra.NewPaymentSagaAdapter(paymentUseCase),
+	)
+	onSagaError := func(err error, orderID string) {
+		sagaMetrics.Add("start_errors_total", 1)
+		logger.Errorw("주문·결제 사가 시작 실패", "error", err, "orderId", orderID)
+	}
+	sagaOrderService := sagaInfra.NewSagaOrderService(orderUseCase, sagaUseCase, onSagaError)
+	sagaPaymentService := sagaInfra.NewSagaPaymentService(paymentUseCase, sagaUseCase, onSagaError)
+	shippingUseCase := shipping.NewShippingUseCase(
+		shipmentRepo,
+		shippingInfra.NewOrderFulfillmentAdapter(sagaOrderService),
+		shippingInfra.NewFakeCarrier(os.Getenv("FAKE_CARRIER_WEBHOOK_SECRET")),
+	)
+	webhookProviders, err := newWebhookProviders()
+	if err != nil {
+		logger.Fatalw("결제 웹훅 설정 오류", "error", err)
+	}
+	webhookUseCase := payment.NewWebhookUseCase(
+		paymentInfra.NewPostgresWebhookEventRepository(database),
+		sagaPaymentService,
+		webhookProviders,
+	)
+	reconciliationUseCase := payment.NewReconciliationUseCase(
+		paymentInfra.NewPostgresReconciliationRepository(database),
+		paymentRepo,
+	)
+	returnsUseCase := returns.NewReturnUseCase(
+		returnRepo,
+		returnsInfra.NewOrderReturnsAdapter(sagaOrderService),
+		returnsInfra.NewPaymentRefundAdapter(paymentUseCase),
+	)
+
+	// 주문 가져오기 명령 (service import-orders -file orders.csv)
+	if len(os.Args) > 1 && os.Args[1] == "import-orders" {
+		code := runImportOrdersCommand(orderUseCase, os.Args[2:], logger)
+		database.Close()
+		os.Exit(code)
+	}
+
+	// 정산 대사 명령 (service reconcile-settlement -file settlement.csv -from 2026-10-17)
+	if len(os.Args) > 1 && os.Args[1] == "reconcile-settlement" {
+		code := runReconcileSettlementCommand(reconciliationUseCase, os.Args[2:], logger)
+		database.Close()
+		os.Exit(code)
+	}
 
 	// Echo 인스턴스 생성
 	e := echo.New()
@@ -69,8 +176,33 @@ func main() {
 	// 요청 ID 미들웨어
 	e.Use(middleware.RequestID())
 
+	// 재시도된 주문·결제 요청의 중복 처리를 막는 Idempotency-Key 미들웨어
+	// 잠금 시간은 게이트웨이 호출 한 번의 최악 시간(기본 3회 × 10초와 재시도 대기)을 여러 번 기다리는 핸들러보다 길어야 합니다
+	idempotencyStore := newIdempotencyStore(database, logger)
+	idempotent := idempotency.Middleware(idempotency.Config{
+		Store:       idempotencyStore,
+		TTL:         getEnvDuration("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+		LockTimeout: getEnvDuration("IDEMPOTENCY_LOCK_TIMEOUT", 5*time.Minute),
+		OnError: func(err error, key string) {
+			logger.Errorw("멱등성 키 저장소 오류", "error", err, "key", key)
+		},
+	})
+
 	// API 라우팅 설정
-	setupAPIRoutes(e, memberUseCase, orderUseCase, paymentUseCase, logger)
+	setupAPIRoutes(e, memberUseCase, productUseCase, inventoryUseCase, cartUseCase, promotionUseCase, sagaOrderService, shippingUseCase, returnsUseCase, sagaPaymentService, webhookUseCase, reconciliationUseCase, sagaUseCase, paymentGateway, idempotent, logger)
+
+	// 백그라운드 작업 시작
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go runPeriodically(jobCtx, getEnvDuration("INVENTORY_EXPIRY_INTERVAL", time.Minute), releaseExpiredReservationsJob(inventoryUseCase, logger))
+	go runPeriodically(jobCtx, getEnvDuration("ORDER_EXPIRY_INTERVAL", time.Minute), expirePendingOrdersJob(sagaOrderService, orderPendingTTL, logger))
+	go runPeriodically(jobCtx, getEnvDuration("PAYMENT_AUTHORIZATION_EXPIRY_INTERVAL", 10*time.Minute), voidExpiredAuthorizationsJob(sagaPaymentService, logger))
+	go runPeriodically(jobCtx, getEnvDuration("PAYMENT_WEBHOOK_RETRY_INTERVAL", time.Minute), retryFailedWebhooksJob(webhookUseCase, logger))
+	go runPeriodically(jobCtx, getEnvDuration("PAYMENT_PROCESSING_RECONCILE_INTERVAL", time.Minute), reconcileProcessingPaymentsJob(paymentUseCase, getEnvDuration("PAYMENT_PROCESSING_STUCK_AFTER", 5*time.Minute), logger))
+	go runPeriodically(jobCtx, getEnvDuration("PAYMENT_REFUND_RECONCILE_INTERVAL", time.Minute), reconcilePendingRefundsJob(paymentUseCase, getEnvDuration("PAYMENT_REFUND_STUCK_AFTER", 5*time.Minute), logger))
+	go runPeriodically(jobCtx, getEnvDuration("SAGA_RESUME_INTERVAL", 30*time.Second), resumeSagasJob(sagaUseCase, logger))
+	go runPeriodically(jobCtx, getEnvDuration("SAGA_SWEEP_INTERVAL", 5*time.Minute), sweepOrderSagasJob(sagaUseCase, getEnvDuration("SAGA_SWEEP_WINDOW", 24*time.Hour), logger))
+	go runPeriodically(jobCtx, time.Hour, deleteExpiredIdempotencyKeysJob(idempotencyStore, logger))
 
 	// HTTP 서버 시작
 	port := os.Getenv("PORT")
@@ -94,6 +226,7 @@ func main() {
 	// 종료 신호 대기
 	<-quit
 	logger.Info("서버 종료 중...")
+	stopJobs()
 
 	// Graceful 종료
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -105,12 +238,156 @@ func main() {
 	logger.Info("서버 종료 완료")
 }
 
+// getEnvDuration은 환경 변수에서 기간 값을 읽고, 없거나 잘못된 경우 기본값을 반환합니다.
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvInt는 환경 변수에서 양의 정수 값을 읽고, 없거나 잘못된 경우 기본값을 반환합니다.
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// newIdempotencyStore는 IDEMPOTENCY_STORE 설정에 맞는 멱등성 기록 저장소를 생성합니다.
+// memory는 인스턴스 사이에 기록을 공유하지 않으므로 단일 인스턴스 개발 환경에서만 사용합니다.
+func newIdempotencyStore(database *db.Database, logger *log.Logger) idempotency.Store {
+	switch os.Getenv("IDEMPOTENCY_STORE") {
+	case "memory":
+		logger.Warnw("IDEMPOTENCY_STORE=memory: 멱등성 키가 인스턴스 사이에 공유되지 않습니다")
+		return idempotency.NewMemoryStore()
+	default:
+		return idempotency.NewPostgresStore(database)
+	}
+}
+
+// newGatewaySimulator는 PAYMENT_SIMULATOR_* 환경 변수로 결제 게이트웨이 시뮬레이터를 생성합니다.
+// 설정하지 않으면 지연이나 무작위 오류 없이 테스트 카드 번호에 정해진 결과만 흉내 냅니다.
+func newGatewaySimulator() (*paymentInfra.GatewaySimulator, error) {
+	outcomes, err := paymentInfra.ParseAmountOutcomes(os.Getenv("PAYMENT_SIMULATOR_AMOUNT_OUTCOMES"))
+	if err != nil {
+		return nil, err
+	}
+
+	var failureRate float64
+	if value := os.Getenv("PAYMENT_SIMULATOR_FAILURE_RATE"); value != "" {
+		failureRate, err = strconv.ParseFloat(value, 64)
+		if err != nil || failureRate < 0 || failureRate > 1 {
+			return nil, fmt.Errorf("invalid PAYMENT_SIMULATOR_FAILURE_RATE %q", value)
+		}
+	}
+
+	seed, _ := strconv.ParseInt(os.Getenv("PAYMENT_SIMULATOR_SEED"), 10, 64)
+	return paymentInfra.NewGatewaySimulator(paymentInfra.SimulatorConfig{
+		Latency:         getEnvDuration("PAYMENT_SIMULATOR_LATENCY", 0),
+		LatencyJitter:   getEnvDuration("PAYMENT_SIMULATOR_LATENCY_JITTER", 0),
+		FailureRate:     failureRate,
+		Timeout:         getEnvDuration("PAYMENT_SIMULATOR_TIMEOUT", 30*time.Second),
+		SettlementDelay: getEnvDuration("PAYMENT_SIMULATOR_SETTLEMENT_DELAY", time.Minute),
+		AmountOutcomes:  outcomes,
+		Seed:            seed,
+	}), nil
+}
+
+// newResilientGateway는 PAYMENT_GATEWAY_* 환경 변수로 게이트웨이 호출에 타임아웃, 재시도, 회로 차단기를 더합니다.
+// 호출 지표는 /api/v1/metrics의 payment_gateway에서 조회할 수 있습니다.
+func newResilientGateway(gateway payment.PaymentGateway) *paymentInfra.ResilientGateway {
+	return paymentInfra.NewResilientGateway(gateway, paymentInfra.ResilienceConfig{
+		CallTimeout:      getEnvDuration("PAYMENT_GATEWAY_CALL_TIMEOUT", 10*time.Second),
+		MaxAttempts:      getEnvInt("PAYMENT_GATEWAY_MAX_ATTEMPTS", 3),
+		BaseBackoff:      getEnvDuration("PAYMENT_GATEWAY_BASE_BACKOFF", 200*time.Millisecond),
+		MaxBackoff:       getEnvDuration("PAYMENT_GATEWAY_MAX_BACKOFF", 2*time.Second),
+		FailureThreshold: getEnvInt("PAYMENT_GATEWAY_FAILURE_THRESHOLD", 5),
+		OpenDuration:     getEnvDuration("PAYMENT_GATEWAY_OPEN_DURATION", 30*time.Second),
+		Metrics:          expvar.NewMap("payment_gateway"),
+	})
+}
+
+// newWebhookProviders는 PAYMENT_WEBHOOK_SECRETS에 등록된 PG사별 웹훅 검증기를 생성합니다.
+// 비밀키가 없는 PG사의 웹훅은 받지 않습니다.
+func newWebhookProviders() (map[string]payment.WebhookProvider, error) {
+	secrets, err := paymentInfra.ParseWebhookSecrets(os.Getenv("PAYMENT_WEBHOOK_SECRETS"))
+	if err != nil {
+		return nil, err
+	}
+
+	tolerance := getEnvDuration("PAYMENT_WEBHOOK_TOLERANCE", paymentInfra.DefaultWebhookTolerance)
+	providers := make(map[string]payment.WebhookProvider, len(secrets))
+	for name, secret := range secrets {
+		providers[name] = paymentInfra.NewHMACWebhookProvider(secret, tolerance)
+	}
+	return providers, nil
+}
+
+// newTaxCalculator는 환경 변수 설정에 맞는 세금 계산기를 생성합니다.
+// TAX_RATE_TABLE이 없으면 한국 부가가치세(10%, 가격 포함) 계산기를 사용합니다.
+func newTaxCalculator() (order.TaxCalculator, error) {
+	spec := os.Getenv("TAX_RATE_TABLE")
+	if spec == "" {
+		return orderInfra.NewKoreanVATCalculator(), nil
+	}
+
+	rates, err := orderInfra.ParseTaxRates(spec)
+	if err != nil {
+		return nil, err
+	}
+	inclusive := os.Getenv("TAX_PRICES_EXCLUDE_TAX") != "true"
+	return orderInfra.NewRateTableTaxCalculator(rates, inclusive), nil
+}
+
+// newShippingRater는 SHIPPING_RATE_TABLE 설정으로 배송비 계산기를 생성합니다.
+// SHIPPING_RATE_TABLE이 없으면 모든 국가에 3,000원, 50,000원 이상 주문은 무료 배송을 적용합니다.
+func newShippingRater() (order.ShippingRater, error) {
+	spec := os.Getenv("SHIPPING_RATE_TABLE")
+	if spec == "" {
+		spec = "*=3000/50000"
+	}
+
+	rates, err := orderInfra.ParseShippingRates(spec)
+	if err != nil {
+		return nil, err
+	}
+	return orderInfra.NewFlatShippingRater(rates), nil
+}
+
+// newQuoteSigner는 ORDER_QUOTE_SECRET으로 가격 견적 서명기를 생성합니다.
+// 설정하지 않으면 임의 키를 사용하므로 재시작하거나 다른 인스턴스로 요청이 가면 기존 견적을 쓸 수 없습니다.
+func newQuoteSigner(logger *log.Logger) order.QuoteSigner {
+	secret := []byte(os.Getenv("ORDER_QUOTE_SECRET"))
+	if len(secret) == 0 {
+		logger.Warnw("ORDER_QUOTE_SECRET이 설정되지 않아 임의 키로 주문 견적에 서명합니다")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			logger.Fatalw("견적 서명 키 생성 실패", "error", err)
+		}
+	}
+	return orderInfra.NewHMACQuoteSigner(secret)
+}
+
 // setupAPIRoutes는 API 엔드포인트를 설정합니다.
 func setupAPIRoutes(
 	e *echo.Echo,
 	memberUseCase member.MemberService,
+	productUseCase catalog.ProductService,
+	inventoryUseCase inventory.InventoryService,
+	cartUseCase cart.CartService,
+	promotionUseCase promotion.PromotionService,
 	orderUseCase order.OrderService,
+	shippingUseCase shipping.ShippingService,
+	returnsUseCase returns.ReturnService,
 	paymentUseCase payment.PaymentService,
+	webhookUseCase payment.WebhookService,
+	reconciliationUseCase payment.ReconciliationService,
+	sagaUseCase saga.SagaService,
+	paymentSimulator *paymentInfra.GatewaySimulator,
+	idempotent echo.MiddlewareFunc,
 	logger *log.Logger,
 ) {
 	// API 버전 그룹
@@ -121,28 +398,117 @@ func setupAPIRoutes(
 		return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
 	})
 
+	// 백그라운드 작업과 결제 게이트웨이 호출 지표 (expvar JSON)
+	api.GET("/metrics", echo.WrapHandler(expvar.Handler()))
+
 	// 회원 관련 엔드포인트
 	members := api.Group("/members")
 	members.POST("", createMemberHandler(memberUseCase, logger))
 	members.GET("/:id", getMemberHandler(memberUseCase, logger))
 	members.PUT("/:id", updateMemberHandler(memberUseCase, logger))
+	members.PUT("/:id/tier", changeMemberTierHandler(memberUseCase, logger))
 	members.DELETE("/:id", deleteMemberHandler(memberUseCase, logger))
 
+	// 상품 카탈로그 관련 엔드포인트
+	products := api.Group("/products")
+	products.POST("", createProductHandler(productUseCase, logger))
+	products.GET("", searchProductsHandler(productUseCase, logger))
+	products.GET("/:id", getProductHandler(productUseCase, logger))
+	products.PUT("/:id", updateProductHandler(productUseCase, logger))
+	products.DELETE("/:id", deleteProductHandler(productUseCase, logger))
+	products.POST("/:id/archive", archiveProductHandler(productUseCase, logger))
+	products.POST("/:id/activate", activateProductHandler(productUseCase, logger))
+	products.POST("/:id/skus", addSKUHandler(productUseCase, logger))
+	products.PUT("/:id/skus/:skuId", updateSKUHandler(productUseCase, logger))
+
+	// 재고 관련 엔드포인트
+	stock := api.Group("/inventory")
+	stock.GET("/reservations/:orderId", getReservationHandler(inventoryUseCase, logger))
+	stock.GET("/:skuId", getStockHandler(inventoryUseCase, logger))
+	stock.POST("/:skuId/adjust", adjustStockHandler(inventoryUseCase, logger))
+
+	// 장바구니 관련 엔드포인트
+	carts := api.Group("/carts")
+	carts.POST("", createCartHandler(cartUseCase, logger))
+	carts.GET("/member/:memberId", getMemberCartHandler(cartUseCase, logger))
+	carts.GET("/:id", getCartHandler(cartUseCase, logger))
+	carts.POST("/:id/items", addCartItemHandler(cartUseCase, logger))
+	carts.PUT("/:id/items/:skuId", updateCartItemHandler(cartUseCase, logger))
+	carts.DELETE("/:id/items/:skuId", removeCartItemHandler(cartUseCase, logger))
+	carts.POST("/:id/merge", mergeCartHandler(cartUseCase, logger))
+	carts.POST("/:id/checkout", checkoutCartHandler(cartUseCase, logger))
+
+	// 쿠폰 관련 엔드포인트
+	coupons := api.Group("/coupons")
+	coupons.POST("", createCouponHandler(promotionUseCase, logger))
+	coupons.GET("/:code", getCouponHandler(promotionUseCase, logger))
+	coupons.POST("/:code/deactivate", deactivateCouponHandler(promotionUseCase, logger))
+	coupons.POST("/:code/activate", activateCouponHandler(promotionUseCase, logger))
+
 	// 주문 관련 엔드포인트
 	orders := api.Group("/orders")
-	orders.POST("", createOrderHandler(orderUseCase, logger))
+	orders.POST("", createOrderHandler(orderUseCase, logger), idempotent)
+	orders.POST("/quote", quoteOrderHandler(orderUseCase, logger))
+	orders.GET("", searchOrdersHandler(orderUseCase, logger))
+	orders.GET("/export", exportOrdersHandler(orderUseCase, logger))
+	orders.POST("/import", importOrdersHandler(orderUseCase, logger))
+	orders.POST("/guest", createGuestOrderHandler(orderUseCase, logger))
+	orders.GET("/guest/:id", getGuestOrderHandler(orderUseCase, logger))
+	orders.POST("/guest/:id/cancel", cancelGuestOrderHandler(orderUseCase, logger))
+	orders.POST("/guest/:id/attach", attachGuestOrderHandler(orderUseCase, logger))
+	orders.GET("/transitions", getOrderTransitionsHandler())
 	orders.GET("/:id", getOrderHandler(orderUseCase, logger))
 	orders.GET("/customer/:customerId", getCustomerOrdersHandler(orderUseCase, logger))
 	orders.PUT("/:id/status", updateOrderStatusHandler(orderUseCase, logger))
 	orders.POST("/:id/cancel", cancelOrderHandler(orderUseCase, logger))
+	orders.GET("/:id/history", getOrderHistoryHandler(orderUseCase, logger))
+	orders.POST("/:id/items", addOrderItemHandler(orderUseCase, logger))
+	orders.PUT("/:id/items/:itemId", changeOrderItemHandler(orderUseCase, logger))
+	orders.DELETE("/:id/items/:itemId", removeOrderItemHandler(orderUseCase, logger))
+	orders.POST("/:id/cancel-items", cancelOrderItemsHandler(orderUseCase, logger))
+
+	// 배송 관련 엔드포인트
+	shipments := api.Group("/shipments")
+	shipments.POST("", createShipmentHandler(shippingUseCase, logger))
+	shipments.GET("/:id", getShipmentHandler(shippingUseCase, logger))
+	shipments.GET("/order/:orderId", getOrderShipmentsHandler(shippingUseCase, logger))
+	shipments.POST("/:id/events", recordShipmentEventHandler(shippingUseCase, logger))
+	api.POST("/shipping/webhooks/:carrier", carrierWebhookHandler(shippingUseCase, logger))
+
+	// 반품 관련 엔드포인트
+	rmas := api.Group("/returns")
+	rmas.POST("", requestReturnHandler(returnsUseCase, logger))
+	rmas.GET("/:id", getReturnHandler(returnsUseCase, logger))
+	rmas.GET("/order/:orderId", getOrderReturnsHandler(returnsUseCase, logger))
+	rmas.POST("/:id/approve", approveReturnHandler(returnsUseCase, logger))
+	rmas.POST("/:id/reject", rejectReturnHandler(returnsUseCase, logger))
+	rmas.POST("/:id/receive", receiveReturnHandler(returnsUseCase, logger))
+	rmas.POST("/:id/inspect", inspectReturnHandler(returnsUseCase, logger))
+	rmas.POST("/:id/refund", refundReturnHandler(returnsUseCase, logger))
 
 	// 결제 관련 엔드포인트
 	payments := api.Group("/payments")
-	payments.POST("", createPaymentHandler(paymentUseCase, logger))
-	payments.POST("/:id/process", processPaymentHandler(paymentUseCase, logger))
+	payments.POST("", createPaymentHandler(paymentUseCase, logger), idempotent)
+	payments.POST("/:id/process", processPaymentHandler(paymentUseCase, logger), idempotent)
+	payments.POST("/:id/authorize", authorizePaymentHandler(paymentUseCase, logger), idempotent)
+	payments.POST("/:id/capture", capturePaymentHandler(paymentUseCase, logger), idempotent)
+	payments.POST("/:id/void", voidPaymentHandler(paymentUseCase, logger))
 	payments.GET("/:id", getPaymentHandler(paymentUseCase, logger))
 	payments.GET("/order/:orderId", getPaymentByOrderHandler(paymentUseCase, logger))
+	payments.GET("/order/:orderId/summary", getOrderPaymentSummaryHandler(paymentUseCase, logger))
 	payments.POST("/:id/refund", refundPaymentHandler(paymentUseCase, logger))
+	payments.POST("/:id/refunds", createRefundHandler(paymentUseCase, logger), idempotent)
+	payments.GET("/:id/refunds", getRefundsHandler(paymentUseCase, logger))
+	payments.GET("/simulator/ledger", getSimulatorLedgerHandler(paymentSimulator))
+	payments.POST("/webhooks/:provider", paymentWebhookHandler(webhookUseCase, logger))
+	payments.GET("/reconciliations/:id", getReconciliationHandler(reconciliationUseCase, logger))
+
+	// 주문·결제 사가 엔드포인트 (진행 상황 확인과 실패한 사가 재시도)
+	sagas := api.Group("/sagas")
+	sagas.GET("", listSagasHandler(sagaUseCase, logger))
+	sagas.GET("/:id", getSagaHandler(sagaUseCase, logger))
+	sagas.GET("/order/:orderId", getOrderSagasHandler(sagaUseCase, logger))
+	sagas.POST("/:id/retry", retrySagaHandler(sagaUseCase, logger))
 }
 
 // API 핸들러 함수들 - 회원
@@ -169,6 +535,7 @@ func createMemberHandler(uc member.MemberService, logger *log.Logger) echo.Handl
 			"id":    member.ID(),
 			"email": member.Email(),
 			"name":  member.Name(),
+			"tier":  string(member.Tier()),
 		})
 	}
 }
@@ -190,6 +557,7 @@ func getMemberHandler(uc member.MemberService, logger *log.Logger) echo.HandlerF
 			"id":    member.ID(),
 			"email": member.Email(),
 			"name":  member.Name(),
+			"tier":  string(member.Tier()),
 		})
 	}
 }
@@ -220,6 +588,42 @@ func updateMemberHandler(uc member.MemberService, logger *log.Logger) echo.Handl
 			"id":    member.ID(),
 			"email": member.Email(),
 			"name":  member.Name(),
+			"tier":  string(member.Tier()),
+		})
+	}
+}
+
+func changeMemberTierHandler(uc member.MemberService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
+		}
+
+		type request struct {
+			Tier string `json:"tier"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		member, err := uc.ChangeMemberTier(c.Request().Context(), id, memberDomain.MemberTier(req.Tier))
+		if err != nil {
+			logger.Errorw("회원 등급 변경 실패", "error", err, "id", id, "tier", req.Tier)
+			status := http.StatusInternalServerError
+			if errors.Is(err, memberDomain.ErrInvalidMemberTier) {
+				status = http.StatusBadRequest
+			}
+			return c.JSON(status, map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"id":    member.ID(),
+			"email": member.Email(),
+			"name":  member.Name(),
+			"tier":  string(member.Tier()),
 		})
 	}
 }
@@ -241,19 +645,138 @@ func deleteMemberHandler(uc member.MemberService, logger *log.Logger) echo.Handl
 	}
 }
 
+// orderResponse는 주문 엔티티를 항목별 세액과 할인 내역을 포함한 API 응답 형태로 변환합니다.
+func orderResponse(o *orderDomain.Order) map[string]interface{} {
+	items := make([]map[string]interface{}, len(o.Items()))
+	for i, item := range o.Items() {
+		items[i] = map[string]interface{}{
+			"id":               item.ID(),
+			"productId":        item.ProductID(),
+			"skuId":            item.SKUID(),
+			"name":             item.Name(),
+			"taxCategory":      item.TaxCategory(),
+			"price":            item.Price(),
+			"quantity":         item.Quantity(),
+			"canceledQuantity": item.CanceledQuantity(),
+			"returnedQuantity": item.ReturnedQuantity(),
+			"subtotal":         item.Subtotal(),
+			"taxAmount":        item.TaxAmount(),
+		}
+	}
+
+	discounts := make([]map[string]interface{}, len(o.Discounts()))
+	for i, d := range o.Discounts() {
+		discounts[i] = map[string]interface{}{
+			"code":        d.Code(),
+			"kind":        d.Kind(),
+			"description": d.Description(),
+			"amount":      d.Amount(),
+		}
+	}
+
+	response := map[string]interface{}{
+		"id":                 o.ID(),
+		"customerId":         o.CustomerID(),
+		"customerName":       o.CustomerName(),
+		"customerEmail":      o.CustomerEmail(),
+		"status":             string(o.Status()),
+		"destinationCountry": o.DestinationCountry(),
+		"items":              items,
+		"subtotal":           o.Subtotal(),
+		"shippingFee":        o.ShippingFee(),
+		"discounts":          discounts,
+		"taxInclusive":       o.TaxInclusive(),
+		"taxTotal":           o.TaxTotal(),
+		"total":              o.TotalAmount(),
+		"createdAt":          o.CreatedAt(),
+		"updatedAt":          o.UpdatedAt(),
+	}
+
+	// 비회원 주문은 주문 당시의 연락처와 배송지를 함께 응답합니다
+	if guest := o.Guest(); guest != nil {
+		response["guest"] = map[string]interface{}{
+			"email":      guest.Email,
+			"name":       guest.Name,
+			"phone":      guest.Phone,
+			"address1":   guest.Address1,
+			"address2":   guest.Address2,
+			"city":       guest.City,
+			"postalCode": guest.PostalCode,
+		}
+	}
+
+	return response
+}
+
+// statusChangeResponse는 주문 상태 전환 이력을 API 응답 형태로 변환합니다.
+func statusChangeResponse(change *orderDomain.StatusChange) map[string]interface{} {
+	return map[string]interface{}{
+		"from":      string(change.From()),
+		"to":        string(change.To()),
+		"actor":     change.Actor(),
+		"reason":    change.Reason(),
+		"changedAt": change.ChangedAt(),
+	}
+}
+
+// orderErrorStatus는 주문 오류에 대응하는 HTTP 상태 코드를 반환합니다.
+func orderErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, order.ErrProductNotFound),
+		errors.Is(err, order.ErrProductUnavailable),
+		errors.Is(err, order.ErrCouponRejected),
+		errors.Is(err, order.ErrTaxRateNotFound),
+		errors.Is(err, order.ErrShippingRateNotFound),
+		errors.Is(err, orderDomain.ErrInvalidCountry),
+		errors.Is(err, order.ErrInvalidCustomerID),
+		errors.Is(err, orderDomain.ErrInvalidOrderItems),
+		errors.Is(err, orderDomain.ErrInvalidItemQuantity),
+		errors.Is(err, orderDomain.ErrInvalidCancelQuantity),
+		errors.Is(err, orderDomain.ErrInvalidReturnQuantity),
+		errors.Is(err, orderDomain.ErrInvalidOrderStatus),
+		errors.Is(err, order.ErrInvalidSortField),
+		errors.Is(err, order.ErrInvalidCursor),
+		errors.Is(err, order.ErrGuestCouponNotAllowed),
+		errors.Is(err, orderDomain.ErrInvalidGuestContact),
+		errors.Is(err, order.ErrCustomerNotFound),
+		errors.Is(err, order.ErrInvalidImportFile),
+		errors.Is(err, order.ErrInvalidQuote),
+		errors.Is(err, order.ErrQuoteMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, orderDomain.ErrGuestEmailMismatch):
+		return http.StatusForbidden
+	case errors.Is(err, order.ErrOutOfStock),
+		errors.Is(err, orderDomain.ErrOrderStatusTransition),
+		errors.Is(err, orderDomain.ErrOrderConflict),
+		errors.Is(err, orderDomain.ErrOrderNotEditable),
+		errors.Is(err, orderDomain.ErrOrderNotReturnable),
+		errors.Is(err, orderDomain.ErrNotGuestOrder),
+		errors.Is(err, order.ErrCustomerInactive),
+		errors.Is(err, order.ErrQuoteExpired):
+		return http.StatusConflict
+	case errors.Is(err, orderDomain.ErrOrderNotFound),
+		errors.Is(err, orderDomain.ErrOrderItemNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // API 핸들러 함수들 - 주문
 func createOrderHandler(uc order.OrderService, logger *log.Logger) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		type orderItemRequest struct {
-			ProductID string  `json:"productId"`
-			Name      string  `json:"name"`
-			Price     float64 `json:"price"`
-			Quantity  int     `json:"quantity"`
+			ProductID string `json:"productId"`
+			SKUID     string `json:"skuId"`
+			Quantity  int    `json:"quantity"`
 		}
 
 		type request struct {
-			CustomerID string             `json:"customerId"`
-			Items      []orderItemRequest `json:"items"`
+			CustomerID         string             `json:"customerId"`
+			Items              []orderItemRequest `json:"items"`
+			CouponCodes        []string           `json:"couponCodes"`
+			DestinationCountry string             `json:"destinationCountry"`
+			QuoteID            string             `json:"quoteId"`
 		}
 
 		var req request
@@ -266,24 +789,84 @@ func createOrderHandler(uc order.OrderService, logger *log.Logger) echo.HandlerF
 		for i, item := range req.Items {
 			items[i] = order.OrderItemRequest{
 				ProductID: item.ProductID,
-				Name:      item.Name,
-				Price:     item.Price,
+				SKUID:     item.SKUID,
 				Quantity:  item.Quantity,
 			}
 		}
 
 		// 주문 생성
-		newOrder, err := uc.CreateOrder(c.Request().Context(), req.CustomerID, items)
+		newOrder, err := uc.CreateOrder(c.Request().Context(), order.CreateOrderRequest{
+			CustomerID:         req.CustomerID,
+			Items:              items,
+			CouponCodes:        req.CouponCodes,
+			DestinationCountry: req.DestinationCountry,
+			QuoteID:            req.QuoteID,
+		})
 		if err != nil {
 			logger.Errorw("주문 생성 실패", "error", err)
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
 		}
 
-		return c.JSON(http.StatusCreated, map[string]interface{}{
-			"id":        newOrder.ID(),
-			"customerId": newOrder.CustomerID(),
-			"status":    string(newOrder.Status()),
-			"total":     newOrder.TotalAmount(),
+		return c.JSON(http.StatusCreated, orderResponse(newOrder))
+	}
+}
+
+// quoteOrderHandler는 주문을 저장하지 않고 가격 견적과 견적 ID를 반환합니다.
+// 고객 ID를 생략하면 비회원 주문 견적으로 계산합니다.
+func quoteOrderHandler(uc order.OrderService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		type orderItemRequest struct {
+			ProductID string `json:"productId"`
+			SKUID     string `json:"skuId"`
+			Quantity  int    `json:"quantity"`
+		}
+
+		type request struct {
+			CustomerID         string             `json:"customerId"`
+			Items              []orderItemRequest `json:"items"`
+			CouponCodes        []string           `json:"couponCodes"`
+			DestinationCountry string             `json:"destinationCountry"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		// 요청 데이터 변환
+		items := make([]order.OrderItemRequest, len(req.Items))
+		for i, item := range req.Items {
+			items[i] = order.OrderItemRequest{
+				ProductID: item.ProductID,
+				SKUID:     item.SKUID,
+				Quantity:  item.Quantity,
+			}
+		}
+
+		quote, err := uc.QuoteOrder(c.Request().Context(), order.CreateOrderRequest{
+			CustomerID:         req.CustomerID,
+			Items:              items,
+			CouponCodes:        req.CouponCodes,
+			DestinationCountry: req.DestinationCountry,
+		})
+		if err != nil {
+			logger.Errorw("주문 견적 계산 실패", "error", err)
+			return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		quoted := orderResponse(quote.Order)
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"quoteId":            quote.QuoteID,
+			"expiresAt":          quote.ExpiresAt,
+			"destinationCountry": quoted["destinationCountry"],
+			"items":              quoted["items"],
+			"subtotal":           quoted["subtotal"],
+			"shippingFee":        quoted["shippingFee"],
+			"discounts":          quoted["discounts"],
+			"discountTotal":      quote.Order.DiscountTotal(),
+			"taxInclusive":       quoted["taxInclusive"],
+			"taxTotal":           quoted["taxTotal"],
+			"total":              quoted["total"],
 		})
 	}
 }
@@ -301,12 +884,7 @@ func getOrderHandler(uc order.OrderService, logger *log.Logger) echo.HandlerFunc
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "Order not found"})
 		}
 
-		return c.JSON(http.StatusOK, map[string]interface{}{
-			"id":        order.ID(),
-			"customerId": order.CustomerID(),
-			"status":    string(order.Status()),
-			"total":     order.TotalAmount(),
-		})
+		return c.JSON(http.StatusOK, orderResponse(order))
 	}
 }
 
@@ -338,6 +916,264 @@ func getCustomerOrdersHandler(uc order.OrderService, logger *log.Logger) echo.Ha
 	}
 }
 
+// parseOrderSearchCriteria는 관리자 주문 검색 쿼리 파라미터를 검색 조건으로 변환합니다.
+// status는 쉼표로 구분하거나 여러 번 지정할 수 있고, 날짜는 RFC3339 형식입니다.
+func parseOrderSearchCriteria(c echo.Context) (order.OrderSearchCriteria, error) {
+	criteria := order.OrderSearchCriteria{
+		CustomerID: c.QueryParam("customerId"),
+		ProductID:  c.QueryParam("productId"),
+		SortBy:     order.OrderSortField(c.QueryParam("sort")),
+		Descending: c.QueryParam("order") != "asc",
+		Cursor:     c.QueryParam("cursor"),
+	}
+
+	for _, value := range c.QueryParams()["status"] {
+		for _, status := range strings.Split(value, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				criteria.Statuses = append(criteria.Statuses, orderDomain.OrderStatus(status))
+			}
+		}
+	}
+
+	times := map[string]*time.Time{
+		"createdFrom": &criteria.CreatedFrom,
+		"createdTo":   &criteria.CreatedTo,
+		"updatedFrom": &criteria.UpdatedFrom,
+		"updatedTo":   &criteria.UpdatedTo,
+	}
+	for name, target := range times {
+		if v := c.QueryParam(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return criteria, fmt.Errorf("Invalid %s", name)
+			}
+			*target = t
+		}
+	}
+
+	var err error
+	if v := c.QueryParam("minAmount"); v != "" {
+		if criteria.MinAmount, err = strconv.ParseFloat(v, 64); err != nil {
+			return criteria, errors.New("Invalid minAmount")
+		}
+	}
+	if v := c.QueryParam("maxAmount"); v != "" {
+		if criteria.MaxAmount, err = strconv.ParseFloat(v, 64); err != nil {
+			return criteria, errors.New("Invalid maxAmount")
+		}
+	}
+	if v := c.QueryParam("limit"); v != "" {
+		if criteria.Limit, err = strconv.Atoi(v); err != nil {
+			return criteria, errors.New("Invalid limit")
+		}
+	}
+
+	return criteria, nil
+}
+
+func searchOrdersHandler(uc order.OrderService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		criteria, err := parseOrderSearchCriteria(c)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+
+		page, err := uc.SearchOrders(c.Request().Context(), criteria)
+		if err != nil {
+			logger.Errorw("주문 검색 실패", "error", err)
+			return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		orders := make([]map[string]interface{}, len(page.Orders))
+		for i, o := range page.Orders {
+			orders[i] = orderResponse(o)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"orders":     orders,
+			"nextCursor": page.NextCursor,
+		})
+	}
+}
+
+// orderExportColumns는 주문 CSV 내보내기의 열 이름입니다.
+var orderExportColumns = []string{
+	"id", "customerId", "status", "destinationCountry", "itemCount",
+	"subtotal", "shippingFee", "discountTotal", "taxTotal", "total", "createdAt", "updatedAt",
+}
+
+// orderExportRow는 주문을 CSV 내보내기 한 행으로 변환합니다.
+func orderExportRow(o *orderDomain.Order) []string {
+	itemCount := 0
+	for _, item := range o.Items() {
+		itemCount += item.Quantity()
+	}
+	formatAmount := func(amount float64) string {
+		return strconv.FormatFloat(amount, 'f', 2, 64)
+	}
+
+	return []string{
+		o.ID(),
+		o.CustomerID(),
+		string(o.Status()),
+		o.DestinationCountry(),
+		strconv.Itoa(itemCount),
+		formatAmount(o.Subtotal()),
+		formatAmount(o.ShippingFee()),
+		formatAmount(o.DiscountTotal()),
+		formatAmount(o.TaxTotal()),
+		formatAmount(o.TotalAmount()),
+		o.CreatedAt().UTC().Format(time.RFC3339),
+		o.UpdatedAt().UTC().Format(time.RFC3339),
+	}
+}
+
+// exportOrdersHandler는 검색 조건에 맞는 주문 전체를 CSV 또는 NDJSON으로 스트리밍합니다.
+// 주문을 페이지 단위로 조회하며 바로 응답에 쓰므로 결과가 많아도 메모리에 모으지 않습니다.
+// 응답을 쓰기 시작한 뒤에 실패하면 상태 코드를 바꿀 수 없으므로 로그만 남기고 응답을 끝냅니다.
+func exportOrdersHandler(uc order.OrderService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		format := c.QueryParam("format")
+		if format == "" {
+			format = "csv"
+		}
+		if format != "csv" && format != "ndjson" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid format"})
+		}
+
+		criteria, err := parseOrderSearchCriteria(c)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+
+		res := c.Response()
+		csvWriter := csv.NewWriter(res)
+		jsonEncoder := json.NewEncoder(res)
+		started := false
+		start := func() error {
+			started = true
+			if format == "csv" {
+				res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+				res.Header().Set("Content-Disposition", `attachment; filename="orders.csv"`)
+				res.WriteHeader(http.StatusOK)
+				return csvWriter.Write(orderExportColumns)
+			}
+			res.Header().Set(echo.HeaderContentType, "application/x-ndjson")
+			res.WriteHeader(http.StatusOK)
+			return nil
+		}
+
+		written := 0
+		err = uc.ExportOrders(c.Request().Context(), criteria, func(o *orderDomain.Order) error {
+			if !started {
+				if err := start(); err != nil {
+					return err
+				}
+			}
+			if format == "csv" {
+				if err := csvWriter.Write(orderExportRow(o)); err != nil {
+					return err
+				}
+			} else if err := jsonEncoder.Encode(orderResponse(o)); err != nil {
+				return err
+			}
+
+			// 100건마다 버퍼를 비워 클라이언트에 바로 전달합니다
+			written++
+			if written%100 == 0 {
+				csvWriter.Flush()
+				res.Flush()
+			}
+			return nil
+		})
+		if err != nil {
+			if !started {
+				logger.Errorw("주문 내보내기 실패", "error", err)
+				return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+			}
+			logger.Errorw("주문 내보내기 중단", "error", err, "written", written)
+			csvWriter.Flush()
+			return nil
+		}
+
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		csvWriter.Flush()
+		return csvWriter.Error()
+	}
+}
+
+// importOrdersHandler는 CSV 파일의 주문을 가져오고 주문별 결과와 행별 오류 보고서를 반환합니다.
+// 파일은 multipart 폼의 file 필드나 text/csv 요청 본문으로 받으며, dryRun=true이면 검증만 합니다.
+func importOrdersHandler(uc order.OrderService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		dryRun, _ := strconv.ParseBool(c.QueryParam("dryRun"))
+		batchSize, _ := strconv.Atoi(c.QueryParam("batchSize"))
+
+		var body io.Reader = c.Request().Body
+		if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+			fileHeader, err := c.FormFile("file")
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "file is required"})
+			}
+			file, err := fileHeader.Open()
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid file"})
+			}
+			defer file.Close()
+			body = file
+		}
+
+		result, err := uc.ImportOrders(c.Request().Context(), body, order.ImportOptions{DryRun: dryRun, BatchSize: batchSize})
+		if err != nil {
+			logger.Errorw("주문 가져오기 실패", "error", err, "dryRun", dryRun)
+			if result == nil {
+				return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+			}
+			// 이미 저장된 배치의 결과도 함께 돌려주어 다시 가져올 때 참고할 수 있게 합니다
+			response := importResultResponse(result)
+			response["error"] = err.Error()
+			return c.JSON(orderErrorStatus(err), response)
+		}
+
+		return c.JSON(http.StatusOK, importResultResponse(result))
+	}
+}
+
+// importResultResponse는 주문 가져오기 결과를 API 응답 형식으로 변환합니다.
+func importResultResponse(result *order.ImportResult) map[string]interface{} {
+	orders := make([]map[string]interface{}, len(result.Orders))
+	for i, o := range result.Orders {
+		orders[i] = map[string]interface{}{
+			"externalRef": o.ExternalRef,
+			"orderId":     o.OrderID,
+			"outcome":     o.Outcome,
+		}
+	}
+
+	errs := make([]map[string]interface{}, len(result.Errors))
+	for i, rowErr := range result.Errors {
+		errs[i] = map[string]interface{}{
+			"line":        rowErr.Line,
+			"externalRef": rowErr.ExternalRef,
+			"message":     rowErr.Message,
+		}
+	}
+
+	return map[string]interface{}{
+		"dryRun":  result.DryRun,
+		"created": result.Created,
+		"valid":   result.Valid,
+		"skipped": result.Skipped,
+		"failed":  result.Failed,
+		"orders":  orders,
+		"errors":  errs,
+	}
+}
+
 func updateOrderStatusHandler(uc order.OrderService, logger *log.Logger) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		id := c.Param("id")
@@ -347,6 +1183,8 @@ func updateOrderStatusHandler(uc order.OrderService, logger *log.Logger) echo.Ha
 
 		type request struct {
 			Status string `json:"status"`
+			Actor  string `json:"actor"`
+			Reason string `json:"reason"`
 		}
 
 		var req request
@@ -355,13 +1193,13 @@ func updateOrderStatusHandler(uc order.OrderService, logger *log.Logger) echo.Ha
 		}
 
 		// 상태 변환
-		status := order.domain.OrderStatus(req.Status)
+		status := orderDomain.OrderStatus(req.Status)
 
 		// 주문 상태 업데이트
-		updatedOrder, err := uc.UpdateOrderStatus(c.Request().Context(), id, status)
+		updatedOrder, err := uc.UpdateOrderStatus(c.Request().Context(), id, status, req.Actor, req.Reason)
 		if err != nil {
 			logger.Errorw("주문 상태 업데이트 실패", "error", err, "id", id, "status", status)
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
 		}
 
 		return c.JSON(http.StatusOK, map[string]interface{}{
@@ -380,11 +1218,22 @@ func cancelOrderHandler(uc order.OrderService, logger *log.Logger) echo.HandlerF
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
 		}
 
+		// 취소 사유는 선택 사항이므로 본문이 없어도 됩니다
+		type request struct {
+			Actor  string `json:"actor"`
+			Reason string `json:"reason"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
 		// 주문 취소
-		canceledOrder, err := uc.CancelOrder(c.Request().Context(), id)
+		canceledOrder, err := uc.CancelOrder(c.Request().Context(), id, req.Actor, req.Reason)
 		if err != nil {
 			logger.Errorw("주문 취소 실패", "error", err, "id", id)
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
 		}
 
 		return c.JSON(http.StatusOK, map[string]interface{}{
@@ -396,6 +1245,315 @@ func cancelOrderHandler(uc order.OrderService, logger *log.Logger) echo.HandlerF
 	}
 }
 
+// orderTokenHeader는 비회원 주문 조회 토큰을 전달하는 요청 헤더입니다.
+// URL에 남지 않도록 쿼리 파라미터 대신 헤더로 받습니다.
+const orderTokenHeader = "X-Order-Token"
+
+func createGuestOrderHandler(uc order.OrderService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		type orderItemRequest struct {
+			ProductID string `json:"productId"`
+			SKUID     string `json:"skuId"`
+			Quantity  int    `json:"quantity"`
+		}
+
+		type guestRequest struct {
+			Email      string `json:"email"`
+			Name       string `json:"name"`
+			Phone      string `json:"phone"`
+			Address1   string `json:"address1"`
+			Address2   string `json:"address2"`
+			City       string `json:"city"`
+			PostalCode string `json:"postalCode"`
+		}
+
+		type request struct {
+			Guest              guestRequest       `json:"guest"`
+			Items              []orderItemRequest `json:"items"`
+			CouponCodes        []string           `json:"couponCodes"`
+			DestinationCountry string             `json:"destinationCountry"`
+			QuoteID            string             `json:"quoteId"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		// 요청 데이터 변환
+		items := make([]order.OrderItemRequest, len(req.Items))
+		for i, item := range req.Items {
+			items[i] = order.OrderItemRequest{
+				ProductID: item.ProductID,
+				SKUID:     item.SKUID,
+				Quantity:  item.Quantity,
+			}
+		}
+
+		guestOrder, err := uc.CreateGuestOrder(c.Request().Context(), order.CreateGuestOrderRequest{
+			Contact: orderDomain.GuestContact{
+				Email:      req.Guest.Email,
+				Name:       req.Guest.Name,
+				Phone:      req.Guest.Phone,
+				Address1:   req.Guest.Address1,
+				Address2:   req.Guest.Address2,
+				City:       req.Guest.City,
+				PostalCode: req.Guest.PostalCode,
+			},
+			Items:              items,
+			CouponCodes:        req.CouponCodes,
+			DestinationCountry: req.DestinationCountry,
+			QuoteID:            req.QuoteID,
+		})
+		if err != nil {
+			logger.Errorw("비회원 주문 생성 실패", "error", err)
+			return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		// 조회 토큰은 이 응답에서만 알 수 있으므로 주문 이메일로도 전달해야 합니다
+		return c.JSON(http.StatusCreated, map[string]interface{}{
+			"order":       orderResponse(guestOrder.Order),
+			"lookupToken": guestOrder.LookupToken,
+		})
+	}
+}
+
+func getGuestOrderHandler(uc order.OrderService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
+		}
+
+		guestOrder, err := uc.GetGuestOrder(c.Request().Context(), id, c.Request().Header.Get(orderTokenHeader))
+		if err != nil {
+			logger.Errorw("비회원 주문 조회 실패", "error", err, "id", id)
+			return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, orderResponse(guestOrder))
+	}
+}
+
+func cancelGuestOrderHandler(uc order.OrderService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
+		}
+
+		// 취소 사유는 선택 사항이므로 본문이 없어도 됩니다
+		type request struct {
+			Reason string `json:"reason"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		canceledOrder, err := uc.CancelGuestOrder(c.Request().Context(), id, c.Request().Header.Get(orderTokenHeader), req.Reason)
+		if err != nil {
+			logger.Errorw("비회원 주문 취소 실패", "error", err, "id", id)
+			return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, orderResponse(canceledOrder))
+	}
+}
+
+// attachGuestOrderHandler는 비회원 주문을 회원 계정에 연결합니다.
+// 회원의 이메일이 주문 이메일과 같아야 하며, 주문 이메일로 받은 조회 토큰으로 이메일 소유를 확인합니다.
+func attachGuestOrderHandler(uc order.OrderService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
+		}
+
+		type request struct {
+			MemberID string `json:"memberId"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil || req.MemberID == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		attachedOrder, err := uc.AttachGuestOrder(c.Request().Context(), id, c.Request().Header.Get(orderTokenHeader), req.MemberID)
+		if err != nil {
+			logger.Errorw("비회원 주문 회원 연결 실패", "error", err, "id", id, "memberId", req.MemberID)
+			return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, orderResponse(attachedOrder))
+	}
+}
+
+func getOrderHistoryHandler(uc order.OrderService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
+		}
+
+		history, err := uc.GetOrderHistory(c.Request().Context(), id)
+		if err != nil {
+			logger.Errorw("주문 상태 이력 조회 실패", "error", err, "id", id)
+			return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		response := make([]map[string]interface{}, len(history))
+		for i, change := range history {
+			response[i] = statusChangeResponse(change)
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// addOrderItemHandler는 결제 전 주문에 항목을 추가합니다.
+func addOrderItemHandler(uc order.OrderService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
+		}
+
+		type request struct {
+			ProductID string `json:"productId"`
+			SKUID     string `json:"skuId"`
+			Quantity  int    `json:"quantity"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		updatedOrder, err := uc.AddOrderItem(c.Request().Context(), id, order.OrderItemRequest{
+			ProductID: req.ProductID,
+			SKUID:     req.SKUID,
+			Quantity:  req.Quantity,
+		})
+		if err != nil {
+			logger.Errorw("주문 항목 추가 실패", "error", err, "id", id)
+			return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, orderResponse(updatedOrder))
+	}
+}
+
+// changeOrderItemHandler는 결제 전 주문 항목의 수량을 변경합니다.
+func changeOrderItemHandler(uc order.OrderService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		itemID := c.Param("itemId")
+		if id == "" || itemID == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
+		}
+
+		type request struct {
+			Quantity int `json:"quantity"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		updatedOrder, err := uc.ChangeOrderItemQuantity(c.Request().Context(), id, itemID, req.Quantity)
+		if err != nil {
+			logger.Errorw("주문 항목 수량 변경 실패", "error", err, "id", id, "itemId", itemID)
+			return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, orderResponse(updatedOrder))
+	}
+}
+
+// removeOrderItemHandler는 결제 전 주문에서 항목을 삭제합니다.
+func removeOrderItemHandler(uc order.OrderService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		itemID := c.Param("itemId")
+		if id == "" || itemID == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
+		}
+
+		updatedOrder, err := uc.RemoveOrderItem(c.Request().Context(), id, itemID)
+		if err != nil {
+			logger.Errorw("주문 항목 삭제 실패", "error", err, "id", id, "itemId", itemID)
+			return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, orderResponse(updatedOrder))
+	}
+}
+
+// cancelOrderItemsHandler는 결제된 주문의 항목 일부를 취소하고 환불 금액을 반환합니다. 환불은 사가가 결제 모듈로 처리합니다.
+func cancelOrderItemsHandler(uc order.OrderService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing ID"})
+		}
+
+		type cancelItemRequest struct {
+			ItemID   string `json:"itemId"`
+			Quantity int    `json:"quantity"`
+		}
+
+		type request struct {
+			Items  []cancelItemRequest `json:"items"`
+			Actor  string              `json:"actor"`
+			Reason string              `json:"reason"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		items := make([]order.CancelItemRequest, len(req.Items))
+		for i, item := range req.Items {
+			items[i] = order.CancelItemRequest{ItemID: item.ItemID, Quantity: item.Quantity}
+		}
+
+		result, err := uc.CancelOrderItems(c.Request().Context(), id, order.CancelItemsRequest{
+			Items:  items,
+			Actor:  req.Actor,
+			Reason: req.Reason,
+		})
+		if err != nil {
+			logger.Errorw("주문 항목 부분 취소 실패", "error", err, "id", id)
+			return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"order":        orderResponse(result.Order),
+			"refundAmount": result.RefundAmount,
+		})
+	}
+}
+
+// getOrderTransitionsHandler는 클라이언트가 가능한 상태 변경을 미리 알 수 있도록 상태 전환 규칙 표를 반환합니다.
+func getOrderTransitionsHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		response := make(map[string][]string)
+		for from, next := range orderDomain.StatusTransitions() {
+			statuses := make([]string, len(next))
+			for i, status := range next {
+				statuses[i] = string(status)
+			}
+			response[string(from)] = statuses
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
 // API 핸들러 함수들 - 결제
 func createPaymentHandler(uc payment.PaymentService, logger *log.Logger) echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -421,7 +1579,7 @@ func createPaymentHandler(uc payment.PaymentService, logger *log.Logger) echo.Ha
 		)
 		if err != nil {
 			logger.Errorw("결제 생성 실패", "error", err)
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return c.JSON(paymentErrorStatus(err), map[string]string{"error": err.Error()})
 		}
 
 		return c.JSON(http.StatusCreated, map[string]interface{}{
@@ -445,7 +1603,7 @@ func processPaymentHandler(uc payment.PaymentService, logger *log.Logger) echo.H
 		processedPayment, err := uc.ProcessPayment(c.Request().Context(), id)
 		if err != nil {
 			logger.Errorw("결제 처리 실패", "error", err, "id", id)
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return c.JSON(paymentErrorStatus(err), map[string]string{"error": err.Error()})
 		}
 
 		return c.JSON(http.StatusOK, map[string]interface{}{
@@ -473,12 +1631,13 @@ func getPaymentHandler(uc payment.PaymentService, logger *log.Logger) echo.Handl
 		}
 
 		return c.JSON(http.StatusOK, map[string]interface{}{
-			"id":            payment.ID(),
-			"orderId":       payment.OrderID(),
-			"amount":        payment.Amount(),
-			"method":        string(payment.Method()),
-			"status":        string(payment.Status()),
-			"transactionId": payment.TransactionID(),
+			"id":             payment.ID(),
+			"orderId":        payment.OrderID(),
+			"amount":         payment.Amount(),
+			"method":         string(payment.Method()),
+			"status":         string(payment.Status()),
+			"transactionId":  payment.TransactionID(),
+			"refundedAmount": payment.RefundedAmount(),
 		})
 	}
 }
@@ -490,20 +1649,17 @@ func getPaymentByOrderHandler(uc payment.PaymentService, logger *log.Logger) ech
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing order ID"})
 		}
 
-		payment, err := uc.GetPaymentByOrderID(c.Request().Context(), orderID)
+		payments, err := uc.GetPaymentsByOrderID(c.Request().Context(), orderID)
 		if err != nil {
 			logger.Errorw("주문별 결제 조회 실패", "error", err, "orderId", orderID)
-			return c.JSON(http.StatusNotFound, map[string]string{"error": "Payment not found"})
+			return c.JSON(paymentErrorStatus(err), map[string]string{"error": err.Error()})
 		}
 
-		return c.JSON(http.StatusOK, map[string]interface{}{
-			"id":            payment.ID(),
-			"orderId":       payment.OrderID(),
-			"amount":        payment.Amount(),
-			"method":        string(payment.Method()),
-			"status":        string(payment.Status()),
-			"transactionId": payment.TransactionID(),
-		})
+		response := make([]map[string]interface{}, len(payments))
+		for i, p := range payments {
+			response[i] = paymentResponse(p)
+		}
+		return c.JSON(http.StatusOK, response)
 	}
 }
 
@@ -523,18 +1679,19 @@ func refundPaymentHandler(uc payment.PaymentService, logger *log.Logger) echo.Ha
 			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
 		}
 
-		// 결제 환불 처리
+		// 결제 환불 처리 (남은 금액 전체)
 		refundedPayment, err := uc.RefundPayment(c.Request().Context(), id, req.Reason)
 		if err != nil {
 			logger.Errorw("결제 환불 실패", "error", err, "id", id)
-			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return c.JSON(paymentErrorStatus(err), map[string]string{"error": err.Error()})
 		}
 
 		return c.JSON(http.StatusOK, map[string]interface{}{
-			"id":      refundedPayment.ID(),
-			"orderId": refundedPayment.OrderID(),
-			"amount":  refundedPayment.Amount(),
-			"status":  string(refundedPayment.Status()),
+			"id":             refundedPayment.ID(),
+			"orderId":        refundedPayment.OrderID(),
+			"amount":         refundedPayment.Amount(),
+			"refundedAmount": refundedPayment.RefundedAmount(),
+			"status":         string(refundedPayment.Status()),
 		})
 	}
 }
\ No newline at end of file
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	payment "example.com/myapp/payment/application"
+	paymentDomain "example.com/myapp/payment/domain"
+	paymentInfra "example.com/myapp/payment/infrastructure"
+	"example.com/myapp/shared/log"
+	"github.com/labstack/echo/v4"
+)
+
+// 결제 게이트웨이 웹훅의 서명 헤더입니다.
+const (
+	paymentWebhookSignatureHeader = "X-Webhook-Signature"
+	paymentWebhookTimestampHeader = "X-Webhook-Timestamp"
+)
+
+// paymentErrorStatus는 결제 오류에 대응하는 HTTP 상태 코드를 반환합니다.
+func paymentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, paymentDomain.ErrPaymentNotFound),
+		errors.Is(err, payment.ErrOrderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, paymentDomain.ErrPaymentNotRefundable),
+		errors.Is(err, paymentDomain.ErrRefundExceedsPayment),
+		errors.Is(err, payment.ErrOrderNotPayable),
+		errors.Is(err, payment.ErrPaymentExceedsAmountDue),
+		errors.Is(err, paymentDomain.ErrPaymentNotAuthorized),
+		errors.Is(err, paymentDomain.ErrAuthorizationExpired):
+		return http.StatusConflict
+	case errors.Is(err, payment.ErrInvalidPaymentID),
+		errors.Is(err, paymentDomain.ErrInvalidOrderID),
+		errors.Is(err, paymentDomain.ErrInvalidPaymentAmount),
+		errors.Is(err, paymentDomain.ErrInvalidPaymentMethod),
+		errors.Is(err, paymentDomain.ErrInvalidRefundAmount),
+		errors.Is(err, paymentDomain.ErrInvalidCaptureAmount),
+		errors.Is(err, paymentDomain.ErrCaptureExceedsAuthorization),
+		errors.Is(err, paymentDomain.ErrAuthorizationNotSupported):
+		return http.StatusBadRequest
+	case errors.Is(err, payment.ErrGatewayUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// paymentResponse는 결제를 API 응답 형식으로 변환합니다.
+func paymentResponse(p *paymentDomain.Payment) map[string]interface{} {
+	response := map[string]interface{}{
+		"id":             p.ID(),
+		"orderId":        p.OrderID(),
+		"amount":         p.Amount(),
+		"method":         string(p.Method()),
+		"status":         string(p.Status()),
+		"transactionId":  p.TransactionID(),
+		"refundedAmount": p.RefundedAmount(),
+		"capturedAmount": p.CapturedAmount(),
+		"createdAt":      p.CreatedAt(),
+		"updatedAt":      p.UpdatedAt(),
+	}
+	if !p.AuthorizationExpiresAt().IsZero() {
+		response["authorizationExpiresAt"] = p.AuthorizationExpiresAt()
+	}
+	return response
+}
+
+// refundResponse는 환불을 API 응답 형식으로 변환합니다.
+func refundResponse(refund *paymentDomain.Refund) map[string]interface{} {
+	return map[string]interface{}{
+		"id":              refund.ID(),
+		"paymentId":       refund.PaymentID(),
+		"amount":          refund.Amount(),
+		"reason":          refund.Reason(),
+		"gatewayRefundId": refund.GatewayRefundID(),
+		"status":          string(refund.Status()),
+		"failureReason":   refund.FailureReason(),
+		"createdAt":       refund.CreatedAt(),
+		"updatedAt":       refund.UpdatedAt(),
+	}
+}
+
+// API 핸들러 함수들 - 가승인, 매입, 취소
+
+func authorizePaymentHandler(uc payment.PaymentService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+
+		authorized, err := uc.AuthorizePayment(c.Request().Context(), id)
+		if err != nil {
+			logger.Errorw("결제 가승인 실패", "error", err, "id", id)
+			return c.JSON(paymentErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, paymentResponse(authorized))
+	}
+}
+
+func capturePaymentHandler(uc payment.PaymentService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+
+		type request struct {
+			Amount float64 `json:"amount"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		captured, err := uc.CapturePayment(c.Request().Context(), id, req.Amount)
+		if err != nil {
+			logger.Errorw("결제 매입 실패", "error", err, "id", id, "amount", req.Amount)
+			return c.JSON(paymentErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, paymentResponse(captured))
+	}
+}
+
+func voidPaymentHandler(uc payment.PaymentService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+
+		type request struct {
+			Reason string `json:"reason"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		voided, err := uc.VoidPayment(c.Request().Context(), id, req.Reason)
+		if err != nil {
+			logger.Errorw("결제 가승인 취소 실패", "error", err, "id", id)
+			return c.JSON(paymentErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, paymentResponse(voided))
+	}
+}
+
+// API 핸들러 함수들 - 주문 결제 현황과 환불
+
+func getOrderPaymentSummaryHandler(uc payment.PaymentService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		orderID := c.Param("orderId")
+
+		summary, err := uc.GetOrderPaymentSummary(c.Request().Context(), orderID)
+		if err != nil {
+			logger.Errorw("주문 결제 현황 조회 실패", "error", err, "orderId", orderID)
+			return c.JSON(paymentErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		payments := make([]map[string]interface{}, len(summary.Payments))
+		for i, p := range summary.Payments {
+			payments[i] = paymentResponse(p)
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"orderId":    summary.OrderID,
+			"orderTotal": summary.OrderTotal,
+			"paid":       summary.Paid,
+			"authorized": summary.Authorized,
+			"pending":    summary.Pending,
+			"refunded":   summary.Refunded,
+			"amountDue":  summary.AmountDue,
+			"payments":   payments,
+		})
+	}
+}
+
+func createRefundHandler(uc payment.PaymentService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+
+		type request struct {
+			Amount float64 `json:"amount"`
+			Reason string  `json:"reason"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		refund, err := uc.CreateRefund(c.Request().Context(), id, req.Amount, req.Reason)
+		if refund != nil && errors.Is(err, payment.ErrGatewayUnavailable) {
+			// 결과를 알 수 없는 환불은 진행 중으로 응답해 같은 Idempotency-Key의 재시도가 환불을 다시 만들지 않게 합니다
+			logger.Warnw("부분 환불 결과 확인 지연", "error", err, "id", id, "refundId", refund.ID())
+			return c.JSON(http.StatusAccepted, refundResponse(refund))
+		}
+		if err != nil {
+			logger.Errorw("부분 환불 실패", "error", err, "id", id, "amount", req.Amount)
+			return c.JSON(paymentErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusCreated, refundResponse(refund))
+	}
+}
+
+func getRefundsHandler(uc payment.PaymentService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+
+		refunds, err := uc.GetRefunds(c.Request().Context(), id)
+		if err != nil {
+			logger.Errorw("환불 내역 조회 실패", "error", err, "id", id)
+			return c.JSON(paymentErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		response := make([]map[string]interface{}, len(refunds))
+		for i, refund := range refunds {
+			response[i] = refundResponse(refund)
+		}
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// getSimulatorLedgerHandler는 결제 게이트웨이 시뮬레이터가 처리한 요청 기록을 반환합니다 (개발·QA용).
+func getSimulatorLedgerHandler(simulator *paymentInfra.GatewaySimulator) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		paymentID := c.QueryParam("paymentId")
+		now := time.Now()
+
+		response := []map[string]interface{}{}
+		for _, entry := range simulator.Ledger() {
+			if paymentID != "" && entry.PaymentID != paymentID {
+				continue
+			}
+			item := map[string]interface{}{
+				"id":            entry.ID,
+				"operation":     string(entry.Operation),
+				"paymentId":     entry.PaymentID,
+				"transactionId": entry.TransactionID,
+				"method":        string(entry.Method),
+				"amount":        entry.Amount,
+				"requestId":     entry.RequestID,
+				"approved":      entry.Approved,
+				"declineCode":   entry.DeclineCode,
+				"settled":       entry.Settled(now),
+				"createdAt":     entry.CreatedAt,
+			}
+			if !entry.SettleAt.IsZero() {
+				item["settleAt"] = entry.SettleAt
+			}
+			response = append(response, item)
+		}
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// paymentWebhookHandler는 결제 게이트웨이(PG사)의 웹훅을 받습니다.
+// 서명은 원본 본문으로 검증해야 하므로 본문을 바인딩하지 않고 그대로 전달합니다.
+// 결제에 반영하지 못한 이벤트는 저장해 두고 500을 반환해 게이트웨이가 다시 보내게 합니다.
+func paymentWebhookHandler(uc payment.WebhookService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		provider := c.Param("provider")
+
+		payload, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		event, err := uc.ReceiveWebhook(
+			c.Request().Context(),
+			provider,
+			c.Request().Header.Get(paymentWebhookSignatureHeader),
+			c.Request().Header.Get(paymentWebhookTimestampHeader),
+			payload,
+		)
+		if err != nil {
+			logger.Errorw("결제 웹훅 처리 실패", "error", err, "provider", provider)
+			switch {
+			case errors.Is(err, payment.ErrUnknownWebhookProvider):
+				return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+			case errors.Is(err, payment.ErrInvalidWebhook):
+				return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+			default:
+				return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			}
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"eventId":  event.EventID,
+			"type":     string(event.Type),
+			"status":   string(event.Status),
+			"attempts": event.Attempts,
+		})
+	}
+}
+
+// getReconciliationHandler는 정산 대사 결과의 요약과 불일치 목록을 반환합니다.
+func getReconciliationHandler(uc payment.ReconciliationService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+
+		reconciliation, err := uc.GetReconciliation(c.Request().Context(), id)
+		if err != nil {
+			if errors.Is(err, payment.ErrReconciliationNotFound) {
+				return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+			}
+			logger.Errorw("정산 대사 결과 조회 실패", "error", err, "id", id)
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, reconciliationResponse(reconciliation))
+	}
+}
+
+// reconciliationResponse는 정산 대사 결과를 API 응답 형식으로 변환합니다.
+func reconciliationResponse(r *payment.Reconciliation) map[string]interface{} {
+	exceptions := make([]map[string]interface{}, len(r.Exceptions))
+	for i, exception := range r.Exceptions {
+		exceptions[i] = map[string]interface{}{
+			"type":           string(exception.Type),
+			"line":           exception.Line,
+			"transactionId":  exception.TransactionID,
+			"paymentId":      exception.PaymentID,
+			"expectedAmount": exception.ExpectedAmount,
+			"actualAmount":   exception.ActualAmount,
+			"expectedStatus": exception.ExpectedStatus,
+			"actualStatus":   exception.ActualStatus,
+			"message":        exception.Message,
+		}
+	}
+
+	return map[string]interface{}{
+		"id":          r.ID,
+		"source":      r.Source,
+		"periodStart": r.PeriodStart,
+		"periodEnd":   r.PeriodEnd,
+		"summary": map[string]interface{}{
+			"rows":             r.Rows,
+			"matched":          r.Matched,
+			"missing":          r.Missing,
+			"extra":            r.Extra,
+			"amountMismatched": r.AmountMismatched,
+			"statusMismatched": r.StatusMismatched,
+			"invalid":          r.Invalid,
+		},
+		"exceptions": exceptions,
+		"createdAt":  r.CreatedAt,
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	promotion "example.com/myapp/promotion/application"
+	promotionDomain "example.com/myapp/promotion/domain"
+	"example.com/myapp/shared/log"
+	"github.com/labstack/echo/v4"
+)
+
+// couponResponse는 쿠폰 엔티티를 API 응답 형태로 변환합니다.
+func couponResponse(coupon *promotionDomain.Coupon) map[string]interface{} {
+	spec := coupon.Spec()
+	response := map[string]interface{}{
+		"id":          coupon.ID(),
+		"code":        coupon.Code(),
+		"name":        coupon.Name(),
+		"kind":        string(spec.Kind),
+		"value":       spec.Value,
+		"buyQuantity": spec.BuyQuantity,
+		"getQuantity": spec.GetQuantity,
+		"conditions": map[string]interface{}{
+			"minSpend":       spec.Conditions.MinSpend,
+			"productIds":     spec.Conditions.ProductIDs,
+			"firstOrderOnly": spec.Conditions.FirstOrderOnly,
+			"memberTiers":    spec.Conditions.MemberTiers,
+		},
+		"usageLimit":     spec.UsageLimit,
+		"perMemberLimit": spec.PerMemberLimit,
+		"stackable":      spec.Stackable,
+		"active":         coupon.IsActive(),
+	}
+	if !spec.StartsAt.IsZero() {
+		response["startsAt"] = spec.StartsAt
+	}
+	if !spec.EndsAt.IsZero() {
+		response["endsAt"] = spec.EndsAt
+	}
+	return response
+}
+
+// promotionErrorStatus는 쿠폰 오류에 대응하는 HTTP 상태 코드를 반환합니다.
+func promotionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, promotionDomain.ErrCouponNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, promotionDomain.ErrDuplicateCouponCode):
+		return http.StatusConflict
+	case errors.Is(err, promotionDomain.ErrInvalidCouponCode),
+		errors.Is(err, promotionDomain.ErrInvalidDiscountKind),
+		errors.Is(err, promotionDomain.ErrInvalidDiscountValue),
+		errors.Is(err, promotionDomain.ErrInvalidBuyXGetY),
+		errors.Is(err, promotionDomain.ErrInvalidUsageLimit),
+		errors.Is(err, promotionDomain.ErrInvalidValidityPeriod):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// API 핸들러 함수들 - 쿠폰
+func createCouponHandler(uc promotion.PromotionService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		type conditionsRequest struct {
+			MinSpend       float64  `json:"minSpend"`
+			ProductIDs     []string `json:"productIds"`
+			FirstOrderOnly bool     `json:"firstOrderOnly"`
+			MemberTiers    []string `json:"memberTiers"`
+		}
+		type request struct {
+			Code           string            `json:"code"`
+			Name           string            `json:"name"`
+			Kind           string            `json:"kind"`
+			Value          float64           `json:"value"`
+			BuyQuantity    int               `json:"buyQuantity"`
+			GetQuantity    int               `json:"getQuantity"`
+			Conditions     conditionsRequest `json:"conditions"`
+			UsageLimit     int               `json:"usageLimit"`
+			PerMemberLimit int               `json:"perMemberLimit"`
+			Stackable      bool              `json:"stackable"`
+			StartsAt       *time.Time        `json:"startsAt"`
+			EndsAt         *time.Time        `json:"endsAt"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		spec := promotionDomain.CouponSpec{
+			Code:        req.Code,
+			Name:        req.Name,
+			Kind:        promotionDomain.DiscountKind(req.Kind),
+			Value:       req.Value,
+			BuyQuantity: req.BuyQuantity,
+			GetQuantity: req.GetQuantity,
+			Conditions: promotionDomain.Conditions{
+				MinSpend:       req.Conditions.MinSpend,
+				ProductIDs:     req.Conditions.ProductIDs,
+				FirstOrderOnly: req.Conditions.FirstOrderOnly,
+				MemberTiers:    req.Conditions.MemberTiers,
+			},
+			UsageLimit:     req.UsageLimit,
+			PerMemberLimit: req.PerMemberLimit,
+			Stackable:      req.Stackable,
+		}
+		if req.StartsAt != nil {
+			spec.StartsAt = *req.StartsAt
+		}
+		if req.EndsAt != nil {
+			spec.EndsAt = *req.EndsAt
+		}
+
+		coupon, err := uc.CreateCoupon(c.Request().Context(), spec)
+		if err != nil {
+			logger.Errorw("쿠폰 생성 실패", "error", err, "code", req.Code)
+			return c.JSON(promotionErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusCreated, couponResponse(coupon))
+	}
+}
+
+func getCouponHandler(uc promotion.PromotionService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		code := c.Param("code")
+		if code == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing coupon code"})
+		}
+
+		coupon, err := uc.GetCoupon(c.Request().Context(), code)
+		if err != nil {
+			logger.Errorw("쿠폰 조회 실패", "error", err, "code", code)
+			return c.JSON(promotionErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, couponResponse(coupon))
+	}
+}
+
+func deactivateCouponHandler(uc promotion.PromotionService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		code := c.Param("code")
+		if code == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing coupon code"})
+		}
+
+		coupon, err := uc.DeactivateCoupon(c.Request().Context(), code)
+		if err != nil {
+			logger.Errorw("쿠폰 사용 중지 실패", "error", err, "code", code)
+			return c.JSON(promotionErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, couponResponse(coupon))
+	}
+}
+
+func activateCouponHandler(uc promotion.PromotionService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		code := c.Param("code")
+		if code == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing coupon code"})
+		}
+
+		coupon, err := uc.ActivateCoupon(c.Request().Context(), code)
+		if err != nil {
+			logger.Errorw("쿠폰 사용 재개 실패", "error", err, "code", code)
+			return c.JSON(promotionErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, couponResponse(coupon))
+	}
+}
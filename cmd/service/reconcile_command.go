@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	payment "example.com/myapp/payment/application"
+	"example.com/myapp/shared/log"
+)
+
+// runReconcileSettlementCommand는 게이트웨이 정산 파일을 결제와 대사해 저장하고 결과를 JSON으로 표준 출력에 씁니다.
+// 기간은 결제 생성일 기준이며 -to를 비우면 -from 하루입니다. 불일치가 있어도 결과를 저장했으면 0을 반환하며,
+// 저장된 결과는 GET /api/v1/payments/reconciliations/:id로 다시 조회할 수 있습니다.
+//
+//	service reconcile-settlement -file settlement.csv -from 2026-10-17 [-to 2026-10-17] [-columns transaction_id=TID,amount=AMT,status=STATUS]
+func runReconcileSettlementCommand(uc payment.ReconciliationService, args []string, logger *log.Logger) int {
+	flags := flag.NewFlagSet("reconcile-settlement", flag.ContinueOnError)
+	path := flags.String("file", "", "대사할 정산 파일 CSV 경로")
+	from := flags.String("from", "", "정산 기간 시작일 (YYYY-MM-DD)")
+	to := flags.String("to", "", "정산 기간 종료일 (YYYY-MM-DD, 이 날 포함, 기본값은 시작일)")
+	columnSpec := flags.String("columns", os.Getenv("PAYMENT_SETTLEMENT_COLUMNS"), "정산 파일 열 매핑 (field=column 목록)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *path == "" || *from == "" {
+		fmt.Fprintln(os.Stderr, "reconcile-settlement: -file and -from are required")
+		flags.Usage()
+		return 2
+	}
+	if *to == "" {
+		*to = *from
+	}
+
+	start, err := time.ParseInLocation("2006-01-02", *from, time.Local)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reconcile-settlement: invalid -from %q (use YYYY-MM-DD)\n", *from)
+		return 2
+	}
+	end, err := time.ParseInLocation("2006-01-02", *to, time.Local)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reconcile-settlement: invalid -to %q (use YYYY-MM-DD)\n", *to)
+		return 2
+	}
+	columns, err := payment.ParseSettlementColumns(*columnSpec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reconcile-settlement: %v\n", err)
+		return 2
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		logger.Errorw("정산 파일 열기 실패", "error", err, "file", *path)
+		return 1
+	}
+	defer file.Close()
+
+	result, err := uc.ReconcileSettlement(context.Background(), file, payment.SettlementOptions{
+		Source:  filepath.Base(*path),
+		Columns: columns,
+		From:    start,
+		To:      end.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		logger.Errorw("정산 대사 실패", "error", err, "file", *path)
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(reconciliationResponse(result)); err != nil {
+		logger.Errorw("정산 대사 결과 출력 실패", "error", err)
+	}
+
+	logger.Infow("정산 대사 완료", "id", result.ID, "file", *path, "rows", result.Rows, "matched", result.Matched,
+		"missing", result.Missing, "extra", result.Extra, "amountMismatched", result.AmountMismatched,
+		"statusMismatched", result.StatusMismatched, "invalid", result.Invalid)
+	return 0
+}
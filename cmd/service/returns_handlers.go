@@ -0,0 +1,282 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	orderDomain "example.com/myapp/order/domain"
+	returns "example.com/myapp/returns/application"
+	returnsDomain "example.com/myapp/returns/domain"
+	"example.com/myapp/shared/log"
+	"github.com/labstack/echo/v4"
+)
+
+// returnItemRequest는 반품 요청, 입고와 검수 API에서 공통으로 받는 항목별 수량입니다.
+type returnItemRequest struct {
+	ItemID   string `json:"itemId"`
+	Quantity int    `json:"quantity"`
+}
+
+// toReturnItems는 API 요청 항목을 유스케이스 요청 항목으로 변환합니다.
+func toReturnItems(items []returnItemRequest) []returns.ReturnItemRequest {
+	converted := make([]returns.ReturnItemRequest, len(items))
+	for i, item := range items {
+		converted[i] = returns.ReturnItemRequest{ItemID: item.ItemID, Quantity: item.Quantity}
+	}
+	return converted
+}
+
+// returnResponse는 반품 엔티티를 API 응답 형태로 변환합니다.
+func returnResponse(ret *returnsDomain.Return) map[string]interface{} {
+	lines := make([]map[string]interface{}, len(ret.Lines()))
+	for i, line := range ret.Lines() {
+		lines[i] = map[string]interface{}{
+			"itemId":           line.ItemID(),
+			"skuId":            line.SKUID(),
+			"quantity":         line.Quantity(),
+			"receivedQuantity": line.ReceivedQuantity(),
+			"acceptedQuantity": line.AcceptedQuantity(),
+		}
+	}
+
+	return map[string]interface{}{
+		"id":              ret.ID(),
+		"orderId":         ret.OrderID(),
+		"customerId":      ret.CustomerID(),
+		"reason":          string(ret.Reason()),
+		"comment":         ret.Comment(),
+		"status":          string(ret.Status()),
+		"lines":           lines,
+		"note":            ret.Note(),
+		"refundAmount":    ret.RefundAmount(),
+		"refundReference": ret.RefundReference(),
+		"createdAt":       ret.CreatedAt(),
+		"updatedAt":       ret.UpdatedAt(),
+	}
+}
+
+// returnErrorStatus는 반품 오류에 대응하는 HTTP 상태 코드를 반환합니다.
+func returnErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, returnsDomain.ErrReturnNotFound),
+		errors.Is(err, orderDomain.ErrOrderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, returns.ErrNotOrderCustomer):
+		return http.StatusForbidden
+	case errors.Is(err, returns.ErrOrderNotReturnable),
+		errors.Is(err, returns.ErrRefundUnavailable),
+		errors.Is(err, returnsDomain.ErrReturnStatusTransition),
+		errors.Is(err, orderDomain.ErrOrderConflict):
+		return http.StatusConflict
+	case errors.Is(err, returnsDomain.ErrInvalidOrderID),
+		errors.Is(err, returnsDomain.ErrInvalidCustomerID),
+		errors.Is(err, returnsDomain.ErrInvalidReason),
+		errors.Is(err, returnsDomain.ErrInvalidReturnLines),
+		errors.Is(err, returnsDomain.ErrReturnLineNotFound),
+		errors.Is(err, returnsDomain.ErrInvalidReceivedAmount),
+		errors.Is(err, returnsDomain.ErrInvalidAcceptedAmount),
+		errors.Is(err, returns.ErrItemNotInOrder),
+		errors.Is(err, returns.ErrExceedsReturnableQuantity):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// API 핸들러 함수들 - 반품
+func requestReturnHandler(uc returns.ReturnService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		type request struct {
+			OrderID    string              `json:"orderId"`
+			CustomerID string              `json:"customerId"`
+			Reason     string              `json:"reason"`
+			Comment    string              `json:"comment"`
+			Items      []returnItemRequest `json:"items"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		ret, err := uc.RequestReturn(c.Request().Context(), returns.CreateReturnRequest{
+			OrderID:    req.OrderID,
+			CustomerID: req.CustomerID,
+			Reason:     returnsDomain.ReturnReason(req.Reason),
+			Comment:    req.Comment,
+			Items:      toReturnItems(req.Items),
+		})
+		if err != nil {
+			logger.Errorw("반품 요청 실패", "error", err, "orderId", req.OrderID)
+			return c.JSON(returnErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusCreated, returnResponse(ret))
+	}
+}
+
+func getReturnHandler(uc returns.ReturnService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing return ID"})
+		}
+
+		ret, err := uc.GetReturn(c.Request().Context(), id)
+		if err != nil {
+			logger.Errorw("반품 조회 실패", "error", err, "id", id)
+			return c.JSON(returnErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, returnResponse(ret))
+	}
+}
+
+func getOrderReturnsHandler(uc returns.ReturnService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		orderID := c.Param("orderId")
+		if orderID == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing order ID"})
+		}
+
+		list, err := uc.GetOrderReturns(c.Request().Context(), orderID)
+		if err != nil {
+			logger.Errorw("주문 반품 목록 조회 실패", "error", err, "orderId", orderID)
+			return c.JSON(returnErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		response := make([]map[string]interface{}, len(list))
+		for i, ret := range list {
+			response[i] = returnResponse(ret)
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+func approveReturnHandler(uc returns.ReturnService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing return ID"})
+		}
+
+		// 메모는 선택 사항이므로 본문이 없어도 됩니다
+		type request struct {
+			Note string `json:"note"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		ret, err := uc.ApproveReturn(c.Request().Context(), id, req.Note)
+		if err != nil {
+			logger.Errorw("반품 승인 실패", "error", err, "id", id)
+			return c.JSON(returnErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, returnResponse(ret))
+	}
+}
+
+func rejectReturnHandler(uc returns.ReturnService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing return ID"})
+		}
+
+		// 메모는 선택 사항이므로 본문이 없어도 됩니다
+		type request struct {
+			Note string `json:"note"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		ret, err := uc.RejectReturn(c.Request().Context(), id, req.Note)
+		if err != nil {
+			logger.Errorw("반품 거절 실패", "error", err, "id", id)
+			return c.JSON(returnErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, returnResponse(ret))
+	}
+}
+
+func receiveReturnHandler(uc returns.ReturnService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing return ID"})
+		}
+
+		type request struct {
+			Items []returnItemRequest `json:"items"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		ret, err := uc.ReceiveReturn(c.Request().Context(), id, toReturnItems(req.Items))
+		if err != nil {
+			logger.Errorw("반품 입고 처리 실패", "error", err, "id", id)
+			return c.JSON(returnErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, returnResponse(ret))
+	}
+}
+
+func inspectReturnHandler(uc returns.ReturnService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing return ID"})
+		}
+
+		type request struct {
+			Items []returnItemRequest `json:"items"`
+			Note  string              `json:"note"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		ret, err := uc.InspectReturn(c.Request().Context(), id, returns.InspectReturnRequest{
+			Items: toReturnItems(req.Items),
+			Note:  req.Note,
+		})
+		if err != nil {
+			logger.Errorw("반품 검수 처리 실패", "error", err, "id", id)
+			return c.JSON(returnErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, returnResponse(ret))
+	}
+}
+
+func refundReturnHandler(uc returns.ReturnService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing return ID"})
+		}
+
+		ret, err := uc.RefundReturn(c.Request().Context(), id)
+		if err != nil {
+			logger.Errorw("반품 환불 실패", "error", err, "id", id)
+			return c.JSON(returnErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, returnResponse(ret))
+	}
+}
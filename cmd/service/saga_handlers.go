@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	saga "example.com/myapp/saga/application"
+	sagaDomain "example.com/myapp/saga/domain"
+	"example.com/myapp/shared/log"
+	"github.com/labstack/echo/v4"
+)
+
+// sagaResponse는 사가 엔티티를 API 응답 형태로 변환합니다.
+func sagaResponse(s *sagaDomain.Saga) map[string]interface{} {
+	steps := make([]map[string]interface{}, len(s.Steps()))
+	for i, step := range s.Steps() {
+		steps[i] = map[string]interface{}{
+			"name":         string(step.Name()),
+			"compensation": step.IsCompensation(),
+			"status":       string(step.Status()),
+			"attempts":     step.Attempts(),
+			"lastError":    step.LastError(),
+			"updatedAt":    step.UpdatedAt(),
+		}
+	}
+
+	return map[string]interface{}{
+		"id":            s.ID(),
+		"kind":          string(s.Kind()),
+		"orderId":       s.OrderID(),
+		"reason":        s.Reason(),
+		"status":        string(s.Status()),
+		"steps":         steps,
+		"lastError":     s.LastError(),
+		"nextAttemptAt": s.NextAttemptAt(),
+		"createdAt":     s.CreatedAt(),
+		"updatedAt":     s.UpdatedAt(),
+	}
+}
+
+// sagaErrorStatus는 사가 오류에 대응하는 HTTP 상태 코드를 반환합니다.
+func sagaErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, sagaDomain.ErrSagaNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, sagaDomain.ErrSagaFinished),
+		errors.Is(err, sagaDomain.ErrSagaRunning):
+		return http.StatusConflict
+	case errors.Is(err, sagaDomain.ErrInvalidOrderID),
+		errors.Is(err, saga.ErrInvalidSagaStatus):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// API 핸들러 함수들 - 사가
+func listSagasHandler(uc saga.SagaService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		status := sagaDomain.SagaStatus(c.QueryParam("status"))
+		if status == "" {
+			status = sagaDomain.StatusFailed
+		}
+
+		limit := 0
+		if v := c.QueryParam("limit"); v != "" {
+			var err error
+			if limit, err = strconv.Atoi(v); err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid limit"})
+			}
+		}
+
+		list, err := uc.ListSagas(c.Request().Context(), status, limit)
+		if err != nil {
+			logger.Errorw("사가 목록 조회 실패", "error", err, "status", status)
+			return c.JSON(sagaErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		response := make([]map[string]interface{}, len(list))
+		for i, s := range list {
+			response[i] = sagaResponse(s)
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+func getSagaHandler(uc saga.SagaService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing saga ID"})
+		}
+
+		s, err := uc.GetSaga(c.Request().Context(), id)
+		if err != nil {
+			logger.Errorw("사가 조회 실패", "error", err, "id", id)
+			return c.JSON(sagaErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, sagaResponse(s))
+	}
+}
+
+func getOrderSagasHandler(uc saga.SagaService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		orderID := c.Param("orderId")
+		if orderID == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing order ID"})
+		}
+
+		list, err := uc.GetOrderSagas(c.Request().Context(), orderID)
+		if err != nil {
+			logger.Errorw("주문 사가 목록 조회 실패", "error", err, "orderId", orderID)
+			return c.JSON(sagaErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		response := make([]map[string]interface{}, len(list))
+		for i, s := range list {
+			response[i] = sagaResponse(s)
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// retrySagaHandler는 재시도 한도를 넘어 실패한 사가를 원인을 해결한 뒤 다시 진행합니다.
+func retrySagaHandler(uc saga.SagaService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing saga ID"})
+		}
+
+		s, err := uc.RetrySaga(c.Request().Context(), id)
+		if err != nil {
+			logger.Errorw("사가 재시도 실패", "error", err, "id", id)
+			return c.JSON(sagaErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, sagaResponse(s))
+	}
+}
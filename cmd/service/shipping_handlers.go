@@ -0,0 +1,233 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	orderDomain "example.com/myapp/order/domain"
+	"example.com/myapp/shared/log"
+	shipping "example.com/myapp/shipping/application"
+	shippingDomain "example.com/myapp/shipping/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// carrierSignatureHeader는 택배사 웹훅 서명이 담기는 헤더입니다.
+const carrierSignatureHeader = "X-Carrier-Signature"
+
+// shipmentResponse는 배송 엔티티를 API 응답 형태로 변환합니다.
+func shipmentResponse(shipment *shippingDomain.Shipment) map[string]interface{} {
+	packages := make([]map[string]interface{}, len(shipment.Packages()))
+	for i, pkg := range shipment.Packages() {
+		items := make([]map[string]interface{}, len(pkg.Items()))
+		for j, item := range pkg.Items() {
+			items[j] = map[string]interface{}{
+				"skuId":    item.SKUID(),
+				"quantity": item.Quantity(),
+			}
+		}
+		packages[i] = map[string]interface{}{
+			"id":          pkg.ID(),
+			"weightGrams": pkg.WeightGrams(),
+			"items":       items,
+		}
+	}
+
+	events := make([]map[string]interface{}, len(shipment.Events()))
+	for i, event := range shipment.Events() {
+		events[i] = map[string]interface{}{
+			"status":      string(event.Status()),
+			"description": event.Description(),
+			"location":    event.Location(),
+			"occurredAt":  event.OccurredAt(),
+		}
+	}
+
+	response := map[string]interface{}{
+		"id":             shipment.ID(),
+		"orderId":        shipment.OrderID(),
+		"carrier":        shipment.Carrier(),
+		"trackingNumber": shipment.TrackingNumber(),
+		"status":         string(shipment.Status()),
+		"packages":       packages,
+		"events":         events,
+		"createdAt":      shipment.CreatedAt(),
+	}
+	if shipment.IsDelivered() {
+		response["deliveredAt"] = shipment.DeliveredAt()
+	}
+	return response
+}
+
+// shippingErrorStatus는 배송 오류에 대응하는 HTTP 상태 코드를 반환합니다.
+func shippingErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, shippingDomain.ErrShipmentNotFound),
+		errors.Is(err, orderDomain.ErrOrderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, shippingDomain.ErrDuplicateTracking),
+		errors.Is(err, shipping.ErrOrderNotShippable),
+		errors.Is(err, orderDomain.ErrOrderConflict):
+		return http.StatusConflict
+	case errors.Is(err, shipping.ErrInvalidWebhook):
+		return http.StatusUnauthorized
+	case errors.Is(err, shippingDomain.ErrInvalidOrderID),
+		errors.Is(err, shippingDomain.ErrInvalidCarrier),
+		errors.Is(err, shippingDomain.ErrInvalidTrackingNumber),
+		errors.Is(err, shippingDomain.ErrInvalidPackage),
+		errors.Is(err, shippingDomain.ErrInvalidPackageItem),
+		errors.Is(err, shippingDomain.ErrInvalidEventStatus),
+		errors.Is(err, shipping.ErrExceedsOrderedQuantity),
+		errors.Is(err, shipping.ErrUnknownCarrier):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// API 핸들러 함수들 - 배송
+func createShipmentHandler(uc shipping.ShippingService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		type itemRequest struct {
+			SKUID    string `json:"skuId"`
+			Quantity int    `json:"quantity"`
+		}
+		type packageRequest struct {
+			WeightGrams int           `json:"weightGrams"`
+			Items       []itemRequest `json:"items"`
+		}
+		type request struct {
+			OrderID        string           `json:"orderId"`
+			Carrier        string           `json:"carrier"`
+			TrackingNumber string           `json:"trackingNumber"`
+			Packages       []packageRequest `json:"packages"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		packages := make([]shipping.PackageRequest, len(req.Packages))
+		for i, pkg := range req.Packages {
+			items := make([]shipping.PackageItemRequest, len(pkg.Items))
+			for j, item := range pkg.Items {
+				items[j] = shipping.PackageItemRequest{SKUID: item.SKUID, Quantity: item.Quantity}
+			}
+			packages[i] = shipping.PackageRequest{WeightGrams: pkg.WeightGrams, Items: items}
+		}
+
+		shipment, err := uc.CreateShipment(c.Request().Context(), shipping.CreateShipmentRequest{
+			OrderID:        req.OrderID,
+			Carrier:        req.Carrier,
+			TrackingNumber: req.TrackingNumber,
+			Packages:       packages,
+		})
+		if err != nil {
+			logger.Errorw("배송 생성 실패", "error", err, "orderId", req.OrderID)
+			return c.JSON(shippingErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusCreated, shipmentResponse(shipment))
+	}
+}
+
+func getShipmentHandler(uc shipping.ShippingService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing shipment ID"})
+		}
+
+		shipment, err := uc.GetShipment(c.Request().Context(), id)
+		if err != nil {
+			logger.Errorw("배송 조회 실패", "error", err, "id", id)
+			return c.JSON(shippingErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, shipmentResponse(shipment))
+	}
+}
+
+func getOrderShipmentsHandler(uc shipping.ShippingService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		orderID := c.Param("orderId")
+		if orderID == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing order ID"})
+		}
+
+		shipments, err := uc.GetOrderShipments(c.Request().Context(), orderID)
+		if err != nil {
+			logger.Errorw("주문 배송 목록 조회 실패", "error", err, "orderId", orderID)
+			return c.JSON(shippingErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		response := make([]map[string]interface{}, len(shipments))
+		for i, shipment := range shipments {
+			response[i] = shipmentResponse(shipment)
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+func recordShipmentEventHandler(uc shipping.ShippingService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		if id == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Missing shipment ID"})
+		}
+
+		type request struct {
+			Status      string     `json:"status"`
+			Description string     `json:"description"`
+			Location    string     `json:"location"`
+			OccurredAt  *time.Time `json:"occurredAt"`
+		}
+
+		var req request
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		eventReq := shipping.TrackingEventRequest{
+			Status:      shippingDomain.ShipmentStatus(req.Status),
+			Description: req.Description,
+			Location:    req.Location,
+		}
+		if req.OccurredAt != nil {
+			eventReq.OccurredAt = *req.OccurredAt
+		}
+
+		shipment, err := uc.RecordEvent(c.Request().Context(), id, eventReq)
+		if err != nil {
+			logger.Errorw("배송 추적 이벤트 기록 실패", "error", err, "id", id, "status", req.Status)
+			return c.JSON(shippingErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, shipmentResponse(shipment))
+	}
+}
+
+// carrierWebhookHandler는 택배사의 배송 추적 웹훅을 받습니다.
+// 서명은 원본 본문으로 검증해야 하므로 본문을 바인딩하지 않고 그대로 전달합니다.
+func carrierWebhookHandler(uc shipping.ShippingService, logger *log.Logger) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		carrier := c.Param("carrier")
+
+		payload, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		}
+
+		signature := c.Request().Header.Get(carrierSignatureHeader)
+		applied, err := uc.ReceiveTrackingWebhook(c.Request().Context(), carrier, signature, payload)
+		if err != nil {
+			logger.Errorw("택배사 웹훅 처리 실패", "error", err, "carrier", carrier)
+			return c.JSON(shippingErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, map[string]interface{}{"applied": applied})
+	}
+}
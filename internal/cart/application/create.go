@@ -0,0 +1,283 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"example.com/myapp/cart/domain"
+)
+
+var (
+	ErrInvalidCartID      = errors.New("invalid cart ID")
+	ErrProductNotFound    = errors.New("product not found in catalog")
+	ErrProductUnavailable = errors.New("product is not available for sale")
+	ErrMemberRequired     = errors.New("guest carts must be merged into a member cart before checkout")
+)
+
+// CreateCart는 장바구니를 생성합니다.
+// 회원 ID가 주어지면 이미 사용 중인 회원 장바구니가 있을 때 그 장바구니를 반환합니다.
+func (uc *CartUseCase) CreateCart(ctx context.Context, memberID string) (*domain.Cart, error) {
+	if memberID == "" {
+		cart := domain.NewGuestCart()
+		if err := uc.repo.Save(ctx, cart); err != nil {
+			return nil, err
+		}
+		return cart, nil
+	}
+
+	cart, err := uc.repo.FindActiveByMemberID(ctx, memberID)
+	if err == nil {
+		return cart, nil
+	}
+	if !errors.Is(err, domain.ErrCartNotFound) {
+		return nil, err
+	}
+
+	cart, err = domain.NewMemberCart(memberID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Save(ctx, cart); err != nil {
+		// 동시에 생성된 회원 장바구니가 있으면 그 장바구니를 사용합니다
+		if errors.Is(err, domain.ErrCartConflict) {
+			return uc.repo.FindActiveByMemberID(ctx, memberID)
+		}
+		return nil, err
+	}
+	return cart, nil
+}
+
+// GetCart는 장바구니를 현재 카탈로그 가격으로 다시 계산하여 조회합니다.
+func (uc *CartUseCase) GetCart(ctx context.Context, id string) (*PricedCart, error) {
+	cart, err := uc.findCart(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.price(ctx, cart)
+}
+
+// GetMemberCart는 회원이 사용 중인 장바구니를 조회합니다.
+func (uc *CartUseCase) GetMemberCart(ctx context.Context, memberID string) (*PricedCart, error) {
+	if memberID == "" {
+		return nil, domain.ErrInvalidMemberID
+	}
+
+	cart, err := uc.repo.FindActiveByMemberID(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.price(ctx, cart)
+}
+
+// AddItem은 상품을 장바구니에 담습니다.
+// 카탈로그에서 판매 중인 SKU인지 확인한 뒤 담습니다.
+func (uc *CartUseCase) AddItem(ctx context.Context, cartID string, req AddItemRequest) (*PricedCart, error) {
+	if req.Quantity <= 0 {
+		return nil, domain.ErrInvalidQuantity
+	}
+
+	cart, err := uc.findCart(ctx, cartID)
+	if err != nil {
+		return nil, err
+	}
+
+	// SKU를 생략한 단일 SKU 상품도 실제 SKU ID로 담기도록 카탈로그에서 확인합니다
+	product, err := uc.pricer.PriceOf(ctx, req.ProductID, req.SKUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cart.AddLine(product.ProductID, product.SKUID, req.Quantity); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, cart); err != nil {
+		return nil, err
+	}
+	return uc.price(ctx, cart)
+}
+
+// UpdateItem은 장바구니에 담긴 SKU의 수량을 변경합니다. 수량이 0이면 제거합니다.
+func (uc *CartUseCase) UpdateItem(ctx context.Context, cartID, skuID string, quantity int) (*PricedCart, error) {
+	cart, err := uc.findCart(ctx, cartID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cart.UpdateQuantity(skuID, quantity); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, cart); err != nil {
+		return nil, err
+	}
+	return uc.price(ctx, cart)
+}
+
+// RemoveItem은 장바구니에서 SKU를 제거합니다.
+func (uc *CartUseCase) RemoveItem(ctx context.Context, cartID, skuID string) (*PricedCart, error) {
+	cart, err := uc.findCart(ctx, cartID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cart.RemoveLine(skuID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, cart); err != nil {
+		return nil, err
+	}
+	return uc.price(ctx, cart)
+}
+
+// MergeGuestCart는 로그인한 회원의 장바구니에 비회원 장바구니를 합칩니다.
+// 이미 병합된 비회원 장바구니로 다시 호출하면 회원 장바구니를 그대로 반환합니다.
+func (uc *CartUseCase) MergeGuestCart(ctx context.Context, guestCartID, memberID string) (*PricedCart, error) {
+	if memberID == "" {
+		return nil, domain.ErrInvalidMemberID
+	}
+
+	guest, err := uc.findCart(ctx, guestCartID)
+	if err != nil {
+		return nil, err
+	}
+	if !guest.IsGuest() {
+		return nil, domain.ErrNotGuestCart
+	}
+
+	if guest.Status() == domain.CartStatusMerged {
+		member, err := uc.CreateCart(ctx, memberID)
+		if err != nil {
+			return nil, err
+		}
+		return uc.price(ctx, member)
+	}
+
+	member, err := uc.repo.FindActiveByMemberID(ctx, memberID)
+	memberIsNew := false
+	if errors.Is(err, domain.ErrCartNotFound) {
+		member, err = domain.NewMemberCart(memberID)
+		memberIsNew = true
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := member.MergeFrom(guest); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Merge(ctx, guest, member, memberIsNew); err != nil {
+		return nil, err
+	}
+	return uc.price(ctx, member)
+}
+
+// Checkout은 장바구니 내용으로 주문을 생성하고 장바구니를 비웁니다.
+// 생성된 주문 ID를 장바구니에 기록하므로 같은 장바구니로 다시 요청해도 주문이 중복 생성되지 않습니다.
+// 쿠폰 코드는 주문 생성 시 할인 계산에 사용됩니다.
+func (uc *CartUseCase) Checkout(ctx context.Context, cartID string, couponCodes []string) (string, error) {
+	cart, err := uc.findCart(ctx, cartID)
+	if err != nil {
+		return "", err
+	}
+
+	if cart.Status() == domain.CartStatusCheckedOut {
+		return cart.OrderID(), nil
+	}
+	if cart.IsGuest() {
+		return "", ErrMemberRequired
+	}
+
+	// 1. 결제 진행 상태로 저장하여 동시 요청이 주문을 중복 생성하지 못하게 합니다
+	if err := cart.BeginCheckout(time.Now()); err != nil {
+		return "", err
+	}
+	if err := uc.repo.Update(ctx, cart); err != nil {
+		if errors.Is(err, domain.ErrCartConflict) {
+			return uc.checkoutResult(ctx, cartID)
+		}
+		return "", err
+	}
+
+	// 2. 주문 생성
+	lines := make([]CheckoutLine, 0, len(cart.Lines()))
+	for _, line := range cart.Lines() {
+		lines = append(lines, CheckoutLine{
+			ProductID: line.ProductID(),
+			SKUID:     line.SKUID(),
+			Quantity:  line.Quantity(),
+		})
+	}
+
+	orderID, err := uc.orders.PlaceOrder(ctx, cart.MemberID(), lines, couponCodes)
+	if err != nil {
+		// 주문 생성에 실패하면 장바구니를 다시 사용할 수 있게 되돌립니다
+		cart.AbortCheckout()
+		if updateErr := uc.repo.Update(ctx, cart); updateErr != nil {
+			return "", fmt.Errorf("%w (failed to reopen cart: %v)", err, updateErr)
+		}
+		return "", err
+	}
+
+	// 3. 주문 ID를 기록하고 장바구니 비우기
+	cart.CompleteCheckout(orderID)
+	if err := uc.repo.Update(ctx, cart); err != nil {
+		return "", fmt.Errorf("order %s created but failed to clear cart: %w", orderID, err)
+	}
+
+	return orderID, nil
+}
+
+// checkoutResult는 다른 요청과 경합한 결제 요청의 결과를 결정합니다.
+func (uc *CartUseCase) checkoutResult(ctx context.Context, cartID string) (string, error) {
+	cart, err := uc.findCart(ctx, cartID)
+	if err != nil {
+		return "", err
+	}
+	if cart.Status() == domain.CartStatusCheckedOut {
+		return cart.OrderID(), nil
+	}
+	return "", domain.ErrCheckoutInProgress
+}
+
+func (uc *CartUseCase) findCart(ctx context.Context, id string) (*domain.Cart, error) {
+	if id == "" {
+		return nil, ErrInvalidCartID
+	}
+	return uc.repo.FindByID(ctx, id)
+}
+
+// price는 장바구니의 각 라인을 현재 카탈로그 가격으로 계산합니다.
+func (uc *CartUseCase) price(ctx context.Context, cart *domain.Cart) (*PricedCart, error) {
+	priced := &PricedCart{
+		Cart:  cart,
+		Lines: make([]PricedLine, 0, len(cart.Lines())),
+	}
+
+	for _, line := range cart.Lines() {
+		product, err := uc.pricer.PriceOf(ctx, line.ProductID(), line.SKUID())
+		if err != nil {
+			if errors.Is(err, ErrProductNotFound) || errors.Is(err, ErrProductUnavailable) {
+				priced.Lines = append(priced.Lines, PricedLine{Line: line})
+				continue
+			}
+			return nil, err
+		}
+
+		subtotal := product.Price * float64(line.Quantity())
+		priced.Lines = append(priced.Lines, PricedLine{
+			Line:      line,
+			Name:      product.Name,
+			UnitPrice: product.Price,
+			Subtotal:  subtotal,
+			Available: true,
+		})
+		priced.Total += subtotal
+	}
+
+	return priced, nil
+}
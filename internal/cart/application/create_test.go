@@ -0,0 +1,334 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"example.com/myapp/cart/domain"
+)
+
+// FakeCartRepository는 테스트를 위한 가짜 CartRepository 구현체입니다.
+// Postgres 구현과 마찬가지로 버전이 다르면 변경을 거부합니다.
+type FakeCartRepository struct {
+	mu    sync.Mutex
+	carts map[string]*domain.Cart
+}
+
+// NewFakeCartRepository는 새로운 FakeCartRepository 인스턴스를 생성합니다.
+func NewFakeCartRepository() *FakeCartRepository {
+	return &FakeCartRepository{
+		carts: make(map[string]*domain.Cart),
+	}
+}
+
+func (f *FakeCartRepository) Save(ctx context.Context, cart *domain.Cart) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.carts[cart.ID()] = copyCart(cart)
+	return nil
+}
+
+func (f *FakeCartRepository) FindByID(ctx context.Context, id string) (*domain.Cart, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cart, ok := f.carts[id]
+	if !ok {
+		return nil, domain.ErrCartNotFound
+	}
+	return copyCart(cart), nil
+}
+
+func (f *FakeCartRepository) FindActiveByMemberID(ctx context.Context, memberID string) (*domain.Cart, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, cart := range f.carts {
+		if cart.MemberID() == memberID &&
+			(cart.Status() == domain.CartStatusActive || cart.Status() == domain.CartStatusCheckingOut) {
+			return copyCart(cart), nil
+		}
+	}
+	return nil, domain.ErrCartNotFound
+}
+
+func (f *FakeCartRepository) Update(ctx context.Context, cart *domain.Cart) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.update(cart)
+}
+
+func (f *FakeCartRepository) Merge(ctx context.Context, guest, member *domain.Cart, memberIsNew bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.update(guest); err != nil {
+		return err
+	}
+	if memberIsNew {
+		f.carts[member.ID()] = copyCart(member)
+		return nil
+	}
+	return f.update(member)
+}
+
+func (f *FakeCartRepository) update(cart *domain.Cart) error {
+	stored, ok := f.carts[cart.ID()]
+	if !ok || stored.Version() != cart.Version() {
+		return domain.ErrCartConflict
+	}
+	cart.AdvanceVersion()
+	f.carts[cart.ID()] = copyCart(cart)
+	return nil
+}
+
+// copyCart는 저장된 상태의 복사본을 만들어 실제 DB 조회처럼 동작하게 합니다.
+func copyCart(cart *domain.Cart) *domain.Cart {
+	lines := make([]*domain.CartLine, len(cart.Lines()))
+	for i, line := range cart.Lines() {
+		lines[i] = domain.RestoreCartLine(line.ProductID(), line.SKUID(), line.Quantity(), line.AddedAt())
+	}
+	return domain.RestoreCart(
+		cart.ID(), cart.MemberID(), lines, cart.Status(), cart.OrderID(),
+		cart.Version(), cart.CreatedAt(), cart.UpdatedAt(),
+	)
+}
+
+// FakeProductPricer는 테스트를 위한 가짜 ProductPricer 구현체입니다.
+type FakeProductPricer struct {
+	mu       sync.Mutex
+	products map[string]*PricedProduct
+	archived map[string]bool
+}
+
+// NewFakeProductPricer는 새로운 FakeProductPricer 인스턴스를 생성합니다.
+func NewFakeProductPricer() *FakeProductPricer {
+	return &FakeProductPricer{
+		products: make(map[string]*PricedProduct),
+		archived: make(map[string]bool),
+	}
+}
+
+func (f *FakeProductPricer) SetPrice(productID, skuID, name string, price float64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.products[productID] = &PricedProduct{ProductID: productID, SKUID: skuID, Name: name, Price: price}
+}
+
+func (f *FakeProductPricer) Archive(productID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.archived[productID] = true
+}
+
+func (f *FakeProductPricer) PriceOf(ctx context.Context, productID, skuID string) (*PricedProduct, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	product, ok := f.products[productID]
+	if !ok || (skuID != "" && skuID != product.SKUID) {
+		return nil, ErrProductNotFound
+	}
+	if f.archived[productID] {
+		return nil, ErrProductUnavailable
+	}
+	copied := *product
+	return &copied, nil
+}
+
+// FakeOrderPlacer는 테스트를 위한 가짜 OrderPlacer 구현체입니다.
+type FakeOrderPlacer struct {
+	mu     sync.Mutex
+	orders [][]CheckoutLine
+	err    error
+}
+
+func (f *FakeOrderPlacer) PlaceOrder(ctx context.Context, customerID string, lines []CheckoutLine, couponCodes []string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return "", f.err
+	}
+	f.orders = append(f.orders, lines)
+	return "order-" + customerID, nil
+}
+
+func newTestCartUseCase() (*CartUseCase, *FakeCartRepository, *FakeProductPricer, *FakeOrderPlacer) {
+	repo := NewFakeCartRepository()
+	pricer := NewFakeProductPricer()
+	pricer.SetPrice("product-1", "sku-1", "키보드 - 적축", 10000)
+	pricer.SetPrice("product-2", "sku-2", "마우스", 5000)
+	orders := &FakeOrderPlacer{}
+	return NewCartUseCase(repo, pricer, orders), repo, pricer, orders
+}
+
+func TestGetCartRepricesFromCatalog(t *testing.T) {
+	useCase, _, pricer, _ := newTestCartUseCase()
+	ctx := context.Background()
+
+	cart, err := useCase.CreateCart(ctx, "")
+	if err != nil {
+		t.Fatalf("CreateCart() error = %v", err)
+	}
+	// SKU를 생략해도 단일 SKU 상품의 실제 SKU로 담깁니다
+	if _, err := useCase.AddItem(ctx, cart.ID(), AddItemRequest{ProductID: "product-1", Quantity: 2}); err != nil {
+		t.Fatalf("AddItem() error = %v", err)
+	}
+	if _, err := useCase.AddItem(ctx, cart.ID(), AddItemRequest{ProductID: "product-2", SKUID: "sku-2", Quantity: 1}); err != nil {
+		t.Fatalf("AddItem() error = %v", err)
+	}
+
+	// 담은 이후 가격 변경과 판매 중단이 조회 시점에 반영되어야 합니다
+	pricer.SetPrice("product-1", "sku-1", "키보드 - 적축", 8000)
+	pricer.Archive("product-2")
+
+	priced, err := useCase.GetCart(ctx, cart.ID())
+	if err != nil {
+		t.Fatalf("GetCart() error = %v", err)
+	}
+
+	if priced.Lines[0].Line.SKUID() != "sku-1" || priced.Lines[0].UnitPrice != 8000 {
+		t.Errorf("line[0] = %v @ %v, want sku-1 @ 8000", priced.Lines[0].Line.SKUID(), priced.Lines[0].UnitPrice)
+	}
+	if priced.Lines[1].Available {
+		t.Errorf("판매 중단된 상품이 주문 가능으로 표시되었습니다")
+	}
+	if priced.Total != 16000 {
+		t.Errorf("Total = %v, want 16000", priced.Total)
+	}
+}
+
+func TestMergeGuestCartIntoMemberCart(t *testing.T) {
+	useCase, _, _, _ := newTestCartUseCase()
+	ctx := context.Background()
+
+	member, err := useCase.CreateCart(ctx, "member-1")
+	if err != nil {
+		t.Fatalf("CreateCart() error = %v", err)
+	}
+	if _, err := useCase.AddItem(ctx, member.ID(), AddItemRequest{ProductID: "product-1", Quantity: 1}); err != nil {
+		t.Fatalf("AddItem() error = %v", err)
+	}
+
+	guest, err := useCase.CreateCart(ctx, "")
+	if err != nil {
+		t.Fatalf("CreateCart() error = %v", err)
+	}
+	if _, err := useCase.AddItem(ctx, guest.ID(), AddItemRequest{ProductID: "product-1", Quantity: 2}); err != nil {
+		t.Fatalf("AddItem() error = %v", err)
+	}
+	if _, err := useCase.AddItem(ctx, guest.ID(), AddItemRequest{ProductID: "product-2", Quantity: 1}); err != nil {
+		t.Fatalf("AddItem() error = %v", err)
+	}
+
+	merged, err := useCase.MergeGuestCart(ctx, guest.ID(), "member-1")
+	if err != nil {
+		t.Fatalf("MergeGuestCart() error = %v", err)
+	}
+	if merged.Cart.ID() != member.ID() {
+		t.Errorf("병합 대상 장바구니 = %v, want %v", merged.Cart.ID(), member.ID())
+	}
+	if len(merged.Lines) != 2 || merged.Lines[0].Line.Quantity() != 3 {
+		t.Errorf("병합 결과가 올바르지 않습니다: lines = %v", len(merged.Lines))
+	}
+
+	// 다시 호출해도 수량이 두 번 더해지지 않습니다
+	again, err := useCase.MergeGuestCart(ctx, guest.ID(), "member-1")
+	if err != nil {
+		t.Fatalf("MergeGuestCart() retry error = %v", err)
+	}
+	if again.Lines[0].Line.Quantity() != 3 {
+		t.Errorf("재병합 후 수량 = %v, want 3", again.Lines[0].Line.Quantity())
+	}
+
+	if _, err := useCase.AddItem(ctx, guest.ID(), AddItemRequest{ProductID: "product-1", Quantity: 1}); !errors.Is(err, domain.ErrCartNotActive) {
+		t.Errorf("병합된 장바구니에 담기 error = %v, want %v", err, domain.ErrCartNotActive)
+	}
+}
+
+func TestCheckoutIsIdempotent(t *testing.T) {
+	useCase, repo, _, orders := newTestCartUseCase()
+	ctx := context.Background()
+
+	cart, err := useCase.CreateCart(ctx, "member-1")
+	if err != nil {
+		t.Fatalf("CreateCart() error = %v", err)
+	}
+	if _, err := useCase.AddItem(ctx, cart.ID(), AddItemRequest{ProductID: "product-1", Quantity: 2}); err != nil {
+		t.Fatalf("AddItem() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	results := make([]string, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			orderID, err := useCase.Checkout(ctx, cart.ID(), nil)
+			if err != nil && !errors.Is(err, domain.ErrCheckoutInProgress) {
+				t.Errorf("Checkout() unexpected error = %v", err)
+			}
+			results[i] = orderID
+		}(i)
+	}
+	wg.Wait()
+
+	if len(orders.orders) != 1 {
+		t.Fatalf("생성된 주문 수 = %v, want 1", len(orders.orders))
+	}
+
+	orderID, err := useCase.Checkout(ctx, cart.ID(), nil)
+	if err != nil || orderID != "order-member-1" {
+		t.Errorf("Checkout() retry = %v, %v, want order-member-1", orderID, err)
+	}
+
+	stored, _ := repo.FindByID(ctx, cart.ID())
+	if stored.Status() != domain.CartStatusCheckedOut || len(stored.Lines()) != 0 {
+		t.Errorf("주문 후 장바구니 = %v (%v lines), want checked_out and empty", stored.Status(), len(stored.Lines()))
+	}
+
+	// 주문 후에는 새 회원 장바구니가 만들어집니다
+	next, err := useCase.CreateCart(ctx, "member-1")
+	if err != nil {
+		t.Fatalf("CreateCart() error = %v", err)
+	}
+	if next.ID() == cart.ID() {
+		t.Errorf("주문된 장바구니가 재사용되었습니다")
+	}
+}
+
+func TestCheckoutFailureReopensCart(t *testing.T) {
+	useCase, repo, _, orders := newTestCartUseCase()
+	ctx := context.Background()
+	errOutOfStock := errors.New("out of stock")
+
+	cart, err := useCase.CreateCart(ctx, "member-1")
+	if err != nil {
+		t.Fatalf("CreateCart() error = %v", err)
+	}
+	if _, err := useCase.AddItem(ctx, cart.ID(), AddItemRequest{ProductID: "product-1", Quantity: 1}); err != nil {
+		t.Fatalf("AddItem() error = %v", err)
+	}
+
+	orders.err = errOutOfStock
+	if _, err := useCase.Checkout(ctx, cart.ID(), nil); !errors.Is(err, errOutOfStock) {
+		t.Fatalf("Checkout() error = %v, want %v", err, errOutOfStock)
+	}
+
+	stored, _ := repo.FindByID(ctx, cart.ID())
+	if stored.Status() != domain.CartStatusActive || len(stored.Lines()) != 1 {
+		t.Errorf("실패 후 장바구니 = %v (%v lines), want active with 1 line", stored.Status(), len(stored.Lines()))
+	}
+
+	guest, _ := useCase.CreateCart(ctx, "")
+	if _, err := useCase.Checkout(ctx, guest.ID(), nil); !errors.Is(err, ErrMemberRequired) {
+		t.Errorf("비회원 Checkout() error = %v, want %v", err, ErrMemberRequired)
+	}
+}
@@ -0,0 +1,97 @@
+package application
+
+import (
+	"context"
+
+	"example.com/myapp/cart/domain"
+)
+
+// CartRepository는 장바구니 관련 영속성 인터페이스를 정의합니다.
+type CartRepository interface {
+	Save(ctx context.Context, cart *domain.Cart) error
+	FindByID(ctx context.Context, id string) (*domain.Cart, error)
+	FindActiveByMemberID(ctx context.Context, memberID string) (*domain.Cart, error)
+	// Update는 낙관적 잠금으로 장바구니를 저장합니다.
+	// 조회 이후 다른 요청이 먼저 변경했다면 domain.ErrCartConflict를 반환합니다.
+	Update(ctx context.Context, cart *domain.Cart) error
+	// Merge는 비회원 장바구니와 회원 장바구니를 하나의 트랜잭션으로 저장합니다.
+	// 회원 장바구니가 아직 저장되지 않았다면 새로 저장합니다.
+	Merge(ctx context.Context, guest, member *domain.Cart, memberIsNew bool) error
+}
+
+// ProductPricer는 장바구니 라인의 현재 상품 정보와 가격을 조회하는 카탈로그 포트를 정의합니다.
+type ProductPricer interface {
+	PriceOf(ctx context.Context, productID, skuID string) (*PricedProduct, error)
+}
+
+// PricedProduct는 카탈로그에서 조회한 현재 상품 정보를 정의합니다.
+type PricedProduct struct {
+	ProductID string
+	SKUID     string
+	Name      string
+	Price     float64
+}
+
+// OrderPlacer는 장바구니 내용으로 주문을 생성하는 주문 포트를 정의합니다.
+type OrderPlacer interface {
+	PlaceOrder(ctx context.Context, customerID string, lines []CheckoutLine, couponCodes []string) (string, error)
+}
+
+// CheckoutLine은 주문으로 전달할 장바구니 라인을 정의합니다.
+type CheckoutLine struct {
+	ProductID string
+	SKUID     string
+	Quantity  int
+}
+
+// CartService는 장바구니 관련 비즈니스 로직을 정의합니다.
+type CartService interface {
+	CreateCart(ctx context.Context, memberID string) (*domain.Cart, error)
+	GetCart(ctx context.Context, id string) (*PricedCart, error)
+	GetMemberCart(ctx context.Context, memberID string) (*PricedCart, error)
+	AddItem(ctx context.Context, cartID string, req AddItemRequest) (*PricedCart, error)
+	UpdateItem(ctx context.Context, cartID, skuID string, quantity int) (*PricedCart, error)
+	RemoveItem(ctx context.Context, cartID, skuID string) (*PricedCart, error)
+	MergeGuestCart(ctx context.Context, guestCartID, memberID string) (*PricedCart, error)
+	Checkout(ctx context.Context, cartID string, couponCodes []string) (string, error)
+}
+
+// AddItemRequest는 장바구니 담기 요청 정보를 정의합니다.
+type AddItemRequest struct {
+	ProductID string
+	SKUID     string
+	Quantity  int
+}
+
+// PricedCart는 현재 카탈로그 가격으로 다시 계산된 장바구니를 정의합니다.
+type PricedCart struct {
+	Cart  *domain.Cart
+	Lines []PricedLine
+	Total float64
+}
+
+// PricedLine은 현재 가격이 적용된 장바구니 라인을 정의합니다.
+// 판매 중단 등으로 가격을 구할 수 없는 라인은 Available이 false이며 합계에서 제외됩니다.
+type PricedLine struct {
+	Line      *domain.CartLine
+	Name      string
+	UnitPrice float64
+	Subtotal  float64
+	Available bool
+}
+
+// CartUseCase는 CartService 구현체를 정의합니다.
+type CartUseCase struct {
+	repo   CartRepository
+	pricer ProductPricer
+	orders OrderPlacer
+}
+
+// NewCartUseCase는 새로운 CartUseCase 인스턴스를 생성합니다.
+func NewCartUseCase(repo CartRepository, pricer ProductPricer, orders OrderPlacer) *CartUseCase {
+	return &CartUseCase{
+		repo:   repo,
+		pricer: pricer,
+		orders: orders,
+	}
+}
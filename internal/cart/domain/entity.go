@@ -0,0 +1,310 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CartStatus는 장바구니 상태를 정의합니다.
+type CartStatus string
+
+const (
+	CartStatusActive      CartStatus = "active"
+	CartStatusCheckingOut CartStatus = "checking_out"
+	CartStatusCheckedOut  CartStatus = "checked_out"
+	CartStatusMerged      CartStatus = "merged"
+)
+
+// checkoutLockTimeout은 중단된 결제 진행 상태를 다시 시도할 수 있게 되기까지의 시간입니다.
+const checkoutLockTimeout = 5 * time.Minute
+
+var (
+	ErrCartNotFound       = errors.New("cart not found")
+	ErrCartNotActive      = errors.New("cart is not active")
+	ErrCartEmpty          = errors.New("cart is empty")
+	ErrCartConflict       = errors.New("cart was modified concurrently")
+	ErrCheckoutInProgress = errors.New("cart checkout is already in progress")
+	ErrInvalidQuantity    = errors.New("quantity must be positive")
+	ErrLineNotFound       = errors.New("cart line not found")
+	ErrNotGuestCart       = errors.New("only guest carts can be merged")
+	ErrInvalidMemberID    = errors.New("invalid member ID")
+)
+
+// CartLine은 장바구니에 담긴 SKU 한 줄을 나타냅니다.
+// 가격은 담을 때가 아니라 조회할 때마다 카탈로그에서 다시 계산합니다.
+type CartLine struct {
+	productID string
+	skuID     string
+	quantity  int
+	addedAt   time.Time
+}
+
+// RestoreCartLine은 저장된 데이터로부터 장바구니 라인을 복원합니다.
+func RestoreCartLine(productID, skuID string, quantity int, addedAt time.Time) *CartLine {
+	return &CartLine{
+		productID: productID,
+		skuID:     skuID,
+		quantity:  quantity,
+		addedAt:   addedAt,
+	}
+}
+
+// ProductID는 상품 ID를 반환합니다.
+func (l *CartLine) ProductID() string {
+	return l.productID
+}
+
+// SKUID는 SKU ID를 반환합니다.
+func (l *CartLine) SKUID() string {
+	return l.skuID
+}
+
+// Quantity는 담은 수량을 반환합니다.
+func (l *CartLine) Quantity() int {
+	return l.quantity
+}
+
+// AddedAt은 라인이 처음 담긴 시간을 반환합니다.
+func (l *CartLine) AddedAt() time.Time {
+	return l.addedAt
+}
+
+// Cart는 장바구니 엔티티를 나타냅니다.
+// memberID가 비어 있으면 비회원(게스트) 장바구니입니다.
+type Cart struct {
+	id        string
+	memberID  string
+	lines     []*CartLine
+	status    CartStatus
+	orderID   string
+	version   int
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewGuestCart는 새로운 비회원 장바구니를 생성합니다.
+func NewGuestCart() *Cart {
+	return newCart("")
+}
+
+// NewMemberCart는 새로운 회원 장바구니를 생성합니다.
+func NewMemberCart(memberID string) (*Cart, error) {
+	if memberID == "" {
+		return nil, ErrInvalidMemberID
+	}
+	return newCart(memberID), nil
+}
+
+func newCart(memberID string) *Cart {
+	now := time.Now()
+	return &Cart{
+		id:        uuid.New().String(),
+		memberID:  memberID,
+		lines:     []*CartLine{},
+		status:    CartStatusActive,
+		createdAt: now,
+		updatedAt: now,
+	}
+}
+
+// RestoreCart는 저장된 데이터로부터 장바구니를 복원합니다.
+func RestoreCart(
+	id, memberID string,
+	lines []*CartLine,
+	status CartStatus,
+	orderID string,
+	version int,
+	createdAt, updatedAt time.Time,
+) *Cart {
+	return &Cart{
+		id:        id,
+		memberID:  memberID,
+		lines:     lines,
+		status:    status,
+		orderID:   orderID,
+		version:   version,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+// ID는 장바구니의 고유 식별자를 반환합니다.
+func (c *Cart) ID() string {
+	return c.id
+}
+
+// MemberID는 장바구니 소유 회원 ID를 반환합니다. 비회원이면 빈 문자열입니다.
+func (c *Cart) MemberID() string {
+	return c.memberID
+}
+
+// IsGuest는 비회원 장바구니인지 확인합니다.
+func (c *Cart) IsGuest() bool {
+	return c.memberID == ""
+}
+
+// Lines는 장바구니 라인 목록을 반환합니다.
+func (c *Cart) Lines() []*CartLine {
+	return c.lines
+}
+
+// Status는 장바구니 상태를 반환합니다.
+func (c *Cart) Status() CartStatus {
+	return c.status
+}
+
+// OrderID는 결제 완료된 장바구니로 생성된 주문 ID를 반환합니다.
+func (c *Cart) OrderID() string {
+	return c.orderID
+}
+
+// Version은 낙관적 잠금을 위한 버전을 반환합니다.
+func (c *Cart) Version() int {
+	return c.version
+}
+
+// CreatedAt은 장바구니가 생성된 시간을 반환합니다.
+func (c *Cart) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// UpdatedAt은 장바구니가 마지막으로 변경된 시간을 반환합니다.
+func (c *Cart) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// AdvanceVersion은 저장소가 변경 내용을 저장한 뒤 버전을 올릴 때 사용합니다.
+func (c *Cart) AdvanceVersion() {
+	c.version++
+}
+
+// AddLine은 SKU를 장바구니에 담습니다. 이미 담긴 SKU면 수량을 더합니다.
+func (c *Cart) AddLine(productID, skuID string, quantity int) error {
+	if c.status != CartStatusActive {
+		return ErrCartNotActive
+	}
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+
+	for _, line := range c.lines {
+		if line.skuID == skuID {
+			line.quantity += quantity
+			c.touch()
+			return nil
+		}
+	}
+
+	c.lines = append(c.lines, &CartLine{
+		productID: productID,
+		skuID:     skuID,
+		quantity:  quantity,
+		addedAt:   time.Now(),
+	})
+	c.touch()
+	return nil
+}
+
+// UpdateQuantity는 담긴 SKU의 수량을 변경합니다. 수량이 0이면 라인을 제거합니다.
+func (c *Cart) UpdateQuantity(skuID string, quantity int) error {
+	if c.status != CartStatusActive {
+		return ErrCartNotActive
+	}
+	if quantity < 0 {
+		return ErrInvalidQuantity
+	}
+	if quantity == 0 {
+		return c.RemoveLine(skuID)
+	}
+
+	for _, line := range c.lines {
+		if line.skuID == skuID {
+			line.quantity = quantity
+			c.touch()
+			return nil
+		}
+	}
+	return ErrLineNotFound
+}
+
+// RemoveLine은 장바구니에서 SKU를 제거합니다.
+func (c *Cart) RemoveLine(skuID string) error {
+	if c.status != CartStatusActive {
+		return ErrCartNotActive
+	}
+
+	for i, line := range c.lines {
+		if line.skuID == skuID {
+			c.lines = append(c.lines[:i], c.lines[i+1:]...)
+			c.touch()
+			return nil
+		}
+	}
+	return ErrLineNotFound
+}
+
+// MergeFrom은 비회원 장바구니의 라인을 이 장바구니로 합치고, 비회원 장바구니를 병합 완료 상태로 만듭니다.
+func (c *Cart) MergeFrom(guest *Cart) error {
+	if !guest.IsGuest() {
+		return ErrNotGuestCart
+	}
+	if c.status != CartStatusActive || guest.status != CartStatusActive {
+		return ErrCartNotActive
+	}
+
+	for _, line := range guest.lines {
+		if err := c.AddLine(line.productID, line.skuID, line.quantity); err != nil {
+			return err
+		}
+	}
+
+	guest.lines = []*CartLine{}
+	guest.status = CartStatusMerged
+	guest.touch()
+	return nil
+}
+
+// BeginCheckout은 장바구니를 결제 진행 상태로 만들어 중복 주문을 막습니다.
+// 오래 방치된 결제 진행 상태는 다시 시작할 수 있습니다.
+func (c *Cart) BeginCheckout(now time.Time) error {
+	switch c.status {
+	case CartStatusActive:
+	case CartStatusCheckingOut:
+		if now.Sub(c.updatedAt) < checkoutLockTimeout {
+			return ErrCheckoutInProgress
+		}
+	default:
+		return ErrCartNotActive
+	}
+
+	if len(c.lines) == 0 {
+		return ErrCartEmpty
+	}
+
+	c.status = CartStatusCheckingOut
+	c.touch()
+	return nil
+}
+
+// AbortCheckout은 주문 생성에 실패한 장바구니를 다시 사용할 수 있게 합니다.
+func (c *Cart) AbortCheckout() {
+	if c.status != CartStatusCheckingOut {
+		return
+	}
+	c.status = CartStatusActive
+	c.touch()
+}
+
+// CompleteCheckout은 주문 ID를 기록하고 장바구니를 비웁니다.
+func (c *Cart) CompleteCheckout(orderID string) {
+	c.orderID = orderID
+	c.lines = []*CartLine{}
+	c.status = CartStatusCheckedOut
+	c.touch()
+}
+
+func (c *Cart) touch() {
+	c.updatedAt = time.Now()
+}
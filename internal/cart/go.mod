@@ -0,0 +1,3 @@
+module example.com/myapp/cart
+
+go 1.21
\ No newline at end of file
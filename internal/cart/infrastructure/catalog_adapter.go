@@ -0,0 +1,51 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	"example.com/myapp/cart/application"
+	catalogApp "example.com/myapp/catalog/application"
+	catalogDomain "example.com/myapp/catalog/domain"
+)
+
+// CatalogPriceAdapter는 카탈로그 모듈의 공개 API로 ProductPricer 포트를 구현합니다.
+type CatalogPriceAdapter struct {
+	products catalogApp.ProductService
+}
+
+// NewCatalogPriceAdapter는 새로운 CatalogPriceAdapter 인스턴스를 생성합니다.
+func NewCatalogPriceAdapter(products catalogApp.ProductService) application.ProductPricer {
+	return &CatalogPriceAdapter{
+		products: products,
+	}
+}
+
+// PriceOf는 카탈로그에서 SKU의 현재 상품명과 가격을 조회합니다.
+func (a *CatalogPriceAdapter) PriceOf(ctx context.Context, productID, skuID string) (*application.PricedProduct, error) {
+	product, sku, err := a.products.ResolveSKU(ctx, productID, skuID)
+	if err != nil {
+		switch {
+		case errors.Is(err, catalogDomain.ErrProductNotFound),
+			errors.Is(err, catalogDomain.ErrSKUNotFound),
+			errors.Is(err, catalogApp.ErrInvalidProductID):
+			return nil, application.ErrProductNotFound
+		case errors.Is(err, catalogDomain.ErrProductArchived):
+			return nil, application.ErrProductUnavailable
+		default:
+			return nil, err
+		}
+	}
+
+	name := product.Name()
+	if sku.Name() != "" {
+		name = name + " - " + sku.Name()
+	}
+
+	return &application.PricedProduct{
+		ProductID: product.ID(),
+		SKUID:     sku.ID(),
+		Name:      name,
+		Price:     sku.Price(),
+	}, nil
+}
@@ -0,0 +1,43 @@
+package infrastructure
+
+import (
+	"context"
+
+	"example.com/myapp/cart/application"
+	orderApp "example.com/myapp/order/application"
+)
+
+// OrderPlacerAdapter는 주문 모듈의 공개 API로 OrderPlacer 포트를 구현합니다.
+type OrderPlacerAdapter struct {
+	orders orderApp.OrderService
+}
+
+// NewOrderPlacerAdapter는 새로운 OrderPlacerAdapter 인스턴스를 생성합니다.
+func NewOrderPlacerAdapter(orders orderApp.OrderService) application.OrderPlacer {
+	return &OrderPlacerAdapter{
+		orders: orders,
+	}
+}
+
+// PlaceOrder는 장바구니 라인으로 주문을 생성하고 주문 ID를 반환합니다.
+// 가격과 쿠폰 할인은 주문 모듈이 카탈로그와 프로모션 모듈에서 다시 확인하여 스냅샷합니다.
+func (a *OrderPlacerAdapter) PlaceOrder(ctx context.Context, customerID string, lines []application.CheckoutLine, couponCodes []string) (string, error) {
+	items := make([]orderApp.OrderItemRequest, len(lines))
+	for i, line := range lines {
+		items[i] = orderApp.OrderItemRequest{
+			ProductID: line.ProductID,
+			SKUID:     line.SKUID,
+			Quantity:  line.Quantity,
+		}
+	}
+
+	order, err := a.orders.CreateOrder(ctx, orderApp.CreateOrderRequest{
+		CustomerID:  customerID,
+		Items:       items,
+		CouponCodes: couponCodes,
+	})
+	if err != nil {
+		return "", err
+	}
+	return order.ID(), nil
+}
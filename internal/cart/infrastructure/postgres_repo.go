@@ -0,0 +1,272 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"example.com/myapp/cart/application"
+	"example.com/myapp/cart/domain"
+	"example.com/myapp/shared/db"
+	"github.com/jackc/pgx/v4"
+)
+
+// PostgresCartRepository는 PostgreSQL을 사용하는 장바구니 저장소 구현체입니다.
+// 동시 변경은 carts.version 컬럼을 이용한 낙관적 잠금으로 감지합니다.
+type PostgresCartRepository struct {
+	db *db.Database
+}
+
+// NewPostgresCartRepository는 새로운 PostgresCartRepository 인스턴스를 생성합니다.
+func NewPostgresCartRepository(database *db.Database) application.CartRepository {
+	return &PostgresCartRepository{
+		db: database,
+	}
+}
+
+// Save는 새 장바구니를 데이터베이스에 저장합니다.
+// 회원이 이미 사용 중인 장바구니를 가지고 있다면 domain.ErrCartConflict를 반환합니다.
+func (r *PostgresCartRepository) Save(ctx context.Context, cart *domain.Cart) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	if err := insertCart(ctx, tx, cart); err != nil {
+		return err
+	}
+	if err := replaceLines(ctx, tx, cart); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID는 ID로 장바구니를 조회합니다.
+func (r *PostgresCartRepository) FindByID(ctx context.Context, id string) (*domain.Cart, error) {
+	query := `
+		SELECT id, COALESCE(member_id, ''), status, COALESCE(order_id, ''), version, created_at, updated_at
+		FROM carts
+		WHERE id = $1
+	`
+
+	return r.findOne(ctx, query, id)
+}
+
+// FindActiveByMemberID는 회원이 사용 중인(결제 진행 중 포함) 장바구니를 조회합니다.
+func (r *PostgresCartRepository) FindActiveByMemberID(ctx context.Context, memberID string) (*domain.Cart, error) {
+	query := `
+		SELECT id, COALESCE(member_id, ''), status, COALESCE(order_id, ''), version, created_at, updated_at
+		FROM carts
+		WHERE member_id = $1 AND status IN ('active', 'checking_out')
+	`
+
+	return r.findOne(ctx, query, memberID)
+}
+
+// Update는 조회 시점의 버전이 그대로일 때만 장바구니를 저장합니다.
+func (r *PostgresCartRepository) Update(ctx context.Context, cart *domain.Cart) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	if err := updateCart(ctx, tx, cart); err != nil {
+		return err
+	}
+	if err := replaceLines(ctx, tx, cart); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	cart.AdvanceVersion()
+	return nil
+}
+
+// Merge는 병합된 비회원 장바구니와 회원 장바구니를 하나의 트랜잭션으로 저장합니다.
+func (r *PostgresCartRepository) Merge(ctx context.Context, guest, member *domain.Cart, memberIsNew bool) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	// 1. 비회원 장바구니를 병합 완료 상태로 변경
+	if err := updateCart(ctx, tx, guest); err != nil {
+		return err
+	}
+	if err := replaceLines(ctx, tx, guest); err != nil {
+		return err
+	}
+
+	// 2. 회원 장바구니 저장
+	if memberIsNew {
+		err = insertCart(ctx, tx, member)
+	} else {
+		err = updateCart(ctx, tx, member)
+	}
+	if err != nil {
+		return err
+	}
+	if err := replaceLines(ctx, tx, member); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	guest.AdvanceVersion()
+	if !memberIsNew {
+		member.AdvanceVersion()
+	}
+	return nil
+}
+
+func (r *PostgresCartRepository) findOne(ctx context.Context, query string, arg string) (*domain.Cart, error) {
+	row := r.db.Pool.QueryRow(ctx, query, arg)
+
+	var id, memberID, status, orderID string
+	var version int
+	var createdAt, updatedAt time.Time
+
+	err := row.Scan(&id, &memberID, &status, &orderID, &version, &createdAt, &updatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCartNotFound
+		}
+		return nil, fmt.Errorf("failed to find cart: %w", err)
+	}
+
+	lines, err := r.findLines(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.RestoreCart(id, memberID, lines, domain.CartStatus(status), orderID, version, createdAt, updatedAt), nil
+}
+
+func (r *PostgresCartRepository) findLines(ctx context.Context, cartID string) ([]*domain.CartLine, error) {
+	query := `
+		SELECT product_id, sku_id, quantity, added_at
+		FROM cart_lines
+		WHERE cart_id = $1
+		ORDER BY added_at, sku_id
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, cartID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cart lines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := []*domain.CartLine{}
+	for rows.Next() {
+		var productID, skuID string
+		var quantity int
+		var addedAt time.Time
+
+		if err := rows.Scan(&productID, &skuID, &quantity, &addedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan cart line: %w", err)
+		}
+		lines = append(lines, domain.RestoreCartLine(productID, skuID, quantity, addedAt))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating cart lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// insertCart는 새 장바구니 행을 추가합니다.
+// 회원당 사용 중인 장바구니는 하나뿐이므로 부분 유니크 인덱스와 충돌하면 저장하지 않습니다.
+func insertCart(ctx context.Context, tx pgx.Tx, cart *domain.Cart) error {
+	query := `
+		INSERT INTO carts (id, member_id, status, order_id, version, created_at, updated_at)
+		VALUES ($1, NULLIF($2, ''), $3, NULLIF($4, ''), $5, $6, $7)
+		ON CONFLICT (member_id) WHERE status IN ('active', 'checking_out') DO NOTHING
+	`
+
+	result, err := tx.Exec(
+		ctx,
+		query,
+		cart.ID(),
+		cart.MemberID(),
+		string(cart.Status()),
+		cart.OrderID(),
+		cart.Version(),
+		cart.CreatedAt(),
+		cart.UpdatedAt(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save cart: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrCartConflict
+	}
+
+	return nil
+}
+
+// updateCart는 버전이 일치할 때만 장바구니 행을 변경하고 버전을 올립니다.
+func updateCart(ctx context.Context, tx pgx.Tx, cart *domain.Cart) error {
+	query := `
+		UPDATE carts
+		SET status = $1, order_id = NULLIF($2, ''), version = version + 1, updated_at = $3
+		WHERE id = $4 AND version = $5
+	`
+
+	result, err := tx.Exec(
+		ctx,
+		query,
+		string(cart.Status()),
+		cart.OrderID(),
+		cart.UpdatedAt(),
+		cart.ID(),
+		cart.Version(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update cart: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrCartConflict
+	}
+
+	return nil
+}
+
+// replaceLines는 장바구니 라인을 현재 상태로 교체합니다.
+func replaceLines(ctx context.Context, tx pgx.Tx, cart *domain.Cart) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM cart_lines WHERE cart_id = $1", cart.ID()); err != nil {
+		return fmt.Errorf("failed to delete cart lines: %w", err)
+	}
+
+	query := `
+		INSERT INTO cart_lines (cart_id, product_id, sku_id, quantity, added_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	for _, line := range cart.Lines() {
+		_, err := tx.Exec(ctx, query, cart.ID(), line.ProductID(), line.SKUID(), line.Quantity(), line.AddedAt())
+		if err != nil {
+			return fmt.Errorf("failed to save cart line: %w", err)
+		}
+	}
+
+	return nil
+}
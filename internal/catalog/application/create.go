@@ -0,0 +1,184 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"example.com/myapp/catalog/domain"
+)
+
+var (
+	ErrInvalidProductID = errors.New("invalid product ID")
+	ErrProductNoSKU     = errors.New("product must have at least one SKU")
+)
+
+// CreateProduct는 새로운 상품을 SKU와 함께 등록합니다.
+func (uc *ProductUseCase) CreateProduct(ctx context.Context, req CreateProductRequest) (*domain.Product, error) {
+	if len(req.SKUs) == 0 {
+		return nil, ErrProductNoSKU
+	}
+
+	product, err := domain.NewProduct(req.Name, req.Description, req.Category)
+	if err != nil {
+		return nil, err
+	}
+	if req.TaxCategory != "" {
+		product.ChangeTaxCategory(req.TaxCategory)
+	}
+
+	for _, skuReq := range req.SKUs {
+		if _, err := product.AddSKU(skuReq.Code, skuReq.Name, skuReq.Price); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.repo.Save(ctx, product); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// GetProduct는 상품 ID로 상품을 조회합니다.
+func (uc *ProductUseCase) GetProduct(ctx context.Context, id string) (*domain.Product, error) {
+	if id == "" {
+		return nil, ErrInvalidProductID
+	}
+	return uc.repo.FindByID(ctx, id)
+}
+
+// SearchProducts는 조건에 맞는 상품 목록을 조회합니다.
+func (uc *ProductUseCase) SearchProducts(ctx context.Context, criteria ProductSearchCriteria) ([]*domain.Product, error) {
+	if criteria.Limit <= 0 || criteria.Limit > 100 {
+		criteria.Limit = 20
+	}
+	if criteria.Offset < 0 {
+		criteria.Offset = 0
+	}
+	return uc.repo.Search(ctx, criteria)
+}
+
+// UpdateProduct는 상품 기본 정보를 수정합니다.
+func (uc *ProductUseCase) UpdateProduct(ctx context.Context, id string, req UpdateProductRequest) (*domain.Product, error) {
+	product, err := uc.GetProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := product.UpdateDetails(req.Name, req.Description, req.Category); err != nil {
+		return nil, err
+	}
+	if req.TaxCategory != "" {
+		product.ChangeTaxCategory(req.TaxCategory)
+	}
+
+	if err := uc.repo.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// ArchiveProduct는 상품을 판매 중지 상태로 변경합니다.
+func (uc *ProductUseCase) ArchiveProduct(ctx context.Context, id string) (*domain.Product, error) {
+	product, err := uc.GetProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := product.Archive(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// ActivateProduct는 보관된 상품을 다시 판매 상태로 변경합니다.
+func (uc *ProductUseCase) ActivateProduct(ctx context.Context, id string) (*domain.Product, error) {
+	product, err := uc.GetProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := product.Activate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// DeleteProduct는 상품을 삭제합니다.
+func (uc *ProductUseCase) DeleteProduct(ctx context.Context, id string) error {
+	if id == "" {
+		return ErrInvalidProductID
+	}
+	return uc.repo.Delete(ctx, id)
+}
+
+// AddSKU는 상품에 SKU를 추가합니다.
+func (uc *ProductUseCase) AddSKU(ctx context.Context, productID string, req SKURequest) (*domain.Product, error) {
+	product, err := uc.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := product.AddSKU(req.Code, req.Name, req.Price); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// UpdateSKU는 SKU 이름과 판매가를 수정합니다.
+func (uc *ProductUseCase) UpdateSKU(ctx context.Context, productID, skuID, name string, price float64) (*domain.Product, error) {
+	product, err := uc.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	sku, err := product.SKU(skuID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sku.UpdatePrice(price); err != nil {
+		return nil, err
+	}
+	if name != "" {
+		sku.Rename(name)
+	}
+
+	if err := uc.repo.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// ResolveSKU는 주문 가능한 상품과 SKU를 조회합니다.
+// 다른 모듈이 권위 있는 상품명과 가격을 얻기 위해 사용하는 공개 API입니다.
+func (uc *ProductUseCase) ResolveSKU(ctx context.Context, productID, skuID string) (*domain.Product, *domain.SKU, error) {
+	product, err := uc.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sku, err := product.ResolveSKU(skuID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return product, sku, nil
+}
@@ -0,0 +1,308 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"example.com/myapp/catalog/domain"
+)
+
+// FakeProductRepository는 테스트를 위한 가짜 ProductRepository 구현체입니다.
+// Postgres 구현과 마찬가지로 최근에 등록한 상품부터 검색하고 Limit과 Offset으로 나눕니다.
+type FakeProductRepository struct {
+	mu       sync.Mutex
+	products map[string]*domain.Product
+	order    []string
+	searched []ProductSearchCriteria
+}
+
+// NewFakeProductRepository는 새로운 FakeProductRepository 인스턴스를 생성합니다.
+func NewFakeProductRepository() *FakeProductRepository {
+	return &FakeProductRepository{
+		products: make(map[string]*domain.Product),
+	}
+}
+
+func (f *FakeProductRepository) Save(ctx context.Context, product *domain.Product) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.products[product.ID()] = product
+	f.order = append(f.order, product.ID())
+	return nil
+}
+
+func (f *FakeProductRepository) FindByID(ctx context.Context, id string) (*domain.Product, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	product, ok := f.products[id]
+	if !ok {
+		return nil, domain.ErrProductNotFound
+	}
+	return product, nil
+}
+
+func (f *FakeProductRepository) Search(ctx context.Context, criteria ProductSearchCriteria) ([]*domain.Product, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.searched = append(f.searched, criteria)
+	matched := []*domain.Product{}
+	for i := len(f.order) - 1; i >= 0; i-- {
+		product, ok := f.products[f.order[i]]
+		if !ok {
+			continue
+		}
+		if criteria.Query != "" && !strings.Contains(product.Name(), criteria.Query) && !strings.Contains(product.Description(), criteria.Query) {
+			continue
+		}
+		if criteria.Category != "" && product.Category() != criteria.Category {
+			continue
+		}
+		if criteria.Status != "" && product.Status() != criteria.Status {
+			continue
+		}
+		matched = append(matched, product)
+	}
+
+	if criteria.Offset >= len(matched) {
+		return []*domain.Product{}, nil
+	}
+	matched = matched[criteria.Offset:]
+	if len(matched) > criteria.Limit {
+		matched = matched[:criteria.Limit]
+	}
+	return matched, nil
+}
+
+func (f *FakeProductRepository) Update(ctx context.Context, product *domain.Product) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.products[product.ID()]; !ok {
+		return domain.ErrProductNotFound
+	}
+	f.products[product.ID()] = product
+	return nil
+}
+
+func (f *FakeProductRepository) Delete(ctx context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.products[id]; !ok {
+		return domain.ErrProductNotFound
+	}
+	delete(f.products, id)
+	return nil
+}
+
+func createTestProduct(t *testing.T, useCase *ProductUseCase, name string, codes ...string) *domain.Product {
+	t.Helper()
+	skus := []SKURequest{}
+	for _, code := range codes {
+		skus = append(skus, SKURequest{Code: code, Name: code + " 옵션", Price: 19000})
+	}
+	product, err := useCase.CreateProduct(context.Background(), CreateProductRequest{
+		Name:     name,
+		Category: "apparel",
+		SKUs:     skus,
+	})
+	if err != nil {
+		t.Fatalf("CreateProduct(%q) error = %v", name, err)
+	}
+	return product
+}
+
+func TestCreateProductRequiresSKU(t *testing.T) {
+	repo := NewFakeProductRepository()
+	useCase := NewProductUseCase(repo)
+
+	if _, err := useCase.CreateProduct(context.Background(), CreateProductRequest{Name: "기본 티셔츠"}); !errors.Is(err, ErrProductNoSKU) {
+		t.Errorf("CreateProduct() error = %v, want %v", err, ErrProductNoSKU)
+	}
+
+	product := createTestProduct(t, useCase, "기본 티셔츠", "TS-M", "TS-L")
+	if product.TaxCategory() != domain.DefaultTaxCategory || len(product.SKUs()) != 2 {
+		t.Errorf("product = %v, %d SKUs, want %v, 2 SKUs", product.TaxCategory(), len(product.SKUs()), domain.DefaultTaxCategory)
+	}
+	if len(repo.order) != 1 {
+		t.Errorf("saved products = %d, want 1", len(repo.order))
+	}
+}
+
+func TestArchiveAndActivateProduct(t *testing.T) {
+	repo := NewFakeProductRepository()
+	useCase := NewProductUseCase(repo)
+	ctx := context.Background()
+	product := createTestProduct(t, useCase, "기본 티셔츠", "TS-M")
+
+	archived, err := useCase.ArchiveProduct(ctx, product.ID())
+	if err != nil {
+		t.Fatalf("ArchiveProduct() error = %v", err)
+	}
+	if archived.Status() != domain.ProductStatusArchived {
+		t.Errorf("status = %v, want %v", archived.Status(), domain.ProductStatusArchived)
+	}
+	if _, err := useCase.ArchiveProduct(ctx, product.ID()); !errors.Is(err, domain.ErrInvalidProductState) {
+		t.Errorf("ArchiveProduct() again error = %v, want %v", err, domain.ErrInvalidProductState)
+	}
+
+	// 판매 중지된 상품은 주문할 수 없습니다
+	if _, _, err := useCase.ResolveSKU(ctx, product.ID(), ""); !errors.Is(err, domain.ErrProductArchived) {
+		t.Errorf("ResolveSKU() on archived product error = %v, want %v", err, domain.ErrProductArchived)
+	}
+
+	activated, err := useCase.ActivateProduct(ctx, product.ID())
+	if err != nil {
+		t.Fatalf("ActivateProduct() error = %v", err)
+	}
+	if activated.Status() != domain.ProductStatusActive {
+		t.Errorf("status = %v, want %v", activated.Status(), domain.ProductStatusActive)
+	}
+	if _, err := useCase.ActivateProduct(ctx, product.ID()); !errors.Is(err, domain.ErrInvalidProductState) {
+		t.Errorf("ActivateProduct() again error = %v, want %v", err, domain.ErrInvalidProductState)
+	}
+
+	if _, err := useCase.ArchiveProduct(ctx, "unknown"); !errors.Is(err, domain.ErrProductNotFound) {
+		t.Errorf("ArchiveProduct(unknown) error = %v, want %v", err, domain.ErrProductNotFound)
+	}
+	if _, err := useCase.ActivateProduct(ctx, ""); !errors.Is(err, ErrInvalidProductID) {
+		t.Errorf("ActivateProduct(\"\") error = %v, want %v", err, ErrInvalidProductID)
+	}
+}
+
+func TestResolveSKU(t *testing.T) {
+	repo := NewFakeProductRepository()
+	useCase := NewProductUseCase(repo)
+	single := createTestProduct(t, useCase, "기본 티셔츠", "TS-M")
+	multiple := createTestProduct(t, useCase, "후드 티셔츠", "HD-M", "HD-L")
+
+	tests := []struct {
+		name      string
+		productID string
+		skuID     string
+		wantSKU   string
+		wantErr   error
+	}{
+		{"SKU ID로 조회", multiple.ID(), multiple.SKUs()[1].ID(), "HD-L", nil},
+		{"SKU가 하나뿐이면 빈 SKU ID 허용", single.ID(), "", "TS-M", nil},
+		{"SKU가 여럿이면 빈 SKU ID 거부", multiple.ID(), "", "", domain.ErrSKUNotFound},
+		{"없는 SKU", single.ID(), "unknown", "", domain.ErrSKUNotFound},
+		{"다른 상품의 SKU", single.ID(), multiple.SKUs()[0].ID(), "", domain.ErrSKUNotFound},
+		{"없는 상품", "unknown", single.SKUs()[0].ID(), "", domain.ErrProductNotFound},
+		{"빈 상품 ID", "", "", "", ErrInvalidProductID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product, sku, err := useCase.ResolveSKU(context.Background(), tt.productID, tt.skuID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveSKU() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if product.ID() != tt.productID || sku.Code() != tt.wantSKU {
+				t.Errorf("ResolveSKU() = %v, %v, want %v, %v", product.ID(), sku.Code(), tt.productID, tt.wantSKU)
+			}
+		})
+	}
+}
+
+func TestSearchProductsPaging(t *testing.T) {
+	repo := NewFakeProductRepository()
+	useCase := NewProductUseCase(repo)
+	ctx := context.Background()
+	for i := 1; i <= 25; i++ {
+		createTestProduct(t, useCase, fmt.Sprintf("상품 %02d", i), "SKU")
+	}
+
+	names := func(products []*domain.Product) string {
+		result := []string{}
+		for _, product := range products {
+			result = append(result, strings.TrimPrefix(product.Name(), "상품 "))
+		}
+		return strings.Join(result, ",")
+	}
+
+	tests := []struct {
+		name       string
+		criteria   ProductSearchCriteria
+		wantLimit  int
+		wantOffset int
+		wantCount  int
+		wantFirst  string
+	}{
+		{"한도 미지정 시 기본 20개", ProductSearchCriteria{}, 20, 0, 20, "25"},
+		{"한도 초과 시 기본 20개", ProductSearchCriteria{Limit: 101}, 20, 0, 20, "25"},
+		{"음수 오프셋은 0으로", ProductSearchCriteria{Limit: 5, Offset: -3}, 5, 0, 5, "25"},
+		{"두 번째 페이지", ProductSearchCriteria{Limit: 10, Offset: 10}, 10, 10, 10, "15"},
+		{"마지막 페이지", ProductSearchCriteria{Limit: 10, Offset: 20}, 10, 20, 5, "05"},
+		{"범위를 벗어난 페이지", ProductSearchCriteria{Limit: 10, Offset: 30}, 10, 30, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products, err := useCase.SearchProducts(ctx, tt.criteria)
+			if err != nil {
+				t.Fatalf("SearchProducts() error = %v", err)
+			}
+			searched := repo.searched[len(repo.searched)-1]
+			if searched.Limit != tt.wantLimit || searched.Offset != tt.wantOffset {
+				t.Errorf("criteria = limit %d, offset %d, want limit %d, offset %d", searched.Limit, searched.Offset, tt.wantLimit, tt.wantOffset)
+			}
+			if len(products) != tt.wantCount {
+				t.Fatalf("products = %d (%s), want %d", len(products), names(products), tt.wantCount)
+			}
+			if len(products) > 0 && strings.TrimPrefix(products[0].Name(), "상품 ") != tt.wantFirst {
+				t.Errorf("first product = %s, want %s", products[0].Name(), tt.wantFirst)
+			}
+		})
+	}
+
+	// 페이지를 이어 붙이면 빠지거나 겹치는 상품이 없습니다
+	seen := map[string]bool{}
+	for offset := 0; ; offset += 7 {
+		products, err := useCase.SearchProducts(ctx, ProductSearchCriteria{Limit: 7, Offset: offset})
+		if err != nil {
+			t.Fatalf("SearchProducts(offset %d) error = %v", offset, err)
+		}
+		if len(products) == 0 {
+			break
+		}
+		for _, product := range products {
+			if seen[product.ID()] {
+				t.Errorf("product %s returned twice", product.Name())
+			}
+			seen[product.ID()] = true
+		}
+	}
+	if len(seen) != 25 {
+		t.Errorf("paged products = %d, want 25", len(seen))
+	}
+}
+
+func TestSearchProductsFiltersByStatus(t *testing.T) {
+	repo := NewFakeProductRepository()
+	useCase := NewProductUseCase(repo)
+	ctx := context.Background()
+	active := createTestProduct(t, useCase, "기본 티셔츠", "TS-M")
+	archived := createTestProduct(t, useCase, "후드 티셔츠", "HD-M")
+	if _, err := useCase.ArchiveProduct(ctx, archived.ID()); err != nil {
+		t.Fatalf("ArchiveProduct() error = %v", err)
+	}
+
+	products, err := useCase.SearchProducts(ctx, ProductSearchCriteria{Status: domain.ProductStatusActive})
+	if err != nil {
+		t.Fatalf("SearchProducts() error = %v", err)
+	}
+	if len(products) != 1 || products[0].ID() != active.ID() {
+		t.Errorf("active products = %d, want only %s", len(products), active.Name())
+	}
+}
@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+
+	"example.com/myapp/catalog/domain"
+)
+
+// ProductSearchCriteria는 상품 검색 조건을 정의합니다.
+type ProductSearchCriteria struct {
+	Query    string
+	Category string
+	Status   domain.ProductStatus
+	MinPrice float64
+	MaxPrice float64
+	Limit    int
+	Offset   int
+}
+
+// ProductRepository는 상품 관련 영속성 인터페이스를 정의합니다.
+type ProductRepository interface {
+	Save(ctx context.Context, product *domain.Product) error
+	FindByID(ctx context.Context, id string) (*domain.Product, error)
+	Search(ctx context.Context, criteria ProductSearchCriteria) ([]*domain.Product, error)
+	Update(ctx context.Context, product *domain.Product) error
+	Delete(ctx context.Context, id string) error
+}
+
+// ProductService는 상품 카탈로그 관련 비즈니스 로직을 정의합니다.
+type ProductService interface {
+	CreateProduct(ctx context.Context, req CreateProductRequest) (*domain.Product, error)
+	GetProduct(ctx context.Context, id string) (*domain.Product, error)
+	SearchProducts(ctx context.Context, criteria ProductSearchCriteria) ([]*domain.Product, error)
+	UpdateProduct(ctx context.Context, id string, req UpdateProductRequest) (*domain.Product, error)
+	ArchiveProduct(ctx context.Context, id string) (*domain.Product, error)
+	ActivateProduct(ctx context.Context, id string) (*domain.Product, error)
+	DeleteProduct(ctx context.Context, id string) error
+	AddSKU(ctx context.Context, productID string, req SKURequest) (*domain.Product, error)
+	UpdateSKU(ctx context.Context, productID, skuID, name string, price float64) (*domain.Product, error)
+	ResolveSKU(ctx context.Context, productID, skuID string) (*domain.Product, *domain.SKU, error)
+}
+
+// SKURequest는 SKU 생성 요청 정보를 정의합니다.
+type SKURequest struct {
+	Code  string
+	Name  string
+	Price float64
+}
+
+// CreateProductRequest는 상품 생성 요청 정보를 정의합니다.
+type CreateProductRequest struct {
+	Name        string
+	Description string
+	Category    string
+	TaxCategory string
+	SKUs        []SKURequest
+}
+
+// UpdateProductRequest는 상품 기본 정보 수정 요청 정보를 정의합니다.
+// 과세 유형을 비워 두면 기존 과세 유형을 유지합니다.
+type UpdateProductRequest struct {
+	Name        string
+	Description string
+	Category    string
+	TaxCategory string
+}
+
+// ProductUseCase는 ProductService 구현체를 정의합니다.
+type ProductUseCase struct {
+	repo ProductRepository
+}
+
+// NewProductUseCase는 새로운 ProductUseCase 인스턴스를 생성합니다.
+func NewProductUseCase(repo ProductRepository) *ProductUseCase {
+	return &ProductUseCase{
+		repo: repo,
+	}
+}
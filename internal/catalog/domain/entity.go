@@ -0,0 +1,318 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductStatus는 상품 판매 상태를 정의합니다.
+type ProductStatus string
+
+const (
+	ProductStatusActive   ProductStatus = "active"
+	ProductStatusArchived ProductStatus = "archived"
+)
+
+var (
+	ErrInvalidProductName  = errors.New("invalid product name")
+	ErrInvalidSKUCode      = errors.New("invalid SKU code")
+	ErrInvalidPrice        = errors.New("invalid price")
+	ErrDuplicateSKUCode    = errors.New("SKU code already exists for this product")
+	ErrProductNotFound     = errors.New("product not found")
+	ErrSKUNotFound         = errors.New("SKU not found")
+	ErrProductArchived     = errors.New("product is archived")
+	ErrInvalidProductState = errors.New("invalid product status transition")
+)
+
+// SKU는 상품의 판매 단위(옵션)를 나타냅니다.
+type SKU struct {
+	id        string
+	productID string
+	code      string
+	name      string
+	price     float64
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewSKU는 새로운 SKU를 생성합니다.
+func NewSKU(productID, code, name string, price float64) (*SKU, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, ErrInvalidSKUCode
+	}
+	if price <= 0 {
+		return nil, ErrInvalidPrice
+	}
+
+	now := time.Now()
+	return &SKU{
+		id:        uuid.New().String(),
+		productID: productID,
+		code:      code,
+		name:      name,
+		price:     price,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// RestoreSKU는 저장된 데이터로부터 SKU를 복원합니다.
+func RestoreSKU(id, productID, code, name string, price float64, createdAt, updatedAt time.Time) *SKU {
+	return &SKU{
+		id:        id,
+		productID: productID,
+		code:      code,
+		name:      name,
+		price:     price,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+// ID는 SKU의 고유 식별자를 반환합니다.
+func (s *SKU) ID() string {
+	return s.id
+}
+
+// ProductID는 SKU가 속한 상품 ID를 반환합니다.
+func (s *SKU) ProductID() string {
+	return s.productID
+}
+
+// Code는 SKU 코드를 반환합니다.
+func (s *SKU) Code() string {
+	return s.code
+}
+
+// Name은 SKU 옵션 이름을 반환합니다.
+func (s *SKU) Name() string {
+	return s.name
+}
+
+// Price는 SKU 판매가를 반환합니다.
+func (s *SKU) Price() float64 {
+	return s.price
+}
+
+// CreatedAt은 SKU가 생성된 시간을 반환합니다.
+func (s *SKU) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// UpdatedAt은 SKU 정보가 마지막으로 업데이트된 시간을 반환합니다.
+func (s *SKU) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// UpdatePrice는 SKU 판매가를 변경합니다.
+func (s *SKU) UpdatePrice(price float64) error {
+	if price <= 0 {
+		return ErrInvalidPrice
+	}
+	s.price = price
+	s.updatedAt = time.Now()
+	return nil
+}
+
+// Rename은 SKU 옵션 이름을 변경합니다.
+func (s *SKU) Rename(name string) {
+	s.name = name
+	s.updatedAt = time.Now()
+}
+
+// DefaultTaxCategory는 과세 유형을 지정하지 않은 상품에 적용되는 과세 유형입니다.
+const DefaultTaxCategory = "standard"
+
+// Product는 상품 엔티티를 나타냅니다.
+type Product struct {
+	id          string
+	name        string
+	description string
+	category    string
+	taxCategory string
+	status      ProductStatus
+	skus        []*SKU
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// NewProduct는 새로운 상품을 생성합니다.
+func NewProduct(name, description, category string) (*Product, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrInvalidProductName
+	}
+
+	now := time.Now()
+	return &Product{
+		id:          uuid.New().String(),
+		name:        name,
+		description: description,
+		category:    category,
+		taxCategory: DefaultTaxCategory,
+		status:      ProductStatusActive,
+		skus:        []*SKU{},
+		createdAt:   now,
+		updatedAt:   now,
+	}, nil
+}
+
+// RestoreProduct는 저장된 데이터로부터 상품을 복원합니다.
+func RestoreProduct(
+	id, name, description, category, taxCategory string,
+	status ProductStatus,
+	skus []*SKU,
+	createdAt, updatedAt time.Time,
+) *Product {
+	return &Product{
+		id:          id,
+		name:        name,
+		description: description,
+		category:    category,
+		taxCategory: taxCategory,
+		status:      status,
+		skus:        skus,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}
+}
+
+// ID는 상품의 고유 식별자를 반환합니다.
+func (p *Product) ID() string {
+	return p.id
+}
+
+// Name은 상품 이름을 반환합니다.
+func (p *Product) Name() string {
+	return p.name
+}
+
+// Description은 상품 설명을 반환합니다.
+func (p *Product) Description() string {
+	return p.description
+}
+
+// Category는 상품 카테고리를 반환합니다.
+func (p *Product) Category() string {
+	return p.category
+}
+
+// TaxCategory는 세금 계산에 사용되는 상품의 과세 유형을 반환합니다.
+func (p *Product) TaxCategory() string {
+	return p.taxCategory
+}
+
+// Status는 상품 판매 상태를 반환합니다.
+func (p *Product) Status() ProductStatus {
+	return p.status
+}
+
+// SKUs는 상품의 SKU 목록을 반환합니다.
+func (p *Product) SKUs() []*SKU {
+	return p.skus
+}
+
+// CreatedAt은 상품이 생성된 시간을 반환합니다.
+func (p *Product) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+// UpdatedAt은 상품 정보가 마지막으로 업데이트된 시간을 반환합니다.
+func (p *Product) UpdatedAt() time.Time {
+	return p.updatedAt
+}
+
+// IsActive는 상품이 판매 중인지 확인합니다.
+func (p *Product) IsActive() bool {
+	return p.status == ProductStatusActive
+}
+
+// UpdateDetails는 상품 기본 정보를 변경합니다.
+func (p *Product) UpdateDetails(name, description, category string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrInvalidProductName
+	}
+	p.name = name
+	p.description = description
+	p.category = category
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// ChangeTaxCategory는 상품의 과세 유형을 변경합니다. 비어 있으면 기본 과세 유형을 사용합니다.
+func (p *Product) ChangeTaxCategory(taxCategory string) {
+	taxCategory = strings.ToLower(strings.TrimSpace(taxCategory))
+	if taxCategory == "" {
+		taxCategory = DefaultTaxCategory
+	}
+	p.taxCategory = taxCategory
+	p.updatedAt = time.Now()
+}
+
+// AddSKU는 상품에 새로운 SKU를 추가합니다.
+func (p *Product) AddSKU(code, name string, price float64) (*SKU, error) {
+	for _, sku := range p.skus {
+		if sku.Code() == strings.TrimSpace(code) {
+			return nil, ErrDuplicateSKUCode
+		}
+	}
+
+	sku, err := NewSKU(p.id, code, name, price)
+	if err != nil {
+		return nil, err
+	}
+
+	p.skus = append(p.skus, sku)
+	p.updatedAt = time.Now()
+	return sku, nil
+}
+
+// SKU는 ID로 상품의 SKU를 찾습니다.
+func (p *Product) SKU(skuID string) (*SKU, error) {
+	for _, sku := range p.skus {
+		if sku.ID() == skuID {
+			return sku, nil
+		}
+	}
+	return nil, ErrSKUNotFound
+}
+
+// ResolveSKU는 주문 가능한 SKU를 결정합니다.
+// SKU ID가 비어 있으면 SKU가 하나뿐인 상품에 한해 해당 SKU를 반환합니다.
+func (p *Product) ResolveSKU(skuID string) (*SKU, error) {
+	if !p.IsActive() {
+		return nil, ErrProductArchived
+	}
+	if skuID != "" {
+		return p.SKU(skuID)
+	}
+	if len(p.skus) != 1 {
+		return nil, ErrSKUNotFound
+	}
+	return p.skus[0], nil
+}
+
+// Archive는 상품을 판매 중지(보관) 상태로 변경합니다.
+func (p *Product) Archive() error {
+	if p.status == ProductStatusArchived {
+		return ErrInvalidProductState
+	}
+	p.status = ProductStatusArchived
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// Activate는 보관된 상품을 다시 판매 상태로 변경합니다.
+func (p *Product) Activate() error {
+	if p.status == ProductStatusActive {
+		return ErrInvalidProductState
+	}
+	p.status = ProductStatusActive
+	p.updatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func newTestProduct(t *testing.T, codes ...string) *Product {
+	t.Helper()
+	product, err := NewProduct("기본 티셔츠", "면 100%", "apparel")
+	if err != nil {
+		t.Fatalf("NewProduct() error = %v", err)
+	}
+	for _, code := range codes {
+		if _, err := product.AddSKU(code, code+" 옵션", 19000); err != nil {
+			t.Fatalf("AddSKU(%q) error = %v", code, err)
+		}
+	}
+	return product
+}
+
+func TestProductArchiveAndActivate(t *testing.T) {
+	product := newTestProduct(t, "TS-M")
+
+	// 판매 중인 상품은 다시 활성화할 수 없습니다
+	if err := product.Activate(); !errors.Is(err, ErrInvalidProductState) {
+		t.Errorf("Activate() on active product error = %v, want %v", err, ErrInvalidProductState)
+	}
+
+	if err := product.Archive(); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	if product.IsActive() || product.Status() != ProductStatusArchived {
+		t.Errorf("status = %v, want %v", product.Status(), ProductStatusArchived)
+	}
+	if err := product.Archive(); !errors.Is(err, ErrInvalidProductState) {
+		t.Errorf("Archive() on archived product error = %v, want %v", err, ErrInvalidProductState)
+	}
+
+	if err := product.Activate(); err != nil {
+		t.Fatalf("Activate() error = %v", err)
+	}
+	if !product.IsActive() {
+		t.Errorf("status = %v, want %v", product.Status(), ProductStatusActive)
+	}
+}
+
+func TestProductResolveSKU(t *testing.T) {
+	single := newTestProduct(t, "TS-M")
+	multiple := newTestProduct(t, "TS-M", "TS-L")
+	archived := newTestProduct(t, "TS-M")
+	if err := archived.Archive(); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		product *Product
+		skuID   string
+		want    *SKU
+		wantErr error
+	}{
+		{"SKU ID로 조회", multiple, multiple.SKUs()[1].ID(), multiple.SKUs()[1], nil},
+		{"SKU가 하나뿐이면 빈 ID 허용", single, "", single.SKUs()[0], nil},
+		{"SKU가 여럿이면 빈 ID 거부", multiple, "", nil, ErrSKUNotFound},
+		{"없는 SKU ID", multiple, "unknown", nil, ErrSKUNotFound},
+		{"다른 상품의 SKU ID", single, multiple.SKUs()[0].ID(), nil, ErrSKUNotFound},
+		{"판매 중지된 상품", archived, archived.SKUs()[0].ID(), nil, ErrProductArchived},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sku, err := tt.product.ResolveSKU(tt.skuID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveSKU(%q) error = %v, want %v", tt.skuID, err, tt.wantErr)
+			}
+			if sku != tt.want {
+				t.Errorf("ResolveSKU(%q) = %v, want %v", tt.skuID, sku, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductAddSKURejectsDuplicateCode(t *testing.T) {
+	product := newTestProduct(t, "TS-M")
+
+	if _, err := product.AddSKU(" TS-M ", "중복", 19000); !errors.Is(err, ErrDuplicateSKUCode) {
+		t.Errorf("AddSKU(duplicate) error = %v, want %v", err, ErrDuplicateSKUCode)
+	}
+	if _, err := product.AddSKU("TS-L", "라지", 0); !errors.Is(err, ErrInvalidPrice) {
+		t.Errorf("AddSKU(price 0) error = %v, want %v", err, ErrInvalidPrice)
+	}
+	if len(product.SKUs()) != 1 {
+		t.Errorf("SKUs = %d, want 1", len(product.SKUs()))
+	}
+}
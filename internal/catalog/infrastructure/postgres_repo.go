@@ -0,0 +1,317 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"example.com/myapp/catalog/application"
+	"example.com/myapp/catalog/domain"
+	"example.com/myapp/shared/db"
+	"github.com/jackc/pgx/v4"
+)
+
+// PostgresProductRepository는 PostgreSQL을 사용하는 상품 저장소 구현체입니다.
+type PostgresProductRepository struct {
+	db *db.Database
+}
+
+// NewPostgresProductRepository는 새로운 PostgresProductRepository 인스턴스를 생성합니다.
+func NewPostgresProductRepository(database *db.Database) application.ProductRepository {
+	return &PostgresProductRepository{
+		db: database,
+	}
+}
+
+// Save는 상품과 SKU 정보를 데이터베이스에 저장합니다.
+func (r *PostgresProductRepository) Save(ctx context.Context, product *domain.Product) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	// 1. 상품 기본 정보 저장
+	productQuery := `
+		INSERT INTO products (id, name, description, category, tax_category, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err = tx.Exec(
+		ctx,
+		productQuery,
+		product.ID(),
+		product.Name(),
+		product.Description(),
+		product.Category(),
+		product.TaxCategory(),
+		string(product.Status()),
+		product.CreatedAt(),
+		product.UpdatedAt(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save product: %w", err)
+	}
+
+	// 2. SKU 저장
+	if err := upsertSKUs(ctx, tx, product); err != nil {
+		return err
+	}
+
+	// 트랜잭션 커밋
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID는 ID로 상품을 조회합니다.
+func (r *PostgresProductRepository) FindByID(ctx context.Context, id string) (*domain.Product, error) {
+	query := `
+		SELECT id, name, description, category, tax_category, status, created_at, updated_at
+		FROM products
+		WHERE id = $1
+	`
+
+	row := r.db.Pool.QueryRow(ctx, query, id)
+
+	var productID, name, description, category, taxCategory, status string
+	var createdAt, updatedAt time.Time
+
+	err := row.Scan(&productID, &name, &description, &category, &taxCategory, &status, &createdAt, &updatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to find product by ID: %w", err)
+	}
+
+	skus, err := r.findSKUs(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.RestoreProduct(
+		productID, name, description, category, taxCategory,
+		domain.ProductStatus(status),
+		skus,
+		createdAt, updatedAt,
+	), nil
+}
+
+// Search는 조건에 맞는 상품 목록을 조회합니다.
+func (r *PostgresProductRepository) Search(ctx context.Context, criteria application.ProductSearchCriteria) ([]*domain.Product, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if criteria.Query != "" {
+		addCondition("(p.name ILIKE $%[1]d OR p.description ILIKE $%[1]d)", "%"+criteria.Query+"%")
+	}
+	if criteria.Category != "" {
+		addCondition("p.category = $%d", criteria.Category)
+	}
+	if criteria.Status != "" {
+		addCondition("p.status = $%d", string(criteria.Status))
+	}
+	if criteria.MinPrice > 0 {
+		addCondition("EXISTS (SELECT 1 FROM product_skus s WHERE s.product_id = p.id AND s.price >= $%d)", criteria.MinPrice)
+	}
+	if criteria.MaxPrice > 0 {
+		addCondition("EXISTS (SELECT 1 FROM product_skus s WHERE s.product_id = p.id AND s.price <= $%d)", criteria.MaxPrice)
+	}
+
+	query := `
+		SELECT p.id
+		FROM products p
+	`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, criteria.Limit, criteria.Offset)
+	query += fmt.Sprintf(" ORDER BY p.created_at DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search products: %w", err)
+	}
+	defer rows.Close()
+
+	productIDs := []string{}
+	for rows.Next() {
+		var productID string
+		if err := rows.Scan(&productID); err != nil {
+			return nil, fmt.Errorf("failed to scan product ID: %w", err)
+		}
+		productIDs = append(productIDs, productID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating product IDs: %w", err)
+	}
+
+	// 상품 ID별로 상세 정보 조회
+	products := []*domain.Product{}
+	for _, productID := range productIDs {
+		product, err := r.FindByID(ctx, productID)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+
+	return products, nil
+}
+
+// Update는 상품과 SKU 정보를 업데이트합니다.
+func (r *PostgresProductRepository) Update(ctx context.Context, product *domain.Product) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	query := `
+		UPDATE products
+		SET name = $1, description = $2, category = $3, tax_category = $4, status = $5, updated_at = $6
+		WHERE id = $7
+	`
+
+	result, err := tx.Exec(
+		ctx,
+		query,
+		product.Name(),
+		product.Description(),
+		product.Category(),
+		product.TaxCategory(),
+		string(product.Status()),
+		product.UpdatedAt(),
+		product.ID(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update product: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrProductNotFound
+	}
+
+	if err := upsertSKUs(ctx, tx, product); err != nil {
+		return err
+	}
+
+	// 트랜잭션 커밋
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Delete는 상품을 삭제합니다.
+func (r *PostgresProductRepository) Delete(ctx context.Context, id string) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	// 1. SKU 삭제
+	_, err = tx.Exec(ctx, "DELETE FROM product_skus WHERE product_id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete product SKUs: %w", err)
+	}
+
+	// 2. 상품 삭제
+	result, err := tx.Exec(ctx, "DELETE FROM products WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+
+	// 영향받은 행이 없으면 상품이 존재하지 않음
+	if result.RowsAffected() == 0 {
+		return domain.ErrProductNotFound
+	}
+
+	// 트랜잭션 커밋
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// findSKUs는 상품의 SKU 목록을 조회합니다.
+func (r *PostgresProductRepository) findSKUs(ctx context.Context, productID string) ([]*domain.SKU, error) {
+	query := `
+		SELECT id, product_id, code, name, price, created_at, updated_at
+		FROM product_skus
+		WHERE product_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query product SKUs: %w", err)
+	}
+	defer rows.Close()
+
+	skus := []*domain.SKU{}
+	for rows.Next() {
+		var id, skuProductID, code, name string
+		var price float64
+		var createdAt, updatedAt time.Time
+
+		if err := rows.Scan(&id, &skuProductID, &code, &name, &price, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan product SKU: %w", err)
+		}
+
+		skus = append(skus, domain.RestoreSKU(id, skuProductID, code, name, price, createdAt, updatedAt))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating product SKUs: %w", err)
+	}
+
+	return skus, nil
+}
+
+// upsertSKUs는 상품의 SKU를 저장하거나 갱신합니다.
+func upsertSKUs(ctx context.Context, tx pgx.Tx, product *domain.Product) error {
+	query := `
+		INSERT INTO product_skus (id, product_id, code, name, price, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO UPDATE
+		SET code = EXCLUDED.code, name = EXCLUDED.name, price = EXCLUDED.price, updated_at = EXCLUDED.updated_at
+	`
+
+	for _, sku := range product.SKUs() {
+		_, err := tx.Exec(
+			ctx,
+			query,
+			sku.ID(),
+			product.ID(),
+			sku.Code(),
+			sku.Name(),
+			sku.Price(),
+			sku.CreatedAt(),
+			sku.UpdatedAt(),
+		)
+
+		if err != nil {
+			return fmt.Errorf("failed to save product SKU: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"example.com/myapp/inventory/domain"
+)
+
+var (
+	ErrInvalidOrderID = errors.New("invalid order ID")
+	ErrInvalidDelta   = errors.New("stock adjustment must not be zero")
+)
+
+// expiredReservationBatchSize는 한 번에 해제할 만료 예약 수입니다.
+const expiredReservationBatchSize = 100
+
+// AdjustStock은 창고의 실재고를 입고(+) 또는 조정(-)합니다.
+func (uc *InventoryUseCase) AdjustStock(ctx context.Context, skuID, warehouseID string, delta int) (*domain.StockItem, error) {
+	if skuID == "" {
+		return nil, domain.ErrInvalidSKU
+	}
+	if warehouseID == "" {
+		return nil, domain.ErrInvalidWarehouse
+	}
+	if delta == 0 {
+		return nil, ErrInvalidDelta
+	}
+	return uc.repo.AdjustOnHand(ctx, skuID, warehouseID, delta)
+}
+
+// GetStock은 SKU의 창고별 재고를 조회합니다.
+func (uc *InventoryUseCase) GetStock(ctx context.Context, skuID string) ([]*domain.StockItem, error) {
+	if skuID == "" {
+		return nil, domain.ErrInvalidSKU
+	}
+	return uc.repo.FindStockBySKU(ctx, skuID)
+}
+
+// ReserveForOrder는 주문의 모든 라인에 대해 재고를 예약합니다.
+// 하나의 라인이라도 재고가 부족하면 주문 전체의 예약이 실패합니다.
+func (uc *InventoryUseCase) ReserveForOrder(ctx context.Context, orderID string, lineRequests []ReservationLineRequest) (*domain.Reservation, error) {
+	if orderID == "" {
+		return nil, ErrInvalidOrderID
+	}
+
+	lines := make([]*domain.ReservationLine, 0, len(lineRequests))
+	for _, req := range lineRequests {
+		line, err := domain.NewReservationLine(req.SKUID, req.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+
+	reservation, err := domain.NewReservation(orderID, lines, uc.reservationTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Reserve(ctx, reservation); err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// ConfirmReservation은 결제 완료된 주문의 예약이 만료되지 않도록 확정합니다.
+func (uc *InventoryUseCase) ConfirmReservation(ctx context.Context, orderID string) (*domain.Reservation, error) {
+	return uc.changeReservation(ctx, orderID, (*domain.Reservation).Confirm)
+}
+
+// CommitReservation은 출고된 주문의 예약 수량을 실재고에서 차감합니다.
+func (uc *InventoryUseCase) CommitReservation(ctx context.Context, orderID string) (*domain.Reservation, error) {
+	return uc.changeReservation(ctx, orderID, (*domain.Reservation).Commit)
+}
+
+// ReleaseReservation은 취소되거나 만료된 주문의 예약을 해제합니다.
+func (uc *InventoryUseCase) ReleaseReservation(ctx context.Context, orderID string) (*domain.Reservation, error) {
+	return uc.changeReservation(ctx, orderID, (*domain.Reservation).Release)
+}
+
+// ChangeReservation은 주문 라인 변경이나 부분 취소에 맞춰 예약 수량을 바꿉니다.
+// 늘어난 수량만 새로 예약하고 줄어든 수량은 재고로 돌려놓습니다.
+func (uc *InventoryUseCase) ChangeReservation(ctx context.Context, orderID string, lineRequests []ReservationLineRequest) (*domain.Reservation, error) {
+	lines := make([]*domain.ReservationLine, 0, len(lineRequests))
+	for _, req := range lineRequests {
+		line, err := domain.NewReservationLine(req.SKUID, req.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+
+	reservation, err := uc.GetReservation(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := reservation.Lines()
+	if err := reservation.ChangeLines(lines); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.ReplaceReservationLines(ctx, reservation, previous); err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// GetReservation은 주문의 재고 예약을 조회합니다.
+func (uc *InventoryUseCase) GetReservation(ctx context.Context, orderID string) (*domain.Reservation, error) {
+	if orderID == "" {
+		return nil, ErrInvalidOrderID
+	}
+	return uc.repo.FindReservationByOrderID(ctx, orderID)
+}
+
+// ReleaseExpiredReservations는 만료된 예약을 해제하고 해제한 건수를 반환합니다.
+func (uc *InventoryUseCase) ReleaseExpiredReservations(ctx context.Context, now time.Time) (int, error) {
+	reservations, err := uc.repo.FindExpiredReservations(ctx, now, expiredReservationBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, reservation := range reservations {
+		previous := reservation.Status()
+		changed, err := reservation.Release()
+		if err != nil || !changed {
+			continue
+		}
+
+		if err := uc.repo.UpdateReservation(ctx, reservation, previous); err != nil {
+			// 동시에 결제 확정된 예약은 건너뜁니다
+			if errors.Is(err, domain.ErrReservationConflict) {
+				continue
+			}
+			return released, fmt.Errorf("failed to release expired reservation: %w", err)
+		}
+		released++
+	}
+
+	return released, nil
+}
+
+// changeReservation은 예약 상태를 변경하고 저장합니다.
+// 상태가 이미 목표 상태라면 저장하지 않으므로 같은 요청을 여러 번 보내도 안전합니다.
+func (uc *InventoryUseCase) changeReservation(
+	ctx context.Context,
+	orderID string,
+	change func(*domain.Reservation) (bool, error),
+) (*domain.Reservation, error) {
+	reservation, err := uc.GetReservation(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := reservation.Status()
+	changed, err := change(reservation)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return reservation, nil
+	}
+
+	if err := uc.repo.UpdateReservation(ctx, reservation, previous); err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
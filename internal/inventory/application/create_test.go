@@ -0,0 +1,410 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"example.com/myapp/inventory/domain"
+)
+
+type stockKey struct {
+	skuID       string
+	warehouseID string
+}
+
+// FakeInventoryRepository는 테스트를 위한 가짜 InventoryRepository 구현체입니다.
+// Postgres 구현과 마찬가지로 예약과 상태 변경을 잠금 안에서 원자적으로 처리합니다.
+type FakeInventoryRepository struct {
+	mu           sync.Mutex
+	onHand       map[stockKey]int
+	reserved     map[stockKey]int
+	reservations map[string]*domain.Reservation
+	statuses     map[string]domain.ReservationStatus
+}
+
+// NewFakeInventoryRepository는 새로운 FakeInventoryRepository 인스턴스를 생성합니다.
+func NewFakeInventoryRepository() *FakeInventoryRepository {
+	return &FakeInventoryRepository{
+		onHand:       make(map[stockKey]int),
+		reserved:     make(map[stockKey]int),
+		reservations: make(map[string]*domain.Reservation),
+		statuses:     make(map[string]domain.ReservationStatus),
+	}
+}
+
+func (f *FakeInventoryRepository) AdjustOnHand(ctx context.Context, skuID, warehouseID string, delta int) (*domain.StockItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := stockKey{skuID, warehouseID}
+	if f.onHand[key]+delta < f.reserved[key] {
+		return nil, domain.ErrInsufficientStock
+	}
+	f.onHand[key] += delta
+	return domain.RestoreStockItem(skuID, warehouseID, f.onHand[key], f.reserved[key], time.Now()), nil
+}
+
+func (f *FakeInventoryRepository) FindStockBySKU(ctx context.Context, skuID string) ([]*domain.StockItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	items := []*domain.StockItem{}
+	for key, onHand := range f.onHand {
+		if key.skuID == skuID {
+			items = append(items, domain.RestoreStockItem(key.skuID, key.warehouseID, onHand, f.reserved[key], time.Now()))
+		}
+	}
+	return items, nil
+}
+
+func (f *FakeInventoryRepository) Reserve(ctx context.Context, reservation *domain.Reservation) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.reservations[reservation.OrderID()]; ok {
+		return domain.ErrReservationExists
+	}
+
+	allocations := make([]stockKey, len(reservation.Lines()))
+	for i, line := range reservation.Lines() {
+		found := false
+		for key, onHand := range f.onHand {
+			if key.skuID == line.SKUID() && onHand-f.reserved[key] >= line.Quantity() {
+				allocations[i] = key
+				found = true
+				break
+			}
+		}
+		if !found {
+			return domain.ErrInsufficientStock
+		}
+	}
+
+	for i, line := range reservation.Lines() {
+		f.reserved[allocations[i]] += line.Quantity()
+		line.AllocateTo(allocations[i].warehouseID)
+	}
+	f.reservations[reservation.OrderID()] = reservation
+	f.statuses[reservation.OrderID()] = reservation.Status()
+	return nil
+}
+
+func (f *FakeInventoryRepository) FindReservationByOrderID(ctx context.Context, orderID string) (*domain.Reservation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	reservation, ok := f.reservations[orderID]
+	if !ok {
+		return nil, domain.ErrReservationNotFound
+	}
+
+	// 저장된 상태의 복사본을 반환하여 실제 DB 조회처럼 동작합니다
+	lines := make([]*domain.ReservationLine, len(reservation.Lines()))
+	for i, line := range reservation.Lines() {
+		lines[i] = domain.RestoreReservationLine(line.SKUID(), line.WarehouseID(), line.Quantity())
+	}
+	return domain.RestoreReservation(
+		reservation.ID(), orderID, lines, f.statuses[orderID],
+		reservation.ExpiresAt(), reservation.CreatedAt(), reservation.UpdatedAt(),
+	), nil
+}
+
+func (f *FakeInventoryRepository) UpdateReservation(ctx context.Context, reservation *domain.Reservation, previous domain.ReservationStatus) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.statuses[reservation.OrderID()] != previous {
+		return domain.ErrReservationConflict
+	}
+	f.statuses[reservation.OrderID()] = reservation.Status()
+
+	for _, line := range reservation.Lines() {
+		key := stockKey{line.SKUID(), line.WarehouseID()}
+		switch reservation.Status() {
+		case domain.ReservationStatusReleased:
+			f.reserved[key] -= line.Quantity()
+		case domain.ReservationStatusCommitted:
+			f.reserved[key] -= line.Quantity()
+			f.onHand[key] -= line.Quantity()
+		}
+	}
+	return nil
+}
+
+func (f *FakeInventoryRepository) ReplaceReservationLines(ctx context.Context, reservation *domain.Reservation, previous []*domain.ReservationLine) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := map[string]*domain.ReservationLine{}
+	for _, line := range f.reservations[reservation.OrderID()].Lines() {
+		stored[line.SKUID()] = line
+	}
+	if len(stored) != len(previous) || f.statuses[reservation.OrderID()] != reservation.Status() {
+		return domain.ErrReservationConflict
+	}
+	for _, line := range previous {
+		if s, ok := stored[line.SKUID()]; !ok || s.Quantity() != line.Quantity() {
+			return domain.ErrReservationConflict
+		}
+	}
+
+	// 모든 증가분을 예약할 수 있는지 먼저 확인합니다
+	for _, line := range reservation.Lines() {
+		old, existed := stored[line.SKUID()]
+		if !existed {
+			found := false
+			for key, onHand := range f.onHand {
+				if key.skuID == line.SKUID() && onHand-f.reserved[key] >= line.Quantity() {
+					line.AllocateTo(key.warehouseID)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return domain.ErrInsufficientStock
+			}
+			continue
+		}
+		key := stockKey{line.SKUID(), line.WarehouseID()}
+		if delta := line.Quantity() - old.Quantity(); delta > 0 && f.onHand[key]-f.reserved[key] < delta {
+			return domain.ErrInsufficientStock
+		}
+	}
+
+	kept := map[string]bool{}
+	for _, line := range reservation.Lines() {
+		kept[line.SKUID()] = true
+		delta := line.Quantity()
+		if old, ok := stored[line.SKUID()]; ok {
+			delta -= old.Quantity()
+		}
+		f.reserved[stockKey{line.SKUID(), line.WarehouseID()}] += delta
+	}
+	for _, line := range previous {
+		if !kept[line.SKUID()] {
+			f.reserved[stockKey{line.SKUID(), line.WarehouseID()}] -= line.Quantity()
+		}
+	}
+	f.reservations[reservation.OrderID()] = reservation
+	return nil
+}
+
+func (f *FakeInventoryRepository) FindExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*domain.Reservation, error) {
+	f.mu.Lock()
+	orderIDs := []string{}
+	for orderID, reservation := range f.reservations {
+		if f.statuses[orderID] == domain.ReservationStatusReserved && now.After(reservation.ExpiresAt()) {
+			orderIDs = append(orderIDs, orderID)
+		}
+	}
+	f.mu.Unlock()
+
+	reservations := []*domain.Reservation{}
+	for _, orderID := range orderIDs {
+		reservation, err := f.FindReservationByOrderID(ctx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, reservation)
+	}
+	return reservations, nil
+}
+
+func TestReserveForOrderIsAllOrNothing(t *testing.T) {
+	repo := NewFakeInventoryRepository()
+	useCase := NewInventoryUseCase(repo, time.Hour)
+	ctx := context.Background()
+
+	if _, err := useCase.AdjustStock(ctx, "sku-1", "wh-1", 5); err != nil {
+		t.Fatalf("AdjustStock() error = %v", err)
+	}
+	if _, err := useCase.AdjustStock(ctx, "sku-2", "wh-1", 1); err != nil {
+		t.Fatalf("AdjustStock() error = %v", err)
+	}
+
+	_, err := useCase.ReserveForOrder(ctx, "order-1", []ReservationLineRequest{
+		{SKUID: "sku-1", Quantity: 2},
+		{SKUID: "sku-2", Quantity: 2},
+	})
+	if !errors.Is(err, domain.ErrInsufficientStock) {
+		t.Fatalf("ReserveForOrder() error = %v, want %v", err, domain.ErrInsufficientStock)
+	}
+
+	if reserved := repo.reserved[stockKey{"sku-1", "wh-1"}]; reserved != 0 {
+		t.Errorf("일부 라인이 예약되었습니다: reserved = %v", reserved)
+	}
+}
+
+func TestReservationLifecycle(t *testing.T) {
+	repo := NewFakeInventoryRepository()
+	useCase := NewInventoryUseCase(repo, time.Hour)
+	ctx := context.Background()
+	key := stockKey{"sku-1", "wh-1"}
+
+	if _, err := useCase.AdjustStock(ctx, "sku-1", "wh-1", 10); err != nil {
+		t.Fatalf("AdjustStock() error = %v", err)
+	}
+
+	// 같은 SKU의 라인은 하나로 합쳐집니다
+	if _, err := useCase.ReserveForOrder(ctx, "order-1", []ReservationLineRequest{
+		{SKUID: "sku-1", Quantity: 2},
+		{SKUID: "sku-1", Quantity: 1},
+	}); err != nil {
+		t.Fatalf("ReserveForOrder() error = %v", err)
+	}
+	if repo.reserved[key] != 3 {
+		t.Fatalf("reserved = %v, want 3", repo.reserved[key])
+	}
+
+	if _, err := useCase.CommitReservation(ctx, "order-1"); err != nil {
+		t.Fatalf("CommitReservation() error = %v", err)
+	}
+	// 재시도해도 두 번 차감되지 않습니다
+	if _, err := useCase.CommitReservation(ctx, "order-1"); err != nil {
+		t.Fatalf("CommitReservation() retry error = %v", err)
+	}
+	if repo.onHand[key] != 7 || repo.reserved[key] != 0 {
+		t.Errorf("onHand = %v, reserved = %v, want 7, 0", repo.onHand[key], repo.reserved[key])
+	}
+
+	if _, err := useCase.ReleaseReservation(ctx, "order-1"); !errors.Is(err, domain.ErrInvalidReservationChange) {
+		t.Errorf("출고된 예약 해제 error = %v, want %v", err, domain.ErrInvalidReservationChange)
+	}
+}
+
+func TestReleaseExpiredReservations(t *testing.T) {
+	repo := NewFakeInventoryRepository()
+	useCase := NewInventoryUseCase(repo, time.Minute)
+	ctx := context.Background()
+	key := stockKey{"sku-1", "wh-1"}
+
+	if _, err := useCase.AdjustStock(ctx, "sku-1", "wh-1", 10); err != nil {
+		t.Fatalf("AdjustStock() error = %v", err)
+	}
+	for _, orderID := range []string{"unpaid", "paid"} {
+		if _, err := useCase.ReserveForOrder(ctx, orderID, []ReservationLineRequest{{SKUID: "sku-1", Quantity: 2}}); err != nil {
+			t.Fatalf("ReserveForOrder() error = %v", err)
+		}
+	}
+	if _, err := useCase.ConfirmReservation(ctx, "paid"); err != nil {
+		t.Fatalf("ConfirmReservation() error = %v", err)
+	}
+
+	released, err := useCase.ReleaseExpiredReservations(ctx, time.Now().Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("ReleaseExpiredReservations() error = %v", err)
+	}
+	if released != 1 {
+		t.Errorf("released = %v, want 1", released)
+	}
+	if repo.reserved[key] != 2 {
+		t.Errorf("결제된 예약까지 해제되었습니다: reserved = %v, want 2", repo.reserved[key])
+	}
+}
+
+func TestConcurrentReservationsNeverOversell(t *testing.T) {
+	repo := NewFakeInventoryRepository()
+	useCase := NewInventoryUseCase(repo, time.Hour)
+	ctx := context.Background()
+
+	const stock = 10
+	const orders = 50
+	if _, err := useCase.AdjustStock(ctx, "sku-1", "wh-1", stock); err != nil {
+		t.Fatalf("AdjustStock() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := []string{}
+	for i := 0; i < orders; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			orderID := fmt.Sprintf("order-%d", i)
+			_, err := useCase.ReserveForOrder(ctx, orderID, []ReservationLineRequest{{SKUID: "sku-1", Quantity: 1}})
+			if err == nil {
+				mu.Lock()
+				succeeded = append(succeeded, orderID)
+				mu.Unlock()
+			} else if !errors.Is(err, domain.ErrInsufficientStock) {
+				t.Errorf("ReserveForOrder() unexpected error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if len(succeeded) != stock {
+		t.Fatalf("succeeded = %v, want %v", len(succeeded), stock)
+	}
+
+	// 같은 예약을 동시에 해제해도 재고는 한 번만 돌아옵니다
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := useCase.ReleaseReservation(ctx, succeeded[0])
+			if err != nil && !errors.Is(err, domain.ErrReservationConflict) {
+				t.Errorf("ReleaseReservation() unexpected error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	key := stockKey{"sku-1", "wh-1"}
+	if repo.reserved[key] != stock-1 {
+		t.Errorf("reserved = %v, want %v", repo.reserved[key], stock-1)
+	}
+}
+
+func TestChangeReservationReservesOnlyTheDifference(t *testing.T) {
+	repo := NewFakeInventoryRepository()
+	useCase := NewInventoryUseCase(repo, time.Hour)
+	ctx := context.Background()
+	sku1 := stockKey{"sku-1", "wh-1"}
+	sku2 := stockKey{"sku-2", "wh-1"}
+
+	if _, err := useCase.AdjustStock(ctx, "sku-1", "wh-1", 5); err != nil {
+		t.Fatalf("AdjustStock() error = %v", err)
+	}
+	if _, err := useCase.AdjustStock(ctx, "sku-2", "wh-1", 2); err != nil {
+		t.Fatalf("AdjustStock() error = %v", err)
+	}
+	if _, err := useCase.ReserveForOrder(ctx, "order-1", []ReservationLineRequest{{SKUID: "sku-1", Quantity: 2}}); err != nil {
+		t.Fatalf("ReserveForOrder() error = %v", err)
+	}
+
+	// sku-1은 늘리고 sku-2는 새로 추가합니다
+	if _, err := useCase.ChangeReservation(ctx, "order-1", []ReservationLineRequest{
+		{SKUID: "sku-1", Quantity: 4},
+		{SKUID: "sku-2", Quantity: 1},
+	}); err != nil {
+		t.Fatalf("ChangeReservation() error = %v", err)
+	}
+	if repo.reserved[sku1] != 4 || repo.reserved[sku2] != 1 {
+		t.Fatalf("reserved = %v, %v, want 4, 1", repo.reserved[sku1], repo.reserved[sku2])
+	}
+
+	// 재고보다 많이 늘리면 아무것도 바뀌지 않습니다
+	_, err := useCase.ChangeReservation(ctx, "order-1", []ReservationLineRequest{{SKUID: "sku-1", Quantity: 6}})
+	if !errors.Is(err, domain.ErrInsufficientStock) {
+		t.Fatalf("ChangeReservation() error = %v, want %v", err, domain.ErrInsufficientStock)
+	}
+	if repo.reserved[sku1] != 4 || repo.reserved[sku2] != 1 {
+		t.Fatalf("실패한 변경이 재고에 반영되었습니다: reserved = %v, %v", repo.reserved[sku1], repo.reserved[sku2])
+	}
+
+	// 결제 확정 후 부분 취소로 줄어든 수량은 재고로 돌아갑니다
+	if _, err := useCase.ConfirmReservation(ctx, "order-1"); err != nil {
+		t.Fatalf("ConfirmReservation() error = %v", err)
+	}
+	if _, err := useCase.ChangeReservation(ctx, "order-1", []ReservationLineRequest{{SKUID: "sku-1", Quantity: 1}}); err != nil {
+		t.Fatalf("ChangeReservation() error = %v", err)
+	}
+	if repo.reserved[sku1] != 1 || repo.reserved[sku2] != 0 {
+		t.Errorf("reserved = %v, %v, want 1, 0", repo.reserved[sku1], repo.reserved[sku2])
+	}
+}
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"example.com/myapp/inventory/domain"
+)
+
+// InventoryRepository는 재고 관련 영속성 인터페이스를 정의합니다.
+// 재고 수량 변경은 동시 주문에서도 초과 판매가 없도록 저장소 수준에서 원자적으로 처리되어야 합니다.
+type InventoryRepository interface {
+	// AdjustOnHand는 실재고를 delta만큼 원자적으로 증감합니다.
+	// 결과 실재고가 예약 수량보다 작아지면 ErrInsufficientStock을 반환합니다.
+	AdjustOnHand(ctx context.Context, skuID, warehouseID string, delta int) (*domain.StockItem, error)
+	FindStockBySKU(ctx context.Context, skuID string) ([]*domain.StockItem, error)
+
+	// Reserve는 예약의 모든 라인에 창고를 할당하고 재고를 예약합니다.
+	// 하나의 라인이라도 예약할 수 없으면 아무것도 예약하지 않고 ErrInsufficientStock을 반환합니다.
+	Reserve(ctx context.Context, reservation *domain.Reservation) error
+	FindReservationByOrderID(ctx context.Context, orderID string) (*domain.Reservation, error)
+
+	// UpdateReservation은 예약 상태가 previous일 때만 새 상태를 저장하고 재고에 반영합니다.
+	// 다른 요청이 먼저 상태를 바꿨다면 ErrReservationConflict를 반환합니다.
+	UpdateReservation(ctx context.Context, reservation *domain.Reservation, previous domain.ReservationStatus) error
+
+	// ReplaceReservationLines는 예약 라인이 previous 그대로일 때만 새 라인을 저장하고 수량 차이를 재고에 반영합니다.
+	// 새로 필요한 수량을 예약할 수 없으면 ErrInsufficientStock을, 다른 요청이 먼저 바꿨다면 ErrReservationConflict를 반환합니다.
+	ReplaceReservationLines(ctx context.Context, reservation *domain.Reservation, previous []*domain.ReservationLine) error
+	FindExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*domain.Reservation, error)
+}
+
+// InventoryService는 재고 관련 비즈니스 로직을 정의합니다.
+type InventoryService interface {
+	AdjustStock(ctx context.Context, skuID, warehouseID string, delta int) (*domain.StockItem, error)
+	GetStock(ctx context.Context, skuID string) ([]*domain.StockItem, error)
+	ReserveForOrder(ctx context.Context, orderID string, lines []ReservationLineRequest) (*domain.Reservation, error)
+	ConfirmReservation(ctx context.Context, orderID string) (*domain.Reservation, error)
+	CommitReservation(ctx context.Context, orderID string) (*domain.Reservation, error)
+	ReleaseReservation(ctx context.Context, orderID string) (*domain.Reservation, error)
+	ChangeReservation(ctx context.Context, orderID string, lines []ReservationLineRequest) (*domain.Reservation, error)
+	GetReservation(ctx context.Context, orderID string) (*domain.Reservation, error)
+	ReleaseExpiredReservations(ctx context.Context, now time.Time) (int, error)
+}
+
+// ReservationLineRequest는 재고 예약 요청 라인을 정의합니다.
+type ReservationLineRequest struct {
+	SKUID    string
+	Quantity int
+}
+
+// InventoryUseCase는 InventoryService 구현체를 정의합니다.
+type InventoryUseCase struct {
+	repo           InventoryRepository
+	reservationTTL time.Duration
+}
+
+// NewInventoryUseCase는 새로운 InventoryUseCase 인스턴스를 생성합니다.
+// reservationTTL은 결제되지 않은 주문의 재고 예약이 유지되는 시간입니다.
+func NewInventoryUseCase(repo InventoryRepository, reservationTTL time.Duration) *InventoryUseCase {
+	return &InventoryUseCase{
+		repo:           repo,
+		reservationTTL: reservationTTL,
+	}
+}
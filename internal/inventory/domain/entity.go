@@ -0,0 +1,311 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReservationStatus는 재고 예약 상태를 정의합니다.
+type ReservationStatus string
+
+const (
+	ReservationStatusReserved  ReservationStatus = "reserved"  // 주문 생성 시 예약됨 (만료 대상)
+	ReservationStatusConfirmed ReservationStatus = "confirmed" // 결제 완료로 만료되지 않음
+	ReservationStatusCommitted ReservationStatus = "committed" // 출고되어 실재고에서 차감됨
+	ReservationStatusReleased  ReservationStatus = "released"  // 취소/만료로 예약 해제됨
+)
+
+var (
+	ErrInvalidSKU               = errors.New("invalid SKU")
+	ErrInvalidWarehouse         = errors.New("invalid warehouse")
+	ErrInvalidQuantity          = errors.New("quantity must be positive")
+	ErrInsufficientStock        = errors.New("insufficient stock")
+	ErrStockNotFound            = errors.New("stock not found")
+	ErrReservationNotFound      = errors.New("reservation not found")
+	ErrReservationExists        = errors.New("reservation already exists for this order")
+	ErrReservationConflict      = errors.New("reservation was modified concurrently")
+	ErrInvalidReservationChange = errors.New("invalid reservation status transition")
+)
+
+// StockItem은 창고별 SKU 재고를 나타냅니다.
+type StockItem struct {
+	skuID       string
+	warehouseID string
+	onHand      int
+	reserved    int
+	updatedAt   time.Time
+}
+
+// RestoreStockItem은 저장된 데이터로부터 재고를 복원합니다.
+func RestoreStockItem(skuID, warehouseID string, onHand, reserved int, updatedAt time.Time) *StockItem {
+	return &StockItem{
+		skuID:       skuID,
+		warehouseID: warehouseID,
+		onHand:      onHand,
+		reserved:    reserved,
+		updatedAt:   updatedAt,
+	}
+}
+
+// SKUID는 SKU ID를 반환합니다.
+func (s *StockItem) SKUID() string {
+	return s.skuID
+}
+
+// WarehouseID는 창고 ID를 반환합니다.
+func (s *StockItem) WarehouseID() string {
+	return s.warehouseID
+}
+
+// OnHand는 실재고 수량을 반환합니다.
+func (s *StockItem) OnHand() int {
+	return s.onHand
+}
+
+// Reserved는 예약된 수량을 반환합니다.
+func (s *StockItem) Reserved() int {
+	return s.reserved
+}
+
+// Available은 주문 가능한 수량(실재고 - 예약)을 반환합니다.
+func (s *StockItem) Available() int {
+	return s.onHand - s.reserved
+}
+
+// UpdatedAt은 재고가 마지막으로 변경된 시간을 반환합니다.
+func (s *StockItem) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// ReservationLine은 예약된 SKU 한 줄을 나타냅니다.
+type ReservationLine struct {
+	skuID       string
+	warehouseID string
+	quantity    int
+}
+
+// NewReservationLine은 창고가 할당되지 않은 예약 라인을 생성합니다.
+func NewReservationLine(skuID string, quantity int) (*ReservationLine, error) {
+	if skuID == "" {
+		return nil, ErrInvalidSKU
+	}
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+	return &ReservationLine{
+		skuID:    skuID,
+		quantity: quantity,
+	}, nil
+}
+
+// RestoreReservationLine은 저장된 데이터로부터 예약 라인을 복원합니다.
+func RestoreReservationLine(skuID, warehouseID string, quantity int) *ReservationLine {
+	return &ReservationLine{
+		skuID:       skuID,
+		warehouseID: warehouseID,
+		quantity:    quantity,
+	}
+}
+
+// SKUID는 SKU ID를 반환합니다.
+func (l *ReservationLine) SKUID() string {
+	return l.skuID
+}
+
+// WarehouseID는 할당된 창고 ID를 반환합니다.
+func (l *ReservationLine) WarehouseID() string {
+	return l.warehouseID
+}
+
+// Quantity는 예약 수량을 반환합니다.
+func (l *ReservationLine) Quantity() int {
+	return l.quantity
+}
+
+// AllocateTo는 예약 라인에 출고 창고를 할당합니다.
+func (l *ReservationLine) AllocateTo(warehouseID string) {
+	l.warehouseID = warehouseID
+}
+
+// Reservation은 주문 단위 재고 예약을 나타냅니다.
+type Reservation struct {
+	id        string
+	orderID   string
+	lines     []*ReservationLine
+	status    ReservationStatus
+	expiresAt time.Time
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewReservation은 새로운 재고 예약을 생성합니다.
+// 같은 SKU의 라인은 하나로 합치고 SKU 순으로 정렬합니다.
+func NewReservation(orderID string, lines []*ReservationLine, ttl time.Duration) (*Reservation, error) {
+	if len(lines) == 0 {
+		return nil, ErrInvalidQuantity
+	}
+
+	now := time.Now()
+	return &Reservation{
+		id:        uuid.New().String(),
+		orderID:   orderID,
+		lines:     mergeLines(lines),
+		status:    ReservationStatusReserved,
+		expiresAt: now.Add(ttl),
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// RestoreReservation은 저장된 데이터로부터 재고 예약을 복원합니다.
+func RestoreReservation(
+	id, orderID string,
+	lines []*ReservationLine,
+	status ReservationStatus,
+	expiresAt, createdAt, updatedAt time.Time,
+) *Reservation {
+	return &Reservation{
+		id:        id,
+		orderID:   orderID,
+		lines:     lines,
+		status:    status,
+		expiresAt: expiresAt,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+// ID는 예약의 고유 식별자를 반환합니다.
+func (r *Reservation) ID() string {
+	return r.id
+}
+
+// OrderID는 예약한 주문 ID를 반환합니다.
+func (r *Reservation) OrderID() string {
+	return r.orderID
+}
+
+// Lines는 예약 라인 목록을 반환합니다.
+func (r *Reservation) Lines() []*ReservationLine {
+	return r.lines
+}
+
+// Status는 예약 상태를 반환합니다.
+func (r *Reservation) Status() ReservationStatus {
+	return r.status
+}
+
+// ExpiresAt은 예약 만료 시간을 반환합니다.
+func (r *Reservation) ExpiresAt() time.Time {
+	return r.expiresAt
+}
+
+// CreatedAt은 예약이 생성된 시간을 반환합니다.
+func (r *Reservation) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// UpdatedAt은 예약이 마지막으로 변경된 시간을 반환합니다.
+func (r *Reservation) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// IsExpired는 예약이 만료되었는지 확인합니다.
+func (r *Reservation) IsExpired(now time.Time) bool {
+	return r.status == ReservationStatusReserved && now.After(r.expiresAt)
+}
+
+// Confirm은 결제 완료된 주문의 예약을 만료 대상에서 제외합니다.
+// 이미 확정된 예약이면 변경 없이 false를 반환합니다.
+func (r *Reservation) Confirm() (bool, error) {
+	switch r.status {
+	case ReservationStatusReserved:
+		return r.transition(ReservationStatusConfirmed), nil
+	case ReservationStatusConfirmed, ReservationStatusCommitted:
+		return false, nil
+	default:
+		return false, ErrInvalidReservationChange
+	}
+}
+
+// Commit은 출고된 주문의 예약을 실재고 차감으로 확정합니다.
+// 이미 확정된 예약이면 변경 없이 false를 반환합니다.
+func (r *Reservation) Commit() (bool, error) {
+	switch r.status {
+	case ReservationStatusReserved, ReservationStatusConfirmed:
+		return r.transition(ReservationStatusCommitted), nil
+	case ReservationStatusCommitted:
+		return false, nil
+	default:
+		return false, ErrInvalidReservationChange
+	}
+}
+
+// Release는 예약을 해제하여 재고를 돌려놓습니다.
+// 이미 해제된 예약이면 변경 없이 false를 반환합니다.
+func (r *Reservation) Release() (bool, error) {
+	switch r.status {
+	case ReservationStatusReserved, ReservationStatusConfirmed:
+		return r.transition(ReservationStatusReleased), nil
+	case ReservationStatusReleased:
+		return false, nil
+	default:
+		return false, ErrInvalidReservationChange
+	}
+}
+
+// ChangeLines는 주문 라인 변경에 맞춰 예약 라인을 새 수량으로 바꿉니다.
+// 이미 예약된 SKU는 기존 창고 할당을 유지하고, 새 SKU는 저장소에서 창고를 할당합니다.
+// 출고되었거나 해제된 예약은 바꿀 수 없습니다.
+func (r *Reservation) ChangeLines(lines []*ReservationLine) error {
+	if r.status != ReservationStatusReserved && r.status != ReservationStatusConfirmed {
+		return ErrInvalidReservationChange
+	}
+	if len(lines) == 0 {
+		return ErrInvalidQuantity
+	}
+
+	warehouses := make(map[string]string, len(r.lines))
+	for _, line := range r.lines {
+		warehouses[line.skuID] = line.warehouseID
+	}
+
+	merged := mergeLines(lines)
+	for _, line := range merged {
+		line.warehouseID = warehouses[line.skuID]
+	}
+
+	r.lines = merged
+	r.updatedAt = time.Now()
+	return nil
+}
+
+// mergeLines는 같은 SKU의 라인을 하나로 합치고, 잠금 순서를 일정하게 유지하기 위해 SKU 순으로 정렬합니다.
+func mergeLines(lines []*ReservationLine) []*ReservationLine {
+	merged := map[string]*ReservationLine{}
+	for _, line := range lines {
+		if existing, ok := merged[line.skuID]; ok {
+			existing.quantity += line.quantity
+			continue
+		}
+		merged[line.skuID] = &ReservationLine{skuID: line.skuID, quantity: line.quantity}
+	}
+
+	sorted := make([]*ReservationLine, 0, len(merged))
+	for _, line := range merged {
+		sorted = append(sorted, line)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].skuID < sorted[j].skuID
+	})
+	return sorted
+}
+
+func (r *Reservation) transition(status ReservationStatus) bool {
+	r.status = status
+	r.updatedAt = time.Now()
+	return true
+}
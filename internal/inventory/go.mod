@@ -0,0 +1,3 @@
+module example.com/myapp/inventory
+
+go 1.21
\ No newline at end of file
@@ -0,0 +1,529 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"example.com/myapp/inventory/application"
+	"example.com/myapp/inventory/domain"
+	"example.com/myapp/shared/db"
+	"github.com/jackc/pgx/v4"
+)
+
+// PostgresInventoryRepository는 PostgreSQL을 사용하는 재고 저장소 구현체입니다.
+// 초과 판매를 막기 위해 재고 행 잠금(SELECT ... FOR UPDATE)과 조건부 UPDATE를 사용합니다.
+type PostgresInventoryRepository struct {
+	db *db.Database
+}
+
+// NewPostgresInventoryRepository는 새로운 PostgresInventoryRepository 인스턴스를 생성합니다.
+func NewPostgresInventoryRepository(database *db.Database) application.InventoryRepository {
+	return &PostgresInventoryRepository{
+		db: database,
+	}
+}
+
+// AdjustOnHand는 실재고를 delta만큼 원자적으로 증감합니다.
+func (r *PostgresInventoryRepository) AdjustOnHand(ctx context.Context, skuID, warehouseID string, delta int) (*domain.StockItem, error) {
+	var query string
+	if delta > 0 {
+		// 입고: 재고 행이 없으면 새로 만듭니다
+		query = `
+			INSERT INTO stock_levels (sku_id, warehouse_id, on_hand, reserved, updated_at)
+			VALUES ($1, $2, $3, 0, $4)
+			ON CONFLICT (sku_id, warehouse_id) DO UPDATE
+			SET on_hand = stock_levels.on_hand + EXCLUDED.on_hand, updated_at = EXCLUDED.updated_at
+			RETURNING sku_id, warehouse_id, on_hand, reserved, updated_at
+		`
+	} else {
+		// 차감: 예약된 수량 아래로는 줄일 수 없습니다
+		query = `
+			UPDATE stock_levels
+			SET on_hand = on_hand + $3, updated_at = $4
+			WHERE sku_id = $1 AND warehouse_id = $2 AND on_hand + $3 >= reserved
+			RETURNING sku_id, warehouse_id, on_hand, reserved, updated_at
+		`
+	}
+
+	row := r.db.Pool.QueryRow(ctx, query, skuID, warehouseID, delta, time.Now())
+
+	var stockSKUID, stockWarehouseID string
+	var onHand, reserved int
+	var updatedAt time.Time
+
+	err := row.Scan(&stockSKUID, &stockWarehouseID, &onHand, &reserved, &updatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrInsufficientStock
+		}
+		return nil, fmt.Errorf("failed to adjust stock: %w", err)
+	}
+
+	return domain.RestoreStockItem(stockSKUID, stockWarehouseID, onHand, reserved, updatedAt), nil
+}
+
+// FindStockBySKU는 SKU의 창고별 재고를 조회합니다.
+func (r *PostgresInventoryRepository) FindStockBySKU(ctx context.Context, skuID string) ([]*domain.StockItem, error) {
+	query := `
+		SELECT sku_id, warehouse_id, on_hand, reserved, updated_at
+		FROM stock_levels
+		WHERE sku_id = $1
+		ORDER BY warehouse_id
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, skuID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stock levels: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*domain.StockItem{}
+	for rows.Next() {
+		var stockSKUID, warehouseID string
+		var onHand, reserved int
+		var updatedAt time.Time
+
+		if err := rows.Scan(&stockSKUID, &warehouseID, &onHand, &reserved, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan stock level: %w", err)
+		}
+		items = append(items, domain.RestoreStockItem(stockSKUID, warehouseID, onHand, reserved, updatedAt))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stock levels: %w", err)
+	}
+
+	return items, nil
+}
+
+// Reserve는 예약의 모든 라인을 하나의 트랜잭션에서 예약합니다.
+func (r *PostgresInventoryRepository) Reserve(ctx context.Context, reservation *domain.Reservation) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	// 1. 중복 예약 확인
+	var exists bool
+	err = tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM stock_reservations WHERE order_id = $1)", reservation.OrderID()).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check existing reservation: %w", err)
+	}
+	if exists {
+		return domain.ErrReservationExists
+	}
+
+	// 2. 라인별 창고 할당 및 예약 수량 증가
+	// 라인은 SKU 순으로 정렬되어 있어 동시 예약 간 교착 상태가 생기지 않습니다.
+	for _, line := range reservation.Lines() {
+		warehouseID, err := lockWarehouseWithStock(ctx, tx, line.SKUID(), line.Quantity())
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(
+			ctx,
+			"UPDATE stock_levels SET reserved = reserved + $1, updated_at = $2 WHERE sku_id = $3 AND warehouse_id = $4",
+			line.Quantity(),
+			reservation.CreatedAt(),
+			line.SKUID(),
+			warehouseID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to reserve stock: %w", err)
+		}
+
+		line.AllocateTo(warehouseID)
+	}
+
+	// 3. 예약 정보 저장
+	reservationQuery := `
+		INSERT INTO stock_reservations (id, order_id, status, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err = tx.Exec(
+		ctx,
+		reservationQuery,
+		reservation.ID(),
+		reservation.OrderID(),
+		string(reservation.Status()),
+		reservation.ExpiresAt(),
+		reservation.CreatedAt(),
+		reservation.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save reservation: %w", err)
+	}
+
+	for _, line := range reservation.Lines() {
+		_, err = tx.Exec(
+			ctx,
+			"INSERT INTO stock_reservation_lines (reservation_id, sku_id, warehouse_id, quantity) VALUES ($1, $2, $3, $4)",
+			reservation.ID(),
+			line.SKUID(),
+			line.WarehouseID(),
+			line.Quantity(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save reservation line: %w", err)
+		}
+	}
+
+	// 트랜잭션 커밋
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindReservationByOrderID는 주문 ID로 재고 예약을 조회합니다.
+func (r *PostgresInventoryRepository) FindReservationByOrderID(ctx context.Context, orderID string) (*domain.Reservation, error) {
+	query := `
+		SELECT id, order_id, status, expires_at, created_at, updated_at
+		FROM stock_reservations
+		WHERE order_id = $1
+	`
+
+	reservation, err := r.scanReservation(ctx, r.db.Pool.QueryRow(ctx, query, orderID))
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// UpdateReservation은 예약 상태를 조건부로 변경하고 재고에 반영합니다.
+func (r *PostgresInventoryRepository) UpdateReservation(ctx context.Context, reservation *domain.Reservation, previous domain.ReservationStatus) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	// 1. 이전 상태일 때만 변경 (동시 해제/확정으로 인한 이중 반영 방지)
+	result, err := tx.Exec(
+		ctx,
+		"UPDATE stock_reservations SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4",
+		string(reservation.Status()),
+		reservation.UpdatedAt(),
+		reservation.ID(),
+		string(previous),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrReservationConflict
+	}
+
+	// 2. 재고 반영
+	var stockQuery string
+	switch reservation.Status() {
+	case domain.ReservationStatusReleased:
+		stockQuery = "UPDATE stock_levels SET reserved = reserved - $1, updated_at = $2 WHERE sku_id = $3 AND warehouse_id = $4"
+	case domain.ReservationStatusCommitted:
+		stockQuery = "UPDATE stock_levels SET on_hand = on_hand - $1, reserved = reserved - $1, updated_at = $2 WHERE sku_id = $3 AND warehouse_id = $4"
+	}
+
+	if stockQuery != "" {
+		for _, line := range reservation.Lines() {
+			_, err = tx.Exec(ctx, stockQuery, line.Quantity(), reservation.UpdatedAt(), line.SKUID(), line.WarehouseID())
+			if err != nil {
+				return fmt.Errorf("failed to apply reservation to stock: %w", err)
+			}
+		}
+	}
+
+	// 트랜잭션 커밋
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceReservationLines는 예약 라인을 새 수량으로 바꾸고 SKU별 수량 차이만큼 예약 재고를 조정합니다.
+func (r *PostgresInventoryRepository) ReplaceReservationLines(ctx context.Context, reservation *domain.Reservation, previous []*domain.ReservationLine) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	// 1. 예약 행을 잠그고 조회 이후 상태나 라인이 바뀌지 않았는지 확인
+	var status string
+	err = tx.QueryRow(ctx, "SELECT status FROM stock_reservations WHERE id = $1 FOR UPDATE", reservation.ID()).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrReservationNotFound
+		}
+		return fmt.Errorf("failed to lock reservation: %w", err)
+	}
+	if domain.ReservationStatus(status) != reservation.Status() {
+		return domain.ErrReservationConflict
+	}
+
+	stored, err := findReservationLines(ctx, tx, reservation.ID())
+	if err != nil {
+		return err
+	}
+	if !sameLines(stored, previous) {
+		return domain.ErrReservationConflict
+	}
+
+	// 2. SKU별 수량 차이를 재고에 반영 (SKU 순으로 처리하여 교착 상태 방지)
+	before := make(map[string]*domain.ReservationLine, len(previous))
+	for _, line := range previous {
+		before[line.SKUID()] = line
+	}
+	after := make(map[string]bool, len(reservation.Lines()))
+	for _, line := range reservation.Lines() {
+		after[line.SKUID()] = true
+	}
+
+	reserveQuery := "UPDATE stock_levels SET reserved = reserved + $1, updated_at = $2 WHERE sku_id = $3 AND warehouse_id = $4"
+	for _, line := range reservation.Lines() {
+		old, existed := before[line.SKUID()]
+		switch {
+		case !existed:
+			warehouseID, err := lockWarehouseWithStock(ctx, tx, line.SKUID(), line.Quantity())
+			if err != nil {
+				return err
+			}
+			line.AllocateTo(warehouseID)
+			_, err = tx.Exec(ctx, reserveQuery, line.Quantity(), reservation.UpdatedAt(), line.SKUID(), warehouseID)
+			if err != nil {
+				return fmt.Errorf("failed to reserve stock: %w", err)
+			}
+		case line.Quantity() > old.Quantity():
+			// 이미 할당된 창고에서 늘어난 수량만 추가로 예약
+			delta := line.Quantity() - old.Quantity()
+			var available int
+			err := tx.QueryRow(
+				ctx,
+				"SELECT on_hand - reserved FROM stock_levels WHERE sku_id = $1 AND warehouse_id = $2 FOR UPDATE",
+				line.SKUID(),
+				line.WarehouseID(),
+			).Scan(&available)
+			if err != nil {
+				return fmt.Errorf("failed to lock stock level: %w", err)
+			}
+			if available < delta {
+				return fmt.Errorf("%w: sku %s", domain.ErrInsufficientStock, line.SKUID())
+			}
+			_, err = tx.Exec(ctx, reserveQuery, delta, reservation.UpdatedAt(), line.SKUID(), line.WarehouseID())
+			if err != nil {
+				return fmt.Errorf("failed to reserve stock: %w", err)
+			}
+		case line.Quantity() < old.Quantity():
+			_, err = tx.Exec(ctx, reserveQuery, line.Quantity()-old.Quantity(), reservation.UpdatedAt(), line.SKUID(), line.WarehouseID())
+			if err != nil {
+				return fmt.Errorf("failed to release stock: %w", err)
+			}
+		}
+	}
+	for _, line := range previous {
+		if after[line.SKUID()] {
+			continue
+		}
+		_, err = tx.Exec(ctx, reserveQuery, -line.Quantity(), reservation.UpdatedAt(), line.SKUID(), line.WarehouseID())
+		if err != nil {
+			return fmt.Errorf("failed to release stock: %w", err)
+		}
+	}
+
+	// 3. 예약 라인 교체
+	if _, err := tx.Exec(ctx, "DELETE FROM stock_reservation_lines WHERE reservation_id = $1", reservation.ID()); err != nil {
+		return fmt.Errorf("failed to delete reservation lines: %w", err)
+	}
+	for _, line := range reservation.Lines() {
+		_, err = tx.Exec(
+			ctx,
+			"INSERT INTO stock_reservation_lines (reservation_id, sku_id, warehouse_id, quantity) VALUES ($1, $2, $3, $4)",
+			reservation.ID(),
+			line.SKUID(),
+			line.WarehouseID(),
+			line.Quantity(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save reservation line: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE stock_reservations SET updated_at = $1 WHERE id = $2", reservation.UpdatedAt(), reservation.ID())
+	if err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	// 트랜잭션 커밋
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindExpiredReservations는 만료 시간이 지난 예약 목록을 조회합니다.
+func (r *PostgresInventoryRepository) FindExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*domain.Reservation, error) {
+	query := `
+		SELECT order_id
+		FROM stock_reservations
+		WHERE status = $1 AND expires_at < $2
+		ORDER BY expires_at
+		LIMIT $3
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, string(domain.ReservationStatusReserved), now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expired reservations: %w", err)
+	}
+	defer rows.Close()
+
+	orderIDs := []string{}
+	for rows.Next() {
+		var orderID string
+		if err := rows.Scan(&orderID); err != nil {
+			return nil, fmt.Errorf("failed to scan reservation order ID: %w", err)
+		}
+		orderIDs = append(orderIDs, orderID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating expired reservations: %w", err)
+	}
+
+	reservations := []*domain.Reservation{}
+	for _, orderID := range orderIDs {
+		reservation, err := r.FindReservationByOrderID(ctx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	return reservations, nil
+}
+
+// scanReservation은 예약 행과 라인을 읽어 도메인 엔티티로 복원합니다.
+func (r *PostgresInventoryRepository) scanReservation(ctx context.Context, row pgx.Row) (*domain.Reservation, error) {
+	var id, orderID, status string
+	var expiresAt, createdAt, updatedAt time.Time
+
+	err := row.Scan(&id, &orderID, &status, &expiresAt, &createdAt, &updatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrReservationNotFound
+		}
+		return nil, fmt.Errorf("failed to find reservation: %w", err)
+	}
+
+	lines, err := findReservationLines(ctx, r.db.Pool, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.RestoreReservation(
+		id, orderID, lines,
+		domain.ReservationStatus(status),
+		expiresAt, createdAt, updatedAt,
+	), nil
+}
+
+// lineQuerier는 풀과 트랜잭션 모두에서 예약 라인을 조회하기 위한 인터페이스입니다.
+type lineQuerier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+// findReservationLines는 예약 라인을 SKU 순으로 조회합니다.
+func findReservationLines(ctx context.Context, q lineQuerier, reservationID string) ([]*domain.ReservationLine, error) {
+	rows, err := q.Query(
+		ctx,
+		"SELECT sku_id, warehouse_id, quantity FROM stock_reservation_lines WHERE reservation_id = $1 ORDER BY sku_id",
+		reservationID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reservation lines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := []*domain.ReservationLine{}
+	for rows.Next() {
+		var skuID, warehouseID string
+		var quantity int
+		if err := rows.Scan(&skuID, &warehouseID, &quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan reservation line: %w", err)
+		}
+		lines = append(lines, domain.RestoreReservationLine(skuID, warehouseID, quantity))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reservation lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// sameLines는 두 예약 라인 목록이 SKU, 창고, 수량까지 같은지 확인합니다.
+func sameLines(a, b []*domain.ReservationLine) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	quantities := make(map[string]*domain.ReservationLine, len(a))
+	for _, line := range a {
+		quantities[line.SKUID()] = line
+	}
+	for _, line := range b {
+		other, ok := quantities[line.SKUID()]
+		if !ok || other.WarehouseID() != line.WarehouseID() || other.Quantity() != line.Quantity() {
+			return false
+		}
+	}
+	return true
+}
+
+// lockWarehouseWithStock은 SKU의 재고 행을 잠그고 수량을 예약할 수 있는 창고를 선택합니다.
+// 가용 재고가 가장 많은 창고를 우선합니다.
+func lockWarehouseWithStock(ctx context.Context, tx pgx.Tx, skuID string, quantity int) (string, error) {
+	query := `
+		SELECT warehouse_id, on_hand - reserved
+		FROM stock_levels
+		WHERE sku_id = $1
+		ORDER BY warehouse_id
+		FOR UPDATE
+	`
+
+	rows, err := tx.Query(ctx, query, skuID)
+	if err != nil {
+		return "", fmt.Errorf("failed to lock stock levels: %w", err)
+	}
+	defer rows.Close()
+
+	selected := ""
+	best := 0
+	for rows.Next() {
+		var warehouseID string
+		var available int
+		if err := rows.Scan(&warehouseID, &available); err != nil {
+			return "", fmt.Errorf("failed to scan stock level: %w", err)
+		}
+		if available >= quantity && available > best {
+			selected = warehouseID
+			best = available
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("error iterating stock levels: %w", err)
+	}
+
+	if selected == "" {
+		return "", fmt.Errorf("%w: sku %s", domain.ErrInsufficientStock, skuID)
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,150 @@
+//go:build integration
+// +build integration
+
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"example.com/myapp/inventory/application"
+	"example.com/myapp/inventory/domain"
+	"example.com/myapp/inventory/infrastructure"
+	"example.com/myapp/shared/db"
+)
+
+func setupTestDatabase(t *testing.T) *db.Database {
+	// 환경 변수에서 테스트 DB 정보 가져오기
+	config := db.Config{
+		Host:     os.Getenv("TEST_DB_HOST"),
+		Port:     os.Getenv("TEST_DB_PORT"),
+		User:     os.Getenv("TEST_DB_USER"),
+		Password: os.Getenv("TEST_DB_PASSWORD"),
+		DBName:   os.Getenv("TEST_DB_NAME"),
+		SSLMode:  "disable",
+	}
+
+	// 기본값 설정
+	if config.Host == "" {
+		config.Host = "localhost"
+	}
+	if config.Port == "" {
+		config.Port = "5432"
+	}
+	if config.User == "" {
+		config.User = "postgres"
+	}
+	if config.Password == "" {
+		config.Password = "postgres"
+	}
+	if config.DBName == "" {
+		config.DBName = "myapp_test"
+	}
+
+	database, err := db.NewDatabase(config)
+	if err != nil {
+		t.Fatalf("테스트 데이터베이스 연결 실패: %v", err)
+	}
+
+	// 테스트 테이블 초기화
+	_, err = database.Pool.Exec(context.Background(), `
+		TRUNCATE TABLE stock_reservation_lines, stock_reservations, stock_levels CASCADE;
+	`)
+	if err != nil {
+		t.Fatalf("테이블 초기화 실패: %v", err)
+	}
+
+	return database
+}
+
+func TestConcurrentReservationIntegration(t *testing.T) {
+	database := setupTestDatabase(t)
+	defer database.Close()
+
+	repo := infrastructure.NewPostgresInventoryRepository(database)
+	useCase := application.NewInventoryUseCase(repo, time.Hour)
+	ctx := context.Background()
+
+	// 두 창고에 나눠 담긴 재고 7개, 두 SKU를 함께 주문하는 동시 요청 30건
+	if _, err := useCase.AdjustStock(ctx, "sku-a", "wh-1", 4); err != nil {
+		t.Fatalf("입고 실패: %v", err)
+	}
+	if _, err := useCase.AdjustStock(ctx, "sku-a", "wh-2", 3); err != nil {
+		t.Fatalf("입고 실패: %v", err)
+	}
+	if _, err := useCase.AdjustStock(ctx, "sku-b", "wh-1", 100); err != nil {
+		t.Fatalf("입고 실패: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := []string{}
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			orderID := fmt.Sprintf("order-%d", i)
+			// 잠금 순서가 다른 요청도 교착 없이 처리되어야 합니다
+			lines := []application.ReservationLineRequest{{SKUID: "sku-b", Quantity: 1}, {SKUID: "sku-a", Quantity: 1}}
+			_, err := useCase.ReserveForOrder(ctx, orderID, lines)
+			if err == nil {
+				mu.Lock()
+				succeeded = append(succeeded, orderID)
+				mu.Unlock()
+			} else if !errors.Is(err, domain.ErrInsufficientStock) {
+				t.Errorf("예약 중 예상치 못한 오류: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if len(succeeded) != 7 {
+		t.Fatalf("성공한 예약 수: got %v, want 7", len(succeeded))
+	}
+
+	// sku-a는 모두 예약되고, 실패한 주문의 sku-b 예약은 롤백되어야 합니다
+	assertReserved(t, useCase, "sku-a", 7)
+	assertReserved(t, useCase, "sku-b", 7)
+
+	// 같은 예약을 동시에 해제해도 한 번만 반영되어야 합니다
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := useCase.ReleaseReservation(ctx, succeeded[0])
+			if err != nil && !errors.Is(err, domain.ErrReservationConflict) {
+				t.Errorf("예약 해제 중 예상치 못한 오류: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assertReserved(t, useCase, "sku-a", 6)
+	assertReserved(t, useCase, "sku-b", 6)
+}
+
+func assertReserved(t *testing.T, useCase *application.InventoryUseCase, skuID string, want int) {
+	t.Helper()
+
+	items, err := useCase.GetStock(context.Background(), skuID)
+	if err != nil {
+		t.Fatalf("재고 조회 실패: %v", err)
+	}
+
+	reserved := 0
+	for _, item := range items {
+		if item.Available() < 0 {
+			t.Errorf("가용 재고가 음수입니다: sku=%v warehouse=%v available=%v", skuID, item.WarehouseID(), item.Available())
+		}
+		reserved += item.Reserved()
+	}
+
+	if reserved != want {
+		t.Errorf("%v 예약 수량: got %v, want %v", skuID, reserved, want)
+	}
+}
@@ -66,6 +66,24 @@ func (uc *MemberUseCase) UpdateMember(ctx context.Context, id, name string) (*do
 	return member, nil
 }
 
+// ChangeMemberTier는 회원 등급을 변경합니다.
+func (uc *MemberUseCase) ChangeMemberTier(ctx context.Context, id string, tier domain.MemberTier) (*domain.Member, error) {
+	member, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := member.ChangeTier(tier); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
 // DeleteMember는 회원을 삭제합니다.
 func (uc *MemberUseCase) DeleteMember(ctx context.Context, id string) error {
 	return uc.repo.Delete(ctx, id)
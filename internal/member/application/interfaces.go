@@ -20,6 +20,7 @@ type MemberService interface {
 	CreateMember(ctx context.Context, email, name, password string) (*domain.Member, error)
 	GetMember(ctx context.Context, id string) (*domain.Member, error)
 	UpdateMember(ctx context.Context, id, name string) (*domain.Member, error)
+	ChangeMemberTier(ctx context.Context, id string, tier domain.MemberTier) (*domain.Member, error)
 	DeleteMember(ctx context.Context, id string) error
 }
 
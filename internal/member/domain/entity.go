@@ -11,8 +11,22 @@ var (
 	ErrInvalidEmail    = errors.New("invalid email address")
 	ErrInvalidName     = errors.New("invalid name")
 	ErrInvalidPassword = errors.New("invalid password")
+	ErrMemberNotFound  = errors.New("member not found")
 )
 
+// MemberTier는 회원 등급을 정의합니다.
+type MemberTier string
+
+const (
+	TierBasic  MemberTier = "basic"
+	TierSilver MemberTier = "silver"
+	TierGold   MemberTier = "gold"
+	TierVIP    MemberTier = "vip"
+)
+
+// ErrInvalidMemberTier는 정의되지 않은 회원 등급일 때 발생하는 오류입니다.
+var ErrInvalidMemberTier = errors.New("invalid member tier")
+
 // Member는 회원 엔티티를 나타냅니다.
 // 캡슐화를 위해 모든 필드는 소문자(비공개)로 정의되어 있습니다.
 type Member struct {
@@ -20,6 +34,7 @@ type Member struct {
 	email     string
 	name      string
 	password  string // 실제로는 해시된 비밀번호가 저장됩니다
+	tier      MemberTier
 	createdAt time.Time
 	updatedAt time.Time
 }
@@ -42,11 +57,25 @@ func NewMember(email, name, password string) (*Member, error) {
 		email:     email,
 		name:      name,
 		password:  password, // 실제로는 해시 처리가 필요합니다
+		tier:      TierBasic,
 		createdAt: now,
 		updatedAt: now,
 	}, nil
 }
 
+// RestoreMember는 저장된 데이터로부터 회원을 복원합니다.
+func RestoreMember(id, email, name, password string, tier MemberTier, createdAt, updatedAt time.Time) *Member {
+	return &Member{
+		id:        id,
+		email:     email,
+		name:      name,
+		password:  password,
+		tier:      tier,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
 // ID는 회원의 고유 식별자를 반환합니다.
 func (m *Member) ID() string {
 	return m.id
@@ -72,6 +101,23 @@ func (m *Member) UpdateName(name string) error {
 	return nil
 }
 
+// Tier는 회원 등급을 반환합니다.
+func (m *Member) Tier() MemberTier {
+	return m.tier
+}
+
+// ChangeTier는 회원 등급을 변경합니다.
+func (m *Member) ChangeTier(tier MemberTier) error {
+	switch tier {
+	case TierBasic, TierSilver, TierGold, TierVIP:
+	default:
+		return ErrInvalidMemberTier
+	}
+	m.tier = tier
+	m.updatedAt = time.Now()
+	return nil
+}
+
 // CreatedAt은 회원이 생성된 시간을 반환합니다.
 func (m *Member) CreatedAt() time.Time {
 	return m.createdAt
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"example.com/myapp/member/application"
 	"example.com/myapp/member/domain"
@@ -12,7 +13,8 @@ import (
 )
 
 // ErrMemberNotFound는 회원을 찾을 수 없을 때 발생하는 오류입니다.
-var ErrMemberNotFound = errors.New("member not found")
+// 다른 모듈이 회원 모듈의 공개 API만으로 구분할 수 있도록 도메인 오류와 같은 값입니다.
+var ErrMemberNotFound = domain.ErrMemberNotFound
 
 // PostgresMemberRepository는 PostgreSQL을 사용하는 회원 저장소 구현체입니다.
 type PostgresMemberRepository struct {
@@ -29,8 +31,8 @@ func NewPostgresMemberRepository(database *db.Database) application.MemberReposi
 // Save는 회원 정보를 데이터베이스에 저장합니다.
 func (r *PostgresMemberRepository) Save(ctx context.Context, member *domain.Member) error {
 	query := `
-		INSERT INTO members (id, email, name, password, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO members (id, email, name, password, tier, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 
 	_, err := r.db.Pool.Exec(
@@ -40,6 +42,7 @@ func (r *PostgresMemberRepository) Save(ctx context.Context, member *domain.Memb
 		member.Email(),
 		member.Name(),
 		member.Password(),
+		string(member.Tier()),
 		member.CreatedAt(),
 		member.UpdatedAt(),
 	)
@@ -54,17 +57,17 @@ func (r *PostgresMemberRepository) Save(ctx context.Context, member *domain.Memb
 // FindByID는 ID로 회원을 조회합니다.
 func (r *PostgresMemberRepository) FindByID(ctx context.Context, id string) (*domain.Member, error) {
 	query := `
-		SELECT id, email, name, password, created_at, updated_at
+		SELECT id, email, name, password, tier, created_at, updated_at
 		FROM members
 		WHERE id = $1
 	`
 
 	row := r.db.Pool.QueryRow(ctx, query, id)
 
-	var memberID, email, name, password string
-	var createdAt, updatedAt string
+	var memberID, email, name, password, tier string
+	var createdAt, updatedAt time.Time
 
-	err := row.Scan(&memberID, &email, &name, &password, &createdAt, &updatedAt)
+	err := row.Scan(&memberID, &email, &name, &password, &tier, &createdAt, &updatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrMemberNotFound
@@ -72,25 +75,23 @@ func (r *PostgresMemberRepository) FindByID(ctx context.Context, id string) (*do
 		return nil, fmt.Errorf("failed to find member by ID: %w", err)
 	}
 
-	// 실제 구현에서는 DB 레코드를 도메인 엔티티로 변환하는 로직이 필요합니다.
-	// 여기서는 코드 예시를 간략하게 하기 위해 생략합니다.
-	return &domain.Member{}, nil
+	return domain.RestoreMember(memberID, email, name, password, domain.MemberTier(tier), createdAt, updatedAt), nil
 }
 
 // FindByEmail은 이메일로 회원을 조회합니다.
 func (r *PostgresMemberRepository) FindByEmail(ctx context.Context, email string) (*domain.Member, error) {
 	query := `
-		SELECT id, email, name, password, created_at, updated_at
+		SELECT id, email, name, password, tier, created_at, updated_at
 		FROM members
 		WHERE email = $1
 	`
 
 	row := r.db.Pool.QueryRow(ctx, query, email)
 
-	var memberID, memberEmail, name, password string
-	var createdAt, updatedAt string
+	var memberID, memberEmail, name, password, tier string
+	var createdAt, updatedAt time.Time
 
-	err := row.Scan(&memberID, &memberEmail, &name, &password, &createdAt, &updatedAt)
+	err := row.Scan(&memberID, &memberEmail, &name, &password, &tier, &createdAt, &updatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrMemberNotFound
@@ -98,22 +99,22 @@ func (r *PostgresMemberRepository) FindByEmail(ctx context.Context, email string
 		return nil, fmt.Errorf("failed to find member by email: %w", err)
 	}
 
-	// 실제 구현에서는 DB 레코드를 도메인 엔티티로 변환하는 로직이 필요합니다.
-	return &domain.Member{}, nil
+	return domain.RestoreMember(memberID, memberEmail, name, password, domain.MemberTier(tier), createdAt, updatedAt), nil
 }
 
 // Update는 회원 정보를 업데이트합니다.
 func (r *PostgresMemberRepository) Update(ctx context.Context, member *domain.Member) error {
 	query := `
 		UPDATE members
-		SET name = $1, updated_at = $2
-		WHERE id = $3
+		SET name = $1, tier = $2, updated_at = $3
+		WHERE id = $4
 	`
 
 	_, err := r.db.Pool.Exec(
 		ctx,
 		query,
 		member.Name(),
+		string(member.Tier()),
 		member.UpdatedAt(),
 		member.ID(),
 	)
@@ -3,30 +3,45 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
 
 	"example.com/myapp/order/domain"
 )
 
 var (
-	ErrInvalidCustomerID = errors.New("invalid customer ID")
-	ErrOrderNotFound     = errors.New("order not found")
+	ErrInvalidCustomerID     = errors.New("invalid customer ID")
+	ErrOrderNotFound         = errors.New("order not found")
+	ErrProductNotFound       = errors.New("product not found in catalog")
+	ErrProductUnavailable    = errors.New("product is not available for order")
+	ErrOutOfStock            = errors.New("insufficient stock for order")
+	ErrCouponRejected        = errors.New("coupon cannot be applied to this order")
+	ErrTaxRateNotFound       = errors.New("no tax rate for product tax category and destination country")
+	ErrShippingRateNotFound  = errors.New("no shipping rate for destination country")
+	ErrGuestCouponNotAllowed = errors.New("coupons require a member account")
+	ErrCustomerNotFound      = errors.New("customer not found")
+	ErrCustomerInactive      = errors.New("customer account is not active")
 )
 
 // CreateOrder는 새로운 주문을 생성합니다.
-func (uc *OrderUseCase) CreateOrder(ctx context.Context, customerID string, itemRequests []OrderItemRequest) (*domain.Order, error) {
+// 쿠폰 코드가 주어지면 할인을 계산하여 할인 내역과 함께 주문에 기록하고,
+// 할인 후 금액으로 항목별 세액을 계산합니다.
+func (uc *OrderUseCase) CreateOrder(ctx context.Context, req CreateOrderRequest) (*domain.Order, error) {
+	customerID := req.CustomerID
 	if customerID == "" {
 		return nil, ErrInvalidCustomerID
 	}
 
-	if len(itemRequests) == 0 {
-		return nil, domain.ErrInvalidOrderItems
+	// 회원 모듈에서 고객을 확인 (없거나 비활성 회원은 주문할 수 없음)
+	customer, err := uc.activeCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
 	}
 
-	// OrderItemRequest를 도메인 OrderItem으로 변환
-	items := make([]*domain.OrderItem, 0, len(itemRequests))
-	for _, req := range itemRequests {
-		item := domain.NewOrderItem(req.ProductID, req.Name, req.Price, req.Quantity)
-		items = append(items, item)
+	// 견적 ID가 있으면 견적 당시 가격으로, 없으면 카탈로그 가격으로 주문합니다
+	items, quote, err := uc.newOrderItems(ctx, req.QuoteID, customerID, req.Items, req.CouponCodes, req.DestinationCountry)
+	if err != nil {
+		return nil, err
 	}
 
 	// 새로운 주문 생성
@@ -34,13 +49,253 @@ func (uc *OrderUseCase) CreateOrder(ctx context.Context, customerID string, item
 	if err != nil {
 		return nil, err
 	}
+	order.SnapshotCustomer(customer.Name, customer.Email)
+
+	if err := uc.finishOrder(ctx, order, quote, req.DestinationCountry, req.CouponCodes); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// activeCustomer는 고객 디렉터리에서 주문할 수 있는 활성 고객을 조회합니다.
+func (uc *OrderUseCase) activeCustomer(ctx context.Context, customerID string) (*Customer, error) {
+	customer, err := uc.customers.FindCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if !customer.Active {
+		return nil, ErrCustomerInactive
+	}
+	return customer, nil
+}
+
+// orderItems는 카탈로그에서 상품 정보를 확인하여 주문 항목 요청을 도메인 OrderItem으로 변환합니다.
+func (uc *OrderUseCase) orderItems(ctx context.Context, reqs []OrderItemRequest) ([]*domain.OrderItem, error) {
+	if len(reqs) == 0 {
+		return nil, domain.ErrInvalidOrderItems
+	}
+
+	items := make([]*domain.OrderItem, 0, len(reqs))
+	for _, itemReq := range reqs {
+		if itemReq.Quantity <= 0 {
+			return nil, domain.ErrInvalidItemQuantity
+		}
+
+		product, err := uc.catalog.FindProduct(ctx, itemReq.ProductID, itemReq.SKUID)
+		if err != nil {
+			return nil, err
+		}
+
+		item := domain.NewOrderItem(product.ProductID, product.SKUID, product.Name, product.TaxCategory, product.Price, itemReq.Quantity)
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// placeOrder는 새 주문의 가격을 계산한 뒤 재고 예약과 쿠폰 사용을 기록하고 저장합니다.
+func (uc *OrderUseCase) placeOrder(ctx context.Context, order *domain.Order, destinationCountry string, couponCodes []string) error {
+	if err := uc.priceOrder(ctx, order, destinationCountry, couponCodes); err != nil {
+		return err
+	}
+	return uc.commitOrder(ctx, order)
+}
+
+// priceOrder는 새 주문에 배송 국가, 배송비, 쿠폰 할인과 세금을 적용합니다. 주문을 저장하지는 않습니다.
+func (uc *OrderUseCase) priceOrder(ctx context.Context, order *domain.Order, destinationCountry string, couponCodes []string) error {
+	if destinationCountry != "" {
+		if err := order.ChangeDestinationCountry(destinationCountry); err != nil {
+			return err
+		}
+	}
+
+	// 배송비 계산 (배송비 할인 쿠폰이 깎을 금액이므로 할인보다 먼저 계산합니다)
+	if err := uc.applyShipping(ctx, order); err != nil {
+		return err
+	}
+
+	// 쿠폰 할인 계산
+	if len(couponCodes) > 0 {
+		if err := uc.applyDiscounts(ctx, order, couponCodes); err != nil {
+			return err
+		}
+	}
+
+	// 세금 계산 (할인이 반영된 금액 기준)
+	return uc.applyTaxes(ctx, order)
+}
+
+// commitOrder는 가격이 확정된 새 주문의 재고 예약과 쿠폰 사용을 기록하고 저장합니다.
+// 중간에 실패하면 이미 기록한 쿠폰 사용과 재고 예약을 되돌립니다.
+func (uc *OrderUseCase) commitOrder(ctx context.Context, order *domain.Order) error {
+	// 재고 예약 (한 라인이라도 부족하면 주문 전체 실패)
+	if err := uc.stock.Reserve(ctx, order.ID(), stockLines(order)); err != nil {
+		return err
+	}
+
+	// 쿠폰 사용 기록 (사용 한도는 여기서 원자적으로 차감됩니다)
+	if len(order.Discounts()) > 0 {
+		if err := uc.discounts.Redeem(ctx, order.ID(), order.CustomerID(), appliedDiscounts(order)); err != nil {
+			if releaseErr := uc.stock.Release(ctx, order.ID()); releaseErr != nil {
+				return fmt.Errorf("failed to release stock after coupon failure: %v: %w", releaseErr, err)
+			}
+			return err
+		}
+	}
 
 	// 저장소에 주문 저장
 	if err := uc.repo.Save(ctx, order); err != nil {
-		return nil, err
+		// 저장에 실패하면 쿠폰 사용과 예약을 되돌립니다
+		if releaseErr := uc.releaseDiscounts(ctx, order); releaseErr != nil {
+			return fmt.Errorf("failed to release coupons after save failure: %v: %w", releaseErr, err)
+		}
+		if releaseErr := uc.stock.Release(ctx, order.ID()); releaseErr != nil {
+			return fmt.Errorf("failed to release stock after save failure: %v: %w", releaseErr, err)
+		}
+		return err
 	}
 
-	return order, nil
+	return nil
+}
+
+// applyDiscounts는 프로모션 모듈에서 쿠폰 할인을 계산하여 주문에 적용합니다.
+func (uc *OrderUseCase) applyDiscounts(ctx context.Context, order *domain.Order, couponCodes []string) error {
+	// 취소되지 않은 이전 주문이 없으면 첫 주문으로 봅니다
+	previous, err := uc.repo.FindByCustomerID(ctx, order.CustomerID())
+	if err != nil {
+		return err
+	}
+	firstOrder := true
+	for _, p := range previous {
+		// 항목을 변경하며 할인을 다시 계산하는 경우 주문 자신은 제외합니다
+		if p.ID() != order.ID() && p.Status() != domain.StatusCanceled {
+			firstOrder = false
+			break
+		}
+	}
+
+	lines := make([]DiscountLine, 0, len(order.Items()))
+	for _, item := range order.Items() {
+		lines = append(lines, DiscountLine{
+			ProductID: item.ProductID(),
+			SKUID:     item.SKUID(),
+			UnitPrice: item.Price(),
+			Quantity:  item.Quantity(),
+		})
+	}
+
+	applied, err := uc.discounts.Quote(ctx, DiscountQuoteRequest{
+		OrderID:     order.ID(),
+		CustomerID:  order.CustomerID(),
+		FirstOrder:  firstOrder,
+		CouponCodes: couponCodes,
+		Lines:       lines,
+		ShippingFee: order.ShippingFee(),
+	})
+	if err != nil {
+		return err
+	}
+
+	discounts := make([]*domain.OrderDiscount, 0, len(applied))
+	for _, a := range applied {
+		discount, err := domain.NewOrderDiscount(a.Code, a.Kind, a.Description, a.Amount)
+		if err != nil {
+			return err
+		}
+		discounts = append(discounts, discount)
+	}
+
+	return order.ApplyDiscounts(discounts)
+}
+
+// applyShipping은 배송 국가와 남은 항목으로 배송비를 계산하여 주문에 기록합니다.
+func (uc *OrderUseCase) applyShipping(ctx context.Context, order *domain.Order) error {
+	quantity := 0
+	for _, item := range order.Items() {
+		quantity += item.Quantity()
+	}
+
+	fee, err := uc.shipping.Rate(ctx, ShippingRateRequest{
+		Country:  order.DestinationCountry(),
+		Subtotal: order.Subtotal(),
+		Quantity: quantity,
+	})
+	if err != nil {
+		return err
+	}
+
+	return order.ApplyShippingFee(fee)
+}
+
+// applyTaxes는 주문 할인을 항목별로 배분한 과세 대상 금액으로 세액을 계산하여 주문에 기록합니다.
+func (uc *OrderUseCase) applyTaxes(ctx context.Context, order *domain.Order) error {
+	taxable := allocateDiscount(order)
+
+	lines := make([]TaxLine, len(order.Items()))
+	for i, item := range order.Items() {
+		lines[i] = TaxLine{TaxCategory: item.TaxCategory(), Amount: taxable[i]}
+	}
+
+	result, err := uc.taxes.Calculate(ctx, TaxRequest{
+		Country: order.DestinationCountry(),
+		Lines:   lines,
+	})
+	if err != nil {
+		return err
+	}
+
+	return order.ApplyTaxes(result.LineTaxes, result.Inclusive)
+}
+
+// allocateDiscount는 배송비 할인을 뺀 주문 할인 합계를 항목 소계에 비례하여 배분하고 항목별 과세 대상 금액을 반환합니다.
+// 반올림 오차는 마지막 항목에서 정리합니다.
+func allocateDiscount(order *domain.Order) []float64 {
+	items := order.Items()
+	taxable := make([]float64, len(items))
+
+	subtotal := order.Subtotal()
+	discount := math.Min(order.MerchandiseDiscountTotal(), subtotal)
+	remaining := discount
+	for i, item := range items {
+		share := remaining
+		if i < len(items)-1 {
+			share = math.Round(discount*item.Subtotal()/subtotal*100) / 100
+			remaining -= share
+		}
+		taxable[i] = math.Max(item.Subtotal()-share, 0)
+	}
+	return taxable
+}
+
+// releaseDiscounts는 주문에 사용된 쿠폰을 다시 사용할 수 있게 합니다.
+func (uc *OrderUseCase) releaseDiscounts(ctx context.Context, order *domain.Order) error {
+	if len(order.Discounts()) == 0 {
+		return nil
+	}
+	return uc.discounts.Release(ctx, order.ID())
+}
+
+// stockLines는 주문의 취소되지 않은 항목을 재고 예약 라인으로 변환합니다.
+func stockLines(order *domain.Order) []StockLine {
+	lines := make([]StockLine, 0, len(order.Items()))
+	for _, item := range order.Items() {
+		if item.Quantity() > 0 {
+			lines = append(lines, StockLine{SKUID: item.SKUID(), Quantity: item.Quantity()})
+		}
+	}
+	return lines
+}
+
+func appliedDiscounts(order *domain.Order) []AppliedDiscount {
+	applied := make([]AppliedDiscount, 0, len(order.Discounts()))
+	for _, d := range order.Discounts() {
+		applied = append(applied, AppliedDiscount{
+			Code:        d.Code(),
+			Kind:        d.Kind(),
+			Description: d.Description(),
+			Amount:      d.Amount(),
+		})
+	}
+	return applied
 }
 
 // GetOrder는 주문 ID로 주문을 조회합니다.
@@ -56,25 +311,65 @@ func (uc *OrderUseCase) GetCustomerOrders(ctx context.Context, customerID string
 	return uc.repo.FindByCustomerID(ctx, customerID)
 }
 
-// UpdateOrderStatus는 주문 상태를 업데이트합니다.
-func (uc *OrderUseCase) UpdateOrderStatus(ctx context.Context, id string, status domain.OrderStatus) (*domain.Order, error) {
+// UpdateOrderStatus는 주문 상태를 업데이트하고 상태 전환 이력을 기록합니다.
+func (uc *OrderUseCase) UpdateOrderStatus(ctx context.Context, id string, status domain.OrderStatus, actor, reason string) (*domain.Order, error) {
 	order, err := uc.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := order.UpdateStatus(status); err != nil {
+	if err := order.UpdateStatus(status, actor, reason); err != nil {
 		return nil, err
 	}
 
+	// 결제 완료와 출고는 재고를 확정할 수 없으면 거부되어야 하므로 재고 예약을 먼저 반영합니다 (재시도해도 안전)
+	if order.Status() != domain.StatusCanceled {
+		if err := uc.syncStock(ctx, order); err != nil {
+			return nil, err
+		}
+	}
+
+	// 다른 요청이 먼저 상태를 바꿨다면 domain.ErrOrderConflict로 거부됩니다
 	if err := uc.repo.Update(ctx, order); err != nil {
 		return nil, err
 	}
 
+	// 취소는 저장된 뒤에만 재고 예약과 쿠폰을 해제하여, 경합에서 진 취소가 결제된 주문의 예약을 풀지 않게 합니다
+	if order.Status() == domain.StatusCanceled {
+		if err := uc.syncStock(ctx, order); err != nil {
+			return nil, err
+		}
+		if err := uc.releaseDiscounts(ctx, order); err != nil {
+			return nil, err
+		}
+	}
+
 	return order, nil
 }
 
 // CancelOrder는 주문을 취소합니다.
-func (uc *OrderUseCase) CancelOrder(ctx context.Context, id string) (*domain.Order, error) {
-	return uc.UpdateOrderStatus(ctx, id, domain.StatusCanceled)
-}
\ No newline at end of file
+func (uc *OrderUseCase) CancelOrder(ctx context.Context, id string, actor, reason string) (*domain.Order, error) {
+	return uc.UpdateOrderStatus(ctx, id, domain.StatusCanceled, actor, reason)
+}
+
+// GetOrderHistory는 주문의 상태 전환 이력을 조회합니다.
+func (uc *OrderUseCase) GetOrderHistory(ctx context.Context, id string) ([]*domain.StatusChange, error) {
+	if _, err := uc.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return uc.repo.FindStatusHistory(ctx, id)
+}
+
+// syncStock은 주문 상태에 맞춰 재고 예약을 확정, 차감 또는 해제합니다.
+func (uc *OrderUseCase) syncStock(ctx context.Context, order *domain.Order) error {
+	switch order.Status() {
+	case domain.StatusPaid:
+		return uc.stock.Confirm(ctx, order.ID())
+	case domain.StatusShipped:
+		return uc.stock.Commit(ctx, order.ID())
+	case domain.StatusCanceled:
+		return uc.stock.Release(ctx, order.ID())
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,1341 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"example.com/myapp/order/domain"
+)
+
+// FakeOrderRepository는 테스트를 위한 가짜 OrderRepository 구현체입니다.
+type FakeOrderRepository struct {
+	orders   map[string]*domain.Order
+	versions map[string]int
+	imports  map[string]string
+}
+
+// NewFakeOrderRepository는 새로운 FakeOrderRepository 인스턴스를 생성합니다.
+// 저장된 주문과 같은 인스턴스를 돌려주므로, 동시 변경은 versions의 저장된 버전을 올려 흉내냅니다.
+func NewFakeOrderRepository() *FakeOrderRepository {
+	return &FakeOrderRepository{
+		orders:   make(map[string]*domain.Order),
+		versions: make(map[string]int),
+		imports:  make(map[string]string),
+	}
+}
+
+func (f *FakeOrderRepository) Save(ctx context.Context, order *domain.Order) error {
+	f.orders[order.ID()] = order
+	return nil
+}
+
+func (f *FakeOrderRepository) FindByID(ctx context.Context, id string) (*domain.Order, error) {
+	order, ok := f.orders[id]
+	if !ok {
+		return nil, domain.ErrOrderNotFound
+	}
+	return order, nil
+}
+
+func (f *FakeOrderRepository) FindByCustomerID(ctx context.Context, customerID string) ([]*domain.Order, error) {
+	orders := []*domain.Order{}
+	for _, order := range f.orders {
+		if order.CustomerID() == customerID {
+			orders = append(orders, order)
+		}
+	}
+	return orders, nil
+}
+
+func (f *FakeOrderRepository) Update(ctx context.Context, order *domain.Order) error {
+	if _, ok := f.orders[order.ID()]; !ok {
+		return domain.ErrOrderNotFound
+	}
+	if f.versions[order.ID()] != order.Version() {
+		return domain.ErrOrderConflict
+	}
+	order.AdvanceVersion()
+	f.versions[order.ID()] = order.Version()
+	f.orders[order.ID()] = order
+	return nil
+}
+
+func (f *FakeOrderRepository) FindStatusHistory(ctx context.Context, orderID string) ([]*domain.StatusChange, error) {
+	order, ok := f.orders[orderID]
+	if !ok {
+		return []*domain.StatusChange{}, nil
+	}
+	return order.StatusChanges(), nil
+}
+
+func (f *FakeOrderRepository) ClaimExpiredPendingOrders(ctx context.Context, cutoff time.Time, lease time.Duration, limit int) ([]string, error) {
+	orderIDs := []string{}
+	for id, order := range f.orders {
+		if len(orderIDs) == limit {
+			break
+		}
+		if order.Status() == domain.StatusPending && order.CreatedAt().Before(cutoff) {
+			orderIDs = append(orderIDs, id)
+		}
+	}
+	return orderIDs, nil
+}
+
+func (f *FakeOrderRepository) Search(ctx context.Context, criteria OrderSearchCriteria, after *OrderCursor, limit int) ([]*domain.Order, error) {
+	// 정렬 값을 비교할 수 있도록 금액과 시간을 모두 float64로 바꿉니다
+	sortValue := func(order *domain.Order) float64 {
+		switch criteria.SortBy {
+		case OrderSortTotalAmount:
+			return order.TotalAmount()
+		case OrderSortUpdatedAt:
+			return float64(order.UpdatedAt().UnixNano())
+		default:
+			return float64(order.CreatedAt().UnixNano())
+		}
+	}
+	less := func(value float64, id string, otherValue float64, otherID string) bool {
+		if value != otherValue {
+			return value < otherValue
+		}
+		return id < otherID
+	}
+
+	orders := []*domain.Order{}
+	for _, order := range f.orders {
+		if criteria.CustomerID != "" && order.CustomerID() != criteria.CustomerID {
+			continue
+		}
+		if len(criteria.Statuses) > 0 {
+			matched := false
+			for _, status := range criteria.Statuses {
+				matched = matched || order.Status() == status
+			}
+			if !matched {
+				continue
+			}
+		}
+		if criteria.MinAmount > 0 && order.TotalAmount() < criteria.MinAmount {
+			continue
+		}
+		if after != nil {
+			var afterValue float64
+			switch value := after.Value.(type) {
+			case float64:
+				afterValue = value
+			case time.Time:
+				afterValue = float64(value.UnixNano())
+			}
+			isAfter := less(afterValue, after.ID, sortValue(order), order.ID())
+			if criteria.Descending {
+				isAfter = less(sortValue(order), order.ID(), afterValue, after.ID)
+			}
+			if !isAfter {
+				continue
+			}
+		}
+		orders = append(orders, order)
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		if criteria.Descending {
+			i, j = j, i
+		}
+		return less(sortValue(orders[i]), orders[i].ID(), sortValue(orders[j]), orders[j].ID())
+	})
+	if len(orders) > limit {
+		orders = orders[:limit]
+	}
+	return orders, nil
+}
+
+func (f *FakeOrderRepository) FindImportedOrderIDs(ctx context.Context, externalRefs []string) (map[string]string, error) {
+	imported := map[string]string{}
+	for _, ref := range externalRefs {
+		if orderID, ok := f.imports[ref]; ok {
+			imported[ref] = orderID
+		}
+	}
+	return imported, nil
+}
+
+func (f *FakeOrderRepository) SaveImportedOrders(ctx context.Context, orders []ImportedOrder) ([]string, error) {
+	skipped := []string{}
+	for _, imported := range orders {
+		if _, ok := f.imports[imported.ExternalRef]; ok {
+			skipped = append(skipped, imported.ExternalRef)
+			continue
+		}
+		f.imports[imported.ExternalRef] = imported.Order.ID()
+		f.orders[imported.Order.ID()] = imported.Order
+	}
+	return skipped, nil
+}
+
+func (f *FakeOrderRepository) Delete(ctx context.Context, id string) error {
+	if _, ok := f.orders[id]; !ok {
+		return domain.ErrOrderNotFound
+	}
+	delete(f.orders, id)
+	return nil
+}
+
+// FakeCustomerDirectory는 테스트를 위한 가짜 CustomerDirectory 구현체입니다.
+// 등록되지 않은 고객은 missing에 없으면 활성 고객으로 봅니다.
+type FakeCustomerDirectory struct {
+	customers map[string]*Customer
+	missing   map[string]bool
+}
+
+// NewFakeCustomerDirectory는 새로운 FakeCustomerDirectory 인스턴스를 생성합니다.
+func NewFakeCustomerDirectory() *FakeCustomerDirectory {
+	return &FakeCustomerDirectory{
+		customers: make(map[string]*Customer),
+		missing:   make(map[string]bool),
+	}
+}
+
+func (f *FakeCustomerDirectory) FindCustomer(ctx context.Context, customerID string) (*Customer, error) {
+	if customer, ok := f.customers[customerID]; ok {
+		return customer, nil
+	}
+	if f.missing[customerID] {
+		return nil, ErrCustomerNotFound
+	}
+	return &Customer{ID: customerID, Name: customerID, Email: customerID + "@example.com", Active: true}, nil
+}
+
+// FakeQuoteSigner는 테스트를 위한 가짜 QuoteSigner 구현체입니다.
+type FakeQuoteSigner struct{}
+
+// NewFakeQuoteSigner는 새로운 FakeQuoteSigner 인스턴스를 생성합니다.
+func NewFakeQuoteSigner() *FakeQuoteSigner {
+	return &FakeQuoteSigner{}
+}
+
+func (f *FakeQuoteSigner) Sign(payload []byte) []byte {
+	sum := sha256.Sum256(append([]byte("test-secret:"), payload...))
+	return sum[:]
+}
+
+func (f *FakeQuoteSigner) Verify(payload, signature []byte) bool {
+	return bytes.Equal(f.Sign(payload), signature)
+}
+
+// FakeProductCatalog는 테스트를 위한 가짜 ProductCatalog 구현체입니다.
+type FakeProductCatalog struct {
+	products map[string]*CatalogProduct
+	archived map[string]bool
+}
+
+// NewFakeProductCatalog는 새로운 FakeProductCatalog 인스턴스를 생성합니다.
+func NewFakeProductCatalog() *FakeProductCatalog {
+	return &FakeProductCatalog{
+		products: make(map[string]*CatalogProduct),
+		archived: make(map[string]bool),
+	}
+}
+
+func (f *FakeProductCatalog) FindProduct(ctx context.Context, productID, skuID string) (*CatalogProduct, error) {
+	product, ok := f.products[productID]
+	if !ok {
+		return nil, ErrProductNotFound
+	}
+	if f.archived[productID] {
+		return nil, ErrProductUnavailable
+	}
+	return product, nil
+}
+
+// FakeStockReserver는 테스트를 위한 가짜 StockReserver 구현체입니다.
+type FakeStockReserver struct {
+	available map[string]int
+	reserved  map[string][]StockLine
+	states    map[string]string
+}
+
+// NewFakeStockReserver는 새로운 FakeStockReserver 인스턴스를 생성합니다.
+func NewFakeStockReserver() *FakeStockReserver {
+	return &FakeStockReserver{
+		available: make(map[string]int),
+		reserved:  make(map[string][]StockLine),
+		states:    make(map[string]string),
+	}
+}
+
+func (f *FakeStockReserver) Reserve(ctx context.Context, orderID string, lines []StockLine) error {
+	for _, line := range lines {
+		if f.available[line.SKUID] < line.Quantity {
+			return ErrOutOfStock
+		}
+	}
+	for _, line := range lines {
+		f.available[line.SKUID] -= line.Quantity
+	}
+	f.reserved[orderID] = lines
+	f.states[orderID] = "reserved"
+	return nil
+}
+
+func (f *FakeStockReserver) Confirm(ctx context.Context, orderID string) error {
+	f.states[orderID] = "confirmed"
+	return nil
+}
+
+func (f *FakeStockReserver) Commit(ctx context.Context, orderID string) error {
+	f.states[orderID] = "committed"
+	return nil
+}
+
+func (f *FakeStockReserver) Release(ctx context.Context, orderID string) error {
+	if f.states[orderID] == "released" {
+		return nil
+	}
+	for _, line := range f.reserved[orderID] {
+		f.available[line.SKUID] += line.Quantity
+	}
+	f.states[orderID] = "released"
+	return nil
+}
+
+func (f *FakeStockReserver) Adjust(ctx context.Context, orderID string, lines []StockLine) error {
+	delta := make(map[string]int)
+	for _, line := range f.reserved[orderID] {
+		delta[line.SKUID] -= line.Quantity
+	}
+	for _, line := range lines {
+		delta[line.SKUID] += line.Quantity
+	}
+	for skuID, quantity := range delta {
+		if quantity > 0 && f.available[skuID] < quantity {
+			return ErrOutOfStock
+		}
+	}
+	for skuID, quantity := range delta {
+		f.available[skuID] -= quantity
+	}
+	f.reserved[orderID] = lines
+	return nil
+}
+
+// FakeTaxCalculator는 테스트를 위한 가짜 TaxCalculator 구현체입니다.
+// 과세 유형별 세율로 세금 별도 가격의 세액을 계산하며, 세율이 없으면 세액은 0입니다.
+type FakeTaxCalculator struct {
+	rates     map[string]float64
+	inclusive bool
+	requests  []TaxRequest
+}
+
+// NewFakeTaxCalculator는 새로운 FakeTaxCalculator 인스턴스를 생성합니다.
+func NewFakeTaxCalculator() *FakeTaxCalculator {
+	return &FakeTaxCalculator{
+		rates:     make(map[string]float64),
+		inclusive: true,
+	}
+}
+
+func (f *FakeTaxCalculator) Calculate(ctx context.Context, req TaxRequest) (*TaxResult, error) {
+	f.requests = append(f.requests, req)
+
+	taxes := make([]float64, len(req.Lines))
+	for i, line := range req.Lines {
+		taxes[i] = line.Amount * f.rates[line.TaxCategory]
+	}
+	return &TaxResult{Inclusive: f.inclusive, LineTaxes: taxes}, nil
+}
+
+// FakeShippingRater는 테스트를 위한 가짜 ShippingRater 구현체입니다.
+// 모든 주문에 같은 배송비를 매깁니다.
+type FakeShippingRater struct {
+	fee      float64
+	requests []ShippingRateRequest
+}
+
+// NewFakeShippingRater는 배송비가 없는 새로운 FakeShippingRater 인스턴스를 생성합니다.
+func NewFakeShippingRater() *FakeShippingRater {
+	return &FakeShippingRater{}
+}
+
+func (f *FakeShippingRater) Rate(ctx context.Context, req ShippingRateRequest) (float64, error) {
+	f.requests = append(f.requests, req)
+	return f.fee, nil
+}
+
+// FakeDiscountEngine은 테스트를 위한 가짜 DiscountEngine 구현체입니다.
+// 등록된 쿠폰 코드마다 정액 할인을 적용합니다.
+type FakeDiscountEngine struct {
+	amounts   map[string]float64
+	limits    map[string]int
+	redeemed  map[string][]AppliedDiscount
+	quotes    []DiscountQuoteRequest
+	redeemErr error
+}
+
+// NewFakeDiscountEngine은 새로운 FakeDiscountEngine 인스턴스를 생성합니다.
+func NewFakeDiscountEngine() *FakeDiscountEngine {
+	return &FakeDiscountEngine{
+		amounts:  make(map[string]float64),
+		limits:   make(map[string]int),
+		redeemed: make(map[string][]AppliedDiscount),
+	}
+}
+
+func (f *FakeDiscountEngine) Quote(ctx context.Context, req DiscountQuoteRequest) ([]AppliedDiscount, error) {
+	f.quotes = append(f.quotes, req)
+
+	applied := []AppliedDiscount{}
+	for _, code := range req.CouponCodes {
+		amount, ok := f.amounts[code]
+		if !ok {
+			return nil, ErrCouponRejected
+		}
+		// 프로모션 모듈처럼 요청한 주문 자신의 사용 기록은 한도에서 제외합니다
+		if limit := f.limits[code]; limit > 0 && f.redemptions(code, req.OrderID) >= limit {
+			return nil, ErrCouponRejected
+		}
+		applied = append(applied, AppliedDiscount{Code: code, Kind: "fixed_amount", Description: code, Amount: amount})
+	}
+	return applied, nil
+}
+
+func (f *FakeDiscountEngine) Redeem(ctx context.Context, orderID, customerID string, discounts []AppliedDiscount) error {
+	if f.redeemErr != nil {
+		return f.redeemErr
+	}
+	f.redeemed[orderID] = discounts
+	return nil
+}
+
+func (f *FakeDiscountEngine) Release(ctx context.Context, orderID string) error {
+	delete(f.redeemed, orderID)
+	return nil
+}
+
+func (f *FakeDiscountEngine) redemptions(code, excludeOrderID string) int {
+	count := 0
+	for orderID, discounts := range f.redeemed {
+		if orderID == excludeOrderID {
+			continue
+		}
+		for _, d := range discounts {
+			if d.Code == code {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestCreateOrderSnapshotsCatalogPrice(t *testing.T) {
+	repo := NewFakeOrderRepository()
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000000}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 10
+	useCase := NewOrderUseCase(repo, NewFakeCustomerDirectory(), catalog, stock, NewFakeDiscountEngine(), NewFakeTaxCalculator(), NewFakeShippingRater(), NewFakeQuoteSigner())
+
+	order, err := useCase.CreateOrder(context.Background(), CreateOrderRequest{
+		CustomerID: "cust-1",
+		Items:      []OrderItemRequest{{ProductID: "prod-1", Quantity: 2}},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+
+	item := order.Items()[0]
+	if item.Name() != "스마트폰" || item.Price() != 1000000 || item.SKUID() != "sku-1" {
+		t.Errorf("카탈로그 스냅샷 불일치: name=%v price=%v sku=%v", item.Name(), item.Price(), item.SKUID())
+	}
+	if order.TotalAmount() != 2000000 {
+		t.Errorf("TotalAmount() = %v, want %v", order.TotalAmount(), 2000000.0)
+	}
+}
+
+func TestCreateOrderRejectsUnknownOrArchivedProduct(t *testing.T) {
+	tests := []struct {
+		name      string
+		productID string
+		quantity  int
+		wantErr   error
+	}{
+		{
+			name:      "존재하지 않는 상품",
+			productID: "unknown",
+			quantity:  1,
+			wantErr:   ErrProductNotFound,
+		},
+		{
+			name:      "판매 중지 상품",
+			productID: "prod-archived",
+			quantity:  1,
+			wantErr:   ErrProductUnavailable,
+		},
+		{
+			name:      "수량 0",
+			productID: "prod-1",
+			quantity:  0,
+			wantErr:   domain.ErrInvalidItemQuantity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewFakeOrderRepository()
+			catalog := NewFakeProductCatalog()
+			catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000}
+			catalog.products["prod-archived"] = &CatalogProduct{ProductID: "prod-archived", SKUID: "sku-2", Name: "단종폰", Price: 1000}
+			catalog.archived["prod-archived"] = true
+			stock := NewFakeStockReserver()
+			stock.available["sku-1"] = 10
+			useCase := NewOrderUseCase(repo, NewFakeCustomerDirectory(), catalog, stock, NewFakeDiscountEngine(), NewFakeTaxCalculator(), NewFakeShippingRater(), NewFakeQuoteSigner())
+
+			_, err := useCase.CreateOrder(context.Background(), CreateOrderRequest{
+				CustomerID: "cust-1",
+				Items:      []OrderItemRequest{{ProductID: tt.productID, Quantity: tt.quantity}},
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateOrder() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(repo.orders) != 0 {
+				t.Error("실패한 주문이 저장되었습니다")
+			}
+		})
+	}
+}
+
+func TestCreateOrderFailsWhenAnyLineIsOutOfStock(t *testing.T) {
+	repo := NewFakeOrderRepository()
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000}
+	catalog.products["prod-2"] = &CatalogProduct{ProductID: "prod-2", SKUID: "sku-2", Name: "케이스", Price: 100}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 10
+	stock.available["sku-2"] = 1
+	useCase := NewOrderUseCase(repo, NewFakeCustomerDirectory(), catalog, stock, NewFakeDiscountEngine(), NewFakeTaxCalculator(), NewFakeShippingRater(), NewFakeQuoteSigner())
+
+	_, err := useCase.CreateOrder(context.Background(), CreateOrderRequest{
+		CustomerID: "cust-1",
+		Items: []OrderItemRequest{
+			{ProductID: "prod-1", Quantity: 1},
+			{ProductID: "prod-2", Quantity: 2},
+		},
+	})
+	if !errors.Is(err, ErrOutOfStock) {
+		t.Fatalf("CreateOrder() error = %v, want %v", err, ErrOutOfStock)
+	}
+	if len(repo.orders) != 0 {
+		t.Error("재고 부족 주문이 저장되었습니다")
+	}
+	if stock.available["sku-1"] != 10 {
+		t.Errorf("다른 라인의 재고가 예약되었습니다: available = %v", stock.available["sku-1"])
+	}
+}
+
+func TestOrderStatusChangesDriveStockReservation(t *testing.T) {
+	repo := NewFakeOrderRepository()
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 5
+	useCase := NewOrderUseCase(repo, NewFakeCustomerDirectory(), catalog, stock, NewFakeDiscountEngine(), NewFakeTaxCalculator(), NewFakeShippingRater(), NewFakeQuoteSigner())
+	ctx := context.Background()
+
+	shipped, err := useCase.CreateOrder(ctx, CreateOrderRequest{CustomerID: "cust-1", Items: []OrderItemRequest{{ProductID: "prod-1", Quantity: 2}}})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	if _, err := useCase.UpdateOrderStatus(ctx, shipped.ID(), domain.StatusPaid, "payment", "결제 승인"); err != nil {
+		t.Fatalf("UpdateOrderStatus(paid) error = %v", err)
+	}
+	if _, err := useCase.UpdateOrderStatus(ctx, shipped.ID(), domain.StatusShipped, "shipping", ""); err != nil {
+		t.Fatalf("UpdateOrderStatus(shipped) error = %v", err)
+	}
+	if stock.states[shipped.ID()] != "committed" {
+		t.Errorf("출고 시 재고가 확정되지 않았습니다: %v", stock.states[shipped.ID()])
+	}
+
+	canceled, err := useCase.CreateOrder(ctx, CreateOrderRequest{CustomerID: "cust-1", Items: []OrderItemRequest{{ProductID: "prod-1", Quantity: 3}}})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	if stock.available["sku-1"] != 0 {
+		t.Fatalf("available = %v, want 0", stock.available["sku-1"])
+	}
+	if _, err := useCase.CancelOrder(ctx, canceled.ID(), "cust-1", "단순 변심"); err != nil {
+		t.Fatalf("CancelOrder() error = %v", err)
+	}
+	if stock.available["sku-1"] != 3 {
+		t.Errorf("취소 시 재고가 해제되지 않았습니다: available = %v", stock.available["sku-1"])
+	}
+}
+
+func TestCreateOrderRecordsDiscountLines(t *testing.T) {
+	repo := NewFakeOrderRepository()
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 10
+	discounts := NewFakeDiscountEngine()
+	discounts.amounts["WELCOME"] = 300
+	discounts.amounts["SPRING"] = 200
+	useCase := NewOrderUseCase(repo, NewFakeCustomerDirectory(), catalog, stock, discounts, NewFakeTaxCalculator(), NewFakeShippingRater(), NewFakeQuoteSigner())
+	ctx := context.Background()
+
+	order, err := useCase.CreateOrder(ctx, CreateOrderRequest{CustomerID: "cust-1", Items: []OrderItemRequest{{ProductID: "prod-1", Quantity: 2}}, CouponCodes: []string{"WELCOME", "SPRING"}})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+
+	if !discounts.quotes[0].FirstOrder {
+		t.Error("첫 주문으로 판단되지 않았습니다")
+	}
+	if len(order.Discounts()) != 2 || order.DiscountTotal() != 500 {
+		t.Errorf("할인 내역 = %v건 %v원, want 2건 500원", len(order.Discounts()), order.DiscountTotal())
+	}
+	if order.Subtotal() != 2000 || order.TotalAmount() != 1500 {
+		t.Errorf("Subtotal() = %v, TotalAmount() = %v, want 2000, 1500", order.Subtotal(), order.TotalAmount())
+	}
+	if len(discounts.redeemed[order.ID()]) != 2 {
+		t.Error("쿠폰 사용이 기록되지 않았습니다")
+	}
+
+	// 취소하면 쿠폰 사용도 해제됩니다
+	if _, err := useCase.CancelOrder(ctx, order.ID(), "", ""); err != nil {
+		t.Fatalf("CancelOrder() error = %v", err)
+	}
+	if _, ok := discounts.redeemed[order.ID()]; ok {
+		t.Error("취소된 주문의 쿠폰 사용이 해제되지 않았습니다")
+	}
+}
+
+func TestCreateOrderReleasesStockWhenCouponRedemptionFails(t *testing.T) {
+	repo := NewFakeOrderRepository()
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 10
+	discounts := NewFakeDiscountEngine()
+	discounts.amounts["LIMITED"] = 100
+	discounts.redeemErr = ErrCouponRejected
+	useCase := NewOrderUseCase(repo, NewFakeCustomerDirectory(), catalog, stock, discounts, NewFakeTaxCalculator(), NewFakeShippingRater(), NewFakeQuoteSigner())
+
+	_, err := useCase.CreateOrder(context.Background(), CreateOrderRequest{CustomerID: "cust-1", Items: []OrderItemRequest{{ProductID: "prod-1", Quantity: 2}}, CouponCodes: []string{"LIMITED"}})
+	if !errors.Is(err, ErrCouponRejected) {
+		t.Fatalf("CreateOrder() error = %v, want %v", err, ErrCouponRejected)
+	}
+	if len(repo.orders) != 0 {
+		t.Error("쿠폰 사용에 실패한 주문이 저장되었습니다")
+	}
+	if stock.available["sku-1"] != 10 {
+		t.Errorf("재고 예약이 해제되지 않았습니다: available = %v", stock.available["sku-1"])
+	}
+}
+
+func TestCreateOrderTaxesDiscountedLines(t *testing.T) {
+	repo := NewFakeOrderRepository()
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", TaxCategory: "standard", Price: 1000}
+	catalog.products["prod-2"] = &CatalogProduct{ProductID: "prod-2", SKUID: "sku-2", Name: "도서", TaxCategory: "exempt", Price: 500}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 10
+	stock.available["sku-2"] = 10
+	discounts := NewFakeDiscountEngine()
+	discounts.amounts["WELCOME"] = 300
+	taxes := NewFakeTaxCalculator()
+	taxes.rates["standard"] = 0.1
+	taxes.inclusive = false
+	useCase := NewOrderUseCase(repo, NewFakeCustomerDirectory(), catalog, stock, discounts, taxes, NewFakeShippingRater(), NewFakeQuoteSigner())
+
+	order, err := useCase.CreateOrder(context.Background(), CreateOrderRequest{
+		CustomerID: "cust-1",
+		Items: []OrderItemRequest{
+			{ProductID: "prod-1", Quantity: 2},
+			{ProductID: "prod-2", Quantity: 2},
+		},
+		CouponCodes:        []string{"WELCOME"},
+		DestinationCountry: "jp",
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+
+	// 할인 300원은 소계 비율(2000:1000)로 배분되어 과세 대상 금액에서 차감됩니다
+	req := taxes.requests[0]
+	if req.Country != "JP" {
+		t.Errorf("Country = %v, want JP", req.Country)
+	}
+	if req.Lines[0].Amount != 1800 || req.Lines[1].Amount != 900 {
+		t.Errorf("과세 대상 금액 = %v, %v, want 1800, 900", req.Lines[0].Amount, req.Lines[1].Amount)
+	}
+
+	if order.Items()[0].TaxAmount() != 180 || order.Items()[1].TaxAmount() != 0 {
+		t.Errorf("항목 세액 = %v, %v, want 180, 0", order.Items()[0].TaxAmount(), order.Items()[1].TaxAmount())
+	}
+	// 세금 별도 가격이면 세액이 총액에 더해집니다
+	if order.TaxTotal() != 180 || order.TotalAmount() != 2880 {
+		t.Errorf("TaxTotal() = %v, TotalAmount() = %v, want 180, 2880", order.TaxTotal(), order.TotalAmount())
+	}
+}
+
+func TestOrderStatusHistoryRecordsActorAndReason(t *testing.T) {
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 5
+	useCase := NewOrderUseCase(NewFakeOrderRepository(), NewFakeCustomerDirectory(), catalog, stock, NewFakeDiscountEngine(), NewFakeTaxCalculator(), NewFakeShippingRater(), NewFakeQuoteSigner())
+	ctx := context.Background()
+
+	order, err := useCase.CreateOrder(ctx, CreateOrderRequest{CustomerID: "cust-1", Items: []OrderItemRequest{{ProductID: "prod-1", Quantity: 1}}})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	if _, err := useCase.UpdateOrderStatus(ctx, order.ID(), domain.StatusPaid, "payment", "결제 승인"); err != nil {
+		t.Fatalf("UpdateOrderStatus(paid) error = %v", err)
+	}
+	if _, err := useCase.CancelOrder(ctx, order.ID(), "", "재고 파손"); err != nil {
+		t.Fatalf("CancelOrder() error = %v", err)
+	}
+
+	// 최종 상태에서는 규칙 표에 없는 전환이 거부되고 이력도 남지 않습니다
+	if _, err := useCase.UpdateOrderStatus(ctx, order.ID(), domain.StatusPaid, "admin", ""); !errors.Is(err, domain.ErrOrderStatusTransition) {
+		t.Fatalf("UpdateOrderStatus(canceled -> paid) error = %v, want %v", err, domain.ErrOrderStatusTransition)
+	}
+
+	history, err := useCase.GetOrderHistory(ctx, order.ID())
+	if err != nil {
+		t.Fatalf("GetOrderHistory() error = %v", err)
+	}
+	want := []struct {
+		from, to      domain.OrderStatus
+		actor, reason string
+	}{
+		{"", domain.StatusPending, "cust-1", "주문 생성"},
+		{domain.StatusPending, domain.StatusPaid, "payment", "결제 승인"},
+		{domain.StatusPaid, domain.StatusCanceled, domain.ActorSystem, "재고 파손"},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("len(history) = %d, want %d", len(history), len(want))
+	}
+	for i, w := range want {
+		got := history[i]
+		if got.From() != w.from || got.To() != w.to || got.Actor() != w.actor || got.Reason() != w.reason {
+			t.Errorf("history[%d] = %s -> %s by %s (%s), want %s -> %s by %s (%s)",
+				i, got.From(), got.To(), got.Actor(), got.Reason(), w.from, w.to, w.actor, w.reason)
+		}
+	}
+
+	if _, err := useCase.GetOrderHistory(ctx, "missing"); !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Errorf("GetOrderHistory(missing) error = %v, want %v", err, domain.ErrOrderNotFound)
+	}
+}
+
+func TestEditPendingOrderRecomputesTotalsAndStock(t *testing.T) {
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000}
+	catalog.products["prod-2"] = &CatalogProduct{ProductID: "prod-2", SKUID: "sku-2", Name: "케이스", Price: 200}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 5
+	stock.available["sku-2"] = 5
+	useCase := NewOrderUseCase(NewFakeOrderRepository(), NewFakeCustomerDirectory(), catalog, stock, NewFakeDiscountEngine(), NewFakeTaxCalculator(), NewFakeShippingRater(), NewFakeQuoteSigner())
+	ctx := context.Background()
+
+	order, err := useCase.CreateOrder(ctx, CreateOrderRequest{CustomerID: "cust-1", Items: []OrderItemRequest{{ProductID: "prod-1", Quantity: 1}}})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	itemID := order.Items()[0].ID()
+
+	if _, err := useCase.ChangeOrderItemQuantity(ctx, order.ID(), itemID, 3); err != nil {
+		t.Fatalf("ChangeOrderItemQuantity() error = %v", err)
+	}
+	order, err = useCase.AddOrderItem(ctx, order.ID(), OrderItemRequest{ProductID: "prod-2", Quantity: 2})
+	if err != nil {
+		t.Fatalf("AddOrderItem() error = %v", err)
+	}
+	if order.TotalAmount() != 3400 {
+		t.Errorf("TotalAmount() = %v, want 3400", order.TotalAmount())
+	}
+	if stock.available["sku-1"] != 2 || stock.available["sku-2"] != 3 {
+		t.Errorf("available = %v, %v, want 2, 3", stock.available["sku-1"], stock.available["sku-2"])
+	}
+
+	// 재고보다 많이 늘리면 변경이 거부되고 예약도 그대로입니다
+	if _, err := useCase.ChangeOrderItemQuantity(ctx, order.ID(), itemID, 10); !errors.Is(err, ErrOutOfStock) {
+		t.Fatalf("ChangeOrderItemQuantity(10) error = %v, want %v", err, ErrOutOfStock)
+	}
+
+	order, err = useCase.RemoveOrderItem(ctx, order.ID(), itemID)
+	if err != nil {
+		t.Fatalf("RemoveOrderItem() error = %v", err)
+	}
+	if len(order.Items()) != 1 || order.TotalAmount() != 400 {
+		t.Errorf("len(Items()) = %d, TotalAmount() = %v, want 1, 400", len(order.Items()), order.TotalAmount())
+	}
+	if stock.available["sku-1"] != 5 {
+		t.Errorf("삭제된 항목의 재고가 해제되지 않았습니다: available = %v", stock.available["sku-1"])
+	}
+
+	// 결제 후에는 항목을 변경할 수 없습니다
+	if _, err := useCase.UpdateOrderStatus(ctx, order.ID(), domain.StatusPaid, "payment", ""); err != nil {
+		t.Fatalf("UpdateOrderStatus(paid) error = %v", err)
+	}
+	if _, err := useCase.AddOrderItem(ctx, order.ID(), OrderItemRequest{ProductID: "prod-1", Quantity: 1}); !errors.Is(err, domain.ErrOrderNotEditable) {
+		t.Errorf("AddOrderItem(paid) error = %v, want %v", err, domain.ErrOrderNotEditable)
+	}
+}
+
+func TestEditPendingOrderWithLimitedCoupon(t *testing.T) {
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 5
+	discounts := NewFakeDiscountEngine()
+	discounts.amounts["ONCE"] = 100
+	discounts.limits["ONCE"] = 1
+	useCase := NewOrderUseCase(NewFakeOrderRepository(), NewFakeCustomerDirectory(), catalog, stock, discounts, NewFakeTaxCalculator(), NewFakeShippingRater(), NewFakeQuoteSigner())
+	ctx := context.Background()
+
+	order, err := useCase.CreateOrder(ctx, CreateOrderRequest{CustomerID: "cust-1", Items: []OrderItemRequest{{ProductID: "prod-1", Quantity: 1}}, CouponCodes: []string{"ONCE"}})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+
+	// 수량이 바뀌어 할인 금액이 달라지는 쿠폰을 흉내냅니다
+	discounts.amounts["ONCE"] = 300
+	order, err = useCase.ChangeOrderItemQuantity(ctx, order.ID(), order.Items()[0].ID(), 3)
+	if err != nil {
+		t.Fatalf("ChangeOrderItemQuantity() error = %v", err)
+	}
+	if order.DiscountTotal() != 300 || order.TotalAmount() != 2700 {
+		t.Errorf("DiscountTotal() = %v, TotalAmount() = %v, want 300, 2700", order.DiscountTotal(), order.TotalAmount())
+	}
+	if got := discounts.redeemed[order.ID()]; len(got) != 1 || got[0].Amount != 300 {
+		t.Errorf("redeemed = %+v, want ONCE 300", got)
+	}
+
+	// 다른 주문에는 여전히 한도가 적용됩니다
+	_, err = useCase.CreateOrder(ctx, CreateOrderRequest{CustomerID: "cust-2", Items: []OrderItemRequest{{ProductID: "prod-1", Quantity: 1}}, CouponCodes: []string{"ONCE"}})
+	if !errors.Is(err, ErrCouponRejected) {
+		t.Errorf("CreateOrder(cust-2) error = %v, want %v", err, ErrCouponRejected)
+	}
+}
+
+func TestCancelOrderItemsRefundsPaidOrder(t *testing.T) {
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", TaxCategory: "standard", Price: 1000}
+	catalog.products["prod-2"] = &CatalogProduct{ProductID: "prod-2", SKUID: "sku-2", Name: "케이스", TaxCategory: "standard", Price: 500}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 5
+	stock.available["sku-2"] = 5
+	taxes := NewFakeTaxCalculator()
+	taxes.rates["standard"] = 0.1
+	taxes.inclusive = false
+	useCase := NewOrderUseCase(NewFakeOrderRepository(), NewFakeCustomerDirectory(), catalog, stock, NewFakeDiscountEngine(), taxes, NewFakeShippingRater(), NewFakeQuoteSigner())
+	ctx := context.Background()
+
+	order, err := useCase.CreateOrder(ctx, CreateOrderRequest{
+		CustomerID: "cust-1",
+		Items:      []OrderItemRequest{{ProductID: "prod-1", Quantity: 2}, {ProductID: "prod-2", Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	phone, phoneCase := order.Items()[0].ID(), order.Items()[1].ID()
+
+	// 결제 전에는 부분 취소할 수 없습니다
+	if _, err := useCase.CancelOrderItems(ctx, order.ID(), CancelItemsRequest{Items: []CancelItemRequest{{ItemID: phone, Quantity: 1}}}); !errors.Is(err, domain.ErrOrderStatusTransition) {
+		t.Fatalf("CancelOrderItems(pending) error = %v, want %v", err, domain.ErrOrderStatusTransition)
+	}
+	if _, err := useCase.UpdateOrderStatus(ctx, order.ID(), domain.StatusPaid, "payment", ""); err != nil {
+		t.Fatalf("UpdateOrderStatus(paid) error = %v", err)
+	}
+
+	// 세금 별도 주문이므로 취소한 금액 1000원에 세액 100원을 더해 환불합니다
+	result, err := useCase.CancelOrderItems(ctx, order.ID(), CancelItemsRequest{Items: []CancelItemRequest{{ItemID: phone, Quantity: 1}}, Actor: "admin", Reason: "고객 요청"})
+	if err != nil {
+		t.Fatalf("CancelOrderItems() error = %v", err)
+	}
+	if result.RefundAmount != 1100 {
+		t.Errorf("RefundAmount = %v, want 1100", result.RefundAmount)
+	}
+	if got := result.Order; got.Status() != domain.StatusPaid || got.TotalAmount() != 1650 || got.Items()[0].CanceledQuantity() != 1 {
+		t.Errorf("Status() = %v, TotalAmount() = %v, CanceledQuantity() = %d, want paid, 1650, 1",
+			got.Status(), got.TotalAmount(), got.Items()[0].CanceledQuantity())
+	}
+	if stock.available["sku-1"] != 4 {
+		t.Errorf("취소된 수량의 재고가 해제되지 않았습니다: available = %v", stock.available["sku-1"])
+	}
+
+	if _, err := useCase.CancelOrderItems(ctx, order.ID(), CancelItemsRequest{Items: []CancelItemRequest{{ItemID: phone, Quantity: 2}}}); !errors.Is(err, domain.ErrInvalidCancelQuantity) {
+		t.Fatalf("CancelOrderItems(over) error = %v, want %v", err, domain.ErrInvalidCancelQuantity)
+	}
+
+	// 남은 수량을 모두 취소하면 남은 결제 금액 전체를 환불하고 주문이 취소됩니다
+	result, err = useCase.CancelOrderItems(ctx, order.ID(), CancelItemsRequest{Items: []CancelItemRequest{{ItemID: phone, Quantity: 1}, {ItemID: phoneCase, Quantity: 1}}})
+	if err != nil {
+		t.Fatalf("CancelOrderItems(all) error = %v", err)
+	}
+	if result.RefundAmount != 1650 || result.Order.Status() != domain.StatusCanceled {
+		t.Errorf("RefundAmount = %v, Status() = %v, want 1650, canceled", result.RefundAmount, result.Order.Status())
+	}
+	if stock.available["sku-1"] != 5 || stock.available["sku-2"] != 5 {
+		t.Errorf("available = %v, %v, want 5, 5", stock.available["sku-1"], stock.available["sku-2"])
+	}
+}
+
+func TestReturnOrderItemsMovesDeliveredOrderToReturned(t *testing.T) {
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000}
+	catalog.products["prod-2"] = &CatalogProduct{ProductID: "prod-2", SKUID: "sku-2", Name: "케이스", Price: 500}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 5
+	stock.available["sku-2"] = 5
+	discounts := NewFakeDiscountEngine()
+	discounts.amounts["WELCOME"] = 250
+	useCase := NewOrderUseCase(NewFakeOrderRepository(), NewFakeCustomerDirectory(), catalog, stock, discounts, NewFakeTaxCalculator(), NewFakeShippingRater(), NewFakeQuoteSigner())
+	ctx := context.Background()
+
+	order, err := useCase.CreateOrder(ctx, CreateOrderRequest{
+		CustomerID:  "cust-1",
+		Items:       []OrderItemRequest{{ProductID: "prod-1", Quantity: 2}, {ProductID: "prod-2", Quantity: 1}},
+		CouponCodes: []string{"WELCOME"},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	phone, phoneCase := order.Items()[0].ID(), order.Items()[1].ID()
+
+	// 배송 완료 전에는 반품할 수 없습니다
+	returnPhone := ReturnItemsRequest{Items: []ReturnItemRequest{{ItemID: phone, Quantity: 1}}, Actor: "returns", Reason: "불량"}
+	if _, err := useCase.ReturnOrderItems(ctx, order.ID(), returnPhone); !errors.Is(err, domain.ErrOrderNotReturnable) {
+		t.Fatalf("ReturnOrderItems(pending) error = %v, want %v", err, domain.ErrOrderNotReturnable)
+	}
+	for _, status := range []domain.OrderStatus{domain.StatusPaid, domain.StatusShipped, domain.StatusDelivered} {
+		if _, err := useCase.UpdateOrderStatus(ctx, order.ID(), status, "", ""); err != nil {
+			t.Fatalf("UpdateOrderStatus(%s) error = %v", status, err)
+		}
+	}
+
+	// 결제 금액 2250원 중 할인을 비율대로 나눈 스마트폰 1개 금액 900원을 환불합니다
+	result, err := useCase.ReturnOrderItems(ctx, order.ID(), returnPhone)
+	if err != nil {
+		t.Fatalf("ReturnOrderItems() error = %v", err)
+	}
+	if result.RefundAmount != 900 || result.Order.Status() != domain.StatusPartiallyReturned {
+		t.Errorf("RefundAmount = %v, Status() = %v, want 900, partially_returned", result.RefundAmount, result.Order.Status())
+	}
+	if result.Order.TotalAmount() != 2250 {
+		t.Errorf("반품해도 결제 금액은 그대로여야 합니다: TotalAmount() = %v", result.Order.TotalAmount())
+	}
+
+	if _, err := useCase.ReturnOrderItems(ctx, order.ID(), ReturnItemsRequest{Items: []ReturnItemRequest{{ItemID: phone, Quantity: 2}}}); !errors.Is(err, domain.ErrInvalidReturnQuantity) {
+		t.Fatalf("ReturnOrderItems(over) error = %v, want %v", err, domain.ErrInvalidReturnQuantity)
+	}
+
+	result, err = useCase.ReturnOrderItems(ctx, order.ID(), ReturnItemsRequest{Items: []ReturnItemRequest{{ItemID: phone, Quantity: 1}, {ItemID: phoneCase, Quantity: 1}}})
+	if err != nil {
+		t.Fatalf("ReturnOrderItems(rest) error = %v", err)
+	}
+	if result.RefundAmount != 1350 || result.Order.Status() != domain.StatusReturned {
+		t.Errorf("RefundAmount = %v, Status() = %v, want 1350, returned", result.RefundAmount, result.Order.Status())
+	}
+}
+
+func TestConcurrentStatusChangeIsRejected(t *testing.T) {
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 5
+	discounts := NewFakeDiscountEngine()
+	discounts.amounts["WELCOME"] = 100
+	repo := NewFakeOrderRepository()
+	useCase := NewOrderUseCase(repo, NewFakeCustomerDirectory(), catalog, stock, discounts, NewFakeTaxCalculator(), NewFakeShippingRater(), NewFakeQuoteSigner())
+	ctx := context.Background()
+
+	order, err := useCase.CreateOrder(ctx, CreateOrderRequest{CustomerID: "cust-1", Items: []OrderItemRequest{{ProductID: "prod-1", Quantity: 2}}, CouponCodes: []string{"WELCOME"}})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+
+	// 조회한 뒤 다른 요청(결제 사가 등)이 먼저 저장한 경우
+	repo.versions[order.ID()]++
+	if _, err := useCase.CancelOrder(ctx, order.ID(), domain.ActorSystem, domain.ReasonExpired); !errors.Is(err, domain.ErrOrderConflict) {
+		t.Fatalf("CancelOrder() error = %v, want %v", err, domain.ErrOrderConflict)
+	}
+	// 저장되지 않은 취소는 재고 예약과 쿠폰을 해제하지 않습니다
+	if stock.available["sku-1"] != 3 {
+		t.Errorf("available = %v, want 3", stock.available["sku-1"])
+	}
+	if _, ok := discounts.redeemed[order.ID()]; !ok {
+		t.Error("저장되지 않은 취소로 쿠폰이 해제되었습니다")
+	}
+
+	// 만료 처리는 경합에서 진 주문을 건너뜁니다
+	expired, err := useCase.ExpirePendingOrders(ctx, time.Now().Add(time.Minute))
+	if err != nil || len(expired) != 0 {
+		t.Errorf("ExpirePendingOrders() = %v, %v, want 0, nil", len(expired), err)
+	}
+}
+
+func TestExpirePendingOrdersCancelsOnlyUnpaidOrders(t *testing.T) {
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 5
+	discounts := NewFakeDiscountEngine()
+	discounts.amounts["WELCOME"] = 100
+	useCase := NewOrderUseCase(NewFakeOrderRepository(), NewFakeCustomerDirectory(), catalog, stock, discounts, NewFakeTaxCalculator(), NewFakeShippingRater(), NewFakeQuoteSigner())
+	ctx := context.Background()
+
+	unpaid, err := useCase.CreateOrder(ctx, CreateOrderRequest{
+		CustomerID:  "cust-1",
+		Items:       []OrderItemRequest{{ProductID: "prod-1", Quantity: 2}},
+		CouponCodes: []string{"WELCOME"},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder(unpaid) error = %v", err)
+	}
+	paid, err := useCase.CreateOrder(ctx, CreateOrderRequest{CustomerID: "cust-2", Items: []OrderItemRequest{{ProductID: "prod-1", Quantity: 1}}})
+	if err != nil {
+		t.Fatalf("CreateOrder(paid) error = %v", err)
+	}
+	if _, err := useCase.UpdateOrderStatus(ctx, paid.ID(), domain.StatusPaid, "", ""); err != nil {
+		t.Fatalf("UpdateOrderStatus(paid) error = %v", err)
+	}
+
+	// 아직 기한이 지나지 않은 주문은 취소하지 않습니다
+	if expired, err := useCase.ExpirePendingOrders(ctx, unpaid.CreatedAt().Add(-time.Minute)); err != nil || len(expired) != 0 {
+		t.Fatalf("ExpirePendingOrders(before) = %v, %v, want 0, nil", len(expired), err)
+	}
+
+	expired, err := useCase.ExpirePendingOrders(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("ExpirePendingOrders() error = %v", err)
+	}
+	if len(expired) != 1 || expired[0].ID() != unpaid.ID() {
+		t.Errorf("expired = %v, want [%v]", len(expired), unpaid.ID())
+	}
+
+	order, _ := useCase.GetOrder(ctx, unpaid.ID())
+	if order.Status() != domain.StatusCanceled {
+		t.Fatalf("Status() = %v, want canceled", order.Status())
+	}
+	history := order.StatusChanges()
+	last := history[len(history)-1]
+	if last.Actor() != domain.ActorSystem || last.Reason() != domain.ReasonExpired {
+		t.Errorf("Actor(), Reason() = %v, %v, want system, expired", last.Actor(), last.Reason())
+	}
+	if stock.available["sku-1"] != 4 {
+		t.Errorf("available = %v, want 4", stock.available["sku-1"])
+	}
+	if _, ok := discounts.redeemed[unpaid.ID()]; ok {
+		t.Error("만료된 주문의 쿠폰은 해제되어야 합니다")
+	}
+	if order, _ := useCase.GetOrder(ctx, paid.ID()); order.Status() != domain.StatusPaid {
+		t.Errorf("결제된 주문은 그대로여야 합니다: Status() = %v", order.Status())
+	}
+}
+
+func TestSearchOrdersPaginatesWithCursor(t *testing.T) {
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 20
+	useCase := NewOrderUseCase(NewFakeOrderRepository(), NewFakeCustomerDirectory(), catalog, stock, NewFakeDiscountEngine(), NewFakeTaxCalculator(), NewFakeShippingRater(), NewFakeQuoteSigner())
+	ctx := context.Background()
+
+	for quantity := 1; quantity <= 5; quantity++ {
+		if _, err := useCase.CreateOrder(ctx, CreateOrderRequest{CustomerID: "cust-1", Items: []OrderItemRequest{{ProductID: "prod-1", Quantity: quantity}}}); err != nil {
+			t.Fatalf("CreateOrder() error = %v", err)
+		}
+	}
+
+	criteria := OrderSearchCriteria{SortBy: OrderSortTotalAmount, Descending: true, MinAmount: 2000, Limit: 2}
+	totals := []float64{}
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatal("커서를 따라가도 검색이 끝나지 않습니다")
+		}
+		page, err := useCase.SearchOrders(ctx, criteria)
+		if err != nil {
+			t.Fatalf("SearchOrders() error = %v", err)
+		}
+		for _, order := range page.Orders {
+			totals = append(totals, order.TotalAmount())
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		criteria.Cursor = page.NextCursor
+	}
+	if want := []float64{5000, 4000, 3000, 2000}; fmt.Sprint(totals) != fmt.Sprint(want) {
+		t.Errorf("totals = %v, want %v", totals, want)
+	}
+
+	// 다른 정렬 기준으로 만든 커서는 받지 않습니다
+	criteria.SortBy = OrderSortCreatedAt
+	if _, err := useCase.SearchOrders(ctx, criteria); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("SearchOrders(mismatched cursor) error = %v, want %v", err, ErrInvalidCursor)
+	}
+	if _, err := useCase.SearchOrders(ctx, OrderSearchCriteria{Statuses: []domain.OrderStatus{"unknown"}}); !errors.Is(err, domain.ErrInvalidOrderStatus) {
+		t.Errorf("SearchOrders(unknown status) error = %v, want %v", err, domain.ErrInvalidOrderStatus)
+	}
+
+	exported := 0
+	err := useCase.ExportOrders(ctx, OrderSearchCriteria{CustomerID: "cust-1"}, func(order *domain.Order) error {
+		exported++
+		return nil
+	})
+	if err != nil || exported != 5 {
+		t.Errorf("ExportOrders() = %v, %v, want 5, nil", exported, err)
+	}
+}
+
+func TestGuestOrderLookupCancelAndAttach(t *testing.T) {
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 5
+	customers := NewFakeCustomerDirectory()
+	customers.customers["member-1"] = &Customer{ID: "member-1", Name: "홍길동", Email: "GUEST@example.com", Active: true}
+	customers.customers["member-2"] = &Customer{ID: "member-2", Name: "김철수", Email: "other@example.com", Active: true}
+	useCase := NewOrderUseCase(NewFakeOrderRepository(), customers, catalog, stock, NewFakeDiscountEngine(), NewFakeTaxCalculator(), NewFakeShippingRater(), NewFakeQuoteSigner())
+	ctx := context.Background()
+
+	contact := domain.GuestContact{Email: " Guest@Example.com ", Name: "홍길동", Address1: "테헤란로 1", City: "서울", PostalCode: "06236"}
+	items := []OrderItemRequest{{ProductID: "prod-1", Quantity: 1}}
+
+	if _, err := useCase.CreateGuestOrder(ctx, CreateGuestOrderRequest{Contact: domain.GuestContact{Email: "not-an-email"}, Items: items}); !errors.Is(err, domain.ErrInvalidGuestContact) {
+		t.Fatalf("CreateGuestOrder(invalid contact) error = %v, want %v", err, domain.ErrInvalidGuestContact)
+	}
+	if _, err := useCase.CreateGuestOrder(ctx, CreateGuestOrderRequest{Contact: contact, Items: items, CouponCodes: []string{"WELCOME"}}); !errors.Is(err, ErrGuestCouponNotAllowed) {
+		t.Fatalf("CreateGuestOrder(coupon) error = %v, want %v", err, ErrGuestCouponNotAllowed)
+	}
+
+	guest, err := useCase.CreateGuestOrder(ctx, CreateGuestOrderRequest{Contact: contact, Items: items})
+	if err != nil {
+		t.Fatalf("CreateGuestOrder() error = %v", err)
+	}
+	if !guest.Order.IsGuest() || guest.Order.Guest().Email != "guest@example.com" || guest.LookupToken == "" {
+		t.Fatalf("IsGuest() = %v, Email = %q, token = %q", guest.Order.IsGuest(), guest.Order.Guest().Email, guest.LookupToken)
+	}
+	if stock.available["sku-1"] != 4 {
+		t.Errorf("available = %v, want 4", stock.available["sku-1"])
+	}
+
+	// 토큰이 맞지 않으면 주문이 없는 것처럼 응답합니다
+	if _, err := useCase.GetGuestOrder(ctx, guest.Order.ID(), "wrong-token"); !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Fatalf("GetGuestOrder(wrong token) error = %v, want %v", err, domain.ErrOrderNotFound)
+	}
+	if _, err := useCase.GetGuestOrder(ctx, guest.Order.ID(), guest.LookupToken); err != nil {
+		t.Fatalf("GetGuestOrder() error = %v", err)
+	}
+
+	if _, err := useCase.AttachGuestOrder(ctx, guest.Order.ID(), guest.LookupToken, "member-2"); !errors.Is(err, domain.ErrGuestEmailMismatch) {
+		t.Fatalf("AttachGuestOrder(other email) error = %v, want %v", err, domain.ErrGuestEmailMismatch)
+	}
+	attached, err := useCase.AttachGuestOrder(ctx, guest.Order.ID(), guest.LookupToken, "member-1")
+	if err != nil {
+		t.Fatalf("AttachGuestOrder() error = %v", err)
+	}
+	if attached.CustomerID() != "member-1" || attached.IsGuest() {
+		t.Errorf("CustomerID() = %v, IsGuest() = %v, want member-1, false", attached.CustomerID(), attached.IsGuest())
+	}
+	if _, err := useCase.AttachGuestOrder(ctx, guest.Order.ID(), guest.LookupToken, "member-1"); !errors.Is(err, domain.ErrNotGuestOrder) {
+		t.Errorf("AttachGuestOrder(again) error = %v, want %v", err, domain.ErrNotGuestOrder)
+	}
+
+	canceled, err := useCase.CancelGuestOrder(ctx, guest.Order.ID(), guest.LookupToken, "단순 변심")
+	if err != nil {
+		t.Fatalf("CancelGuestOrder() error = %v", err)
+	}
+	history := canceled.StatusChanges()
+	if last := history[len(history)-1]; canceled.Status() != domain.StatusCanceled || last.Actor() != "guest:guest@example.com" {
+		t.Errorf("Status() = %v, Actor() = %v, want canceled, guest:guest@example.com", canceled.Status(), last.Actor())
+	}
+	if stock.available["sku-1"] != 5 {
+		t.Errorf("available = %v, want 5", stock.available["sku-1"])
+	}
+}
+
+func TestCreateOrderValidatesAndSnapshotsCustomer(t *testing.T) {
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 5
+	customers := NewFakeCustomerDirectory()
+	customers.customers["cust-1"] = &Customer{ID: "cust-1", Name: "홍길동", Email: "hong@example.com", Active: true}
+	customers.customers["cust-dormant"] = &Customer{ID: "cust-dormant", Name: "휴면", Email: "dormant@example.com", Active: false}
+	customers.missing["cust-deleted"] = true
+	useCase := NewOrderUseCase(NewFakeOrderRepository(), customers, catalog, stock, NewFakeDiscountEngine(), NewFakeTaxCalculator(), NewFakeShippingRater(), NewFakeQuoteSigner())
+	ctx := context.Background()
+	items := []OrderItemRequest{{ProductID: "prod-1", Quantity: 1}}
+
+	tests := []struct {
+		customerID string
+		wantErr    error
+	}{
+		{customerID: "cust-deleted", wantErr: ErrCustomerNotFound},
+		{customerID: "cust-dormant", wantErr: ErrCustomerInactive},
+	}
+	for _, tt := range tests {
+		if _, err := useCase.CreateOrder(ctx, CreateOrderRequest{CustomerID: tt.customerID, Items: items}); !errors.Is(err, tt.wantErr) {
+			t.Errorf("CreateOrder(%s) error = %v, want %v", tt.customerID, err, tt.wantErr)
+		}
+	}
+	if stock.available["sku-1"] != 5 {
+		t.Errorf("거절된 주문은 재고를 예약하지 않아야 합니다: available = %v", stock.available["sku-1"])
+	}
+
+	order, err := useCase.CreateOrder(ctx, CreateOrderRequest{CustomerID: "cust-1", Items: items})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+
+	// 회원 정보가 바뀌어도 주문에는 주문 시점의 정보가 남습니다
+	customers.customers["cust-1"].Name = "홍길순"
+	order, _ = useCase.GetOrder(ctx, order.ID())
+	if order.CustomerName() != "홍길동" || order.CustomerEmail() != "hong@example.com" {
+		t.Errorf("CustomerName(), CustomerEmail() = %v, %v, want 홍길동, hong@example.com", order.CustomerName(), order.CustomerEmail())
+	}
+}
+
+func TestCreateOrderHonorsQuotedPrice(t *testing.T) {
+	repo := NewFakeOrderRepository()
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 5
+	discounts := NewFakeDiscountEngine()
+	discounts.amounts["WELCOME"] = 100
+	shipping := NewFakeShippingRater()
+	shipping.fee = 300
+	useCase := NewOrderUseCase(repo, NewFakeCustomerDirectory(), catalog, stock, discounts, NewFakeTaxCalculator(), shipping, NewFakeQuoteSigner())
+	ctx := context.Background()
+
+	req := CreateOrderRequest{
+		CustomerID:  "cust-1",
+		Items:       []OrderItemRequest{{ProductID: "prod-1", Quantity: 2}},
+		CouponCodes: []string{"WELCOME"},
+	}
+	quote, err := useCase.QuoteOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("QuoteOrder() error = %v", err)
+	}
+	if quote.Order.TotalAmount() != 2200 || quote.Order.ShippingFee() != 300 || quote.QuoteID == "" {
+		t.Fatalf("TotalAmount() = %v, ShippingFee() = %v, QuoteID = %q, want 2200, 300 and a quote ID",
+			quote.Order.TotalAmount(), quote.Order.ShippingFee(), quote.QuoteID)
+	}
+
+	// 견적은 재고를 예약하거나 주문을 저장하지 않습니다
+	if stock.available["sku-1"] != 5 || len(repo.orders) != 0 {
+		t.Fatalf("available = %v, orders = %v, want 5, 0", stock.available["sku-1"], len(repo.orders))
+	}
+
+	// 견적 이후 가격이나 배송비가 올라도 견적 가격으로 주문합니다
+	catalog.products["prod-1"].Price = 1200
+	shipping.fee = 500
+	req.QuoteID = quote.QuoteID
+	order, err := useCase.CreateOrder(ctx, req)
+	if err != nil {
+		t.Fatalf("CreateOrder(quote) error = %v", err)
+	}
+	if order.TotalAmount() != 2200 || order.ShippingFee() != 300 || order.Items()[0].Price() != 1000 {
+		t.Errorf("TotalAmount() = %v, ShippingFee() = %v, Price() = %v, want 2200, 300, 1000",
+			order.TotalAmount(), order.ShippingFee(), order.Items()[0].Price())
+	}
+	if stock.available["sku-1"] != 3 {
+		t.Errorf("available = %v, want 3", stock.available["sku-1"])
+	}
+
+	changed := req
+	changed.Items = []OrderItemRequest{{ProductID: "prod-1", Quantity: 3}}
+	if _, err := useCase.CreateOrder(ctx, changed); !errors.Is(err, ErrQuoteMismatch) {
+		t.Errorf("CreateOrder(changed items) error = %v, want %v", err, ErrQuoteMismatch)
+	}
+
+	tampered := req
+	tampered.QuoteID = "e30." + strings.SplitN(quote.QuoteID, ".", 2)[1]
+	if _, err := useCase.CreateOrder(ctx, tampered); !errors.Is(err, ErrInvalidQuote) {
+		t.Errorf("CreateOrder(tampered) error = %v, want %v", err, ErrInvalidQuote)
+	}
+}
+
+func TestImportOrdersIsIdempotentAndReportsRowErrors(t *testing.T) {
+	catalog := NewFakeProductCatalog()
+	catalog.products["prod-1"] = &CatalogProduct{ProductID: "prod-1", SKUID: "sku-1", Name: "스마트폰", Price: 1000}
+	catalog.products["prod-2"] = &CatalogProduct{ProductID: "prod-2", SKUID: "sku-2", Name: "케이스", Price: 100}
+	stock := NewFakeStockReserver()
+	stock.available["sku-1"] = 10
+	stock.available["sku-2"] = 10
+	customers := NewFakeCustomerDirectory()
+	customers.missing["cust-deleted"] = true
+	repo := NewFakeOrderRepository()
+	useCase := NewOrderUseCase(repo, customers, catalog, stock, NewFakeDiscountEngine(), NewFakeTaxCalculator(), NewFakeShippingRater(), NewFakeQuoteSigner())
+	ctx := context.Background()
+
+	file := strings.Join([]string{
+		"external_ref,customer_id,product_id,sku_id,quantity,unit_price,status,placed_at",
+		"OLD-1,cust-1,prod-1,sku-1,1,900,delivered,2023-05-01",
+		"OLD-1,cust-1,prod-2,sku-2,2,,delivered,2023-05-01",
+		"B2B-1,cust-2,prod-1,sku-1,3,,,",
+		"BAD-1,cust-3,prod-1,sku-1,0,,,",
+		"BAD-2,cust-deleted,prod-1,sku-1,1,,,",
+		"BAD-2,cust-deleted,prod-2,sku-2,1,,,",
+	}, "\n")
+
+	// 미리보기는 검증만 하고 저장하거나 재고를 예약하지 않습니다
+	preview, err := useCase.ImportOrders(ctx, strings.NewReader(file), ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("ImportOrders(dry run) error = %v", err)
+	}
+	if preview.Valid != 2 || preview.Failed != 2 || len(repo.orders) != 0 || stock.available["sku-1"] != 10 {
+		t.Fatalf("dry run = valid %d, failed %d, saved %d, available %d, want 2, 2, 0, 10", preview.Valid, preview.Failed, len(repo.orders), stock.available["sku-1"])
+	}
+
+	result, err := useCase.ImportOrders(ctx, strings.NewReader(file), ImportOptions{BatchSize: 1})
+	if err != nil {
+		t.Fatalf("ImportOrders() error = %v", err)
+	}
+	if result.Created != 2 || result.Failed != 2 {
+		t.Fatalf("Created, Failed = %d, %d, want 2, 2", result.Created, result.Failed)
+	}
+
+	// 형식 오류는 해당 행에, 주문 단위 오류는 주문의 모든 행에 기록됩니다
+	lines := []int{}
+	for _, rowErr := range result.Errors {
+		lines = append(lines, rowErr.Line)
+	}
+	if fmt.Sprint(lines) != "[5 6 7]" {
+		t.Errorf("error lines = %v, want [5 6 7]", lines)
+	}
+
+	// 이전 주문은 가져온 상태와 주문 시각, 파일의 단가로 기록되고 재고를 예약하지 않습니다
+	old, _ := repo.FindByID(ctx, repo.imports["OLD-1"])
+	if old.Status() != domain.StatusDelivered || old.CreatedAt().Format("2006-01-02") != "2023-05-01" || old.Subtotal() != 1100 {
+		t.Errorf("imported order = %v, %v, %v, want delivered, 2023-05-01, 1100", old.Status(), old.CreatedAt(), old.Subtotal())
+	}
+	// 결제 대기 주문은 새 주문처럼 재고를 예약합니다
+	if stock.available["sku-1"] != 7 || stock.available["sku-2"] != 10 {
+		t.Errorf("available = %v, %v, want 7, 10", stock.available["sku-1"], stock.available["sku-2"])
+	}
+
+	// 같은 파일을 다시 가져오면 이미 가져온 주문은 건너뜁니다
+	again, err := useCase.ImportOrders(ctx, strings.NewReader(file), ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportOrders(again) error = %v", err)
+	}
+	if again.Created != 0 || again.Skipped != 2 || len(repo.orders) != 2 || stock.available["sku-1"] != 7 {
+		t.Errorf("again = created %d, skipped %d, orders %d, available %d, want 0, 2, 2, 7", again.Created, again.Skipped, len(repo.orders), stock.available["sku-1"])
+	}
+
+	if _, err := useCase.ImportOrders(ctx, strings.NewReader("customer_id,product_id\n"), ImportOptions{}); !errors.Is(err, ErrInvalidImportFile) {
+		t.Errorf("ImportOrders(missing columns) error = %v, want %v", err, ErrInvalidImportFile)
+	}
+}
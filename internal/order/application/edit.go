@@ -0,0 +1,132 @@
+package application
+
+import (
+	"context"
+
+	"example.com/myapp/order/domain"
+)
+
+// AddOrderItem은 결제 전 주문에 항목을 추가합니다.
+// 상품 정보는 카탈로그에서 다시 확인하여 스냅샷합니다.
+func (uc *OrderUseCase) AddOrderItem(ctx context.Context, orderID string, req OrderItemRequest) (*domain.Order, error) {
+	if req.Quantity <= 0 {
+		return nil, domain.ErrInvalidItemQuantity
+	}
+
+	product, err := uc.catalog.FindProduct(ctx, req.ProductID, req.SKUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.editOrder(ctx, orderID, func(order *domain.Order) error {
+		item := domain.NewOrderItem(product.ProductID, product.SKUID, product.Name, product.TaxCategory, product.Price, req.Quantity)
+		return order.AddItem(item)
+	})
+}
+
+// ChangeOrderItemQuantity는 결제 전 주문 항목의 수량을 변경합니다.
+func (uc *OrderUseCase) ChangeOrderItemQuantity(ctx context.Context, orderID, itemID string, quantity int) (*domain.Order, error) {
+	return uc.editOrder(ctx, orderID, func(order *domain.Order) error {
+		return order.ChangeItemQuantity(itemID, quantity)
+	})
+}
+
+// RemoveOrderItem은 결제 전 주문에서 항목을 삭제합니다.
+func (uc *OrderUseCase) RemoveOrderItem(ctx context.Context, orderID, itemID string) (*domain.Order, error) {
+	return uc.editOrder(ctx, orderID, func(order *domain.Order) error {
+		return order.RemoveItem(itemID)
+	})
+}
+
+// editOrder는 결제 전 주문의 항목을 변경한 뒤 배송비, 할인, 세금과 재고 예약을 다시 맞추고 저장합니다.
+// 적용된 쿠폰은 같은 코드로 다시 계산하며, 변경된 항목으로 쿠폰 조건을 충족하지 못하면 변경이 거부됩니다.
+// 쿠폰 사용 기록은 주문 단위이므로 사용 횟수는 그대로 두고 다시 계산된 할인 금액만 갱신합니다.
+func (uc *OrderUseCase) editOrder(ctx context.Context, orderID string, edit func(*domain.Order) error) (*domain.Order, error) {
+	order, err := uc.repo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := edit(order); err != nil {
+		return nil, err
+	}
+
+	if err := uc.applyShipping(ctx, order); err != nil {
+		return nil, err
+	}
+	if codes := couponCodes(order); len(codes) > 0 {
+		if err := uc.applyDiscounts(ctx, order, codes); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.applyTaxes(ctx, order); err != nil {
+		return nil, err
+	}
+
+	// 늘어난 수량을 예약할 수 없으면 주문을 저장하지 않습니다
+	if err := uc.stock.Adjust(ctx, order.ID(), stockLines(order)); err != nil {
+		return nil, err
+	}
+
+	if len(order.Discounts()) > 0 {
+		if err := uc.discounts.Redeem(ctx, order.ID(), order.CustomerID(), appliedDiscounts(order)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.repo.Update(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// CancelOrderItems는 결제된 주문의 항목 일부를 취소하고 환불할 금액을 계산합니다.
+// 취소된 수량의 재고 예약은 해제되며, 모든 수량이 취소되면 주문이 취소되고 쿠폰 사용도 해제됩니다.
+func (uc *OrderUseCase) CancelOrderItems(ctx context.Context, orderID string, req CancelItemsRequest) (*ItemCancellation, error) {
+	order, err := uc.repo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	quantities := make(map[string]int, len(req.Items))
+	for _, item := range req.Items {
+		quantities[item.ItemID] += item.Quantity
+	}
+
+	refund, err := order.CancelItems(quantities, req.Actor, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status() == domain.StatusCanceled {
+		if err := uc.syncStock(ctx, order); err != nil {
+			return nil, err
+		}
+		if err := uc.releaseDiscounts(ctx, order); err != nil {
+			return nil, err
+		}
+	} else if err := uc.stock.Adjust(ctx, order.ID(), stockLines(order)); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return &ItemCancellation{Order: order, RefundAmount: refund}, nil
+}
+
+// couponCodes는 주문에 적용된 쿠폰 코드를 적용 순서대로 중복 없이 반환합니다.
+func couponCodes(order *domain.Order) []string {
+	seen := make(map[string]bool, len(order.Discounts()))
+	codes := make([]string, 0, len(order.Discounts()))
+	for _, discount := range order.Discounts() {
+		if discount.Code() == "" || seen[discount.Code()] {
+			continue
+		}
+		seen[discount.Code()] = true
+		codes = append(codes, discount.Code())
+	}
+	return codes
+}
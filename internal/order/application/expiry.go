@@ -0,0 +1,42 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"example.com/myapp/order/domain"
+)
+
+const (
+	// expiredOrderBatchSize는 한 번에 만료 처리할 결제 대기 주문 수입니다.
+	expiredOrderBatchSize = 100
+	// expiredOrderClaimLease는 선점한 주문을 다른 인스턴스가 건너뛰는 시간입니다.
+	// 처리 도중 인스턴스가 종료되면 이 시간이 지난 뒤 다른 인스턴스가 이어서 처리합니다.
+	expiredOrderClaimLease = 5 * time.Minute
+)
+
+// ExpirePendingOrders는 cutoff 이전에 생성되어 아직 결제되지 않은 주문을 취소하고 취소한 주문 목록을 반환합니다.
+// 취소는 CancelOrder를 거치므로 재고 예약과 쿠폰도 함께 해제됩니다.
+func (uc *OrderUseCase) ExpirePendingOrders(ctx context.Context, cutoff time.Time) ([]*domain.Order, error) {
+	orderIDs, err := uc.repo.ClaimExpiredPendingOrders(ctx, cutoff, expiredOrderClaimLease, expiredOrderBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	expired := []*domain.Order{}
+	for _, orderID := range orderIDs {
+		order, err := uc.CancelOrder(ctx, orderID, domain.ActorSystem, domain.ReasonExpired)
+		if err != nil {
+			// 선점 후 결제되거나 취소된 주문은 건너뜁니다
+			if errors.Is(err, domain.ErrOrderStatusTransition) || errors.Is(err, domain.ErrOrderConflict) || errors.Is(err, domain.ErrOrderNotFound) {
+				continue
+			}
+			return expired, fmt.Errorf("failed to expire pending order %s: %w", orderID, err)
+		}
+		expired = append(expired, order)
+	}
+
+	return expired, nil
+}
@@ -0,0 +1,75 @@
+package application
+
+import (
+	"context"
+
+	"example.com/myapp/order/domain"
+)
+
+// CreateGuestOrder는 회원 계정 없이 비회원 주문을 생성하고 주문 조회 토큰을 발급합니다.
+// 회원별 쿠폰 사용 한도와 등급 조건을 확인할 수 없으므로 비회원 주문에는 쿠폰을 적용할 수 없습니다.
+func (uc *OrderUseCase) CreateGuestOrder(ctx context.Context, req CreateGuestOrderRequest) (*GuestOrder, error) {
+	if len(req.CouponCodes) > 0 {
+		return nil, ErrGuestCouponNotAllowed
+	}
+
+	items, quote, err := uc.newOrderItems(ctx, req.QuoteID, "", req.Items, nil, req.DestinationCountry)
+	if err != nil {
+		return nil, err
+	}
+
+	order, token, err := domain.NewGuestOrder(req.Contact, items)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.finishOrder(ctx, order, quote, req.DestinationCountry, nil); err != nil {
+		return nil, err
+	}
+	return &GuestOrder{Order: order, LookupToken: token}, nil
+}
+
+// GetGuestOrder는 주문 조회 토큰으로 비회원 주문을 조회합니다.
+// 토큰이 맞지 않으면 주문이 있는지 알 수 없도록 ErrOrderNotFound를 반환합니다.
+func (uc *OrderUseCase) GetGuestOrder(ctx context.Context, orderID, token string) (*domain.Order, error) {
+	order, err := uc.repo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !order.VerifyLookupToken(token) {
+		return nil, domain.ErrOrderNotFound
+	}
+	return order, nil
+}
+
+// CancelGuestOrder는 주문 조회 토큰으로 비회원 주문을 취소합니다.
+func (uc *OrderUseCase) CancelGuestOrder(ctx context.Context, orderID, token, reason string) (*domain.Order, error) {
+	order, err := uc.GetGuestOrder(ctx, orderID, token)
+	if err != nil {
+		return nil, err
+	}
+	return uc.CancelOrder(ctx, order.ID(), domain.GuestActor(order.Guest().Email), reason)
+}
+
+// AttachGuestOrder는 비회원 주문을 같은 이메일의 회원 계정에 연결합니다.
+// 회원 이메일은 아직 인증 절차가 없으므로, 주문 이메일로 받은 조회 토큰을 함께 제시해야 연결할 수 있습니다.
+func (uc *OrderUseCase) AttachGuestOrder(ctx context.Context, orderID, token, customerID string) (*domain.Order, error) {
+	order, err := uc.GetGuestOrder(ctx, orderID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	customer, err := uc.activeCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := order.AttachToCustomer(customer.ID, customer.Name, customer.Email); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
@@ -0,0 +1,412 @@
+package application
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"example.com/myapp/order/domain"
+)
+
+const (
+	defaultImportBatchSize = 100
+	maxImportBatchSize     = 1000
+)
+
+var ErrInvalidImportFile = errors.New("invalid order import file")
+
+// importColumns는 주문 가져오기 CSV의 열 이름입니다. 열 순서는 자유이며 필수 열은 required가 true입니다.
+var importColumns = []struct {
+	name     string
+	required bool
+}{
+	{"external_ref", true},
+	{"customer_id", true},
+	{"product_id", true},
+	{"sku_id", true},
+	{"quantity", true},
+	{"unit_price", false},
+	{"status", false},
+	{"placed_at", false},
+	{"destination_country", false},
+}
+
+// ImportOutcome은 가져오기 파일의 주문 하나를 처리한 결과를 정의합니다.
+type ImportOutcome string
+
+const (
+	// ImportCreated는 주문을 새로 저장했음을 나타냅니다.
+	ImportCreated ImportOutcome = "created"
+	// ImportSkipped는 같은 외부 주문 번호로 이미 가져온 주문이 있어 건너뛰었음을 나타냅니다.
+	ImportSkipped ImportOutcome = "skipped"
+	// ImportFailed는 검증에 실패하여 저장하지 않았음을 나타냅니다.
+	ImportFailed ImportOutcome = "failed"
+	// ImportValid는 미리보기에서 검증을 통과했음을 나타냅니다.
+	ImportValid ImportOutcome = "valid"
+)
+
+// ImportOptions는 주문 가져오기 옵션을 정의합니다.
+// DryRun이면 모든 검증을 수행하되 재고를 예약하거나 주문을 저장하지 않습니다.
+type ImportOptions struct {
+	DryRun    bool
+	BatchSize int
+}
+
+// ImportedOrder는 저장할 가져온 주문과 외부 주문 번호를 정의합니다.
+type ImportedOrder struct {
+	ExternalRef string
+	Order       *domain.Order
+}
+
+// ImportOrderResult는 외부 주문 번호별 처리 결과를 정의합니다.
+// 건너뛴 주문의 OrderID는 이전에 가져온 주문의 ID입니다.
+type ImportOrderResult struct {
+	ExternalRef string
+	OrderID     string
+	Outcome     ImportOutcome
+}
+
+// ImportRowError는 CSV 행의 오류를 정의합니다. Line은 파일의 줄 번호(헤더가 1)입니다.
+// 주문 단위 검증에 실패하면 그 주문의 모든 행에 같은 오류가 기록됩니다.
+type ImportRowError struct {
+	Line        int
+	ExternalRef string
+	Message     string
+}
+
+// ImportResult는 주문 가져오기 결과 보고서를 정의합니다.
+type ImportResult struct {
+	DryRun  bool
+	Created int
+	Valid   int
+	Skipped int
+	Failed  int
+	Orders  []ImportOrderResult
+	Errors  []ImportRowError
+}
+
+// importRow는 CSV 한 행(주문 항목 하나)을 정의합니다.
+type importRow struct {
+	line               int
+	customerID         string
+	productID          string
+	skuID              string
+	quantity           int
+	unitPrice          *float64
+	status             domain.OrderStatus
+	placedAt           time.Time
+	destinationCountry string
+}
+
+// importGroup은 외부 주문 번호로 묶은 주문 하나의 행을 정의합니다.
+type importGroup struct {
+	externalRef string
+	lines       []int
+	rows        []importRow
+	failed      bool
+}
+
+// ImportOrders는 CSV 파일에서 주문을 가져옵니다.
+// 한 행이 주문 항목 하나이며 같은 external_ref의 행을 한 주문으로 묶습니다.
+// 주문은 BatchSize개씩 한 트랜잭션으로 저장하고, 이미 가져온 external_ref는 건너뛰므로 같은 파일을 다시 가져와도 안전합니다.
+// 결제 대기 주문은 새 주문처럼 재고를 예약하고, 그 밖의 상태는 이력으로만 기록하여 재고를 건드리지 않습니다.
+// 저장 중 오류가 나면 이미 커밋된 배치까지의 결과를 오류와 함께 반환합니다.
+func (uc *OrderUseCase) ImportOrders(ctx context.Context, r io.Reader, opts ImportOptions) (*ImportResult, error) {
+	if opts.BatchSize <= 0 || opts.BatchSize > maxImportBatchSize {
+		opts.BatchSize = defaultImportBatchSize
+	}
+
+	result := &ImportResult{DryRun: opts.DryRun, Orders: []ImportOrderResult{}, Errors: []ImportRowError{}}
+	groups, err := parseImportFile(r, result)
+	if err != nil {
+		return nil, err
+	}
+
+	customers := map[string]*Customer{}
+	for start := 0; start < len(groups); start += opts.BatchSize {
+		end := start + opts.BatchSize
+		if end > len(groups) {
+			end = len(groups)
+		}
+		if err := uc.importBatch(ctx, groups[start:end], customers, opts.DryRun, result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// parseImportFile은 CSV를 읽어 외부 주문 번호별로 행을 묶습니다.
+// 행 단위 형식 오류는 결과에 기록하고, 헤더가 잘못되었거나 CSV를 읽을 수 없으면 ErrInvalidImportFile을 반환합니다.
+func parseImportFile(r io.Reader, result *ImportResult) ([]*importGroup, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read header: %v", ErrInvalidImportFile, err)
+	}
+	index := map[string]int{}
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, column := range importColumns {
+		if _, ok := index[column.name]; !ok && column.required {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidImportFile, column.name)
+		}
+	}
+
+	groups := []*importGroup{}
+	byRef := map[string]*importGroup{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		field := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		ref := field("external_ref")
+		if ref == "" {
+			result.Errors = append(result.Errors, ImportRowError{Line: line, Message: "external_ref is required"})
+			continue
+		}
+		group, ok := byRef[ref]
+		if !ok {
+			group = &importGroup{externalRef: ref}
+			byRef[ref] = group
+			groups = append(groups, group)
+		}
+		group.lines = append(group.lines, line)
+
+		row, err := parseImportRow(line, field)
+		if err == nil && len(group.rows) > 0 {
+			err = sameImportOrder(group.rows[0], row)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, ImportRowError{Line: line, ExternalRef: ref, Message: err.Error()})
+			group.failed = true
+			continue
+		}
+		group.rows = append(group.rows, row)
+	}
+	return groups, nil
+}
+
+// parseImportRow는 CSV 행의 값을 변환하고 형식을 검증합니다.
+func parseImportRow(line int, field func(string) string) (importRow, error) {
+	row := importRow{
+		line:               line,
+		customerID:         field("customer_id"),
+		productID:          field("product_id"),
+		skuID:              field("sku_id"),
+		status:             domain.StatusPending,
+		destinationCountry: strings.ToUpper(field("destination_country")),
+	}
+	if row.customerID == "" {
+		return row, errors.New("customer_id is required")
+	}
+	if row.productID == "" || row.skuID == "" {
+		return row, errors.New("product_id and sku_id are required")
+	}
+
+	quantity, err := strconv.Atoi(field("quantity"))
+	if err != nil || quantity <= 0 {
+		return row, fmt.Errorf("invalid quantity %q", field("quantity"))
+	}
+	row.quantity = quantity
+
+	if value := field("unit_price"); value != "" {
+		price, err := strconv.ParseFloat(value, 64)
+		if err != nil || price < 0 {
+			return row, fmt.Errorf("invalid unit_price %q", value)
+		}
+		row.unitPrice = &price
+	}
+
+	if value := field("status"); value != "" {
+		row.status = domain.OrderStatus(strings.ToLower(value))
+	}
+
+	if value := field("placed_at"); value != "" {
+		placedAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			placedAt, err = time.Parse("2006-01-02", value)
+		}
+		if err != nil {
+			return row, fmt.Errorf("invalid placed_at %q (use RFC 3339 or YYYY-MM-DD)", value)
+		}
+		row.placedAt = placedAt
+	}
+	return row, nil
+}
+
+// sameImportOrder는 같은 주문의 행끼리 주문 단위 값이 일치하는지 확인합니다.
+func sameImportOrder(first, row importRow) error {
+	if row.customerID != first.customerID ||
+		row.status != first.status ||
+		!row.placedAt.Equal(first.placedAt) ||
+		row.destinationCountry != first.destinationCountry {
+		return fmt.Errorf("customer_id, status, placed_at and destination_country must match line %d of the same order", first.line)
+	}
+	return nil
+}
+
+// importBatch는 주문 묶음을 검증하고 미리보기가 아니면 한 트랜잭션으로 저장합니다.
+func (uc *OrderUseCase) importBatch(ctx context.Context, groups []*importGroup, customers map[string]*Customer, dryRun bool, result *ImportResult) error {
+	refs := make([]string, len(groups))
+	for i, group := range groups {
+		refs[i] = group.externalRef
+	}
+	existing, err := uc.repo.FindImportedOrderIDs(ctx, refs)
+	if err != nil {
+		return err
+	}
+
+	batch := []ImportedOrder{}
+	// reserved는 이 묶음에서 재고를 예약한 결제 대기 주문의 외부 주문 번호입니다
+	reserved := map[string]bool{}
+	for _, group := range groups {
+		if orderID, ok := existing[group.externalRef]; ok {
+			result.record(group.externalRef, orderID, ImportSkipped)
+			continue
+		}
+		if group.failed {
+			result.record(group.externalRef, "", ImportFailed)
+			continue
+		}
+
+		order, err := uc.buildImportedOrder(ctx, group.rows, customers)
+		if err == nil && !dryRun && order.Status() == domain.StatusPending {
+			// 결제 대기 주문은 새 주문과 같이 재고를 예약합니다
+			err = uc.stock.Reserve(ctx, order.ID(), stockLines(order))
+			reserved[group.externalRef] = err == nil
+		}
+		if err != nil {
+			result.fail(group, err)
+			continue
+		}
+
+		if dryRun {
+			result.record(group.externalRef, order.ID(), ImportValid)
+			continue
+		}
+		batch = append(batch, ImportedOrder{ExternalRef: group.externalRef, Order: order})
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+
+	conflicts, err := uc.repo.SaveImportedOrders(ctx, batch)
+	if err != nil {
+		for _, imported := range batch {
+			uc.releaseImported(ctx, imported, reserved)
+		}
+		return err
+	}
+
+	// 동시에 실행된 다른 가져오기가 먼저 저장한 주문은 건너뜁니다
+	skipped := map[string]bool{}
+	for _, ref := range conflicts {
+		skipped[ref] = true
+	}
+	for _, imported := range batch {
+		if skipped[imported.ExternalRef] {
+			uc.releaseImported(ctx, imported, reserved)
+			result.record(imported.ExternalRef, "", ImportSkipped)
+			continue
+		}
+		result.record(imported.ExternalRef, imported.Order.ID(), ImportCreated)
+	}
+	return nil
+}
+
+// releaseImported는 저장하지 못한 결제 대기 주문의 재고 예약을 해제합니다.
+// 해제에 실패한 예약은 재고 예약 만료 작업이 정리합니다.
+func (uc *OrderUseCase) releaseImported(ctx context.Context, imported ImportedOrder, reserved map[string]bool) {
+	if reserved[imported.ExternalRef] {
+		_ = uc.stock.Release(ctx, imported.Order.ID())
+	}
+}
+
+// buildImportedOrder는 한 주문의 행으로 도메인 주문을 만들고 고객, 상품과 세금을 검증합니다.
+// 단가가 비어 있으면 카탈로그 가격을 사용합니다.
+func (uc *OrderUseCase) buildImportedOrder(ctx context.Context, rows []importRow, customers map[string]*Customer) (*domain.Order, error) {
+	first := rows[0]
+	customer, ok := customers[first.customerID]
+	if !ok {
+		var err error
+		customer, err = uc.customers.FindCustomer(ctx, first.customerID)
+		if err != nil {
+			return nil, err
+		}
+		customers[first.customerID] = customer
+	}
+	// 이전 주문 이력은 비활성 회원의 것이어도 가져오지만, 새로 처리할 결제 대기 주문은 활성 회원만 가능합니다
+	if first.status == domain.StatusPending && !customer.Active {
+		return nil, ErrCustomerInactive
+	}
+
+	items := make([]*domain.OrderItem, 0, len(rows))
+	for _, row := range rows {
+		product, err := uc.catalog.FindProduct(ctx, row.productID, row.skuID)
+		if err != nil {
+			return nil, err
+		}
+		price := product.Price
+		if row.unitPrice != nil {
+			price = *row.unitPrice
+		}
+		items = append(items, domain.NewOrderItem(product.ProductID, product.SKUID, product.Name, product.TaxCategory, price, row.quantity))
+	}
+
+	order, err := domain.NewOrder(customer.ID, items)
+	if err != nil {
+		return nil, err
+	}
+	order.SnapshotCustomer(customer.Name, customer.Email)
+	// 가격은 결제 대기 상태에서만 계산할 수 있으므로 가져온 상태를 기록하기 전에 세금을 적용합니다
+	if err := uc.priceOrder(ctx, order, first.destinationCountry, nil); err != nil {
+		return nil, err
+	}
+	if err := order.Import(first.status, first.placedAt); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// record는 주문 하나의 처리 결과를 보고서에 추가합니다.
+func (r *ImportResult) record(externalRef, orderID string, outcome ImportOutcome) {
+	switch outcome {
+	case ImportCreated:
+		r.Created++
+	case ImportValid:
+		r.Valid++
+	case ImportSkipped:
+		r.Skipped++
+	case ImportFailed:
+		r.Failed++
+	}
+	r.Orders = append(r.Orders, ImportOrderResult{ExternalRef: externalRef, OrderID: orderID, Outcome: outcome})
+}
+
+// fail은 주문 단위 검증 오류를 그 주문의 모든 행에 기록합니다.
+func (r *ImportResult) fail(group *importGroup, err error) {
+	for _, line := range group.lines {
+		r.Errors = append(r.Errors, ImportRowError{Line: line, ExternalRef: group.externalRef, Message: err.Error()})
+	}
+	r.record(group.externalRef, "", ImportFailed)
+}
@@ -2,6 +2,8 @@ package application
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"example.com/myapp/order/domain"
 )
@@ -11,35 +13,281 @@ type OrderRepository interface {
 	Save(ctx context.Context, order *domain.Order) error
 	FindByID(ctx context.Context, id string) (*domain.Order, error)
 	FindByCustomerID(ctx context.Context, customerID string) ([]*domain.Order, error)
+	// Update는 주문 상태를 저장하고 아직 저장되지 않은 상태 전환 이력을 추가합니다.
 	Update(ctx context.Context, order *domain.Order) error
 	Delete(ctx context.Context, id string) error
+	// FindStatusHistory는 주문의 상태 전환 이력을 시간 순서대로 조회합니다.
+	FindStatusHistory(ctx context.Context, orderID string) ([]*domain.StatusChange, error)
+	// ClaimExpiredPendingOrders는 cutoff 이전에 생성된 결제 대기 주문을 최대 limit건 선점하고 ID를 반환합니다.
+	// 선점한 주문은 lease 동안 다른 인스턴스의 조회에서 제외됩니다.
+	ClaimExpiredPendingOrders(ctx context.Context, cutoff time.Time, lease time.Duration, limit int) ([]string, error)
+	// Search는 검색 조건에 맞는 주문을 정렬 순서대로 after 다음부터 최대 limit건 조회합니다.
+	Search(ctx context.Context, criteria OrderSearchCriteria, after *OrderCursor, limit int) ([]*domain.Order, error)
+	// FindImportedOrderIDs는 이미 가져온 외부 주문 번호와 해당 주문 ID를 반환합니다.
+	FindImportedOrderIDs(ctx context.Context, externalRefs []string) (map[string]string, error)
+	// SaveImportedOrders는 가져온 주문들을 한 트랜잭션으로 저장하고,
+	// 그사이 다른 가져오기가 먼저 저장하여 건너뛴 외부 주문 번호를 반환합니다.
+	SaveImportedOrders(ctx context.Context, orders []ImportedOrder) ([]string, error)
+}
+
+// CustomerDirectory는 주문 고객을 확인하는 회원 포트를 정의합니다.
+// 고객이 없으면 ErrCustomerNotFound를 반환해야 합니다.
+type CustomerDirectory interface {
+	FindCustomer(ctx context.Context, customerID string) (*Customer, error)
+}
+
+// Customer는 주문 시점에 스냅샷할 고객 정보와 주문 가능 여부를 정의합니다.
+type Customer struct {
+	ID     string
+	Name   string
+	Email  string
+	Active bool
+}
+
+// ProductCatalog는 주문 항목의 상품 정보를 조회하는 카탈로그 포트를 정의합니다.
+type ProductCatalog interface {
+	FindProduct(ctx context.Context, productID, skuID string) (*CatalogProduct, error)
+}
+
+// CatalogProduct는 주문 시점에 스냅샷할 권위 있는 상품 정보를 정의합니다.
+type CatalogProduct struct {
+	ProductID   string
+	SKUID       string
+	Name        string
+	TaxCategory string
+	Price       float64
+}
+
+// StockReserver는 주문 수명주기에 맞춰 재고를 예약·확정·해제하는 재고 포트를 정의합니다.
+// 모든 메서드는 같은 주문에 대해 여러 번 호출되어도 안전해야 합니다.
+type StockReserver interface {
+	Reserve(ctx context.Context, orderID string, lines []StockLine) error
+	Confirm(ctx context.Context, orderID string) error
+	Commit(ctx context.Context, orderID string) error
+	Release(ctx context.Context, orderID string) error
+	// Adjust는 주문 라인 변경이나 부분 취소 후 남은 라인으로 예약 수량을 맞춥니다.
+	Adjust(ctx context.Context, orderID string, lines []StockLine) error
+}
+
+// StockLine은 재고 예약이 필요한 SKU와 수량을 정의합니다.
+type StockLine struct {
+	SKUID    string
+	Quantity int
+}
+
+// DiscountEngine은 쿠폰 할인을 계산하고 사용을 기록하는 프로모션 포트를 정의합니다.
+// Redeem과 Release는 같은 주문에 대해 여러 번 호출되어도 안전해야 합니다.
+type DiscountEngine interface {
+	Quote(ctx context.Context, req DiscountQuoteRequest) ([]AppliedDiscount, error)
+	Redeem(ctx context.Context, orderID, customerID string, discounts []AppliedDiscount) error
+	Release(ctx context.Context, orderID string) error
+}
+
+// DiscountQuoteRequest는 할인 계산에 필요한 주문 정보를 정의합니다.
+type DiscountQuoteRequest struct {
+	// OrderID의 쿠폰 사용 기록은 사용 한도에서 제외되어, 주문을 수정하며 다시 계산해도 자신의 사용 때문에 거부되지 않습니다.
+	OrderID     string
+	CustomerID  string
+	FirstOrder  bool
+	CouponCodes []string
+	Lines       []DiscountLine
+	// ShippingFee는 배송비 할인 쿠폰이 깎을 수 있는 주문의 배송비입니다.
+	ShippingFee float64
+}
+
+// DiscountLine은 할인 계산 대상 주문 라인을 정의합니다.
+type DiscountLine struct {
+	ProductID string
+	SKUID     string
+	UnitPrice float64
+	Quantity  int
+}
+
+// AppliedDiscount는 주문에 적용될 할인 한 건을 정의합니다.
+type AppliedDiscount struct {
+	Code        string
+	Kind        string
+	Description string
+	Amount      float64
+}
+
+// TaxCalculator는 주문 라인별 세액을 계산하는 세금 포트를 정의합니다.
+type TaxCalculator interface {
+	Calculate(ctx context.Context, req TaxRequest) (*TaxResult, error)
+}
+
+// TaxRequest는 세금 계산에 필요한 배송 국가와 주문 라인을 정의합니다.
+type TaxRequest struct {
+	Country string
+	Lines   []TaxLine
+}
+
+// TaxLine은 세금 계산 대상 주문 라인을 정의합니다.
+// Amount는 쿠폰 할인을 배분하여 차감한 과세 대상 금액입니다.
+type TaxLine struct {
+	TaxCategory string
+	Amount      float64
+}
+
+// TaxResult는 라인 순서대로 계산된 세액과 세금 포함 가격 여부를 정의합니다.
+type TaxResult struct {
+	Inclusive bool
+	LineTaxes []float64
+}
+
+// ShippingRater는 주문의 배송비를 계산하는 배송비 포트를 정의합니다.
+type ShippingRater interface {
+	Rate(ctx context.Context, req ShippingRateRequest) (float64, error)
+}
+
+// ShippingRateRequest는 배송비 계산에 필요한 배송 국가와 할인 전 상품 금액, 수량을 정의합니다.
+type ShippingRateRequest struct {
+	Country  string
+	Subtotal float64
+	Quantity int
+}
+
+// QuoteSigner는 가격 견적 ID에 서명하고 서명을 검증하는 포트를 정의합니다.
+type QuoteSigner interface {
+	Sign(payload []byte) []byte
+	Verify(payload, signature []byte) bool
 }
 
 // OrderService는 주문 관련 비즈니스 로직을 정의합니다.
 type OrderService interface {
-	CreateOrder(ctx context.Context, customerID string, items []OrderItemRequest) (*domain.Order, error)
+	CreateOrder(ctx context.Context, req CreateOrderRequest) (*domain.Order, error)
+	// QuoteOrder는 주문을 저장하지 않고 가격을 계산하여 짧은 시간 동안 유효한 견적 ID와 함께 반환합니다.
+	QuoteOrder(ctx context.Context, req CreateOrderRequest) (*OrderQuote, error)
 	GetOrder(ctx context.Context, id string) (*domain.Order, error)
 	GetCustomerOrders(ctx context.Context, customerID string) ([]*domain.Order, error)
-	UpdateOrderStatus(ctx context.Context, id string, status domain.OrderStatus) (*domain.Order, error)
-	CancelOrder(ctx context.Context, id string) (*domain.Order, error)
+	// UpdateOrderStatus는 주문 상태를 변경하고 변경한 행위자와 사유를 이력에 기록합니다.
+	UpdateOrderStatus(ctx context.Context, id string, status domain.OrderStatus, actor, reason string) (*domain.Order, error)
+	CancelOrder(ctx context.Context, id string, actor, reason string) (*domain.Order, error)
+	GetOrderHistory(ctx context.Context, id string) ([]*domain.StatusChange, error)
+
+	// 결제 전 주문 항목 변경 (할인, 세금과 재고 예약을 다시 계산합니다)
+	AddOrderItem(ctx context.Context, orderID string, req OrderItemRequest) (*domain.Order, error)
+	ChangeOrderItemQuantity(ctx context.Context, orderID, itemID string, quantity int) (*domain.Order, error)
+	RemoveOrderItem(ctx context.Context, orderID, itemID string) (*domain.Order, error)
+
+	// CancelOrderItems는 결제된 주문의 항목 일부를 취소하고 환불할 금액을 반환합니다.
+	CancelOrderItems(ctx context.Context, orderID string, req CancelItemsRequest) (*ItemCancellation, error)
+
+	// ReturnOrderItems는 배송 완료된 주문의 반품 수량을 기록하고 환불할 금액을 반환합니다.
+	ReturnOrderItems(ctx context.Context, orderID string, req ReturnItemsRequest) (*ItemReturn, error)
+
+	// ExpirePendingOrders는 cutoff 이전에 생성되어 아직 결제되지 않은 주문을 취소하고 취소한 주문 목록을 반환합니다.
+	// 오류가 나도 그 전까지 취소한 주문은 함께 반환합니다.
+	ExpirePendingOrders(ctx context.Context, cutoff time.Time) ([]*domain.Order, error)
+
+	// 비회원 주문 (주문 조회 토큰으로 조회와 취소, 이후 회원 계정에 연결)
+	CreateGuestOrder(ctx context.Context, req CreateGuestOrderRequest) (*GuestOrder, error)
+	GetGuestOrder(ctx context.Context, orderID, token string) (*domain.Order, error)
+	CancelGuestOrder(ctx context.Context, orderID, token, reason string) (*domain.Order, error)
+	AttachGuestOrder(ctx context.Context, orderID, token, customerID string) (*domain.Order, error)
+
+	// 관리자 주문 검색과 내보내기
+	SearchOrders(ctx context.Context, criteria OrderSearchCriteria) (*OrderPage, error)
+	ExportOrders(ctx context.Context, criteria OrderSearchCriteria, fn func(*domain.Order) error) error
+
+	// ImportOrders는 CSV 파일의 주문을 외부 주문 번호 기준으로 중복 없이 가져오고 행별 오류 보고서를 반환합니다.
+	ImportOrders(ctx context.Context, r io.Reader, opts ImportOptions) (*ImportResult, error)
+}
+
+// CancelItemsRequest는 결제 후 부분 취소 요청 정보를 정의합니다.
+type CancelItemsRequest struct {
+	Items  []CancelItemRequest
+	Actor  string
+	Reason string
+}
+
+// CancelItemRequest는 취소할 주문 항목과 수량을 정의합니다.
+type CancelItemRequest struct {
+	ItemID   string
+	Quantity int
+}
+
+// ItemCancellation은 부분 취소 결과와 환불할 금액을 정의합니다.
+type ItemCancellation struct {
+	Order        *domain.Order
+	RefundAmount float64
+}
+
+// ReturnItemsRequest는 반품 검수를 마친 항목의 반품 기록 요청 정보를 정의합니다.
+type ReturnItemsRequest struct {
+	Items  []ReturnItemRequest
+	Actor  string
+	Reason string
+}
+
+// ReturnItemRequest는 반품할 주문 항목과 수량을 정의합니다.
+type ReturnItemRequest struct {
+	ItemID   string
+	Quantity int
+}
+
+// ItemReturn은 반품 기록 결과와 환불할 금액을 정의합니다.
+type ItemReturn struct {
+	Order        *domain.Order
+	RefundAmount float64
+}
+
+// CreateOrderRequest는 주문 생성 요청 정보를 정의합니다.
+// 배송 국가를 생략하면 domain.DefaultDestinationCountry로 세금을 계산합니다.
+// QuoteID가 있으면 요청이 견적과 같은지 확인하고 견적 당시의 가격, 할인과 세액으로 주문합니다.
+type CreateOrderRequest struct {
+	CustomerID         string
+	Items              []OrderItemRequest
+	CouponCodes        []string
+	DestinationCountry string
+	QuoteID            string
+}
+
+// CreateGuestOrderRequest는 비회원 주문 생성 요청 정보를 정의합니다.
+type CreateGuestOrderRequest struct {
+	Contact            domain.GuestContact
+	Items              []OrderItemRequest
+	CouponCodes        []string
+	DestinationCountry string
+	QuoteID            string
+}
+
+// GuestOrder는 생성된 비회원 주문과 한 번만 발급되는 주문 조회 토큰을 정의합니다.
+type GuestOrder struct {
+	Order       *domain.Order
+	LookupToken string
 }
 
 // OrderItemRequest는 주문 항목 생성 요청 정보를 정의합니다.
+// 상품명과 가격은 클라이언트가 아닌 카탈로그에서 결정됩니다.
 type OrderItemRequest struct {
 	ProductID string
-	Name      string
-	Price     float64
+	SKUID     string
 	Quantity  int
 }
 
 // OrderUseCase는 OrderService 구현체를 정의합니다.
 type OrderUseCase struct {
-	repo OrderRepository
+	repo      OrderRepository
+	customers CustomerDirectory
+	catalog   ProductCatalog
+	stock     StockReserver
+	discounts DiscountEngine
+	taxes     TaxCalculator
+	shipping  ShippingRater
+	quotes    QuoteSigner
 }
 
 // NewOrderUseCase는 새로운 OrderUseCase 인스턴스를 생성합니다.
-func NewOrderUseCase(repo OrderRepository) *OrderUseCase {
+func NewOrderUseCase(repo OrderRepository, customers CustomerDirectory, catalog ProductCatalog, stock StockReserver, discounts DiscountEngine, taxes TaxCalculator, shipping ShippingRater, quotes QuoteSigner) *OrderUseCase {
 	return &OrderUseCase{
-		repo: repo,
+		repo:      repo,
+		customers: customers,
+		catalog:   catalog,
+		stock:     stock,
+		discounts: discounts,
+		taxes:     taxes,
+		shipping:  shipping,
+		quotes:    quotes,
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,250 @@
+package application
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"example.com/myapp/order/domain"
+)
+
+// quoteTTL은 가격 견적을 주문에 사용할 수 있는 시간입니다.
+const quoteTTL = 15 * time.Minute
+
+var (
+	ErrInvalidQuote  = errors.New("invalid order quote")
+	ErrQuoteExpired  = errors.New("order quote has expired")
+	ErrQuoteMismatch = errors.New("order request does not match the quote")
+)
+
+// OrderQuote는 주문을 저장하지 않고 계산한 가격 견적을 정의합니다.
+// Order는 가격 계산에만 쓰인 저장되지 않은 주문이며, QuoteID를 CreateOrder에 전달하면 견적 가격으로 주문합니다.
+type OrderQuote struct {
+	Order     *domain.Order
+	QuoteID   string
+	ExpiresAt time.Time
+}
+
+// quotePayload는 견적 ID에 서명하여 담는 주문 요청과 계산된 가격입니다.
+type quotePayload struct {
+	CustomerID   string            `json:"customerId"`
+	Country      string            `json:"country"`
+	CouponCodes  []string          `json:"couponCodes,omitempty"`
+	Lines        []quoteLine       `json:"lines"`
+	ShippingFee  float64           `json:"shippingFee"`
+	Discounts    []AppliedDiscount `json:"discounts,omitempty"`
+	TaxInclusive bool              `json:"taxInclusive"`
+	Total        float64           `json:"total"`
+	ExpiresAt    int64             `json:"expiresAt"`
+}
+
+// quoteLine은 견적 당시의 주문 라인 가격입니다.
+type quoteLine struct {
+	ProductID   string  `json:"productId"`
+	SKUID       string  `json:"skuId"`
+	Name        string  `json:"name"`
+	TaxCategory string  `json:"taxCategory"`
+	Price       float64 `json:"price"`
+	Quantity    int     `json:"quantity"`
+	TaxAmount   float64 `json:"taxAmount"`
+}
+
+// QuoteOrder는 주문 생성과 같은 방식으로 가격, 배송비, 할인과 세금을 계산하지만 재고를 예약하거나 저장하지 않습니다.
+// 고객 ID가 없으면 비회원 주문 견적으로 보고 쿠폰을 적용하지 않습니다.
+func (uc *OrderUseCase) QuoteOrder(ctx context.Context, req CreateOrderRequest) (*OrderQuote, error) {
+	customerID := req.CustomerID
+	if customerID == "" && len(req.CouponCodes) > 0 {
+		return nil, ErrGuestCouponNotAllowed
+	}
+
+	var customer *Customer
+	if customerID != "" {
+		var err error
+		if customer, err = uc.activeCustomer(ctx, customerID); err != nil {
+			return nil, err
+		}
+	}
+
+	items, err := uc.orderItems(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := domain.NewOrder(customerID, items)
+	if err != nil {
+		return nil, err
+	}
+	if customer != nil {
+		order.SnapshotCustomer(customer.Name, customer.Email)
+	}
+
+	if err := uc.priceOrder(ctx, order, req.DestinationCountry, req.CouponCodes); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(quoteTTL)
+	quoteID, err := uc.signQuote(order, req.CouponCodes, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &OrderQuote{Order: order, QuoteID: quoteID, ExpiresAt: expiresAt}, nil
+}
+
+// signQuote는 가격이 계산된 주문을 서명된 견적 ID로 만듭니다.
+func (uc *OrderUseCase) signQuote(order *domain.Order, couponCodes []string, expiresAt time.Time) (string, error) {
+	payload := quotePayload{
+		CustomerID:   order.CustomerID(),
+		Country:      order.DestinationCountry(),
+		CouponCodes:  couponCodes,
+		Lines:        make([]quoteLine, len(order.Items())),
+		ShippingFee:  order.ShippingFee(),
+		Discounts:    appliedDiscounts(order),
+		TaxInclusive: order.TaxInclusive(),
+		Total:        order.TotalAmount(),
+		ExpiresAt:    expiresAt.Unix(),
+	}
+	for i, item := range order.Items() {
+		payload.Lines[i] = quoteLine{
+			ProductID:   item.ProductID(),
+			SKUID:       item.SKUID(),
+			Name:        item.Name(),
+			TaxCategory: item.TaxCategory(),
+			Price:       item.Price(),
+			Quantity:    item.Quantity(),
+			TaxAmount:   item.TaxAmount(),
+		}
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode order quote: %w", err)
+	}
+	encoding := base64.RawURLEncoding
+	return encoding.EncodeToString(data) + "." + encoding.EncodeToString(uc.quotes.Sign(data)), nil
+}
+
+// verifyQuote는 견적 ID의 서명과 유효 기간을 확인하고, 주문 요청이 견적과 같은지 확인합니다.
+func (uc *OrderUseCase) verifyQuote(quoteID, customerID string, items []OrderItemRequest, couponCodes []string, destinationCountry string) (*quotePayload, error) {
+	encoding := base64.RawURLEncoding
+	parts := strings.Split(quoteID, ".")
+	if len(parts) != 2 {
+		return nil, ErrInvalidQuote
+	}
+	data, err := encoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidQuote
+	}
+	signature, err := encoding.DecodeString(parts[1])
+	if err != nil || !uc.quotes.Verify(data, signature) {
+		return nil, ErrInvalidQuote
+	}
+
+	var payload quotePayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, ErrInvalidQuote
+	}
+	if time.Now().Unix() > payload.ExpiresAt {
+		return nil, ErrQuoteExpired
+	}
+
+	// 견적과 다른 고객, 배송 국가, 쿠폰이나 항목으로는 견적 가격을 쓸 수 없습니다
+	if destinationCountry == "" {
+		destinationCountry = domain.DefaultDestinationCountry
+	}
+	if payload.CustomerID != customerID ||
+		!strings.EqualFold(strings.TrimSpace(destinationCountry), payload.Country) ||
+		strings.Join(payload.CouponCodes, ",") != strings.Join(couponCodes, ",") ||
+		len(payload.Lines) != len(items) {
+		return nil, ErrQuoteMismatch
+	}
+	for i, item := range items {
+		line := payload.Lines[i]
+		if item.ProductID != line.ProductID || (item.SKUID != "" && item.SKUID != line.SKUID) || item.Quantity != line.Quantity {
+			return nil, ErrQuoteMismatch
+		}
+	}
+
+	return &payload, nil
+}
+
+// newOrderItems는 견적 ID가 있으면 요청이 견적과 같은지 확인하고 견적 라인으로, 없으면 카탈로그 가격으로 주문 항목을 만듭니다.
+func (uc *OrderUseCase) newOrderItems(ctx context.Context, quoteID, customerID string, reqs []OrderItemRequest, couponCodes []string, destinationCountry string) ([]*domain.OrderItem, *quotePayload, error) {
+	if quoteID == "" {
+		items, err := uc.orderItems(ctx, reqs)
+		return items, nil, err
+	}
+
+	quote, err := uc.verifyQuote(quoteID, customerID, reqs, couponCodes, destinationCountry)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, err := uc.quotedItems(ctx, quote)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, quote, nil
+}
+
+// finishOrder는 견적이 있으면 견적 가격을, 없으면 새로 계산한 가격을 주문에 적용하고 주문을 확정합니다.
+func (uc *OrderUseCase) finishOrder(ctx context.Context, order *domain.Order, quote *quotePayload, destinationCountry string, couponCodes []string) error {
+	if quote == nil {
+		return uc.placeOrder(ctx, order, destinationCountry, couponCodes)
+	}
+	if err := applyQuote(order, quote); err != nil {
+		return err
+	}
+	return uc.commitOrder(ctx, order)
+}
+
+// quotedItems는 견적 라인의 상품이 여전히 주문 가능한지 카탈로그에서 확인하고, 견적 당시 가격으로 주문 항목을 만듭니다.
+func (uc *OrderUseCase) quotedItems(ctx context.Context, quote *quotePayload) ([]*domain.OrderItem, error) {
+	items := make([]*domain.OrderItem, 0, len(quote.Lines))
+	for _, line := range quote.Lines {
+		if _, err := uc.catalog.FindProduct(ctx, line.ProductID, line.SKUID); err != nil {
+			return nil, err
+		}
+		items = append(items, domain.NewOrderItem(line.ProductID, line.SKUID, line.Name, line.TaxCategory, line.Price, line.Quantity))
+	}
+	return items, nil
+}
+
+// applyQuote는 견적의 배송 국가, 배송비, 할인과 세액을 다시 계산하지 않고 주문에 그대로 적용합니다.
+// 쿠폰 사용 한도는 주문을 저장할 때 다시 확인됩니다.
+func applyQuote(order *domain.Order, quote *quotePayload) error {
+	if err := order.ChangeDestinationCountry(quote.Country); err != nil {
+		return err
+	}
+	if err := order.ApplyShippingFee(quote.ShippingFee); err != nil {
+		return err
+	}
+
+	discounts := make([]*domain.OrderDiscount, 0, len(quote.Discounts))
+	for _, d := range quote.Discounts {
+		discount, err := domain.NewOrderDiscount(d.Code, d.Kind, d.Description, d.Amount)
+		if err != nil {
+			return err
+		}
+		discounts = append(discounts, discount)
+	}
+	if err := order.ApplyDiscounts(discounts); err != nil {
+		return err
+	}
+
+	taxes := make([]float64, len(quote.Lines))
+	for i, line := range quote.Lines {
+		taxes[i] = line.TaxAmount
+	}
+	if err := order.ApplyTaxes(taxes, quote.TaxInclusive); err != nil {
+		return err
+	}
+
+	if math.Abs(order.TotalAmount()-quote.Total) > 0.005 {
+		return ErrInvalidQuote
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package application
+
+import (
+	"context"
+)
+
+// ReturnOrderItems는 배송 완료된 주문의 반품 수량을 기록하고 환불할 금액을 계산합니다.
+// 반품된 상품은 검수 결과에 따라 처리되므로 재고 예약에는 반영하지 않습니다.
+func (uc *OrderUseCase) ReturnOrderItems(ctx context.Context, orderID string, req ReturnItemsRequest) (*ItemReturn, error) {
+	order, err := uc.repo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	quantities := make(map[string]int, len(req.Items))
+	for _, item := range req.Items {
+		quantities[item.ItemID] += item.Quantity
+	}
+
+	refund, err := order.ReturnItems(quantities, req.Actor, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return &ItemReturn{Order: order, RefundAmount: refund}, nil
+}
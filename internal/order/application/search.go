@@ -0,0 +1,175 @@
+package application
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"example.com/myapp/order/domain"
+)
+
+// OrderSortField는 주문 검색 결과의 정렬 기준을 정의합니다.
+type OrderSortField string
+
+const (
+	OrderSortCreatedAt   OrderSortField = "createdAt"
+	OrderSortUpdatedAt   OrderSortField = "updatedAt"
+	OrderSortTotalAmount OrderSortField = "totalAmount"
+)
+
+const (
+	defaultOrderSearchLimit = 20
+	maxOrderSearchLimit     = 100
+	// orderExportPageSize는 내보내기에서 한 번에 조회하는 주문 수입니다.
+	orderExportPageSize = 200
+)
+
+var (
+	ErrInvalidSortField = errors.New("invalid order sort field")
+	ErrInvalidCursor    = errors.New("invalid order search cursor")
+)
+
+// OrderSearchCriteria는 관리자 주문 검색 조건을 정의합니다.
+// 비어 있는 조건은 적용하지 않으며, 날짜 범위는 From 이상 To 미만입니다.
+// Cursor에는 이전 페이지의 NextCursor를 같은 정렬 조건과 함께 그대로 전달합니다.
+type OrderSearchCriteria struct {
+	Statuses    []domain.OrderStatus
+	CustomerID  string
+	ProductID   string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	UpdatedFrom time.Time
+	UpdatedTo   time.Time
+	MinAmount   float64
+	MaxAmount   float64
+	SortBy      OrderSortField
+	Descending  bool
+	Cursor      string
+	Limit       int
+}
+
+// OrderCursor는 직전 페이지 마지막 주문의 정렬 값과 ID로 다음 페이지의 시작 위치를 나타냅니다.
+// Value는 날짜 정렬이면 time.Time, 금액 정렬이면 float64입니다.
+type OrderCursor struct {
+	Value interface{}
+	ID    string
+}
+
+// OrderPage는 주문 검색 결과 한 페이지를 정의합니다.
+// 다음 페이지가 없으면 NextCursor는 비어 있습니다.
+type OrderPage struct {
+	Orders     []*domain.Order
+	NextCursor string
+}
+
+// SearchOrders는 조건에 맞는 주문을 정렬 기준과 커서에 따라 한 페이지씩 조회합니다.
+func (uc *OrderUseCase) SearchOrders(ctx context.Context, criteria OrderSearchCriteria) (*OrderPage, error) {
+	if criteria.Limit <= 0 || criteria.Limit > maxOrderSearchLimit {
+		criteria.Limit = defaultOrderSearchLimit
+	}
+	return uc.searchPage(ctx, criteria)
+}
+
+// ExportOrders는 조건에 맞는 모든 주문을 정렬 순서대로 fn에 전달합니다.
+// 주문을 페이지 단위로 나누어 조회하므로 결과가 많아도 전체를 메모리에 올리지 않습니다.
+func (uc *OrderUseCase) ExportOrders(ctx context.Context, criteria OrderSearchCriteria, fn func(*domain.Order) error) error {
+	criteria.Limit = orderExportPageSize
+	for {
+		page, err := uc.searchPage(ctx, criteria)
+		if err != nil {
+			return err
+		}
+		for _, order := range page.Orders {
+			if err := fn(order); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		criteria.Cursor = page.NextCursor
+	}
+}
+
+// searchPage는 검색 조건을 검증하고 Limit 건의 주문과 다음 페이지 커서를 조회합니다.
+func (uc *OrderUseCase) searchPage(ctx context.Context, criteria OrderSearchCriteria) (*OrderPage, error) {
+	if criteria.SortBy == "" {
+		criteria.SortBy = OrderSortCreatedAt
+	}
+	if criteria.SortBy != OrderSortCreatedAt && criteria.SortBy != OrderSortUpdatedAt && criteria.SortBy != OrderSortTotalAmount {
+		return nil, ErrInvalidSortField
+	}
+
+	transitions := domain.StatusTransitions()
+	for _, status := range criteria.Statuses {
+		if _, ok := transitions[status]; !ok {
+			return nil, domain.ErrInvalidOrderStatus
+		}
+	}
+
+	var after *OrderCursor
+	if criteria.Cursor != "" {
+		cursor, err := decodeOrderCursor(criteria.Cursor, criteria.SortBy)
+		if err != nil {
+			return nil, err
+		}
+		after = cursor
+	}
+
+	// 다음 페이지가 있는지 알기 위해 한 건 더 조회합니다
+	orders, err := uc.repo.Search(ctx, criteria, after, criteria.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &OrderPage{Orders: orders}
+	if len(orders) > criteria.Limit {
+		page.Orders = orders[:criteria.Limit]
+		page.NextCursor = encodeOrderCursor(page.Orders[criteria.Limit-1], criteria.SortBy)
+	}
+	return page, nil
+}
+
+// encodeOrderCursor는 주문의 정렬 값과 ID를 URL에 안전한 불투명 문자열로 만듭니다.
+func encodeOrderCursor(order *domain.Order, sortBy OrderSortField) string {
+	var value string
+	switch sortBy {
+	case OrderSortUpdatedAt:
+		value = order.UpdatedAt().UTC().Format(time.RFC3339Nano)
+	case OrderSortTotalAmount:
+		value = strconv.FormatFloat(order.TotalAmount(), 'f', -1, 64)
+	default:
+		value = order.CreatedAt().UTC().Format(time.RFC3339Nano)
+	}
+	raw := strings.Join([]string{string(sortBy), value, order.ID()}, "|")
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeOrderCursor는 커서를 해석합니다. 다른 정렬 기준으로 만든 커서는 받지 않습니다.
+func decodeOrderCursor(cursor string, sortBy OrderSortField) (*OrderCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	parts := strings.SplitN(string(raw), "|", 3)
+	if len(parts) != 3 || OrderSortField(parts[0]) != sortBy || parts[2] == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	if sortBy == OrderSortTotalAmount {
+		amount, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return &OrderCursor{Value: amount, ID: parts[2]}, nil
+	}
+
+	at, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &OrderCursor{Value: at, ID: parts[2]}, nil
+}
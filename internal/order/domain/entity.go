@@ -2,6 +2,8 @@ package domain
 
 import (
 	"errors"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,38 +13,159 @@ import (
 type OrderStatus string
 
 const (
-	StatusPending   OrderStatus = "pending"
-	StatusPaid      OrderStatus = "paid"
-	StatusShipped   OrderStatus = "shipped"
-	StatusDelivered OrderStatus = "delivered"
-	StatusCanceled  OrderStatus = "canceled"
+	StatusPending           OrderStatus = "pending"
+	StatusPaid              OrderStatus = "paid"
+	StatusShipped           OrderStatus = "shipped"
+	StatusDelivered         OrderStatus = "delivered"
+	StatusCanceled          OrderStatus = "canceled"
+	StatusPartiallyReturned OrderStatus = "partially_returned"
+	StatusReturned          OrderStatus = "returned"
 )
 
 var (
-	ErrInvalidOrderAmount   = errors.New("invalid order amount")
-	ErrInvalidOrderItems    = errors.New("order must have at least one item")
-	ErrInvalidOrderStatus   = errors.New("invalid order status")
-	ErrOrderNotFound        = errors.New("order not found")
+	ErrInvalidOrderAmount    = errors.New("invalid order amount")
+	ErrInvalidOrderItems     = errors.New("order must have at least one item")
+	ErrInvalidOrderStatus    = errors.New("invalid order status")
+	ErrOrderNotFound         = errors.New("order not found")
+	ErrOrderConflict         = errors.New("order was modified concurrently")
 	ErrOrderStatusTransition = errors.New("invalid order status transition")
+	ErrInvalidItemQuantity   = errors.New("order item quantity must be positive")
+	ErrInvalidDiscount       = errors.New("invalid order discount")
+	ErrInvalidTax            = errors.New("invalid order tax")
+	ErrInvalidShippingFee    = errors.New("invalid order shipping fee")
+	ErrInvalidCountry        = errors.New("destination country must be a two-letter ISO code")
+	ErrOrderItemNotFound     = errors.New("order item not found")
+	ErrOrderNotEditable      = errors.New("order items can only be edited before payment")
+	ErrInvalidCancelQuantity = errors.New("cancel quantity must be positive and not exceed the remaining item quantity")
+	ErrOrderNotReturnable    = errors.New("only delivered orders can be returned")
+	ErrInvalidReturnQuantity = errors.New("return quantity must be positive and not exceed the unreturned item quantity")
 )
 
+// ActorSystem은 행위자를 알 수 없는 상태 변경(백그라운드 작업 등)에 기록되는 행위자입니다.
+const ActorSystem = "system"
+
+// ReasonExpired는 결제 기한이 지나 자동 취소된 주문의 상태 변경 사유입니다.
+const ReasonExpired = "expired"
+
+// statusTransitions는 상태별로 전환할 수 있는 다음 상태를 정의합니다.
+// 배송 완료된 주문은 반품으로만 상태가 바뀌며, 반품 완료와 취소는 최종 상태입니다.
+var statusTransitions = map[OrderStatus][]OrderStatus{
+	StatusPending:           {StatusPaid, StatusCanceled},
+	StatusPaid:              {StatusShipped, StatusCanceled},
+	StatusShipped:           {StatusDelivered, StatusCanceled},
+	StatusDelivered:         {StatusPartiallyReturned, StatusReturned},
+	StatusPartiallyReturned: {StatusReturned},
+	StatusReturned:          {},
+	StatusCanceled:          {},
+}
+
+// StatusTransitions는 주문 상태 전환 규칙 표를 반환합니다.
+// 반환된 맵은 복사본이므로 수정해도 규칙에 영향을 주지 않습니다.
+func StatusTransitions() map[OrderStatus][]OrderStatus {
+	transitions := make(map[OrderStatus][]OrderStatus, len(statusTransitions))
+	for from, to := range statusTransitions {
+		transitions[from] = append([]OrderStatus{}, to...)
+	}
+	return transitions
+}
+
+// StatusChange는 주문 상태 전환 이력 한 건을 나타냅니다.
+// 주문 생성 시 기록되는 첫 이력의 이전 상태는 빈 값입니다.
+type StatusChange struct {
+	id        string
+	from      OrderStatus
+	to        OrderStatus
+	actor     string
+	reason    string
+	changedAt time.Time
+}
+
+// newStatusChange는 새로운 상태 전환 이력을 생성합니다. 행위자가 없으면 ActorSystem으로 기록합니다.
+func newStatusChange(from, to OrderStatus, actor, reason string, changedAt time.Time) *StatusChange {
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		actor = ActorSystem
+	}
+	return &StatusChange{
+		id:        uuid.New().String(),
+		from:      from,
+		to:        to,
+		actor:     actor,
+		reason:    strings.TrimSpace(reason),
+		changedAt: changedAt,
+	}
+}
+
+// RestoreStatusChange는 저장된 데이터로부터 상태 전환 이력을 복원합니다.
+func RestoreStatusChange(id string, from, to OrderStatus, actor, reason string, changedAt time.Time) *StatusChange {
+	return &StatusChange{
+		id:        id,
+		from:      from,
+		to:        to,
+		actor:     actor,
+		reason:    reason,
+		changedAt: changedAt,
+	}
+}
+
+// ID는 이력의 고유 식별자를 반환합니다.
+func (c *StatusChange) ID() string {
+	return c.id
+}
+
+// From은 전환 전 상태를 반환합니다.
+func (c *StatusChange) From() OrderStatus {
+	return c.from
+}
+
+// To는 전환 후 상태를 반환합니다.
+func (c *StatusChange) To() OrderStatus {
+	return c.to
+}
+
+// Actor는 상태를 변경한 행위자를 반환합니다.
+func (c *StatusChange) Actor() string {
+	return c.actor
+}
+
+// Reason은 상태 변경 사유를 반환합니다.
+func (c *StatusChange) Reason() string {
+	return c.reason
+}
+
+// ChangedAt은 상태가 변경된 시간을 반환합니다.
+func (c *StatusChange) ChangedAt() time.Time {
+	return c.changedAt
+}
+
+// DefaultDestinationCountry는 배송 국가가 지정되지 않은 주문에 사용하는 국가 코드입니다.
+const DefaultDestinationCountry = "KR"
+
 // OrderItem은 주문 항목을 나타냅니다.
 type OrderItem struct {
-	id        string
-	productID string
-	name      string
-	price     float64
-	quantity  int
+	id               string
+	productID        string
+	skuID            string
+	name             string
+	taxCategory      string
+	price            float64
+	quantity         int
+	canceledQuantity int
+	returnedQuantity int
+	taxAmount        float64
 }
 
 // NewOrderItem은 새로운 주문 항목을 생성합니다.
-func NewOrderItem(productID, name string, price float64, quantity int) *OrderItem {
+// 상품명, 가격과 과세 유형은 주문 시점의 카탈로그 정보를 스냅샷으로 보관합니다.
+func NewOrderItem(productID, skuID, name, taxCategory string, price float64, quantity int) *OrderItem {
 	return &OrderItem{
-		id:        uuid.New().String(),
-		productID: productID,
-		name:      name,
-		price:     price,
-		quantity:  quantity,
+		id:          uuid.New().String(),
+		productID:   productID,
+		skuID:       skuID,
+		name:        name,
+		taxCategory: taxCategory,
+		price:       price,
+		quantity:    quantity,
 	}
 }
 
@@ -56,35 +179,134 @@ func (i *OrderItem) ProductID() string {
 	return i.productID
 }
 
+// SKUID는 주문한 SKU ID를 반환합니다.
+func (i *OrderItem) SKUID() string {
+	return i.skuID
+}
+
 // Name은 상품 이름을 반환합니다.
 func (i *OrderItem) Name() string {
 	return i.name
 }
 
+// TaxCategory는 상품의 과세 유형을 반환합니다.
+func (i *OrderItem) TaxCategory() string {
+	return i.taxCategory
+}
+
 // Price는 상품 단가를 반환합니다.
 func (i *OrderItem) Price() float64 {
 	return i.price
 }
 
-// Quantity는 주문 수량을 반환합니다.
+// Quantity는 취소되지 않은 주문 수량을 반환합니다.
 func (i *OrderItem) Quantity() int {
 	return i.quantity
 }
 
+// CanceledQuantity는 결제 후 부분 취소된 수량을 반환합니다.
+func (i *OrderItem) CanceledQuantity() int {
+	return i.canceledQuantity
+}
+
+// ReturnedQuantity는 배송 완료 후 반품된 수량을 반환합니다. 반품된 수량도 Quantity에 포함됩니다.
+func (i *OrderItem) ReturnedQuantity() int {
+	return i.returnedQuantity
+}
+
 // Subtotal은 상품별 소계를 반환합니다.
 func (i *OrderItem) Subtotal() float64 {
 	return i.price * float64(i.quantity)
 }
 
+// TaxAmount는 항목에 부과된 세액을 반환합니다.
+func (i *OrderItem) TaxAmount() float64 {
+	return i.taxAmount
+}
+
+// RestoreOrderItem은 저장된 데이터로부터 주문 항목을 복원합니다.
+func RestoreOrderItem(id, productID, skuID, name, taxCategory string, price float64, quantity, canceledQuantity, returnedQuantity int, taxAmount float64) *OrderItem {
+	return &OrderItem{
+		id:               id,
+		productID:        productID,
+		skuID:            skuID,
+		name:             name,
+		taxCategory:      taxCategory,
+		price:            price,
+		quantity:         quantity,
+		canceledQuantity: canceledQuantity,
+		returnedQuantity: returnedQuantity,
+		taxAmount:        taxAmount,
+	}
+}
+
+// DiscountKindFreeShipping은 상품 금액 대신 배송비를 깎는 할인의 종류입니다.
+const DiscountKindFreeShipping = "free_shipping"
+
+// OrderDiscount는 주문에 적용된 할인 내역을 나타냅니다.
+// 총액이 어떻게 계산되었는지 설명할 수 있도록 할인 한 건마다 한 줄로 기록합니다.
+type OrderDiscount struct {
+	code        string
+	kind        string
+	description string
+	amount      float64
+}
+
+// NewOrderDiscount는 새로운 주문 할인 내역을 생성합니다.
+func NewOrderDiscount(code, kind, description string, amount float64) (*OrderDiscount, error) {
+	if code == "" || amount < 0 {
+		return nil, ErrInvalidDiscount
+	}
+	return &OrderDiscount{
+		code:        code,
+		kind:        kind,
+		description: description,
+		amount:      amount,
+	}, nil
+}
+
+// Code는 적용된 쿠폰 코드를 반환합니다.
+func (d *OrderDiscount) Code() string {
+	return d.code
+}
+
+// Kind는 할인 방식을 반환합니다.
+func (d *OrderDiscount) Kind() string {
+	return d.kind
+}
+
+// Description은 할인 설명을 반환합니다.
+func (d *OrderDiscount) Description() string {
+	return d.description
+}
+
+// Amount는 할인 금액을 반환합니다.
+func (d *OrderDiscount) Amount() float64 {
+	return d.amount
+}
+
 // Order는 주문 엔티티를 나타냅니다.
 type Order struct {
 	id         string
 	customerID string
-	items      []*OrderItem
-	totalAmount float64
-	status     OrderStatus
-	createdAt  time.Time
-	updatedAt  time.Time
+	// 주문 시점의 고객 이름과 이메일 스냅샷
+	customerName       string
+	customerEmail      string
+	items              []*OrderItem
+	discounts          []*OrderDiscount
+	destinationCountry string
+	taxInclusive       bool
+	shippingFee        float64
+	totalAmount        float64
+	status             OrderStatus
+	statusChanges      []*StatusChange
+	// 비회원 주문의 연락처와 주문 조회 토큰 해시
+	guest           *GuestContact
+	lookupTokenHash string
+	// 낙관적 잠금을 위한 버전 (저장할 때마다 증가)
+	version   int
+	createdAt time.Time
+	updatedAt time.Time
 }
 
 // NewOrder는 새로운 주문을 생성합니다.
@@ -96,6 +318,9 @@ func NewOrder(customerID string, items []*OrderItem) (*Order, error) {
 	// 총 금액 계산
 	var totalAmount float64
 	for _, item := range items {
+		if item.Quantity() <= 0 {
+			return nil, ErrInvalidItemQuantity
+		}
 		totalAmount += item.Subtotal()
 	}
 
@@ -105,31 +330,457 @@ func NewOrder(customerID string, items []*OrderItem) (*Order, error) {
 
 	now := time.Now()
 	return &Order{
-		id:          uuid.New().String(),
-		customerID:  customerID,
-		items:       items,
-		totalAmount: totalAmount,
-		status:      StatusPending,
-		createdAt:   now,
-		updatedAt:   now,
+		id:                 uuid.New().String(),
+		customerID:         customerID,
+		items:              items,
+		discounts:          []*OrderDiscount{},
+		destinationCountry: DefaultDestinationCountry,
+		taxInclusive:       true,
+		totalAmount:        totalAmount,
+		status:             StatusPending,
+		statusChanges:      []*StatusChange{newStatusChange("", StatusPending, customerID, "주문 생성", now)},
+		createdAt:          now,
+		updatedAt:          now,
 	}, nil
 }
 
+// RestoreOrder는 저장된 데이터로부터 주문을 복원합니다.
+func RestoreOrder(
+	id, customerID, customerName, customerEmail string,
+	guest *GuestContact,
+	lookupTokenHash string,
+	items []*OrderItem,
+	discounts []*OrderDiscount,
+	destinationCountry string,
+	taxInclusive bool,
+	shippingFee float64,
+	totalAmount float64,
+	status OrderStatus,
+	version int,
+	createdAt, updatedAt time.Time,
+) *Order {
+	return &Order{
+		id:                 id,
+		customerID:         customerID,
+		customerName:       customerName,
+		customerEmail:      customerEmail,
+		guest:              guest,
+		lookupTokenHash:    lookupTokenHash,
+		items:              items,
+		discounts:          discounts,
+		destinationCountry: destinationCountry,
+		taxInclusive:       taxInclusive,
+		shippingFee:        shippingFee,
+		totalAmount:        totalAmount,
+		status:             status,
+		version:            version,
+		createdAt:          createdAt,
+		updatedAt:          updatedAt,
+	}
+}
+
 // ID는 주문의 고유 식별자를 반환합니다.
 func (o *Order) ID() string {
 	return o.id
 }
 
+// Version은 낙관적 잠금을 위한 버전을 반환합니다.
+func (o *Order) Version() int {
+	return o.version
+}
+
+// AdvanceVersion은 저장소가 변경 내용을 저장한 뒤 버전을 올릴 때 사용합니다.
+func (o *Order) AdvanceVersion() {
+	o.version++
+}
+
 // CustomerID는 고객 ID를 반환합니다.
 func (o *Order) CustomerID() string {
 	return o.customerID
 }
 
+// CustomerName은 주문 시점의 고객 이름을 반환합니다.
+func (o *Order) CustomerName() string {
+	return o.customerName
+}
+
+// CustomerEmail은 주문 시점의 고객 이메일을 반환합니다.
+func (o *Order) CustomerEmail() string {
+	return o.customerEmail
+}
+
+// SnapshotCustomer는 주문 시점의 고객 이름과 이메일을 기록합니다.
+// 이후 회원 정보가 바뀌거나 탈퇴해도 주문에는 이 값이 남습니다.
+func (o *Order) SnapshotCustomer(name, email string) {
+	o.customerName = name
+	o.customerEmail = email
+}
+
 // Items는 주문 항목 목록을 반환합니다.
 func (o *Order) Items() []*OrderItem {
 	return o.items
 }
 
+// Discounts는 주문에 적용된 할인 내역을 반환합니다.
+func (o *Order) Discounts() []*OrderDiscount {
+	return o.discounts
+}
+
+// Subtotal은 할인 전 상품 금액 합계를 반환합니다.
+func (o *Order) Subtotal() float64 {
+	var subtotal float64
+	for _, item := range o.items {
+		subtotal += item.Subtotal()
+	}
+	return subtotal
+}
+
+// DiscountTotal은 적용된 할인 금액 합계를 반환합니다.
+func (o *Order) DiscountTotal() float64 {
+	var total float64
+	for _, discount := range o.discounts {
+		total += discount.Amount()
+	}
+	return total
+}
+
+// MerchandiseDiscountTotal은 배송비 할인을 뺀 상품 금액 할인 합계를 반환합니다.
+func (o *Order) MerchandiseDiscountTotal() float64 {
+	var total float64
+	for _, discount := range o.discounts {
+		if discount.Kind() != DiscountKindFreeShipping {
+			total += discount.Amount()
+		}
+	}
+	return total
+}
+
+// ShippingFee는 할인 전 배송비를 반환합니다.
+func (o *Order) ShippingFee() float64 {
+	return o.shippingFee
+}
+
+// ApplyShippingFee는 배송비를 기록하고 총액을 다시 계산합니다. 결제 전 주문에만 적용할 수 있습니다.
+func (o *Order) ApplyShippingFee(fee float64) error {
+	if o.status != StatusPending {
+		return ErrOrderStatusTransition
+	}
+	if fee < 0 {
+		return ErrInvalidShippingFee
+	}
+
+	o.shippingFee = fee
+	o.recalculateTotal()
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// netShippingFee는 배송비 할인을 뺀 배송비를 반환합니다.
+func (o *Order) netShippingFee() float64 {
+	fee := o.shippingFee
+	for _, discount := range o.discounts {
+		if discount.Kind() == DiscountKindFreeShipping {
+			fee -= discount.Amount()
+		}
+	}
+	if fee < 0 {
+		return 0
+	}
+	return fee
+}
+
+// ApplyDiscounts는 할인 내역을 기록하고 총액을 다시 계산합니다.
+// 결제 전 주문에만 적용할 수 있으며, 할인 후 상품 금액은 0보다 작아지지 않습니다.
+func (o *Order) ApplyDiscounts(discounts []*OrderDiscount) error {
+	if o.status != StatusPending {
+		return ErrOrderStatusTransition
+	}
+
+	o.discounts = discounts
+	o.recalculateTotal()
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// AddItem은 결제 전 주문에 항목을 추가합니다.
+// 같은 SKU의 항목이 이미 있으면 새 항목을 만들지 않고 기존 항목의 수량을 늘리며, 기존 단가를 유지합니다.
+// 할인과 세금은 다시 계산해야 하므로 호출한 쪽에서 ApplyDiscounts와 ApplyTaxes를 다시 적용합니다.
+func (o *Order) AddItem(item *OrderItem) error {
+	if o.status != StatusPending {
+		return ErrOrderNotEditable
+	}
+	if item.quantity <= 0 {
+		return ErrInvalidItemQuantity
+	}
+
+	if existing := o.findItemBySKU(item.skuID); existing != nil {
+		existing.quantity += item.quantity
+	} else {
+		o.items = append(o.items, item)
+	}
+	o.recalculateTotal()
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// ChangeItemQuantity는 결제 전 주문 항목의 수량을 변경합니다.
+func (o *Order) ChangeItemQuantity(itemID string, quantity int) error {
+	if o.status != StatusPending {
+		return ErrOrderNotEditable
+	}
+	if quantity <= 0 {
+		return ErrInvalidItemQuantity
+	}
+
+	item := o.findItem(itemID)
+	if item == nil {
+		return ErrOrderItemNotFound
+	}
+	item.quantity = quantity
+	o.recalculateTotal()
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// RemoveItem은 결제 전 주문에서 항목을 삭제합니다. 마지막 남은 항목은 삭제할 수 없으며 주문을 취소해야 합니다.
+func (o *Order) RemoveItem(itemID string) error {
+	if o.status != StatusPending {
+		return ErrOrderNotEditable
+	}
+	if o.findItem(itemID) == nil {
+		return ErrOrderItemNotFound
+	}
+	if len(o.items) == 1 {
+		return ErrInvalidOrderItems
+	}
+
+	items := make([]*OrderItem, 0, len(o.items)-1)
+	for _, item := range o.items {
+		if item.id != itemID {
+			items = append(items, item)
+		}
+	}
+	o.items = items
+	o.recalculateTotal()
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// CancelItems는 결제된 주문의 항목 일부를 취소하고 환불할 금액을 반환합니다.
+// quantities는 항목 ID별 취소 수량입니다. 환불 금액은 남은 결제 금액을 취소 전 항목 금액(별도 세액 포함)
+// 비율로 나누어 계산하므로 할인도 비율대로 함께 취소됩니다. 배송비는 일부 취소로 돌려주지 않습니다.
+// 남은 수량을 모두 취소하면 배송비를 포함한 남은 결제 금액 전체를 환불하고 주문은 취소 상태가 됩니다.
+func (o *Order) CancelItems(quantities map[string]int, actor, reason string) (float64, error) {
+	if o.status != StatusPaid {
+		return 0, ErrOrderStatusTransition
+	}
+	if len(quantities) == 0 {
+		return 0, ErrInvalidCancelQuantity
+	}
+	for itemID, quantity := range quantities {
+		item := o.findItem(itemID)
+		if item == nil {
+			return 0, ErrOrderItemNotFound
+		}
+		if quantity <= 0 || quantity > item.quantity {
+			return 0, ErrInvalidCancelQuantity
+		}
+	}
+
+	var gross, canceledGross float64
+	remaining := 0
+	for _, item := range o.items {
+		lineGross := item.Subtotal()
+		if !o.taxInclusive {
+			lineGross += item.taxAmount
+		}
+		gross += lineGross
+		remaining += item.quantity - quantities[item.id]
+		if n := quantities[item.id]; n > 0 {
+			canceledGross += lineGross * float64(n) / float64(item.quantity)
+		}
+	}
+
+	refund := o.totalAmount
+	if remaining > 0 && gross > 0 {
+		refund = roundAmount((o.totalAmount - o.netShippingFee()) * canceledGross / gross)
+	}
+
+	for _, item := range o.items {
+		n := quantities[item.id]
+		if n == 0 {
+			continue
+		}
+		item.taxAmount = roundAmount(item.taxAmount * float64(item.quantity-n) / float64(item.quantity))
+		item.quantity -= n
+		item.canceledQuantity += n
+	}
+	o.totalAmount = roundAmount(o.totalAmount - refund)
+	o.updatedAt = time.Now()
+
+	if remaining == 0 {
+		if err := o.UpdateStatus(StatusCanceled, actor, reason); err != nil {
+			return 0, err
+		}
+	}
+	return refund, nil
+}
+
+// ReturnItems는 배송 완료된 주문의 반품 수량을 기록하고 환불할 금액을 반환합니다.
+// quantities는 항목 ID별 반품 수량입니다. 환불 금액은 결제 금액을 항목 금액(별도 세액 포함) 비율로
+// 나누어 계산하므로 할인도 비율대로 함께 환불되며, 배송비는 환불하지 않습니다. 결제 금액 자체는 바뀌지 않습니다.
+// 모든 수량이 반품되면 주문은 반품 완료 상태가, 일부만 반품되면 부분 반품 상태가 됩니다.
+func (o *Order) ReturnItems(quantities map[string]int, actor, reason string) (float64, error) {
+	if o.status != StatusDelivered && o.status != StatusPartiallyReturned {
+		return 0, ErrOrderNotReturnable
+	}
+	if len(quantities) == 0 {
+		return 0, ErrInvalidReturnQuantity
+	}
+	for itemID, quantity := range quantities {
+		item := o.findItem(itemID)
+		if item == nil {
+			return 0, ErrOrderItemNotFound
+		}
+		if quantity <= 0 || item.returnedQuantity+quantity > item.quantity {
+			return 0, ErrInvalidReturnQuantity
+		}
+	}
+
+	var gross, returnedGross float64
+	unreturned := 0
+	for _, item := range o.items {
+		lineGross := item.Subtotal()
+		if !o.taxInclusive {
+			lineGross += item.taxAmount
+		}
+		gross += lineGross
+		if n := quantities[item.id]; n > 0 {
+			returnedGross += lineGross * float64(n) / float64(item.quantity)
+		}
+		unreturned += item.quantity - item.returnedQuantity - quantities[item.id]
+	}
+
+	var refund float64
+	if gross > 0 {
+		refund = roundAmount((o.totalAmount - o.netShippingFee()) * returnedGross / gross)
+	}
+
+	for _, item := range o.items {
+		item.returnedQuantity += quantities[item.id]
+	}
+	o.updatedAt = time.Now()
+
+	status := StatusPartiallyReturned
+	if unreturned == 0 {
+		status = StatusReturned
+	}
+	if status != o.status {
+		if err := o.UpdateStatus(status, actor, reason); err != nil {
+			return 0, err
+		}
+	}
+	return refund, nil
+}
+
+// findItem은 ID로 주문 항목을 찾습니다.
+func (o *Order) findItem(itemID string) *OrderItem {
+	for _, item := range o.items {
+		if item.id == itemID {
+			return item
+		}
+	}
+	return nil
+}
+
+// findItemBySKU는 SKU로 주문 항목을 찾습니다.
+func (o *Order) findItemBySKU(skuID string) *OrderItem {
+	for _, item := range o.items {
+		if item.skuID == skuID {
+			return item
+		}
+	}
+	return nil
+}
+
+// roundAmount는 금액을 소수점 둘째 자리까지 반올림합니다.
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// DestinationCountry는 세금 계산에 사용되는 배송 국가 코드를 반환합니다.
+func (o *Order) DestinationCountry() string {
+	return o.destinationCountry
+}
+
+// ChangeDestinationCountry는 배송 국가를 변경합니다. 결제 전 주문에만 변경할 수 있습니다.
+func (o *Order) ChangeDestinationCountry(country string) error {
+	if o.status != StatusPending {
+		return ErrOrderStatusTransition
+	}
+
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if len(country) != 2 || country[0] < 'A' || country[0] > 'Z' || country[1] < 'A' || country[1] > 'Z' {
+		return ErrInvalidCountry
+	}
+
+	o.destinationCountry = country
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// TaxInclusive는 상품 가격에 세금이 포함되어 있는지 여부를 반환합니다.
+func (o *Order) TaxInclusive() bool {
+	return o.taxInclusive
+}
+
+// TaxTotal은 주문 항목 세액의 합계를 반환합니다.
+func (o *Order) TaxTotal() float64 {
+	var total float64
+	for _, item := range o.items {
+		total += item.TaxAmount()
+	}
+	return total
+}
+
+// ApplyTaxes는 항목별 세액을 주문 항목 순서대로 기록하고 총액을 다시 계산합니다.
+// 세금이 가격에 포함되어 있지 않으면 세액을 총액에 더합니다.
+func (o *Order) ApplyTaxes(itemTaxes []float64, inclusive bool) error {
+	if o.status != StatusPending {
+		return ErrOrderStatusTransition
+	}
+	if len(itemTaxes) != len(o.items) {
+		return ErrInvalidTax
+	}
+	for _, tax := range itemTaxes {
+		if tax < 0 {
+			return ErrInvalidTax
+		}
+	}
+
+	for i, item := range o.items {
+		item.taxAmount = itemTaxes[i]
+	}
+	o.taxInclusive = inclusive
+	o.recalculateTotal()
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// recalculateTotal은 상품 금액, 할인, 배송비와 별도 세액으로 총액을 계산합니다.
+// 배송비 할인은 배송비에서만 빼므로 상품 금액을 깎지 않습니다.
+func (o *Order) recalculateTotal() {
+	total := o.Subtotal() - o.MerchandiseDiscountTotal()
+	if total < 0 {
+		total = 0
+	}
+	total += o.netShippingFee()
+	if !o.taxInclusive {
+		total += o.TaxTotal()
+	}
+	o.totalAmount = total
+}
+
 // TotalAmount는 주문 총액을 반환합니다.
 func (o *Order) TotalAmount() float64 {
 	return o.totalAmount
@@ -150,31 +801,32 @@ func (o *Order) UpdatedAt() time.Time {
 	return o.updatedAt
 }
 
-// UpdateStatus는 주문 상태를 업데이트합니다.
-func (o *Order) UpdateStatus(status OrderStatus) error {
+// StatusChanges는 주문을 불러온 뒤 기록된, 아직 저장되지 않았을 수 있는 상태 전환 이력을 반환합니다.
+// 저장소는 이력 ID로 중복 저장을 막으므로 같은 주문을 다시 저장해도 안전합니다.
+func (o *Order) StatusChanges() []*StatusChange {
+	return o.statusChanges
+}
+
+// UpdateStatus는 주문 상태를 업데이트하고 상태 전환 이력을 기록합니다.
+func (o *Order) UpdateStatus(status OrderStatus, actor, reason string) error {
 	// 상태 전환 유효성 검사
 	if !isValidStatusTransition(o.status, status) {
 		return ErrOrderStatusTransition
 	}
 
+	now := time.Now()
+	o.statusChanges = append(o.statusChanges, newStatusChange(o.status, status, actor, reason, now))
 	o.status = status
-	o.updatedAt = time.Now()
+	o.updatedAt = now
 	return nil
 }
 
-// isValidStatusTransition은 주문 상태 전환이 유효한지 확인합니다.
+// isValidStatusTransition은 주문 상태 전환이 규칙 표에 정의되어 있는지 확인합니다.
 func isValidStatusTransition(from, to OrderStatus) bool {
-	// 상태 전환 규칙
-	switch from {
-	case StatusPending:
-		return to == StatusPaid || to == StatusCanceled
-	case StatusPaid:
-		return to == StatusShipped || to == StatusCanceled
-	case StatusShipped:
-		return to == StatusDelivered || to == StatusCanceled
-	case StatusDelivered, StatusCanceled:
-		return false // 배송 완료 또는 취소 상태에서는 다른 상태로 전환 불가
-	default:
-		return false
-	}
-}
\ No newline at end of file
+	for _, next := range statusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
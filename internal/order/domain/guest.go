@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidGuestContact = errors.New("guest order requires a valid email, name and shipping address")
+	ErrNotGuestOrder       = errors.New("order is not a guest order")
+	ErrGuestEmailMismatch  = errors.New("member email does not match the guest order email")
+)
+
+// GuestContact는 회원 계정 없이 주문한 고객의 연락처와 배송지를 정의합니다.
+// 배송 국가는 주문의 DestinationCountry를 따릅니다.
+type GuestContact struct {
+	Email      string
+	Name       string
+	Phone      string
+	Address1   string
+	Address2   string
+	City       string
+	PostalCode string
+}
+
+// normalize는 앞뒤 공백을 제거하고 이메일을 소문자로 맞춘 뒤 필수 항목을 검증합니다.
+func (g GuestContact) normalize() (GuestContact, error) {
+	g.Email = strings.ToLower(strings.TrimSpace(g.Email))
+	g.Name = strings.TrimSpace(g.Name)
+	g.Phone = strings.TrimSpace(g.Phone)
+	g.Address1 = strings.TrimSpace(g.Address1)
+	g.Address2 = strings.TrimSpace(g.Address2)
+	g.City = strings.TrimSpace(g.City)
+	g.PostalCode = strings.TrimSpace(g.PostalCode)
+
+	if address, err := mail.ParseAddress(g.Email); err != nil || address.Address != g.Email {
+		return g, ErrInvalidGuestContact
+	}
+	if g.Name == "" || g.Address1 == "" || g.City == "" || g.PostalCode == "" {
+		return g, ErrInvalidGuestContact
+	}
+	return g, nil
+}
+
+// NewGuestOrder는 회원 계정 없이 비회원 주문을 생성하고 주문 조회 토큰을 발급합니다.
+// 토큰은 해시만 주문에 저장되므로 반환된 토큰은 이때 한 번만 알 수 있습니다.
+func NewGuestOrder(contact GuestContact, items []*OrderItem) (*Order, string, error) {
+	contact, err := contact.normalize()
+	if err != nil {
+		return nil, "", err
+	}
+
+	order, err := NewOrder("", items)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, err := newLookupToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	order.guest = &contact
+	order.SnapshotCustomer(contact.Name, contact.Email)
+	order.lookupTokenHash = hashLookupToken(token)
+	order.statusChanges = []*StatusChange{newStatusChange("", StatusPending, GuestActor(contact.Email), "주문 생성", order.createdAt)}
+	return order, token, nil
+}
+
+// GuestActor는 비회원이 직접 변경한 상태 이력에 기록되는 행위자입니다.
+func GuestActor(email string) string {
+	return "guest:" + email
+}
+
+// newLookupToken은 추측할 수 없는 256비트 주문 조회 토큰을 만듭니다.
+func newLookupToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate order lookup token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashLookupToken은 저장용 주문 조회 토큰 해시를 계산합니다.
+func hashLookupToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Guest는 비회원 주문의 연락처를 반환합니다. 회원 주문이면 nil입니다.
+// 회원 계정에 연결된 뒤에도 주문 당시의 연락처는 그대로 남습니다.
+func (o *Order) Guest() *GuestContact {
+	return o.guest
+}
+
+// IsGuest는 아직 회원 계정에 연결되지 않은 비회원 주문인지 반환합니다.
+func (o *Order) IsGuest() bool {
+	return o.guest != nil && o.customerID == ""
+}
+
+// LookupTokenHash는 저장용 주문 조회 토큰 해시를 반환합니다.
+func (o *Order) LookupTokenHash() string {
+	return o.lookupTokenHash
+}
+
+// VerifyLookupToken은 주문 조회 토큰이 이 주문에 발급된 토큰인지 확인합니다.
+func (o *Order) VerifyLookupToken(token string) bool {
+	if o.lookupTokenHash == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashLookupToken(token)), []byte(o.lookupTokenHash)) == 1
+}
+
+// AttachToCustomer는 비회원 주문을 같은 이메일의 회원 계정에 연결하고 회원 정보를 스냅샷합니다.
+func (o *Order) AttachToCustomer(customerID, name, email string) error {
+	if !o.IsGuest() {
+		return ErrNotGuestOrder
+	}
+	if customerID == "" || !strings.EqualFold(strings.TrimSpace(email), o.guest.Email) {
+		return ErrGuestEmailMismatch
+	}
+
+	o.customerID = customerID
+	o.SnapshotCustomer(name, email)
+	o.updatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrOrderAlreadyPlaced = errors.New("only a newly created order can be imported")
+	ErrInvalidPlacedAt    = errors.New("imported order time must not be in the future")
+)
+
+// ActorImport는 가져온 주문의 상태 전환 이력에 기록되는 행위자입니다.
+const ActorImport = "system:import"
+
+// Import는 새로 만든 주문을 이전 플랫폼이나 B2B 주문서에서 가져온 주문으로 표시합니다.
+// 결제 대기가 아닌 주문은 가져온 상태와 주문 시각을 그대로 기록하며, 중간 상태 전환 이력은 남기지 않습니다.
+// 결제 대기 주문은 새 주문처럼 현재 시각으로 생성됩니다.
+func (o *Order) Import(status OrderStatus, placedAt time.Time) error {
+	if o.status != StatusPending || len(o.statusChanges) != 1 {
+		return ErrOrderAlreadyPlaced
+	}
+	if _, ok := statusTransitions[status]; !ok {
+		return ErrInvalidOrderStatus
+	}
+
+	now := time.Now()
+	if status == StatusPending || placedAt.IsZero() {
+		placedAt = now
+	}
+	if placedAt.After(now) {
+		return ErrInvalidPlacedAt
+	}
+
+	o.status = status
+	o.statusChanges = []*StatusChange{newStatusChange("", status, ActorImport, "주문 가져오기", placedAt)}
+	o.createdAt = placedAt
+	o.updatedAt = now
+	return nil
+}
@@ -0,0 +1,53 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	catalogApp "example.com/myapp/catalog/application"
+	catalogDomain "example.com/myapp/catalog/domain"
+	"example.com/myapp/order/application"
+)
+
+// CatalogProductAdapter는 카탈로그 모듈의 공개 API로 ProductCatalog 포트를 구현합니다.
+type CatalogProductAdapter struct {
+	products catalogApp.ProductService
+}
+
+// NewCatalogProductAdapter는 새로운 CatalogProductAdapter 인스턴스를 생성합니다.
+func NewCatalogProductAdapter(products catalogApp.ProductService) application.ProductCatalog {
+	return &CatalogProductAdapter{
+		products: products,
+	}
+}
+
+// FindProduct는 카탈로그에서 주문 가능한 상품 정보를 조회합니다.
+func (a *CatalogProductAdapter) FindProduct(ctx context.Context, productID, skuID string) (*application.CatalogProduct, error) {
+	product, sku, err := a.products.ResolveSKU(ctx, productID, skuID)
+	if err != nil {
+		switch {
+		case errors.Is(err, catalogDomain.ErrProductNotFound),
+			errors.Is(err, catalogDomain.ErrSKUNotFound),
+			errors.Is(err, catalogApp.ErrInvalidProductID):
+			return nil, application.ErrProductNotFound
+		case errors.Is(err, catalogDomain.ErrProductArchived):
+			return nil, application.ErrProductUnavailable
+		default:
+			return nil, err
+		}
+	}
+
+	// 상품명에 SKU 옵션명을 덧붙여 주문 당시 표기를 보존합니다.
+	name := product.Name()
+	if sku.Name() != "" {
+		name = name + " - " + sku.Name()
+	}
+
+	return &application.CatalogProduct{
+		ProductID:   product.ID(),
+		SKUID:       sku.ID(),
+		Name:        name,
+		TaxCategory: product.TaxCategory(),
+		Price:       sku.Price(),
+	}, nil
+}
@@ -0,0 +1,82 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	inventoryApp "example.com/myapp/inventory/application"
+	inventoryDomain "example.com/myapp/inventory/domain"
+	"example.com/myapp/order/application"
+)
+
+// InventoryStockAdapter는 재고 모듈의 공개 API로 StockReserver 포트를 구현합니다.
+type InventoryStockAdapter struct {
+	inventory inventoryApp.InventoryService
+}
+
+// NewInventoryStockAdapter는 새로운 InventoryStockAdapter 인스턴스를 생성합니다.
+func NewInventoryStockAdapter(inventory inventoryApp.InventoryService) application.StockReserver {
+	return &InventoryStockAdapter{
+		inventory: inventory,
+	}
+}
+
+// Reserve는 주문 라인 전체의 재고를 예약합니다.
+func (a *InventoryStockAdapter) Reserve(ctx context.Context, orderID string, lines []application.StockLine) error {
+	requests := make([]inventoryApp.ReservationLineRequest, len(lines))
+	for i, line := range lines {
+		requests[i] = inventoryApp.ReservationLineRequest{
+			SKUID:    line.SKUID,
+			Quantity: line.Quantity,
+		}
+	}
+
+	_, err := a.inventory.ReserveForOrder(ctx, orderID, requests)
+	if errors.Is(err, inventoryDomain.ErrInsufficientStock) {
+		return application.ErrOutOfStock
+	}
+	return err
+}
+
+// Confirm은 결제된 주문의 재고 예약을 확정합니다.
+func (a *InventoryStockAdapter) Confirm(ctx context.Context, orderID string) error {
+	_, err := a.inventory.ConfirmReservation(ctx, orderID)
+	return ignoreMissingReservation(err)
+}
+
+// Commit은 출고된 주문의 예약 수량을 실재고에서 차감합니다.
+func (a *InventoryStockAdapter) Commit(ctx context.Context, orderID string) error {
+	_, err := a.inventory.CommitReservation(ctx, orderID)
+	return ignoreMissingReservation(err)
+}
+
+// Release는 주문의 재고 예약을 해제합니다.
+func (a *InventoryStockAdapter) Release(ctx context.Context, orderID string) error {
+	_, err := a.inventory.ReleaseReservation(ctx, orderID)
+	return ignoreMissingReservation(err)
+}
+
+// Adjust는 주문의 남은 라인으로 재고 예약 수량을 바꿉니다.
+func (a *InventoryStockAdapter) Adjust(ctx context.Context, orderID string, lines []application.StockLine) error {
+	requests := make([]inventoryApp.ReservationLineRequest, len(lines))
+	for i, line := range lines {
+		requests[i] = inventoryApp.ReservationLineRequest{
+			SKUID:    line.SKUID,
+			Quantity: line.Quantity,
+		}
+	}
+
+	_, err := a.inventory.ChangeReservation(ctx, orderID, requests)
+	if errors.Is(err, inventoryDomain.ErrInsufficientStock) {
+		return application.ErrOutOfStock
+	}
+	return ignoreMissingReservation(err)
+}
+
+// ignoreMissingReservation은 재고 모듈 도입 이전에 생성된 주문처럼 예약이 없는 경우를 무시합니다.
+func ignoreMissingReservation(err error) error {
+	if errors.Is(err, inventoryDomain.ErrReservationNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	memberApp "example.com/myapp/member/application"
+	memberDomain "example.com/myapp/member/domain"
+	"example.com/myapp/order/application"
+)
+
+// MemberCustomerAdapter는 회원 모듈의 공개 API로 CustomerDirectory 포트를 구현합니다.
+type MemberCustomerAdapter struct {
+	members memberApp.MemberService
+}
+
+// NewMemberCustomerAdapter는 새로운 MemberCustomerAdapter 인스턴스를 생성합니다.
+func NewMemberCustomerAdapter(members memberApp.MemberService) application.CustomerDirectory {
+	return &MemberCustomerAdapter{
+		members: members,
+	}
+}
+
+// FindCustomer는 회원 모듈에서 주문 고객 정보를 조회합니다.
+// 회원 모듈에는 아직 휴면·정지 상태가 없으므로 조회되는 회원은 모두 활성 고객입니다.
+// 탈퇴한 회원은 삭제되므로 ErrCustomerNotFound가 됩니다.
+func (a *MemberCustomerAdapter) FindCustomer(ctx context.Context, customerID string) (*application.Customer, error) {
+	member, err := a.members.GetMember(ctx, customerID)
+	if err != nil {
+		if errors.Is(err, memberDomain.ErrMemberNotFound) {
+			return nil, application.ErrCustomerNotFound
+		}
+		return nil, err
+	}
+
+	return &application.Customer{
+		ID:     member.ID(),
+		Name:   member.Name(),
+		Email:  member.Email(),
+		Active: true,
+	}, nil
+}
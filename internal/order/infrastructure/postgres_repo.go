@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"example.com/myapp/order/application"
 	"example.com/myapp/order/domain"
@@ -31,17 +33,39 @@ func (r *PostgresOrderRepository) Save(ctx context.Context, order *domain.Order)
 	}
 	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
 
+	if err := insertOrder(ctx, tx, order); err != nil {
+		return err
+	}
+
+	// 트랜잭션 커밋
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// insertOrder는 트랜잭션 안에서 새 주문과 항목, 할인 내역, 비회원 연락처 및 상태 전환 이력을 저장합니다.
+func insertOrder(ctx context.Context, tx pgx.Tx, order *domain.Order) error {
 	// 1. 주문 기본 정보 저장
 	orderQuery := `
-		INSERT INTO orders (id, customer_id, total_amount, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO orders (
+			id, customer_id, customer_name, customer_email, destination_country, tax_inclusive, tax_total, shipping_fee, total_amount, status, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
-	_, err = tx.Exec(
+	_, err := tx.Exec(
 		ctx,
 		orderQuery,
 		order.ID(),
 		order.CustomerID(),
+		order.CustomerName(),
+		order.CustomerEmail(),
+		order.DestinationCountry(),
+		order.TaxInclusive(),
+		order.TaxTotal(),
+		order.ShippingFee(),
 		order.TotalAmount(),
 		string(order.Status()),
 		order.CreatedAt(),
@@ -52,32 +76,40 @@ func (r *PostgresOrderRepository) Save(ctx context.Context, order *domain.Order)
 		return fmt.Errorf("failed to save order: %w", err)
 	}
 
-	// 2. 주문 항목 저장
-	for _, item := range order.Items() {
-		itemQuery := `
-			INSERT INTO order_items (id, order_id, product_id, name, price, quantity)
-			VALUES ($1, $2, $3, $4, $5, $6)
-		`
+	// 2. 주문 항목과 할인 내역 저장
+	if err := insertLines(ctx, tx, order); err != nil {
+		return err
+	}
 
+	// 비회원 주문이면 연락처와 조회 토큰 해시 저장
+	if guest := order.Guest(); guest != nil {
+		guestQuery := `
+			INSERT INTO order_guest_contacts (
+				order_id, email, name, phone, address1, address2, city, postal_code, lookup_token_hash
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`
 		_, err = tx.Exec(
 			ctx,
-			itemQuery,
-			item.ID(),
+			guestQuery,
 			order.ID(),
-			item.ProductID(),
-			item.Name(),
-			item.Price(),
-			item.Quantity(),
+			guest.Email,
+			guest.Name,
+			guest.Phone,
+			guest.Address1,
+			guest.Address2,
+			guest.City,
+			guest.PostalCode,
+			order.LookupTokenHash(),
 		)
-
 		if err != nil {
-			return fmt.Errorf("failed to save order item: %w", err)
+			return fmt.Errorf("failed to save guest contact: %w", err)
 		}
 	}
 
-	// 트랜잭션 커밋
-	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+	// 3. 상태 전환 이력 저장
+	if err := insertStatusChanges(ctx, tx, order); err != nil {
+		return err
 	}
 
 	return nil
@@ -87,18 +119,26 @@ func (r *PostgresOrderRepository) Save(ctx context.Context, order *domain.Order)
 func (r *PostgresOrderRepository) FindByID(ctx context.Context, id string) (*domain.Order, error) {
 	// 1. 주문 기본 정보 조회
 	orderQuery := `
-		SELECT id, customer_id, total_amount, status, created_at, updated_at
-		FROM orders
-		WHERE id = $1
+		SELECT o.id, o.customer_id, o.customer_name, o.customer_email, o.destination_country, o.tax_inclusive, o.shipping_fee, o.total_amount, o.status, o.version, o.created_at, o.updated_at,
+			g.email, g.name, g.phone, g.address1, g.address2, g.city, g.postal_code, g.lookup_token_hash
+		FROM orders o
+		LEFT JOIN order_guest_contacts g ON g.order_id = o.id
+		WHERE o.id = $1
 	`
 
 	row := r.db.Pool.QueryRow(ctx, orderQuery, id)
 
-	var orderID, customerID, status string
-	var totalAmount float64
-	var createdAt, updatedAt string
+	var orderID, customerID, customerName, customerEmail, destinationCountry, status string
+	var taxInclusive bool
+	var shippingFee, totalAmount float64
+	var version int
+	var createdAt, updatedAt time.Time
+	var guestEmail, guestName, guestPhone, guestAddress1, guestAddress2, guestCity, guestPostalCode, lookupTokenHash *string
 
-	err := row.Scan(&orderID, &customerID, &totalAmount, &status, &createdAt, &updatedAt)
+	err := row.Scan(
+		&orderID, &customerID, &customerName, &customerEmail, &destinationCountry, &taxInclusive, &shippingFee, &totalAmount, &status, &version, &createdAt, &updatedAt,
+		&guestEmail, &guestName, &guestPhone, &guestAddress1, &guestAddress2, &guestCity, &guestPostalCode, &lookupTokenHash,
+	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrOrderNotFound
@@ -108,9 +148,10 @@ func (r *PostgresOrderRepository) FindByID(ctx context.Context, id string) (*dom
 
 	// 2. 주문 항목 조회
 	itemsQuery := `
-		SELECT id, product_id, name, price, quantity
+		SELECT id, product_id, sku_id, name, tax_category, price, quantity, canceled_quantity, returned_quantity, tax_amount
 		FROM order_items
 		WHERE order_id = $1
+		ORDER BY line_no
 	`
 
 	rows, err := r.db.Pool.Query(ctx, itemsQuery, id)
@@ -121,15 +162,15 @@ func (r *PostgresOrderRepository) FindByID(ctx context.Context, id string) (*dom
 
 	items := []*domain.OrderItem{}
 	for rows.Next() {
-		var itemID, productID, name string
-		var price float64
-		var quantity int
+		var itemID, productID, skuID, name, taxCategory string
+		var price, taxAmount float64
+		var quantity, canceledQuantity, returnedQuantity int
 
-		if err := rows.Scan(&itemID, &productID, &name, &price, &quantity); err != nil {
+		if err := rows.Scan(&itemID, &productID, &skuID, &name, &taxCategory, &price, &quantity, &canceledQuantity, &returnedQuantity, &taxAmount); err != nil {
 			return nil, fmt.Errorf("failed to scan order item: %w", err)
 		}
 
-		item := domain.NewOrderItem(productID, name, price, quantity)
+		item := domain.RestoreOrderItem(itemID, productID, skuID, name, taxCategory, price, quantity, canceledQuantity, returnedQuantity, taxAmount)
 		items = append(items, item)
 	}
 
@@ -137,9 +178,70 @@ func (r *PostgresOrderRepository) FindByID(ctx context.Context, id string) (*dom
 		return nil, fmt.Errorf("error iterating order items: %w", err)
 	}
 
-	// 실제 구현에서는 DB 레코드를 도메인 엔티티로 복원하는 로직이 필요합니다.
-	// 여기서는 코드 예시를 간략하게 하기 위해 생략합니다.
-	return &domain.Order{}, nil
+	// 3. 할인 내역 조회
+	discounts, err := r.findDiscounts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. 비회원 주문 연락처 복원
+	var guest *domain.GuestContact
+	tokenHash := ""
+	if guestEmail != nil {
+		guest = &domain.GuestContact{
+			Email:      *guestEmail,
+			Name:       *guestName,
+			Phone:      *guestPhone,
+			Address1:   *guestAddress1,
+			Address2:   *guestAddress2,
+			City:       *guestCity,
+			PostalCode: *guestPostalCode,
+		}
+		tokenHash = *lookupTokenHash
+	}
+
+	return domain.RestoreOrder(
+		orderID, customerID, customerName, customerEmail, guest, tokenHash, items, discounts, destinationCountry, taxInclusive, shippingFee, totalAmount,
+		domain.OrderStatus(status), version, createdAt, updatedAt,
+	), nil
+}
+
+// findDiscounts는 주문의 할인 내역을 적용 순서대로 조회합니다.
+func (r *PostgresOrderRepository) findDiscounts(ctx context.Context, orderID string) ([]*domain.OrderDiscount, error) {
+	query := `
+		SELECT code, kind, description, amount
+		FROM order_discounts
+		WHERE order_id = $1
+		ORDER BY line_no
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order discounts: %w", err)
+	}
+	defer rows.Close()
+
+	discounts := []*domain.OrderDiscount{}
+	for rows.Next() {
+		var code, kind, description string
+		var amount float64
+
+		if err := rows.Scan(&code, &kind, &description, &amount); err != nil {
+			return nil, fmt.Errorf("failed to scan order discount: %w", err)
+		}
+
+		discount, err := domain.NewOrderDiscount(code, kind, description, amount)
+		if err != nil {
+			return nil, err
+		}
+		discounts = append(discounts, discount)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order discounts: %w", err)
+	}
+
+	return discounts, nil
 }
 
 // FindByCustomerID는 고객 ID로 주문 목록을 조회합니다.
@@ -183,26 +285,407 @@ func (r *PostgresOrderRepository) FindByCustomerID(ctx context.Context, customer
 	return orders, nil
 }
 
-// Update는 주문 정보를 업데이트합니다.
+// Update는 주문 상태, 금액, 항목과 할인 내역, 상태 전환 이력을 하나의 트랜잭션으로 업데이트합니다.
+// 항목과 할인 내역은 결제 전 변경과 부분 취소를 반영하기 위해 통째로 교체합니다.
+// 조회 이후 다른 요청이 먼저 저장했다면 orders.version이 달라지므로 저장하지 않고 domain.ErrOrderConflict를 반환합니다.
 func (r *PostgresOrderRepository) Update(ctx context.Context, order *domain.Order) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
 	query := `
 		UPDATE orders
-		SET status = $1, updated_at = $2
-		WHERE id = $3
+		SET customer_id = $1, customer_name = $2, customer_email = $3, status = $4, tax_inclusive = $5, tax_total = $6,
+			shipping_fee = $7, total_amount = $8, version = version + 1, updated_at = $9
+		WHERE id = $10 AND version = $11
 	`
 
-	_, err := r.db.Pool.Exec(
+	result, err := tx.Exec(
 		ctx,
 		query,
+		order.CustomerID(),
+		order.CustomerName(),
+		order.CustomerEmail(),
 		string(order.Status()),
+		order.TaxInclusive(),
+		order.TaxTotal(),
+		order.ShippingFee(),
+		order.TotalAmount(),
 		order.UpdatedAt(),
 		order.ID(),
+		order.Version(),
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to update order: %w", err)
 	}
+	if result.RowsAffected() == 0 {
+		var exists bool
+		if err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM orders WHERE id = $1)", order.ID()).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check order existence: %w", err)
+		}
+		if exists {
+			return domain.ErrOrderConflict
+		}
+		return domain.ErrOrderNotFound
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM order_items WHERE order_id = $1", order.ID()); err != nil {
+		return fmt.Errorf("failed to delete order items: %w", err)
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM order_discounts WHERE order_id = $1", order.ID()); err != nil {
+		return fmt.Errorf("failed to delete order discounts: %w", err)
+	}
+	if err := insertLines(ctx, tx, order); err != nil {
+		return err
+	}
+
+	if err := insertStatusChanges(ctx, tx, order); err != nil {
+		return err
+	}
+
+	// 트랜잭션 커밋
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	order.AdvanceVersion()
+	return nil
+}
+
+// orderSortColumns는 검색 정렬 기준별 정렬 컬럼입니다.
+var orderSortColumns = map[application.OrderSortField]string{
+	application.OrderSortCreatedAt:   "o.created_at",
+	application.OrderSortUpdatedAt:   "o.updated_at",
+	application.OrderSortTotalAmount: "o.total_amount",
+}
+
+// Search는 검색 조건에 맞는 주문을 정렬 순서대로 after 다음부터 최대 limit건 조회합니다.
+// 정렬 값이 같은 주문은 ID로 순서를 정하므로 커서 기반 페이지가 겹치거나 빠지지 않습니다.
+func (r *PostgresOrderRepository) Search(ctx context.Context, criteria application.OrderSearchCriteria, after *application.OrderCursor, limit int) ([]*domain.Order, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if len(criteria.Statuses) > 0 {
+		statuses := make([]string, len(criteria.Statuses))
+		for i, status := range criteria.Statuses {
+			statuses[i] = string(status)
+		}
+		addCondition("o.status = ANY($%d)", statuses)
+	}
+	if criteria.CustomerID != "" {
+		addCondition("o.customer_id = $%d", criteria.CustomerID)
+	}
+	if criteria.ProductID != "" {
+		addCondition("EXISTS (SELECT 1 FROM order_items i WHERE i.order_id = o.id AND i.product_id = $%d)", criteria.ProductID)
+	}
+	if !criteria.CreatedFrom.IsZero() {
+		addCondition("o.created_at >= $%d", criteria.CreatedFrom)
+	}
+	if !criteria.CreatedTo.IsZero() {
+		addCondition("o.created_at < $%d", criteria.CreatedTo)
+	}
+	if !criteria.UpdatedFrom.IsZero() {
+		addCondition("o.updated_at >= $%d", criteria.UpdatedFrom)
+	}
+	if !criteria.UpdatedTo.IsZero() {
+		addCondition("o.updated_at < $%d", criteria.UpdatedTo)
+	}
+	if criteria.MinAmount > 0 {
+		addCondition("o.total_amount >= $%d", criteria.MinAmount)
+	}
+	if criteria.MaxAmount > 0 {
+		addCondition("o.total_amount <= $%d", criteria.MaxAmount)
+	}
+
+	column, ok := orderSortColumns[criteria.SortBy]
+	if !ok {
+		return nil, application.ErrInvalidSortField
+	}
+	direction, comparison := "ASC", ">"
+	if criteria.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	if after != nil {
+		args = append(args, after.Value, after.ID)
+		conditions = append(conditions, fmt.Sprintf("(%s, o.id) %s ($%d, $%d)", column, comparison, len(args)-1, len(args)))
+	}
+
+	query := `
+		SELECT o.id
+		FROM orders o
+	`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY %[1]s %[2]s, o.id %[2]s LIMIT $%[3]d", column, direction, len(args))
+
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search orders: %w", err)
+	}
+	defer rows.Close()
+
+	orderIDs := []string{}
+	for rows.Next() {
+		var orderID string
+		if err := rows.Scan(&orderID); err != nil {
+			return nil, fmt.Errorf("failed to scan order ID: %w", err)
+		}
+		orderIDs = append(orderIDs, orderID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order IDs: %w", err)
+	}
+
+	// 주문 ID별로 상세 정보 조회
+	orders := []*domain.Order{}
+	for _, orderID := range orderIDs {
+		order, err := r.FindByID(ctx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
 
+	return orders, nil
+}
+
+// FindImportedOrderIDs는 이미 가져온 외부 주문 번호와 해당 주문 ID를 반환합니다.
+func (r *PostgresOrderRepository) FindImportedOrderIDs(ctx context.Context, externalRefs []string) (map[string]string, error) {
+	query := `
+		SELECT external_ref, order_id
+		FROM order_imports
+		WHERE external_ref = ANY($1)
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, externalRefs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query imported orders: %w", err)
+	}
+	defer rows.Close()
+
+	imported := map[string]string{}
+	for rows.Next() {
+		var externalRef, orderID string
+		if err := rows.Scan(&externalRef, &orderID); err != nil {
+			return nil, fmt.Errorf("failed to scan imported order: %w", err)
+		}
+		imported[externalRef] = orderID
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating imported orders: %w", err)
+	}
+
+	return imported, nil
+}
+
+// SaveImportedOrders는 가져온 주문들을 한 트랜잭션으로 저장합니다.
+// 외부 주문 번호를 먼저 order_imports에 기록하고, 이미 기록되어 있으면 그 주문은 저장하지 않고 건너뜁니다.
+func (r *PostgresOrderRepository) SaveImportedOrders(ctx context.Context, orders []application.ImportedOrder) ([]string, error) {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	query := `
+		INSERT INTO order_imports (external_ref, order_id, imported_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (external_ref) DO NOTHING
+	`
+
+	skipped := []string{}
+	for _, imported := range orders {
+		result, err := tx.Exec(ctx, query, imported.ExternalRef, imported.Order.ID(), imported.Order.UpdatedAt())
+		if err != nil {
+			return nil, fmt.Errorf("failed to record order import: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			skipped = append(skipped, imported.ExternalRef)
+			continue
+		}
+
+		if err := insertOrder(ctx, tx, imported.Order); err != nil {
+			return nil, err
+		}
+	}
+
+	// 트랜잭션 커밋
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return skipped, nil
+}
+
+// ClaimExpiredPendingOrders는 cutoff 이전에 생성된 결제 대기 주문을 최대 limit건 선점하고 ID를 반환합니다.
+// FOR UPDATE SKIP LOCKED로 다른 인스턴스가 잠근 행을 건너뛰고, 선점 기한(expiry_claimed_until)을 기록하여
+// 여러 인스턴스가 같은 주문을 동시에 처리하지 않게 합니다.
+func (r *PostgresOrderRepository) ClaimExpiredPendingOrders(ctx context.Context, cutoff time.Time, lease time.Duration, limit int) ([]string, error) {
+	query := `
+		UPDATE orders
+		SET expiry_claimed_until = $1
+		WHERE id IN (
+			SELECT id
+			FROM orders
+			WHERE status = $2 AND created_at < $3
+				AND (expiry_claimed_until IS NULL OR expiry_claimed_until < $4)
+			ORDER BY created_at
+			LIMIT $5
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id
+	`
+
+	now := time.Now()
+	rows, err := r.db.Pool.Query(ctx, query, now.Add(lease), string(domain.StatusPending), cutoff, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim expired pending orders: %w", err)
+	}
+	defer rows.Close()
+
+	orderIDs := []string{}
+	for rows.Next() {
+		var orderID string
+		if err := rows.Scan(&orderID); err != nil {
+			return nil, fmt.Errorf("failed to scan order ID: %w", err)
+		}
+		orderIDs = append(orderIDs, orderID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating claimed order IDs: %w", err)
+	}
+
+	return orderIDs, nil
+}
+
+// FindStatusHistory는 주문의 상태 전환 이력을 시간 순서대로 조회합니다.
+func (r *PostgresOrderRepository) FindStatusHistory(ctx context.Context, orderID string) ([]*domain.StatusChange, error) {
+	query := `
+		SELECT id, from_status, to_status, actor, reason, changed_at
+		FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY changed_at, id
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order status history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []*domain.StatusChange{}
+	for rows.Next() {
+		var id, from, to, actor, reason string
+		var changedAt time.Time
+
+		if err := rows.Scan(&id, &from, &to, &actor, &reason, &changedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan order status change: %w", err)
+		}
+		history = append(history, domain.RestoreStatusChange(id, domain.OrderStatus(from), domain.OrderStatus(to), actor, reason, changedAt))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order status history: %w", err)
+	}
+
+	return history, nil
+}
+
+// insertLines는 주문 항목과 할인 내역을 순서대로 저장합니다.
+func insertLines(ctx context.Context, tx pgx.Tx, order *domain.Order) error {
+	itemQuery := `
+		INSERT INTO order_items (
+			id, order_id, line_no, product_id, sku_id, name, tax_category, price, quantity, canceled_quantity, returned_quantity, tax_amount
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	for i, item := range order.Items() {
+		_, err := tx.Exec(
+			ctx,
+			itemQuery,
+			item.ID(),
+			order.ID(),
+			i+1,
+			item.ProductID(),
+			item.SKUID(),
+			item.Name(),
+			item.TaxCategory(),
+			item.Price(),
+			item.Quantity(),
+			item.CanceledQuantity(),
+			item.ReturnedQuantity(),
+			item.TaxAmount(),
+		)
+
+		if err != nil {
+			return fmt.Errorf("failed to save order item: %w", err)
+		}
+	}
+
+	discountQuery := `
+		INSERT INTO order_discounts (order_id, line_no, code, kind, description, amount)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	for i, discount := range order.Discounts() {
+		_, err := tx.Exec(
+			ctx,
+			discountQuery,
+			order.ID(),
+			i+1,
+			discount.Code(),
+			discount.Kind(),
+			discount.Description(),
+			discount.Amount(),
+		)
+
+		if err != nil {
+			return fmt.Errorf("failed to save order discount: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// insertStatusChanges는 주문의 상태 전환 이력 중 아직 저장되지 않은 이력만 추가합니다.
+func insertStatusChanges(ctx context.Context, tx pgx.Tx, order *domain.Order) error {
+	query := `
+		INSERT INTO order_status_history (id, order_id, from_status, to_status, actor, reason, changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	for _, change := range order.StatusChanges() {
+		_, err := tx.Exec(
+			ctx,
+			query,
+			change.ID(),
+			order.ID(),
+			string(change.From()),
+			string(change.To()),
+			change.Actor(),
+			change.Reason(),
+			change.ChangedAt(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save order status change: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -214,12 +697,32 @@ func (r *PostgresOrderRepository) Delete(ctx context.Context, id string) error {
 	}
 	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
 
-	// 1. 주문 항목 삭제
+	// 1. 주문 항목, 할인 내역, 상태 이력, 비회원 연락처 및 가져오기 기록 삭제
 	_, err = tx.Exec(ctx, "DELETE FROM order_items WHERE order_id = $1", id)
 	if err != nil {
 		return fmt.Errorf("failed to delete order items: %w", err)
 	}
 
+	_, err = tx.Exec(ctx, "DELETE FROM order_discounts WHERE order_id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete order discounts: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM order_status_history WHERE order_id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete order status history: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM order_guest_contacts WHERE order_id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete guest contact: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM order_imports WHERE order_id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete order import record: %w", err)
+	}
+
 	// 2. 주문 삭제
 	result, err := tx.Exec(ctx, "DELETE FROM orders WHERE id = $1", id)
 	if err != nil {
@@ -237,4 +740,4 @@ func (r *PostgresOrderRepository) Delete(ctx context.Context, id string) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
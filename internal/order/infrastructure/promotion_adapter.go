@@ -0,0 +1,110 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"example.com/myapp/order/application"
+	promotionApp "example.com/myapp/promotion/application"
+	promotionDomain "example.com/myapp/promotion/domain"
+)
+
+// rejectionErrors는 주문 요청 자체의 문제로 보고 ErrCouponRejected로 변환할 프로모션 오류입니다.
+var rejectionErrors = []error{
+	promotionDomain.ErrInvalidCouponCode,
+	promotionDomain.ErrCouponNotFound,
+	promotionDomain.ErrCouponInactive,
+	promotionDomain.ErrCouponNotStarted,
+	promotionDomain.ErrCouponExpired,
+	promotionDomain.ErrMinSpendNotMet,
+	promotionDomain.ErrNoEligibleProducts,
+	promotionDomain.ErrNoShippingFee,
+	promotionDomain.ErrFirstOrderOnly,
+	promotionDomain.ErrMemberTierNotEligible,
+	promotionDomain.ErrCouponNotStackable,
+	promotionDomain.ErrDuplicateCoupon,
+	promotionDomain.ErrUsageLimitReached,
+	promotionDomain.ErrMemberUsageLimitReached,
+}
+
+// PromotionDiscountAdapter는 프로모션 모듈의 공개 API로 DiscountEngine 포트를 구현합니다.
+type PromotionDiscountAdapter struct {
+	promotions promotionApp.PromotionService
+}
+
+// NewPromotionDiscountAdapter는 새로운 PromotionDiscountAdapter 인스턴스를 생성합니다.
+func NewPromotionDiscountAdapter(promotions promotionApp.PromotionService) application.DiscountEngine {
+	return &PromotionDiscountAdapter{
+		promotions: promotions,
+	}
+}
+
+// Quote는 주문 라인에 쿠폰을 적용한 할인 내역을 계산합니다.
+// 주문의 배송비를 함께 넘기므로 무료 배송 쿠폰은 배송비만큼 할인되며, 배송비가 없는 주문에서는 거절됩니다.
+func (a *PromotionDiscountAdapter) Quote(ctx context.Context, req application.DiscountQuoteRequest) ([]application.AppliedDiscount, error) {
+	lines := make([]promotionDomain.PricingLine, len(req.Lines))
+	for i, line := range req.Lines {
+		lines[i] = promotionDomain.PricingLine{
+			ProductID: line.ProductID,
+			SKUID:     line.SKUID,
+			UnitPrice: line.UnitPrice,
+			Quantity:  line.Quantity,
+		}
+	}
+
+	discounts, err := a.promotions.PriceDiscounts(ctx, promotionApp.DiscountRequest{
+		MemberID:    req.CustomerID,
+		OrderID:     req.OrderID,
+		FirstOrder:  req.FirstOrder,
+		Codes:       req.CouponCodes,
+		Lines:       lines,
+		ShippingFee: req.ShippingFee,
+	})
+	if err != nil {
+		return nil, mapPromotionError(err)
+	}
+
+	applied := make([]application.AppliedDiscount, len(discounts))
+	for i, d := range discounts {
+		applied[i] = application.AppliedDiscount{
+			Code:        d.Code,
+			Kind:        string(d.Kind),
+			Description: d.Description,
+			Amount:      d.Amount,
+		}
+	}
+	return applied, nil
+}
+
+// Redeem은 주문에 적용된 쿠폰 사용을 기록합니다.
+func (a *PromotionDiscountAdapter) Redeem(ctx context.Context, orderID, customerID string, discounts []application.AppliedDiscount) error {
+	requests := make([]promotionDomain.Discount, len(discounts))
+	for i, d := range discounts {
+		requests[i] = promotionDomain.Discount{
+			Code:        d.Code,
+			Kind:        promotionDomain.DiscountKind(d.Kind),
+			Description: d.Description,
+			Amount:      d.Amount,
+		}
+	}
+
+	return mapPromotionError(a.promotions.RedeemDiscounts(ctx, orderID, customerID, requests))
+}
+
+// Release는 주문의 쿠폰 사용을 해제합니다.
+func (a *PromotionDiscountAdapter) Release(ctx context.Context, orderID string) error {
+	return a.promotions.ReleaseDiscounts(ctx, orderID)
+}
+
+func mapPromotionError(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, target := range rejectionErrors {
+		if errors.Is(err, target) {
+			return fmt.Errorf("%w: %v", application.ErrCouponRejected, err)
+		}
+	}
+	return err
+}
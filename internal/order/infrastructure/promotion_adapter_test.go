@@ -0,0 +1,161 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"example.com/myapp/order/application"
+	"example.com/myapp/order/domain"
+	promotionApp "example.com/myapp/promotion/application"
+	promotionDomain "example.com/myapp/promotion/domain"
+)
+
+// memoryCouponRepository는 쿠폰과 사용 기록을 메모리에 보관하는 CouponRepository 구현체입니다.
+type memoryCouponRepository struct {
+	coupons     map[string]*promotionDomain.Coupon
+	redemptions []*promotionDomain.Redemption
+}
+
+func (r *memoryCouponRepository) Save(ctx context.Context, coupon *promotionDomain.Coupon) error {
+	r.coupons[coupon.Code()] = coupon
+	return nil
+}
+
+func (r *memoryCouponRepository) FindByCode(ctx context.Context, code string) (*promotionDomain.Coupon, error) {
+	coupon, ok := r.coupons[code]
+	if !ok {
+		return nil, promotionDomain.ErrCouponNotFound
+	}
+	return coupon, nil
+}
+
+func (r *memoryCouponRepository) Update(ctx context.Context, coupon *promotionDomain.Coupon) error {
+	r.coupons[coupon.Code()] = coupon
+	return nil
+}
+
+func (r *memoryCouponRepository) CountRedemptions(ctx context.Context, couponID, memberID, excludeOrderID string) (int, int, error) {
+	return 0, 0, nil
+}
+
+func (r *memoryCouponRepository) Redeem(ctx context.Context, redemptions []*promotionDomain.Redemption) error {
+	r.redemptions = append(r.redemptions, redemptions...)
+	return nil
+}
+
+func (r *memoryCouponRepository) ReleaseRedemptions(ctx context.Context, orderID string) error {
+	return nil
+}
+
+// memoryOrderRepository는 저장된 주문을 메모리에 보관하며, 이 테스트에서 쓰지 않는 메서드는 구현하지 않습니다.
+type memoryOrderRepository struct {
+	application.OrderRepository
+	orders map[string]*domain.Order
+}
+
+func (r *memoryOrderRepository) Save(ctx context.Context, order *domain.Order) error {
+	r.orders[order.ID()] = order
+	return nil
+}
+
+func (r *memoryOrderRepository) FindByCustomerID(ctx context.Context, customerID string) ([]*domain.Order, error) {
+	return nil, nil
+}
+
+type activeCustomers struct{}
+
+func (activeCustomers) FindCustomer(ctx context.Context, customerID string) (*application.Customer, error) {
+	return &application.Customer{ID: customerID, Name: "홍길동", Email: "hong@example.com", Active: true}, nil
+}
+
+type priceList map[string]float64
+
+func (p priceList) FindProduct(ctx context.Context, productID, skuID string) (*application.CatalogProduct, error) {
+	price, ok := p[skuID]
+	if !ok {
+		return nil, application.ErrProductNotFound
+	}
+	return &application.CatalogProduct{ProductID: productID, SKUID: skuID, Name: skuID, TaxCategory: "standard", Price: price}, nil
+}
+
+// unlimitedStock은 모든 예약을 받아들이며, 이 테스트에서 쓰지 않는 메서드는 구현하지 않습니다.
+type unlimitedStock struct {
+	application.StockReserver
+}
+
+func (unlimitedStock) Reserve(ctx context.Context, orderID string, lines []application.StockLine) error {
+	return nil
+}
+
+func TestFreeShippingCouponWaivesOrderShippingFee(t *testing.T) {
+	ctx := context.Background()
+	coupons := &memoryCouponRepository{coupons: make(map[string]*promotionDomain.Coupon)}
+	promotions := promotionApp.NewPromotionUseCase(coupons, nil)
+	if _, err := promotions.CreateCoupon(ctx, promotionDomain.CouponSpec{
+		Code: "FREESHIP",
+		Name: "무료 배송",
+		Kind: promotionDomain.KindFreeShipping,
+	}); err != nil {
+		t.Fatalf("CreateCoupon() error = %v", err)
+	}
+
+	rates, err := ParseShippingRates("KR=3000/50000")
+	if err != nil {
+		t.Fatalf("ParseShippingRates() error = %v", err)
+	}
+	useCase := application.NewOrderUseCase(
+		&memoryOrderRepository{orders: make(map[string]*domain.Order)},
+		activeCustomers{},
+		priceList{"sku-1": 20000, "sku-2": 60000},
+		unlimitedStock{},
+		NewPromotionDiscountAdapter(promotions),
+		NewKoreanVATCalculator(),
+		NewFlatShippingRater(rates),
+		NewHMACQuoteSigner([]byte("secret")),
+	)
+
+	// 쿠폰이 없으면 배송비가 총액에 더해집니다
+	order, err := useCase.CreateOrder(ctx, application.CreateOrderRequest{
+		CustomerID: "member-1",
+		Items:      []application.OrderItemRequest{{ProductID: "prod-1", SKUID: "sku-1", Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	if order.ShippingFee() != 3000 || order.TotalAmount() != 23000 {
+		t.Fatalf("shipping fee, total = %v, %v, want 3000, 23000", order.ShippingFee(), order.TotalAmount())
+	}
+
+	// 무료 배송 쿠폰은 배송비만큼 할인하고 상품 금액과 세액은 그대로 둡니다
+	order, err = useCase.CreateOrder(ctx, application.CreateOrderRequest{
+		CustomerID:  "member-1",
+		Items:       []application.OrderItemRequest{{ProductID: "prod-1", SKUID: "sku-1", Quantity: 1}},
+		CouponCodes: []string{"FREESHIP"},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() with free shipping error = %v", err)
+	}
+	if len(order.Discounts()) != 1 || order.Discounts()[0].Kind() != domain.DiscountKindFreeShipping || order.Discounts()[0].Amount() != 3000 {
+		t.Fatalf("discounts = %+v, want one free_shipping discount of 3000", order.Discounts())
+	}
+	if order.TotalAmount() != 20000 {
+		t.Errorf("total = %v, want 20000", order.TotalAmount())
+	}
+	if order.TaxTotal() != 1818.18 {
+		t.Errorf("tax total = %v, want 1818.18 (tax on the full merchandise amount)", order.TaxTotal())
+	}
+	if len(coupons.redemptions) != 1 || coupons.redemptions[0].Amount() != 3000 {
+		t.Errorf("redemptions = %+v, want one redemption of 3000", coupons.redemptions)
+	}
+
+	// 무료 배송 기준을 넘은 주문에는 깎을 배송비가 없으므로 쿠폰이 거절됩니다
+	_, err = useCase.CreateOrder(ctx, application.CreateOrderRequest{
+		CustomerID:  "member-1",
+		Items:       []application.OrderItemRequest{{ProductID: "prod-2", SKUID: "sku-2", Quantity: 1}},
+		CouponCodes: []string{"FREESHIP"},
+	})
+	if !errors.Is(err, application.ErrCouponRejected) {
+		t.Errorf("CreateOrder() over free shipping threshold error = %v, want ErrCouponRejected", err)
+	}
+}
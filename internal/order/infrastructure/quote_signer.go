@@ -0,0 +1,33 @@
+package infrastructure
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+
+	"example.com/myapp/order/application"
+)
+
+// HMACQuoteSigner는 HMAC-SHA256으로 QuoteSigner 포트를 구현합니다.
+// 여러 인스턴스가 같은 견적을 받으려면 모두 같은 비밀 키를 사용해야 합니다.
+type HMACQuoteSigner struct {
+	secret []byte
+}
+
+// NewHMACQuoteSigner는 새로운 HMACQuoteSigner 인스턴스를 생성합니다.
+func NewHMACQuoteSigner(secret []byte) application.QuoteSigner {
+	return &HMACQuoteSigner{
+		secret: secret,
+	}
+}
+
+// Sign은 견적 내용의 HMAC-SHA256 서명을 계산합니다.
+func (s *HMACQuoteSigner) Sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// Verify는 서명이 견적 내용과 일치하는지 상수 시간으로 비교합니다.
+func (s *HMACQuoteSigner) Verify(payload, signature []byte) bool {
+	return hmac.Equal(s.Sign(payload), signature)
+}
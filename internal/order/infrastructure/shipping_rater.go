@@ -0,0 +1,90 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"example.com/myapp/order/application"
+)
+
+// anyShippingCountry는 배송비표에서 국가별 배송비가 없을 때 쓰는 기본 배송비를 나타내는 국가입니다.
+const anyShippingCountry = "*"
+
+// ShippingRate는 배송 국가별 배송비와 무료 배송 기준 금액을 정의합니다.
+// FreeOver가 0보다 크면 할인 전 상품 금액이 그 이상인 주문은 배송비가 없습니다.
+type ShippingRate struct {
+	Country  string
+	Fee      float64
+	FreeOver float64
+}
+
+// FlatShippingRater는 배송 국가별 고정 배송비를 매기는 ShippingRater 구현체입니다.
+type FlatShippingRater struct {
+	rates map[string]ShippingRate
+}
+
+// NewFlatShippingRater는 새로운 FlatShippingRater 인스턴스를 생성합니다.
+func NewFlatShippingRater(rates []ShippingRate) application.ShippingRater {
+	table := make(map[string]ShippingRate, len(rates))
+	for _, rate := range rates {
+		table[strings.ToUpper(rate.Country)] = rate
+	}
+	return &FlatShippingRater{
+		rates: table,
+	}
+}
+
+// Rate는 배송 국가의 배송비를 반환합니다. 국가별 배송비가 없으면 기본 배송비를 사용합니다.
+func (r *FlatShippingRater) Rate(ctx context.Context, req application.ShippingRateRequest) (float64, error) {
+	rate, ok := r.rates[strings.ToUpper(req.Country)]
+	if !ok {
+		rate, ok = r.rates[anyShippingCountry]
+	}
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", application.ErrShippingRateNotFound, req.Country)
+	}
+
+	if rate.FreeOver > 0 && req.Subtotal >= rate.FreeOver {
+		return 0, nil
+	}
+	return rate.Fee, nil
+}
+
+// ParseShippingRates는 "국가=배송비" 또는 "국가=배송비/무료배송기준금액" 항목을 쉼표로 구분한 배송비표 설정을 해석합니다.
+// 예: "KR=3000/50000,*=25000"
+func ParseShippingRates(spec string) ([]ShippingRate, error) {
+	rates := []ShippingRate{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		country, value, ok := strings.Cut(entry, "=")
+		country = strings.TrimSpace(country)
+		if !ok || country == "" {
+			return nil, fmt.Errorf("invalid shipping rate entry %q", entry)
+		}
+		feeValue, freeOverValue, hasFreeOver := strings.Cut(value, "/")
+		fee, err := strconv.ParseFloat(strings.TrimSpace(feeValue), 64)
+		if err != nil || fee < 0 {
+			return nil, fmt.Errorf("invalid shipping fee %q", feeValue)
+		}
+		var freeOver float64
+		if hasFreeOver {
+			freeOver, err = strconv.ParseFloat(strings.TrimSpace(freeOverValue), 64)
+			if err != nil || freeOver < 0 {
+				return nil, fmt.Errorf("invalid free shipping threshold %q", freeOverValue)
+			}
+		}
+
+		rates = append(rates, ShippingRate{
+			Country:  country,
+			Fee:      fee,
+			FreeOver: freeOver,
+		})
+	}
+	return rates, nil
+}
@@ -0,0 +1,140 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"example.com/myapp/order/application"
+)
+
+const (
+	// koreanVATRate는 한국 부가가치세율입니다.
+	koreanVATRate = 0.10
+	// taxCategoryExempt는 부가가치세가 면제되는 상품의 과세 유형입니다.
+	taxCategoryExempt = "exempt"
+	// anyTaxCategory는 세율표에서 국가의 기본 세율을 나타내는 과세 유형입니다.
+	anyTaxCategory = "*"
+)
+
+// KoreanVATCalculator는 가격에 포함된 10% 부가가치세를 역산하는 TaxCalculator 구현체입니다.
+// 면세(exempt) 상품은 세액이 0입니다.
+type KoreanVATCalculator struct{}
+
+// NewKoreanVATCalculator는 새로운 KoreanVATCalculator 인스턴스를 생성합니다.
+func NewKoreanVATCalculator() application.TaxCalculator {
+	return &KoreanVATCalculator{}
+}
+
+// Calculate는 라인별 공급가액을 반올림한 뒤 나머지를 세액으로 계산합니다.
+func (c *KoreanVATCalculator) Calculate(ctx context.Context, req application.TaxRequest) (*application.TaxResult, error) {
+	taxes := make([]float64, len(req.Lines))
+	for i, line := range req.Lines {
+		if line.TaxCategory == taxCategoryExempt {
+			continue
+		}
+		taxes[i] = inclusiveTax(line.Amount, koreanVATRate)
+	}
+	return &application.TaxResult{Inclusive: true, LineTaxes: taxes}, nil
+}
+
+// TaxRate는 과세 유형과 배송 국가별 세율을 정의합니다.
+// Category가 "*"이면 해당 국가의 기본 세율로 사용됩니다.
+type TaxRate struct {
+	Category string
+	Country  string
+	Rate     float64
+}
+
+type taxRateKey struct {
+	category string
+	country  string
+}
+
+// RateTableTaxCalculator는 설정된 세율표로 세액을 계산하는 TaxCalculator 구현체입니다.
+type RateTableTaxCalculator struct {
+	rates     map[taxRateKey]float64
+	inclusive bool
+}
+
+// NewRateTableTaxCalculator는 새로운 RateTableTaxCalculator 인스턴스를 생성합니다.
+// inclusive가 true이면 상품 가격에 세금이 포함된 것으로 보고 세액을 역산합니다.
+func NewRateTableTaxCalculator(rates []TaxRate, inclusive bool) application.TaxCalculator {
+	table := make(map[taxRateKey]float64, len(rates))
+	for _, rate := range rates {
+		key := taxRateKey{category: rate.Category, country: strings.ToUpper(rate.Country)}
+		table[key] = rate.Rate
+	}
+	return &RateTableTaxCalculator{
+		rates:     table,
+		inclusive: inclusive,
+	}
+}
+
+// Calculate는 라인의 과세 유형과 배송 국가에 맞는 세율로 세액을 계산합니다.
+// 해당 과세 유형의 세율이 없으면 국가 기본 세율을 사용합니다.
+func (c *RateTableTaxCalculator) Calculate(ctx context.Context, req application.TaxRequest) (*application.TaxResult, error) {
+	country := strings.ToUpper(req.Country)
+
+	taxes := make([]float64, len(req.Lines))
+	for i, line := range req.Lines {
+		rate, ok := c.rates[taxRateKey{category: line.TaxCategory, country: country}]
+		if !ok {
+			rate, ok = c.rates[taxRateKey{category: anyTaxCategory, country: country}]
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: %s/%s", application.ErrTaxRateNotFound, line.TaxCategory, country)
+		}
+
+		if c.inclusive {
+			taxes[i] = inclusiveTax(line.Amount, rate)
+		} else {
+			taxes[i] = roundAmount(line.Amount * rate)
+		}
+	}
+	return &application.TaxResult{Inclusive: c.inclusive, LineTaxes: taxes}, nil
+}
+
+// ParseTaxRates는 "과세유형:국가=세율" 항목을 쉼표로 구분한 세율표 설정을 해석합니다.
+// 예: "standard:KR=0.1,exempt:KR=0,*:JP=0.1"
+func ParseTaxRates(spec string) ([]TaxRate, error) {
+	rates := []TaxRate{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid tax rate entry %q", entry)
+		}
+		category, country, ok := strings.Cut(key, ":")
+		if !ok || category == "" || country == "" {
+			return nil, fmt.Errorf("invalid tax rate key %q", key)
+		}
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 {
+			return nil, fmt.Errorf("invalid tax rate %q", value)
+		}
+
+		rates = append(rates, TaxRate{
+			Category: strings.TrimSpace(category),
+			Country:  strings.TrimSpace(country),
+			Rate:     rate,
+		})
+	}
+	return rates, nil
+}
+
+// inclusiveTax는 세금이 포함된 금액에서 세액을 역산합니다.
+func inclusiveTax(amount, rate float64) float64 {
+	return roundAmount(amount - roundAmount(amount/(1+rate)))
+}
+
+// roundAmount는 금액을 소수점 둘째 자리까지 반올림합니다.
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
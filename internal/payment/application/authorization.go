@@ -0,0 +1,259 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"example.com/myapp/payment/domain"
+)
+
+const (
+	// expiredAuthorizationBatchSize는 한 번에 취소할 만료된 가승인 수입니다.
+	expiredAuthorizationBatchSize = 100
+	// expiredAuthorizationClaimLease는 선점한 결제를 다른 인스턴스가 건너뛰는 시간입니다.
+	expiredAuthorizationClaimLease = 5 * time.Minute
+)
+
+// AuthorizePayment는 처리 대기 중인 카드 결제를 가승인합니다.
+// 가승인된 금액은 주문의 남은 결제 금액에서 빠지며, authorizationTTL 안에 매입하지 않으면 자동으로 취소됩니다.
+// ProcessPayment처럼 처리 중 상태와 시도 ID를 먼저 저장하므로 다시 요청해도 중복 가승인되지 않습니다.
+func (uc *PaymentUseCase) AuthorizePayment(ctx context.Context, paymentID string) (*domain.Payment, error) {
+	if paymentID == "" {
+		return nil, ErrInvalidPaymentID
+	}
+
+	// 결제 정보 조회
+	payment, err := uc.repo.FindByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 이미 처리된 결제인지 확인 (처리 중인 가승인 시도는 이어서 처리합니다)
+	resuming := payment.IsProcessing(domain.AttemptKindAuthorization)
+	if payment.Status() != domain.PaymentStatusPending && !resuming {
+		return payment, nil
+	}
+	if payment.Method() != domain.PaymentMethodCreditCard {
+		return nil, domain.ErrAuthorizationNotSupported
+	}
+
+	// 생성 이후 다른 결제가 먼저 승인되었거나 주문이 취소되었으면 가승인하지 않습니다
+	if !resuming {
+		if err := uc.checkPayable(ctx, payment); err != nil {
+			return uc.reject(ctx, payment, err, err)
+		}
+	}
+
+	// 처리 중 상태 저장 (다른 요청이 먼저 시작했으면 저장된 결제를 반환합니다)
+	attemptCtx, payment, started, err := uc.beginAttempt(ctx, payment, domain.AttemptKindAuthorization)
+	if err != nil || !started {
+		return payment, err
+	}
+
+	// 결제 게이트웨이를 통해 가승인
+	transactionID, err := uc.gateway.Authorize(attemptCtx, payment)
+	if errors.Is(err, ErrGatewayUnavailable) {
+		// 결과를 알 수 없으므로 거절하지 않고 처리 중 상태로 둡니다
+		return nil, fmt.Errorf("payment authorization failed: %w", err)
+	}
+	if err != nil {
+		return uc.reject(ctx, payment, err, fmt.Errorf("payment authorization failed: %w", err))
+	}
+
+	if err := uc.completeAttempt(ctx, payment, transactionID); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// CapturePayment는 가승인된 결제에서 amount만큼 매입합니다. amount가 0이면 승인 금액 전체를 매입합니다.
+// 만료된 가승인은 매입하지 않고 취소한 뒤 domain.ErrAuthorizationExpired를 반환합니다.
+// AuthorizePayment처럼 매입 금액과 시도 ID를 처리 중 상태로 먼저 저장하므로 다시 요청해도 중복 매입되지 않으며,
+// 처리 중인 매입을 다시 요청하면 저장된 금액으로 같은 시도를 이어서 처리합니다.
+func (uc *PaymentUseCase) CapturePayment(ctx context.Context, paymentID string, amount float64) (*domain.Payment, error) {
+	if paymentID == "" {
+		return nil, ErrInvalidPaymentID
+	}
+
+	payment, err := uc.repo.FindByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 이미 매입된 결제는 다시 매입하지 않습니다
+	if payment.Status() == domain.PaymentStatusCaptured {
+		return payment, nil
+	}
+
+	var attemptCtx context.Context
+	if payment.IsProcessing(domain.AttemptKindCapture) {
+		attemptCtx = WithGatewayRequestID(ctx, payment.Attempt().ID)
+	} else {
+		if amount == 0 {
+			amount = payment.Amount()
+		}
+
+		now := time.Now()
+		if payment.IsAuthorizationExpired(now) {
+			if err := uc.void(ctx, payment, domain.ErrAuthorizationExpired.Error()); err != nil {
+				return nil, err
+			}
+			return payment, domain.ErrAuthorizationExpired
+		}
+
+		// 금액과 상태를 게이트웨이 호출 전에 검증하고 처리 중 상태로 저장합니다
+		if err := payment.StartCapture(amount, now); err != nil {
+			return nil, err
+		}
+		var started bool
+		attemptCtx, payment, started, err = uc.markProcessing(ctx, payment)
+		if err != nil || !started {
+			return payment, err
+		}
+	}
+
+	// 결제 게이트웨이를 통해 매입
+	err = uc.gateway.Capture(attemptCtx, payment, payment.CapturedAmount())
+	if errors.Is(err, ErrGatewayUnavailable) {
+		// 결과를 알 수 없으므로 처리 중 상태로 두고 같은 시도로 다시 처리합니다
+		return nil, fmt.Errorf("payment capture failed: %w", err)
+	}
+	if err != nil {
+		if failErr := uc.failCapture(ctx, payment); failErr != nil {
+			return nil, failErr
+		}
+		return nil, fmt.Errorf("payment capture failed: %w", err)
+	}
+
+	if err := uc.completeAttempt(ctx, payment, payment.TransactionID()); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// VoidPayment는 가승인된 결제를 매입하지 않고 취소합니다.
+// CapturePayment처럼 취소 시도를 처리 중 상태로 먼저 저장하므로 같은 가승인을 동시에 매입하면 둘 중 하나만 처리되고,
+// 처리 중인 취소를 다시 요청하면 같은 시도를 이어서 처리합니다.
+func (uc *PaymentUseCase) VoidPayment(ctx context.Context, paymentID string, reason string) (*domain.Payment, error) {
+	if paymentID == "" {
+		return nil, ErrInvalidPaymentID
+	}
+
+	payment, err := uc.repo.FindByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 이미 취소된 결제는 다시 취소하지 않습니다
+	if payment.Status() == domain.PaymentStatusVoided {
+		return payment, nil
+	}
+
+	if err := uc.void(ctx, payment, reason); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// VoidExpiredAuthorizations는 now 기준으로 만료된 가승인을 취소하고 취소한 결제 목록을 반환합니다.
+func (uc *PaymentUseCase) VoidExpiredAuthorizations(ctx context.Context, now time.Time) ([]*domain.Payment, error) {
+	paymentIDs, err := uc.repo.ClaimExpiredAuthorizations(ctx, now, expiredAuthorizationClaimLease, expiredAuthorizationBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	voided := []*domain.Payment{}
+	for _, paymentID := range paymentIDs {
+		payment, err := uc.repo.FindByID(ctx, paymentID)
+		if err != nil {
+			if errors.Is(err, domain.ErrPaymentNotFound) {
+				continue
+			}
+			return voided, err
+		}
+		// 선점 후 매입되거나 취소된 결제는 건너뜁니다
+		if !payment.IsAuthorizationExpired(now) {
+			continue
+		}
+
+		if err := uc.void(ctx, payment, domain.ErrAuthorizationExpired.Error()); err != nil {
+			return voided, fmt.Errorf("failed to void expired authorization %s: %w", paymentID, err)
+		}
+		voided = append(voided, payment)
+	}
+
+	return voided, nil
+}
+
+// void는 취소 시도를 처리 중 상태로 저장한 뒤 게이트웨이에서 가승인을 취소하고 결제를 취소 상태로 저장합니다.
+// 조회한 뒤 다른 요청이 먼저 매입이나 취소를 시작했으면 게이트웨이를 호출하지 않고 domain.ErrPaymentNotAuthorized를 반환합니다.
+// 게이트웨이 장애로 결과를 모르면 처리 중 상태로 두며, 다시 요청하거나 처리 중 결제 점검이 같은 시도로 마무리합니다.
+func (uc *PaymentUseCase) void(ctx context.Context, payment *domain.Payment, reason string) error {
+	attemptCtx := WithGatewayRequestID(ctx, payment.Attempt().ID)
+	if !payment.IsProcessing(domain.AttemptKindVoid) {
+		if err := payment.StartVoid(time.Now()); err != nil {
+			return err
+		}
+		var started bool
+		var err error
+		attemptCtx, _, started, err = uc.markProcessing(ctx, payment)
+		if err != nil {
+			return err
+		}
+		if !started {
+			return domain.ErrPaymentNotAuthorized
+		}
+	}
+
+	err := uc.gateway.Void(attemptCtx, payment)
+	if errors.Is(err, ErrGatewayUnavailable) {
+		// 결과를 알 수 없으므로 처리 중 상태로 두고 같은 시도로 다시 처리합니다
+		return fmt.Errorf("payment void failed: %w", err)
+	}
+	if err != nil {
+		if failErr := uc.failVoid(ctx, payment); failErr != nil {
+			return failErr
+		}
+		return fmt.Errorf("payment void failed: %w", err)
+	}
+	if err := payment.Void(reason); err != nil {
+		return err
+	}
+	if err := uc.repo.Update(ctx, payment); err != nil {
+		return fmt.Errorf("failed to update payment status after void: %w", err)
+	}
+	return nil
+}
+
+// failCapture는 게이트웨이가 매입하지 않은 시도를 버리고 결제를 가승인 상태로 되돌려 저장합니다.
+func (uc *PaymentUseCase) failCapture(ctx context.Context, payment *domain.Payment) error {
+	if err := payment.FailCapture(time.Now()); err != nil {
+		return err
+	}
+	if err := uc.repo.Update(ctx, payment); err != nil {
+		return fmt.Errorf("failed to update payment status after failed capture: %w", err)
+	}
+	return nil
+}
+
+// failVoid는 게이트웨이가 취소하지 않은 시도를 버리고 결제를 가승인 상태로 되돌려 저장합니다.
+func (uc *PaymentUseCase) failVoid(ctx context.Context, payment *domain.Payment) error {
+	if err := payment.FailVoid(time.Now()); err != nil {
+		return err
+	}
+	if err := uc.repo.Update(ctx, payment); err != nil {
+		return fmt.Errorf("failed to update payment status after failed void: %w", err)
+	}
+	return nil
+}
+
+// reject는 결제를 거부 상태로 저장하고, 저장에 성공하면 결제와 cause를 반환합니다.
+func (uc *PaymentUseCase) reject(ctx context.Context, payment *domain.Payment, reason error, cause error) (*domain.Payment, error) {
+	payment.Reject(reason.Error())
+	if err := uc.repo.Update(ctx, payment); err != nil {
+		return nil, fmt.Errorf("failed to update payment status after rejection: %w", err)
+	}
+	return payment, cause
+}
@@ -9,11 +9,16 @@ import (
 )
 
 var (
-	ErrPaymentAlreadyExists = errors.New("payment already exists for this order")
-	ErrInvalidPaymentID     = errors.New("invalid payment ID")
+	ErrInvalidPaymentID        = errors.New("invalid payment ID")
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrOrderNotPayable         = errors.New("order is not awaiting payment")
+	ErrPaymentExceedsAmountDue = errors.New("payment amount exceeds the amount due for the order")
 )
 
 // CreatePayment는 새로운 결제를 생성합니다.
+// 한 주문을 여러 결제로 나누어 낼 수 있으며, 거절된 결제는 남은 금액에서 빼지 않으므로 다시 결제할 수 있습니다.
+// 결제 금액은 남은 결제 금액에서 처리 대기 중인 결제 금액을 뺀 금액을 넘을 수 없으며,
+// 저장소가 주문 단위로 합계를 다시 확인하고 저장하므로 같은 주문에 동시에 결제를 만들어도 주문 금액을 넘지 않습니다.
 func (uc *PaymentUseCase) CreatePayment(
 	ctx context.Context,
 	orderID string,
@@ -21,13 +26,20 @@ func (uc *PaymentUseCase) CreatePayment(
 	method domain.PaymentMethod,
 	paymentData map[string]string,
 ) (*domain.Payment, error) {
-	// 이미 해당 주문에 대한 결제가 있는지 확인
-	existingPayment, err := uc.repo.FindByOrderID(ctx, orderID)
-	if err != nil && !errors.Is(err, domain.ErrPaymentNotFound) {
-		return nil, fmt.Errorf("failed to check existing payment: %w", err)
+	if orderID == "" {
+		return nil, domain.ErrInvalidOrderID
+	}
+
+	// 주문의 남은 결제 금액 확인
+	summary, payable, err := uc.orderPaymentSummary(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !payable.AcceptsPayment {
+		return nil, ErrOrderNotPayable
 	}
-	if existingPayment != nil {
-		return nil, ErrPaymentAlreadyExists
+	if amount > summary.AmountDue-summary.Pending+0.005 {
+		return nil, ErrPaymentExceedsAmountDue
 	}
 
 	// 결제 엔티티 생성
@@ -36,8 +48,11 @@ func (uc *PaymentUseCase) CreatePayment(
 		return nil, err
 	}
 
-	// 저장소에 결제 저장
-	if err := uc.repo.Save(ctx, payment); err != nil {
+	// 저장소에 결제 저장 (조회 이후 다른 결제가 저장되었으면 거절합니다)
+	if err := uc.repo.SaveWithinOrderTotal(ctx, payment, payable.TotalAmount); err != nil {
+		if errors.Is(err, ErrPaymentExceedsAmountDue) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to save payment: %w", err)
 	}
 
@@ -45,6 +60,9 @@ func (uc *PaymentUseCase) CreatePayment(
 }
 
 // ProcessPayment는 결제를 처리합니다.
+// 게이트웨이를 호출하기 전에 처리 중 상태와 시도 ID를 저장하고, 시도 ID를 게이트웨이 요청 ID로 보냅니다.
+// 게이트웨이 장애로 결과를 모르면 처리 중 상태로 두며, 다시 요청하면 같은 시도 ID로 다시 보내므로 중복 청구되지 않습니다.
+// 가상계좌는 게이트웨이가 계좌를 발급한 뒤 입금 웹훅(ApplyGatewayEvent)이 올 때까지 처리 대기 상태로 남습니다.
 func (uc *PaymentUseCase) ProcessPayment(ctx context.Context, paymentID string) (*domain.Payment, error) {
 	if paymentID == "" {
 		return nil, ErrInvalidPaymentID
@@ -56,28 +74,44 @@ func (uc *PaymentUseCase) ProcessPayment(ctx context.Context, paymentID string)
 		return nil, err
 	}
 
-	// 이미 처리된 결제인지 확인
-	if payment.Status() != domain.PaymentStatusPending {
+	// 이미 처리된 결제인지 확인 (처리 중인 판매 시도는 이어서 처리합니다)
+	resuming := payment.IsProcessing(domain.AttemptKindSale)
+	if payment.Status() != domain.PaymentStatusPending && !resuming {
+		return payment, nil
+	}
+	// 이미 계좌를 발급받아 입금을 기다리는 가상계좌 결제인지 확인
+	if !resuming && payment.Method() == domain.PaymentMethodVirtualAccount && payment.TransactionID() != "" {
 		return payment, nil
 	}
 
+	// 생성 이후 다른 결제가 먼저 승인되었거나 주문이 취소되었으면 승인하지 않습니다
+	if !resuming {
+		if err := uc.checkPayable(ctx, payment); err != nil {
+			return uc.reject(ctx, payment, err, err)
+		}
+	}
+
+	// 처리 중 상태 저장 (다른 요청이 먼저 시작했으면 저장된 결제를 반환합니다)
+	attemptCtx, payment, started, err := uc.beginAttempt(ctx, payment, domain.AttemptKindSale)
+	if err != nil || !started {
+		return payment, err
+	}
+
 	// 결제 게이트웨이를 통해 결제 처리
-	transactionID, err := uc.gateway.ProcessPayment(ctx, payment)
+	transactionID, err := uc.gateway.ProcessPayment(attemptCtx, payment)
+	if errors.Is(err, ErrGatewayUnavailable) {
+		// 결과를 알 수 없으므로 거절하지 않고 처리 중 상태로 둡니다
+		return nil, fmt.Errorf("payment processing failed: %w", err)
+	}
 	if err != nil {
 		// 결제 실패 처리
-		payment.Reject(err.Error())
-		if updateErr := uc.repo.Update(ctx, payment); updateErr != nil {
-			return nil, fmt.Errorf("failed to update payment status after rejection: %w", updateErr)
-		}
-		return payment, fmt.Errorf("payment processing failed: %w", err)
+		return uc.reject(ctx, payment, err, fmt.Errorf("payment processing failed: %w", err))
 	}
 
 	// 결제 성공 처리
-	payment.Approve(transactionID)
-	if err := uc.repo.Update(ctx, payment); err != nil {
-		return nil, fmt.Errorf("failed to update payment status after approval: %w", err)
+	if err := uc.completeAttempt(ctx, payment, transactionID); err != nil {
+		return nil, err
 	}
-
 	return payment, nil
 }
 
@@ -89,40 +123,10 @@ func (uc *PaymentUseCase) GetPayment(ctx context.Context, id string) (*domain.Pa
 	return uc.repo.FindByID(ctx, id)
 }
 
-// GetPaymentByOrderID는 주문 ID로 결제 정보를 조회합니다.
-func (uc *PaymentUseCase) GetPaymentByOrderID(ctx context.Context, orderID string) (*domain.Payment, error) {
+// GetPaymentsByOrderID는 주문의 모든 결제를 생성 순서대로 조회합니다.
+func (uc *PaymentUseCase) GetPaymentsByOrderID(ctx context.Context, orderID string) ([]*domain.Payment, error) {
 	if orderID == "" {
 		return nil, domain.ErrInvalidOrderID
 	}
 	return uc.repo.FindByOrderID(ctx, orderID)
 }
-
-// RefundPayment는 결제를 환불합니다.
-func (uc *PaymentUseCase) RefundPayment(ctx context.Context, id string, reason string) (*domain.Payment, error) {
-	if id == "" {
-		return nil, ErrInvalidPaymentID
-	}
-
-	// 결제 정보 조회
-	payment, err := uc.repo.FindByID(ctx, id)
-	if err != nil {
-		return nil, err
-	}
-
-	// 게이트웨이를 통해 환불 처리
-	if err := uc.gateway.RefundPayment(ctx, payment, reason); err != nil {
-		return nil, fmt.Errorf("refund processing failed: %w", err)
-	}
-
-	// 결제 상태 업데이트
-	if err := payment.Refund(reason); err != nil {
-		return nil, err
-	}
-
-	// 저장소 업데이트
-	if err := uc.repo.Update(ctx, payment); err != nil {
-		return nil, fmt.Errorf("failed to update payment status after refund: %w", err)
-	}
-
-	return payment, nil
-}
\ No newline at end of file
@@ -0,0 +1,1262 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"example.com/myapp/payment/domain"
+)
+
+// FakePaymentRepository는 테스트를 위한 가짜 PaymentRepository 구현체입니다.
+// Postgres 구현과 마찬가지로 저장된 상태의 복사본을 돌려주며, MarkProcessing은 저장된 상태가 맞을 때만 시도를 저장합니다.
+type FakePaymentRepository struct {
+	mu       sync.Mutex
+	payments map[string]*domain.Payment
+	order    []string
+}
+
+// NewFakePaymentRepository는 새로운 FakePaymentRepository 인스턴스를 생성합니다.
+func NewFakePaymentRepository() *FakePaymentRepository {
+	return &FakePaymentRepository{
+		payments: make(map[string]*domain.Payment),
+	}
+}
+
+func (f *FakePaymentRepository) Save(ctx context.Context, payment *domain.Payment) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.payments[payment.ID()] = copyPayment(payment)
+	f.order = append(f.order, payment.ID())
+	return nil
+}
+
+func (f *FakePaymentRepository) SaveWithinOrderTotal(ctx context.Context, payment *domain.Payment, orderTotal float64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	committed := 0.0
+	for _, id := range f.order {
+		existing := f.payments[id]
+		if existing.OrderID() != payment.OrderID() {
+			continue
+		}
+		switch existing.Status() {
+		case domain.PaymentStatusPending, domain.PaymentStatusProcessing, domain.PaymentStatusAuthorized:
+			committed += existing.Amount()
+		case domain.PaymentStatusRejected, domain.PaymentStatusVoided:
+		default:
+			committed += existing.CapturedAmount()
+		}
+	}
+	if committed+payment.Amount() > orderTotal+0.005 {
+		return ErrPaymentExceedsAmountDue
+	}
+	f.payments[payment.ID()] = copyPayment(payment)
+	f.order = append(f.order, payment.ID())
+	return nil
+}
+
+func (f *FakePaymentRepository) FindByID(ctx context.Context, id string) (*domain.Payment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	payment, ok := f.payments[id]
+	if !ok {
+		return nil, domain.ErrPaymentNotFound
+	}
+	return copyPayment(payment), nil
+}
+
+func (f *FakePaymentRepository) FindByOrderID(ctx context.Context, orderID string) ([]*domain.Payment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	payments := []*domain.Payment{}
+	for _, id := range f.order {
+		if payment := f.payments[id]; payment.OrderID() == orderID {
+			payments = append(payments, copyPayment(payment))
+		}
+	}
+	return payments, nil
+}
+
+func (f *FakePaymentRepository) FindByTransactionID(ctx context.Context, transactionID string) (*domain.Payment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range f.order {
+		if payment := f.payments[id]; payment.TransactionID() == transactionID {
+			return copyPayment(payment), nil
+		}
+	}
+	return nil, domain.ErrPaymentNotFound
+}
+
+func (f *FakePaymentRepository) Update(ctx context.Context, payment *domain.Payment) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.payments[payment.ID()]; !ok {
+		return domain.ErrPaymentNotFound
+	}
+	f.payments[payment.ID()] = copyPayment(payment)
+	return nil
+}
+
+func (f *FakePaymentRepository) MarkProcessing(ctx context.Context, payment *domain.Payment) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, ok := f.payments[payment.ID()]
+	if !ok {
+		return domain.ErrPaymentNotFound
+	}
+	expected, notReady := domain.PaymentStatusPending, domain.ErrPaymentNotPending
+	switch payment.Attempt().Kind {
+	case domain.AttemptKindCapture, domain.AttemptKindVoid:
+		expected, notReady = domain.PaymentStatusAuthorized, domain.ErrPaymentNotAuthorized
+	}
+	if stored.Status() != expected {
+		return notReady
+	}
+	f.payments[payment.ID()] = copyPayment(payment)
+	return nil
+}
+
+func (f *FakePaymentRepository) Transition(ctx context.Context, payment *domain.Payment, from domain.PaymentStatus, attemptID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, ok := f.payments[payment.ID()]
+	if !ok {
+		return domain.ErrPaymentNotFound
+	}
+	if stored.Status() != from || stored.Attempt().ID != attemptID {
+		return domain.ErrPaymentNotPending
+	}
+	f.payments[payment.ID()] = copyPayment(payment)
+	return nil
+}
+
+// stored는 저장된 결제를 반환합니다. 테스트에서 저장소의 상태를 확인할 때 사용합니다.
+func (f *FakePaymentRepository) stored(id string) *domain.Payment {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return copyPayment(f.payments[id])
+}
+
+// copyPayment는 저장된 상태의 복사본을 만들어 실제 DB 조회처럼 동작하게 합니다.
+func copyPayment(payment *domain.Payment) *domain.Payment {
+	paymentData := make(map[string]string, len(payment.PaymentData()))
+	for key, value := range payment.PaymentData() {
+		paymentData[key] = value
+	}
+	refunds := make([]*domain.Refund, len(payment.Refunds()))
+	for i, r := range payment.Refunds() {
+		refunds[i] = domain.RestoreRefund(r.ID(), r.PaymentID(), r.Amount(), r.Reason(), r.GatewayRefundID(), r.Status(), r.FailureReason(), r.CreatedAt(), r.UpdatedAt())
+	}
+	return domain.RestorePayment(
+		payment.ID(), payment.OrderID(), payment.Amount(), payment.Method(), payment.Status(), payment.TransactionID(),
+		paymentData, refunds, payment.CapturedAmount(), payment.AuthorizationExpiresAt(), payment.Attempt(),
+		payment.CreatedAt(), payment.UpdatedAt(),
+	)
+}
+
+func (f *FakePaymentRepository) ClaimStuckProcessing(ctx context.Context, cutoff, now time.Time, lease time.Duration, limit int) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	paymentIDs := []string{}
+	for _, id := range f.order {
+		if len(paymentIDs) == limit {
+			break
+		}
+		payment := f.payments[id]
+		if payment.Status() == domain.PaymentStatusProcessing && !payment.Attempt().StartedAt.After(cutoff) {
+			paymentIDs = append(paymentIDs, id)
+		}
+	}
+	return paymentIDs, nil
+}
+
+func (f *FakePaymentRepository) ClaimPendingRefunds(ctx context.Context, cutoff, now time.Time, lease time.Duration, limit int) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	paymentIDs := []string{}
+	for _, id := range f.order {
+		if len(paymentIDs) == limit {
+			break
+		}
+		for _, refund := range f.payments[id].Refunds() {
+			if refund.Status() == domain.RefundStatusPending && !refund.CreatedAt().After(cutoff) {
+				paymentIDs = append(paymentIDs, id)
+				break
+			}
+		}
+	}
+	return paymentIDs, nil
+}
+
+func (f *FakePaymentRepository) FindSettledBetween(ctx context.Context, from, to time.Time) ([]*domain.Payment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	payments := []*domain.Payment{}
+	for _, id := range f.order {
+		payment := f.payments[id]
+		if payment.CreatedAt().Before(from) || !payment.CreatedAt().Before(to) || payment.TransactionID() == "" {
+			continue
+		}
+		switch payment.Status() {
+		case domain.PaymentStatusApproved, domain.PaymentStatusCaptured, domain.PaymentStatusPartiallyRefunded, domain.PaymentStatusRefunded:
+			payments = append(payments, copyPayment(payment))
+		}
+	}
+	return payments, nil
+}
+
+func (f *FakePaymentRepository) ClaimExpiredAuthorizations(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	paymentIDs := []string{}
+	for _, id := range f.order {
+		if len(paymentIDs) == limit {
+			break
+		}
+		if f.payments[id].IsAuthorizationExpired(now) {
+			paymentIDs = append(paymentIDs, id)
+		}
+	}
+	return paymentIDs, nil
+}
+
+// FakeOrderDirectory는 테스트를 위한 가짜 OrderDirectory 구현체입니다.
+type FakeOrderDirectory struct {
+	orders map[string]*PayableOrder
+}
+
+// NewFakeOrderDirectory는 새로운 FakeOrderDirectory 인스턴스를 생성합니다.
+func NewFakeOrderDirectory() *FakeOrderDirectory {
+	return &FakeOrderDirectory{
+		orders: make(map[string]*PayableOrder),
+	}
+}
+
+func (f *FakeOrderDirectory) FindOrder(ctx context.Context, orderID string) (*PayableOrder, error) {
+	order, ok := f.orders[orderID]
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+	return order, nil
+}
+
+// FakePaymentGateway는 테스트를 위한 가짜 PaymentGateway 구현체입니다.
+// declined에 있는 결제 수단은 승인을 거절하고, unavailable이면 판매와 가승인에 일시 장애를 반환합니다.
+// lostResponses이면 판매와 가승인을 처리한 뒤 응답만 잃어버린 것처럼 일시 장애를 반환합니다.
+// captureUnavailable이면 매입에 일시 장애를 반환하고, captureDeclined이면 매입을 거절합니다.
+// lostRefunds이면 환불을 처리한 뒤 응답만 잃어버린 것처럼 일시 장애를 반환합니다.
+type FakePaymentGateway struct {
+	mu                 sync.Mutex
+	declined           map[domain.PaymentMethod]bool
+	unavailable        bool
+	lostResponses      bool
+	captureUnavailable bool
+	captureDeclined    bool
+	refundErr          error
+	lostRefunds        bool
+	refunds            []float64
+	refundIDs          map[string]string
+	captures           []float64
+	capturedIDs        []string
+	voids              []string
+	requestIDs         []string
+	transactionIDs     map[string]string
+}
+
+// NewFakePaymentGateway는 새로운 FakePaymentGateway 인스턴스를 생성합니다.
+func NewFakePaymentGateway() *FakePaymentGateway {
+	return &FakePaymentGateway{
+		declined:       make(map[domain.PaymentMethod]bool),
+		refundIDs:      make(map[string]string),
+		transactionIDs: make(map[string]string),
+	}
+}
+
+func (f *FakePaymentGateway) ProcessPayment(ctx context.Context, payment *domain.Payment) (string, error) {
+	return f.charge(ctx, payment, "txn_")
+}
+
+func (f *FakePaymentGateway) Authorize(ctx context.Context, payment *domain.Payment) (string, error) {
+	return f.charge(ctx, payment, "auth_")
+}
+
+// charge는 판매나 가승인 요청을 요청 ID별로 기록하고 결과를 반환합니다.
+func (f *FakePaymentGateway) charge(ctx context.Context, payment *domain.Payment, prefix string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	requestID, _ := GatewayRequestID(ctx)
+	f.requestIDs = append(f.requestIDs, requestID)
+	if f.unavailable {
+		return "", fmt.Errorf("%w: timeout", ErrGatewayUnavailable)
+	}
+	if f.declined[payment.Method()] {
+		return "", errors.New("card declined")
+	}
+	f.transactionIDs[requestID] = prefix + payment.ID()
+	if f.lostResponses {
+		return "", fmt.Errorf("%w: response lost", ErrGatewayUnavailable)
+	}
+	return prefix + payment.ID(), nil
+}
+
+func (f *FakePaymentGateway) LookupAttempt(ctx context.Context, payment *domain.Payment) (*GatewayAttempt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	transactionID, ok := f.transactionIDs[payment.Attempt().ID]
+	if !ok {
+		return &GatewayAttempt{Status: GatewayAttemptNotFound}, nil
+	}
+	return &GatewayAttempt{Status: GatewayAttemptApproved, TransactionID: transactionID}, nil
+}
+
+// Capture는 매입 요청을 요청 ID별로 한 번만 기록합니다.
+func (f *FakePaymentGateway) Capture(ctx context.Context, payment *domain.Payment, amount float64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	requestID, _ := GatewayRequestID(ctx)
+	f.requestIDs = append(f.requestIDs, requestID)
+	if f.captureUnavailable {
+		return fmt.Errorf("%w: timeout", ErrGatewayUnavailable)
+	}
+	if f.captureDeclined {
+		return errors.New("capture declined")
+	}
+	if _, ok := f.transactionIDs[requestID]; !ok {
+		f.transactionIDs[requestID] = payment.TransactionID()
+		f.captures = append(f.captures, amount)
+		f.capturedIDs = append(f.capturedIDs, payment.ID())
+	}
+	return nil
+}
+
+// Void는 취소 요청을 요청 ID별로 한 번만 기록합니다.
+func (f *FakePaymentGateway) Void(ctx context.Context, payment *domain.Payment) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	requestID, _ := GatewayRequestID(ctx)
+	if _, ok := f.transactionIDs[requestID]; !ok {
+		f.transactionIDs[requestID] = payment.TransactionID()
+		f.voids = append(f.voids, payment.ID())
+	}
+	return nil
+}
+
+// RefundPayment는 환불 요청을 요청 ID별로 한 번만 기록하고 같은 환불 ID를 돌려줍니다.
+func (f *FakePaymentGateway) RefundPayment(ctx context.Context, payment *domain.Payment, amount float64, reason string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.refundErr != nil {
+		return "", f.refundErr
+	}
+	requestID, _ := GatewayRequestID(ctx)
+	if refundID, ok := f.refundIDs[requestID]; ok {
+		return refundID, nil
+	}
+	f.refunds = append(f.refunds, amount)
+	refundID := fmt.Sprintf("rfnd_%d", len(f.refunds))
+	f.refundIDs[requestID] = refundID
+	if f.lostRefunds {
+		return "", fmt.Errorf("%w: response lost", ErrGatewayUnavailable)
+	}
+	return refundID, nil
+}
+
+func (f *FakePaymentGateway) LookupRefund(ctx context.Context, payment *domain.Payment, refundID string) (*GatewayAttempt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	gatewayRefundID, ok := f.refundIDs[refundID]
+	if !ok {
+		return &GatewayAttempt{Status: GatewayAttemptNotFound}, nil
+	}
+	return &GatewayAttempt{Status: GatewayAttemptApproved, TransactionID: gatewayRefundID}, nil
+}
+
+// FakeWebhookEventRepository는 테스트를 위한 가짜 WebhookEventRepository 구현체입니다.
+type FakeWebhookEventRepository struct {
+	events       map[string]*WebhookEvent
+	claimedUntil map[string]time.Time
+}
+
+// NewFakeWebhookEventRepository는 새로운 FakeWebhookEventRepository 인스턴스를 생성합니다.
+func NewFakeWebhookEventRepository() *FakeWebhookEventRepository {
+	return &FakeWebhookEventRepository{
+		events:       make(map[string]*WebhookEvent),
+		claimedUntil: make(map[string]time.Time),
+	}
+}
+
+func (f *FakeWebhookEventRepository) Save(ctx context.Context, event *WebhookEvent) (bool, error) {
+	key := event.Provider + "/" + event.EventID
+	if _, ok := f.events[key]; ok {
+		return false, nil
+	}
+	copied := *event
+	f.events[key] = &copied
+	return true, nil
+}
+
+func (f *FakeWebhookEventRepository) Claim(ctx context.Context, provider, eventID string, now time.Time, lease time.Duration) (*WebhookEvent, bool, error) {
+	key := provider + "/" + eventID
+	event := *f.events[key]
+	if !f.claimable(key, now) {
+		return &event, false, nil
+	}
+	f.claimedUntil[key] = now.Add(lease)
+	return &event, true, nil
+}
+
+func (f *FakeWebhookEventRepository) ClaimRetryable(ctx context.Context, now time.Time, lease time.Duration, maxAttempts, limit int) ([]*WebhookEvent, error) {
+	events := []*WebhookEvent{}
+	for key, stored := range f.events {
+		if len(events) == limit || stored.Attempts >= maxAttempts || !f.claimable(key, now) {
+			continue
+		}
+		f.claimedUntil[key] = now.Add(lease)
+		event := *stored
+		events = append(events, &event)
+	}
+	return events, nil
+}
+
+func (f *FakeWebhookEventRepository) Finish(ctx context.Context, event *WebhookEvent) error {
+	key := event.Provider + "/" + event.EventID
+	copied := *event
+	f.events[key] = &copied
+	delete(f.claimedUntil, key)
+	return nil
+}
+
+// claimable은 처리되지 않았고 선점되지 않은 이벤트인지 확인합니다.
+func (f *FakeWebhookEventRepository) claimable(key string, now time.Time) bool {
+	switch f.events[key].Status {
+	case WebhookEventStatusReceived, WebhookEventStatusFailed:
+		return !f.claimedUntil[key].After(now)
+	default:
+		return false
+	}
+}
+
+// FakeReconciliationRepository는 테스트를 위한 가짜 ReconciliationRepository 구현체입니다.
+type FakeReconciliationRepository struct {
+	reconciliations map[string]*Reconciliation
+}
+
+// NewFakeReconciliationRepository는 새로운 FakeReconciliationRepository 인스턴스를 생성합니다.
+func NewFakeReconciliationRepository() *FakeReconciliationRepository {
+	return &FakeReconciliationRepository{
+		reconciliations: make(map[string]*Reconciliation),
+	}
+}
+
+func (f *FakeReconciliationRepository) Save(ctx context.Context, reconciliation *Reconciliation) error {
+	f.reconciliations[reconciliation.ID] = reconciliation
+	return nil
+}
+
+func (f *FakeReconciliationRepository) FindByID(ctx context.Context, id string) (*Reconciliation, error) {
+	reconciliation, ok := f.reconciliations[id]
+	if !ok {
+		return nil, ErrReconciliationNotFound
+	}
+	return reconciliation, nil
+}
+
+// FakeWebhookProvider는 테스트를 위한 가짜 WebhookProvider 구현체입니다.
+// 서명이 "valid"인 웹훅만 받고, 본문은 GatewayEvent JSON으로 해석합니다.
+type FakeWebhookProvider struct{}
+
+func (FakeWebhookProvider) Verify(signature, timestamp string, payload []byte, now time.Time) error {
+	if signature != "valid" {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+func (FakeWebhookProvider) ParseEvent(payload []byte) (*GatewayEvent, error) {
+	var event GatewayEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func TestCreateRefundRecordsPartialRefundsUpToPaymentAmount(t *testing.T) {
+	repo := NewFakePaymentRepository()
+	gateway := NewFakePaymentGateway()
+	orders := NewFakeOrderDirectory()
+	orders.orders["ord-1"] = &PayableOrder{ID: "ord-1", TotalAmount: 1000, AcceptsPayment: true}
+	useCase := NewPaymentUseCase(repo, orders, gateway, time.Hour)
+	ctx := context.Background()
+
+	payment, err := useCase.CreatePayment(ctx, "ord-1", 1000, domain.PaymentMethodCreditCard, map[string]string{})
+	if err != nil {
+		t.Fatalf("CreatePayment() error = %v", err)
+	}
+	if _, err := useCase.CreateRefund(ctx, payment.ID(), 100, "승인 전"); !errors.Is(err, domain.ErrPaymentNotRefundable) {
+		t.Errorf("CreateRefund(pending) error = %v, want %v", err, domain.ErrPaymentNotRefundable)
+	}
+	if _, err := useCase.ProcessPayment(ctx, payment.ID()); err != nil {
+		t.Fatalf("ProcessPayment() error = %v", err)
+	}
+
+	refund, err := useCase.CreateRefund(ctx, payment.ID(), 300, "부분 반품")
+	if err != nil {
+		t.Fatalf("CreateRefund() error = %v", err)
+	}
+	if refund.Status() != domain.RefundStatusSucceeded || refund.GatewayRefundID() != "rfnd_1" {
+		t.Errorf("refund = %v, %v, want succeeded, rfnd_1", refund.Status(), refund.GatewayRefundID())
+	}
+	// 게이트웨이 요청 ID는 저장된 환불 ID입니다
+	if gateway.refundIDs[refund.ID()] != "rfnd_1" {
+		t.Errorf("gateway refund request IDs = %v, want %s", gateway.refundIDs, refund.ID())
+	}
+	payment = repo.stored(payment.ID())
+	if payment.Status() != domain.PaymentStatusPartiallyRefunded || payment.RefundableAmount() != 700 {
+		t.Errorf("payment = %v, refundable %v, want partially_refunded, 700", payment.Status(), payment.RefundableAmount())
+	}
+
+	// 게이트웨이가 실패한 환불은 내역에 남지만 환불 가능 금액을 줄이지 않습니다
+	gateway.refundErr = errors.New("gateway timeout")
+	if _, err := useCase.CreateRefund(ctx, payment.ID(), 200, "재시도 대상"); err == nil {
+		t.Fatal("CreateRefund() error = nil, want gateway error")
+	}
+	gateway.refundErr = nil
+	payment = repo.stored(payment.ID())
+	if payment.RefundableAmount() != 700 {
+		t.Errorf("RefundableAmount() = %v, want 700", payment.RefundableAmount())
+	}
+
+	if _, err := useCase.CreateRefund(ctx, payment.ID(), 700.01, "초과"); !errors.Is(err, domain.ErrRefundExceedsPayment) {
+		t.Errorf("CreateRefund(over) error = %v, want %v", err, domain.ErrRefundExceedsPayment)
+	}
+	if _, err := useCase.CreateRefund(ctx, payment.ID(), 0, "0원"); !errors.Is(err, domain.ErrInvalidRefundAmount) {
+		t.Errorf("CreateRefund(0) error = %v, want %v", err, domain.ErrInvalidRefundAmount)
+	}
+
+	// 남은 금액 전체를 환불하면 결제가 환불 상태가 됩니다
+	if _, err := useCase.RefundPayment(ctx, payment.ID(), "전체 환불"); err != nil {
+		t.Fatalf("RefundPayment() error = %v", err)
+	}
+	payment = repo.stored(payment.ID())
+	if payment.Status() != domain.PaymentStatusRefunded || payment.RefundedAmount() != 1000 {
+		t.Errorf("payment = %v, refunded %v, want refunded, 1000", payment.Status(), payment.RefundedAmount())
+	}
+
+	refunds, err := useCase.GetRefunds(ctx, payment.ID())
+	if err != nil {
+		t.Fatalf("GetRefunds() error = %v", err)
+	}
+	statuses := []domain.RefundStatus{}
+	for _, r := range refunds {
+		statuses = append(statuses, r.Status())
+	}
+	if fmt.Sprint(statuses) != "[succeeded failed succeeded]" || fmt.Sprint(gateway.refunds) != "[300 700]" {
+		t.Errorf("refunds = %v, gateway refunds = %v, want [succeeded failed succeeded], [300 700]", statuses, gateway.refunds)
+	}
+}
+
+func TestReconcilePendingRefunds(t *testing.T) {
+	repo := NewFakePaymentRepository()
+	gateway := NewFakePaymentGateway()
+	orders := NewFakeOrderDirectory()
+	orders.orders["ord-1"] = &PayableOrder{ID: "ord-1", TotalAmount: 1000, AcceptsPayment: true}
+	useCase := NewPaymentUseCase(repo, orders, gateway, time.Hour)
+	ctx := context.Background()
+
+	payment, err := useCase.CreatePayment(ctx, "ord-1", 1000, domain.PaymentMethodCreditCard, map[string]string{})
+	if err != nil {
+		t.Fatalf("CreatePayment() error = %v", err)
+	}
+	if _, err := useCase.ProcessPayment(ctx, payment.ID()); err != nil {
+		t.Fatalf("ProcessPayment() error = %v", err)
+	}
+
+	// 게이트웨이가 환불한 뒤 응답을 잃어버려도 실패로 기록하지 않고 진행 중으로 둡니다
+	gateway.lostRefunds = true
+	lost, err := useCase.CreateRefund(ctx, payment.ID(), 300, "부분 반품")
+	if !errors.Is(err, ErrGatewayUnavailable) {
+		t.Fatalf("CreateRefund(lost) error = %v, want %v", err, ErrGatewayUnavailable)
+	}
+	if lost == nil || lost.Status() != domain.RefundStatusPending {
+		t.Fatalf("CreateRefund(lost) refund = %+v, want the pending refund", lost)
+	}
+	gateway.lostRefunds = false
+
+	// 게이트웨이가 받지 못한 환불도 진행 중으로 둡니다
+	gateway.refundErr = fmt.Errorf("%w: timeout", ErrGatewayUnavailable)
+	if _, err := useCase.CreateRefund(ctx, payment.ID(), 200, "추가 반품"); !errors.Is(err, ErrGatewayUnavailable) {
+		t.Fatalf("CreateRefund(unavailable) error = %v, want %v", err, ErrGatewayUnavailable)
+	}
+	gateway.refundErr = nil
+
+	stored := repo.stored(payment.ID())
+	if stored.RefundableAmount() != 500 || stored.RefundedAmount() != 0 {
+		t.Errorf("refundable %v, refunded %v, want 500, 0", stored.RefundableAmount(), stored.RefundedAmount())
+	}
+
+	if reconciled, err := useCase.ReconcilePendingRefunds(ctx, time.Now().Add(-time.Minute)); err != nil || reconciled != 0 {
+		t.Fatalf("ReconcilePendingRefunds(recent) = %d, %v, want 0", reconciled, err)
+	}
+	reconciled, err := useCase.ReconcilePendingRefunds(ctx, time.Now())
+	if err != nil || reconciled != 2 {
+		t.Fatalf("ReconcilePendingRefunds() = %d, %v, want 2", reconciled, err)
+	}
+
+	// 처리된 환불은 조회한 결과로 완료하고, 받지 못한 환불만 같은 요청 ID로 다시 보냅니다
+	stored = repo.stored(payment.ID())
+	gatewayRefundIDs := []string{}
+	for _, refund := range stored.Refunds() {
+		if refund.Status() != domain.RefundStatusSucceeded {
+			t.Errorf("refund %v status = %v, want succeeded", refund.Amount(), refund.Status())
+		}
+		gatewayRefundIDs = append(gatewayRefundIDs, refund.GatewayRefundID())
+	}
+	if fmt.Sprint(gatewayRefundIDs) != "[rfnd_1 rfnd_2]" || fmt.Sprint(gateway.refunds) != "[300 200]" {
+		t.Errorf("gateway refund IDs = %v, gateway refunds = %v, want [rfnd_1 rfnd_2], [300 200]", gatewayRefundIDs, gateway.refunds)
+	}
+	if stored.Status() != domain.PaymentStatusPartiallyRefunded || stored.RefundedAmount() != 500 {
+		t.Errorf("payment = %v, refunded %v, want partially_refunded, 500", stored.Status(), stored.RefundedAmount())
+	}
+
+	if reconciled, err := useCase.ReconcilePendingRefunds(ctx, time.Now()); err != nil || reconciled != 0 {
+		t.Errorf("ReconcilePendingRefunds(again) = %d, %v, want 0", reconciled, err)
+	}
+}
+
+// racingPaymentRepository는 결제 현황을 조회한 뒤 저장하기 전에 다른 요청이 같은 주문에 결제를 저장한 상황을 흉내 냅니다.
+type racingPaymentRepository struct {
+	*FakePaymentRepository
+	concurrent *domain.Payment
+}
+
+func (r *racingPaymentRepository) SaveWithinOrderTotal(ctx context.Context, payment *domain.Payment, orderTotal float64) error {
+	if r.concurrent != nil {
+		if err := r.FakePaymentRepository.SaveWithinOrderTotal(ctx, r.concurrent, orderTotal); err != nil {
+			return err
+		}
+		r.concurrent = nil
+	}
+	return r.FakePaymentRepository.SaveWithinOrderTotal(ctx, payment, orderTotal)
+}
+
+func TestCreatePaymentRechecksAmountDueWhenSaving(t *testing.T) {
+	concurrent, err := domain.NewPayment("ord-1", 700, domain.PaymentMethodCreditCard, map[string]string{})
+	if err != nil {
+		t.Fatalf("NewPayment() error = %v", err)
+	}
+	repo := &racingPaymentRepository{FakePaymentRepository: NewFakePaymentRepository(), concurrent: concurrent}
+	orders := NewFakeOrderDirectory()
+	orders.orders["ord-1"] = &PayableOrder{ID: "ord-1", TotalAmount: 1000, AcceptsPayment: true}
+	useCase := NewPaymentUseCase(repo, orders, NewFakePaymentGateway(), time.Hour)
+	ctx := context.Background()
+
+	// 조회할 때는 1000이 남았지만 저장할 때는 동시에 만들어진 700 결제 때문에 300만 남았습니다
+	if _, err := useCase.CreatePayment(ctx, "ord-1", 500, domain.PaymentMethodBankTransfer, map[string]string{}); !errors.Is(err, ErrPaymentExceedsAmountDue) {
+		t.Fatalf("CreatePayment(500) error = %v, want %v", err, ErrPaymentExceedsAmountDue)
+	}
+	if len(repo.order) != 1 {
+		t.Errorf("saved payments = %d, want 1", len(repo.order))
+	}
+
+	if _, err := useCase.CreatePayment(ctx, "ord-1", 300, domain.PaymentMethodBankTransfer, map[string]string{}); err != nil {
+		t.Fatalf("CreatePayment(300) error = %v", err)
+	}
+}
+
+func TestSplitTenderPaymentsAndOrderSummary(t *testing.T) {
+	repo := NewFakePaymentRepository()
+	gateway := NewFakePaymentGateway()
+	gateway.declined[domain.PaymentMethodVirtualAccount] = true
+	orders := NewFakeOrderDirectory()
+	orders.orders["ord-1"] = &PayableOrder{ID: "ord-1", TotalAmount: 1000, AcceptsPayment: true}
+	useCase := NewPaymentUseCase(repo, orders, gateway, time.Hour)
+	ctx := context.Background()
+
+	pay := func(amount float64, method domain.PaymentMethod) (*domain.Payment, error) {
+		payment, err := useCase.CreatePayment(ctx, "ord-1", amount, method, map[string]string{})
+		if err != nil {
+			return nil, err
+		}
+		return useCase.ProcessPayment(ctx, payment.ID())
+	}
+
+	// 카드 600 + 거절된 가상계좌 400 + 계좌이체 400
+	if _, err := pay(600, domain.PaymentMethodCreditCard); err != nil {
+		t.Fatalf("card payment error = %v", err)
+	}
+	if _, err := pay(400, domain.PaymentMethodVirtualAccount); err == nil {
+		t.Fatal("virtual account payment error = nil, want declined")
+	}
+	if _, err := useCase.CreatePayment(ctx, "ord-1", 401, domain.PaymentMethodBankTransfer, map[string]string{}); !errors.Is(err, ErrPaymentExceedsAmountDue) {
+		t.Errorf("CreatePayment(401) error = %v, want %v", err, ErrPaymentExceedsAmountDue)
+	}
+	if _, err := pay(400, domain.PaymentMethodBankTransfer); err != nil {
+		t.Fatalf("bank transfer payment error = %v", err)
+	}
+
+	// 반품 환불은 결제 순서대로 나누어 환불되고, 같은 사유로 다시 요청하면 중복 환불하지 않습니다
+	refunds, err := useCase.RefundOrder(ctx, "ord-1", 700, "반품 ret-1")
+	if err != nil {
+		t.Fatalf("RefundOrder() error = %v", err)
+	}
+	again, err := useCase.RefundOrder(ctx, "ord-1", 700, "반품 ret-1")
+	if err != nil {
+		t.Fatalf("RefundOrder(again) error = %v", err)
+	}
+	if len(refunds) != 2 || len(again) != 2 || fmt.Sprint(gateway.refunds) != "[600 100]" {
+		t.Errorf("refunds = %d, again = %d, gateway refunds = %v, want 2, 2, [600 100]", len(refunds), len(again), gateway.refunds)
+	}
+
+	summary, err := useCase.GetOrderPaymentSummary(ctx, "ord-1")
+	if err != nil {
+		t.Fatalf("GetOrderPaymentSummary() error = %v", err)
+	}
+	if summary.Paid != 1000 || summary.Refunded != 700 || summary.AmountDue != 0 || len(summary.Payments) != 3 {
+		t.Errorf("summary = paid %v, refunded %v, due %v, payments %d, want 1000, 700, 0, 3", summary.Paid, summary.Refunded, summary.AmountDue, len(summary.Payments))
+	}
+
+	// 주문이 결제 대기 상태가 아니면 결제할 수 없습니다
+	orders.orders["ord-2"] = &PayableOrder{ID: "ord-2", TotalAmount: 500}
+	if _, err := useCase.CreatePayment(ctx, "ord-2", 500, domain.PaymentMethodCreditCard, map[string]string{}); !errors.Is(err, ErrOrderNotPayable) {
+		t.Errorf("CreatePayment(canceled order) error = %v, want %v", err, ErrOrderNotPayable)
+	}
+}
+
+func TestAuthorizeCaptureAndVoidExpiredAuthorizations(t *testing.T) {
+	repo := NewFakePaymentRepository()
+	gateway := NewFakePaymentGateway()
+	orders := NewFakeOrderDirectory()
+	orders.orders["ord-1"] = &PayableOrder{ID: "ord-1", TotalAmount: 1000, AcceptsPayment: true}
+	orders.orders["ord-2"] = &PayableOrder{ID: "ord-2", TotalAmount: 500, AcceptsPayment: true}
+	useCase := NewPaymentUseCase(repo, orders, gateway, time.Hour)
+	ctx := context.Background()
+
+	authorize := func(orderID string, amount float64, method domain.PaymentMethod) (*domain.Payment, error) {
+		payment, err := useCase.CreatePayment(ctx, orderID, amount, method, map[string]string{})
+		if err != nil {
+			return nil, err
+		}
+		return useCase.AuthorizePayment(ctx, payment.ID())
+	}
+
+	// 가승인은 카드 결제만 가능하고, 가승인되지 않은 결제는 취소할 수 없습니다
+	orders.orders["ord-3"] = &PayableOrder{ID: "ord-3", TotalAmount: 100, AcceptsPayment: true}
+	if _, err := authorize("ord-3", 100, domain.PaymentMethodBankTransfer); !errors.Is(err, domain.ErrAuthorizationNotSupported) {
+		t.Errorf("AuthorizePayment(bank transfer) error = %v, want %v", err, domain.ErrAuthorizationNotSupported)
+	}
+	pending, _ := useCase.GetPaymentsByOrderID(ctx, "ord-3")
+	if _, err := useCase.VoidPayment(ctx, pending[0].ID(), "수단 변경"); !errors.Is(err, domain.ErrPaymentNotAuthorized) {
+		t.Errorf("VoidPayment(pending) error = %v, want %v", err, domain.ErrPaymentNotAuthorized)
+	}
+
+	// 가승인 금액은 남은 결제 금액에서 빠지지만 매입 전에는 환불할 수 없습니다
+	card, err := authorize("ord-1", 1000, domain.PaymentMethodCreditCard)
+	if err != nil {
+		t.Fatalf("AuthorizePayment() error = %v", err)
+	}
+	if card.Status() != domain.PaymentStatusAuthorized || card.TransactionID() != "auth_"+card.ID() {
+		t.Errorf("payment = %v, %v, want authorized, auth_%v", card.Status(), card.TransactionID(), card.ID())
+	}
+	summary, _ := useCase.GetOrderPaymentSummary(ctx, "ord-1")
+	if summary.Authorized != 1000 || summary.Paid != 0 || summary.AmountDue != 0 {
+		t.Errorf("summary = authorized %v, paid %v, due %v, want 1000, 0, 0", summary.Authorized, summary.Paid, summary.AmountDue)
+	}
+	if _, err := useCase.CreateRefund(ctx, card.ID(), 100, "매입 전"); !errors.Is(err, domain.ErrPaymentNotRefundable) {
+		t.Errorf("CreateRefund(authorized) error = %v, want %v", err, domain.ErrPaymentNotRefundable)
+	}
+
+	// 부분 매입 후에는 매입한 금액까지만 환불할 수 있습니다
+	if _, err := useCase.CapturePayment(ctx, card.ID(), 1000.01); !errors.Is(err, domain.ErrCaptureExceedsAuthorization) {
+		t.Errorf("CapturePayment(over) error = %v, want %v", err, domain.ErrCaptureExceedsAuthorization)
+	}
+	if _, err := useCase.CapturePayment(ctx, card.ID(), 800); err != nil {
+		t.Fatalf("CapturePayment() error = %v", err)
+	}
+	card = repo.stored(card.ID())
+	if card.Status() != domain.PaymentStatusCaptured || card.CapturedAmount() != 800 || fmt.Sprint(gateway.captures) != "[800]" {
+		t.Errorf("payment = %v, captured %v, gateway captures %v, want captured, 800, [800]", card.Status(), card.CapturedAmount(), gateway.captures)
+	}
+	if _, err := useCase.CreateRefund(ctx, card.ID(), 800.01, "초과"); !errors.Is(err, domain.ErrRefundExceedsPayment) {
+		t.Errorf("CreateRefund(over) error = %v, want %v", err, domain.ErrRefundExceedsPayment)
+	}
+	if _, err := useCase.RefundPayment(ctx, card.ID(), "전체 반품"); err != nil {
+		t.Fatalf("RefundPayment() error = %v", err)
+	}
+	card = repo.stored(card.ID())
+	if card.Status() != domain.PaymentStatusRefunded || fmt.Sprint(gateway.refunds) != "[800]" {
+		t.Errorf("payment = %v, gateway refunds %v, want refunded, [800]", card.Status(), gateway.refunds)
+	}
+
+	// 만료된 가승인은 매입할 수 없고 자동 취소 대상입니다
+	expiring, err := authorize("ord-2", 500, domain.PaymentMethodCreditCard)
+	if err != nil {
+		t.Fatalf("AuthorizePayment(ord-2) error = %v", err)
+	}
+	if voided, err := useCase.VoidExpiredAuthorizations(ctx, time.Now()); err != nil || len(voided) != 0 {
+		t.Fatalf("VoidExpiredAuthorizations(before) = %v, %v, want 0, nil", len(voided), err)
+	}
+	voided, err := useCase.VoidExpiredAuthorizations(ctx, time.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("VoidExpiredAuthorizations() error = %v", err)
+	}
+	expiring = repo.stored(expiring.ID())
+	if len(voided) != 1 || expiring.Status() != domain.PaymentStatusVoided || fmt.Sprint(gateway.voids) != fmt.Sprint([]string{expiring.ID()}) {
+		t.Errorf("voided = %v, payment = %v, gateway voids = %v, want 1, voided, [%v]", len(voided), expiring.Status(), gateway.voids, expiring.ID())
+	}
+	if _, err := useCase.CapturePayment(ctx, expiring.ID(), 0); !errors.Is(err, domain.ErrPaymentNotAuthorized) {
+		t.Errorf("CapturePayment(voided) error = %v, want %v", err, domain.ErrPaymentNotAuthorized)
+	}
+	summary, _ = useCase.GetOrderPaymentSummary(ctx, "ord-2")
+	if summary.Authorized != 0 || summary.AmountDue != 500 {
+		t.Errorf("summary = authorized %v, due %v, want 0, 500", summary.Authorized, summary.AmountDue)
+	}
+}
+
+func TestReceiveWebhookAppliesGatewayEventsOnce(t *testing.T) {
+	repo := NewFakePaymentRepository()
+	gateway := NewFakePaymentGateway()
+	orders := NewFakeOrderDirectory()
+	orders.orders["ord-1"] = &PayableOrder{ID: "ord-1", TotalAmount: 1000, AcceptsPayment: true}
+	useCase := NewPaymentUseCase(repo, orders, gateway, time.Hour)
+	events := NewFakeWebhookEventRepository()
+	webhooks := NewWebhookUseCase(events, useCase, map[string]WebhookProvider{"simulator": FakeWebhookProvider{}})
+	ctx := context.Background()
+
+	receive := func(event GatewayEvent) (*WebhookEvent, error) {
+		payload, _ := json.Marshal(event)
+		return webhooks.ReceiveWebhook(ctx, "Simulator", "valid", "0", payload)
+	}
+
+	// 가상계좌는 계좌 발급 후 입금 웹훅이 올 때까지 처리 대기 상태입니다
+	account, _ := useCase.CreatePayment(ctx, "ord-1", 600, domain.PaymentMethodVirtualAccount, map[string]string{})
+	account, err := useCase.ProcessPayment(ctx, account.ID())
+	if err != nil || account.Status() != domain.PaymentStatusPending || account.TransactionID() == "" {
+		t.Fatalf("ProcessPayment(virtual account) = %v, %v, want pending with transaction ID", account.Status(), err)
+	}
+
+	// 입금 웹훅은 트랜잭션 ID로 결제를 찾아 승인하고, 같은 이벤트는 한 번만 처리합니다
+	deposit := GatewayEvent{ID: "evt-1", Type: GatewayEventDepositReceived, TransactionID: account.TransactionID(), Amount: 600}
+	for i := 0; i < 2; i++ {
+		event, err := receive(deposit)
+		if err != nil || event.Status != WebhookEventStatusProcessed || event.Attempts != 1 {
+			t.Fatalf("ReceiveWebhook(deposit #%d) = %+v, %v, want processed once", i+1, event, err)
+		}
+	}
+	account = repo.stored(account.ID())
+	if account.Status() != domain.PaymentStatusApproved {
+		t.Errorf("virtual account status = %v, want %v", account.Status(), domain.PaymentStatusApproved)
+	}
+
+	// 서명이 틀리거나 등록되지 않은 PG사의 웹훅은 저장하지 않습니다
+	if _, err := webhooks.ReceiveWebhook(ctx, "simulator", "forged", "0", []byte(`{"ID":"evt-x"}`)); !errors.Is(err, ErrInvalidWebhook) {
+		t.Errorf("ReceiveWebhook(forged) error = %v, want %v", err, ErrInvalidWebhook)
+	}
+	if _, err := webhooks.ReceiveWebhook(ctx, "unknown", "valid", "0", []byte(`{"ID":"evt-x"}`)); !errors.Is(err, ErrUnknownWebhookProvider) {
+		t.Errorf("ReceiveWebhook(unknown provider) error = %v, want %v", err, ErrUnknownWebhookProvider)
+	}
+	if len(events.events) != 1 {
+		t.Errorf("stored events = %d, want 1", len(events.events))
+	}
+
+	// 승인 웹훅보다 먼저 온 차지백은 실패로 저장했다가, 승인 후 재처리에서 환불로 기록합니다
+	card, _ := useCase.CreatePayment(ctx, "ord-1", 400, domain.PaymentMethodCreditCard, map[string]string{})
+	chargeback := GatewayEvent{ID: "evt-2", Type: GatewayEventChargeback, PaymentID: card.ID(), Amount: 150, Reason: "fraud"}
+	if event, err := receive(chargeback); !errors.Is(err, domain.ErrPaymentNotRefundable) || event.Status != WebhookEventStatusFailed {
+		t.Fatalf("ReceiveWebhook(early chargeback) = %+v, %v, want failed", event, err)
+	}
+	if _, err := receive(GatewayEvent{ID: "evt-3", Type: GatewayEventPaymentApproved, PaymentID: card.ID(), TransactionID: "txn-card"}); err != nil {
+		t.Fatalf("ReceiveWebhook(approved) error = %v", err)
+	}
+	processed, err := webhooks.RetryFailedWebhooks(ctx, time.Now())
+	if err != nil || processed != 1 {
+		t.Fatalf("RetryFailedWebhooks() = %d, %v, want 1, nil", processed, err)
+	}
+	if again, _ := webhooks.RetryFailedWebhooks(ctx, time.Now()); again != 0 {
+		t.Errorf("RetryFailedWebhooks(again) = %d, want 0", again)
+	}
+	card = repo.stored(card.ID())
+	if card.Status() != domain.PaymentStatusPartiallyRefunded || card.RefundedAmount() != 150 || len(gateway.refunds) != 0 {
+		t.Errorf("card = %v, refunded %v, gateway refunds %v, want partially_refunded, 150, none", card.Status(), card.RefundedAmount(), gateway.refunds)
+	}
+	if stored := events.events["simulator/evt-2"]; stored.Status != WebhookEventStatusProcessed || stored.Attempts != 2 {
+		t.Errorf("chargeback event = %v after %d attempts, want processed after 2", stored.Status, stored.Attempts)
+	}
+
+	// 처리하지 않는 종류의 이벤트는 무시한 것으로 기록합니다
+	if event, err := receive(GatewayEvent{ID: "evt-4", Type: "payout.paid"}); err != nil || event.Status != WebhookEventStatusIgnored {
+		t.Errorf("ReceiveWebhook(unsupported) = %+v, %v, want ignored", event, err)
+	}
+}
+
+// stalePaymentRepository는 결제를 조회한 뒤 저장하기 전에 다른 요청이 결제를 마무리한 상황을 흉내 냅니다.
+type stalePaymentRepository struct {
+	*FakePaymentRepository
+	stale *domain.Payment
+}
+
+func (r *stalePaymentRepository) FindByID(ctx context.Context, id string) (*domain.Payment, error) {
+	return copyPayment(r.stale), nil
+}
+
+func TestApplyGatewayEventTransitionsProcessingAttempt(t *testing.T) {
+	repo := NewFakePaymentRepository()
+	gateway := NewFakePaymentGateway()
+	gateway.unavailable = true
+	orders := NewFakeOrderDirectory()
+	orders.orders["ord-1"] = &PayableOrder{ID: "ord-1", TotalAmount: 1000, AcceptsPayment: true}
+	useCase := NewPaymentUseCase(repo, orders, gateway, time.Hour)
+	ctx := context.Background()
+
+	// 처리 중인 가승인 시도의 승인 웹훅은 결제를 가승인합니다
+	hold, _ := useCase.CreatePayment(ctx, "ord-1", 400, domain.PaymentMethodCreditCard, map[string]string{})
+	if _, err := useCase.AuthorizePayment(ctx, hold.ID()); !errors.Is(err, ErrGatewayUnavailable) {
+		t.Fatalf("AuthorizePayment() error = %v, want %v", err, ErrGatewayUnavailable)
+	}
+	if _, err := useCase.ApplyGatewayEvent(ctx, &GatewayEvent{ID: "evt-1", Type: GatewayEventPaymentApproved, PaymentID: hold.ID(), TransactionID: "auth-1"}); err != nil {
+		t.Fatalf("ApplyGatewayEvent(approved) error = %v", err)
+	}
+	hold = repo.stored(hold.ID())
+	if hold.Status() != domain.PaymentStatusAuthorized || hold.TransactionID() != "auth-1" || hold.AuthorizationExpiresAt().IsZero() {
+		t.Errorf("payment = %v, %v, expires %v, want authorized, auth-1 with expiry", hold.Status(), hold.TransactionID(), hold.AuthorizationExpiresAt())
+	}
+
+	// 조회한 뒤 다른 요청이 거절한 결제는 승인 웹훅이 덮어쓰지 않습니다
+	sale, _ := useCase.CreatePayment(ctx, "ord-1", 600, domain.PaymentMethodCreditCard, map[string]string{})
+	if _, err := useCase.ProcessPayment(ctx, sale.ID()); !errors.Is(err, ErrGatewayUnavailable) {
+		t.Fatalf("ProcessPayment() error = %v, want %v", err, ErrGatewayUnavailable)
+	}
+	stale := NewPaymentUseCase(&stalePaymentRepository{FakePaymentRepository: repo, stale: repo.stored(sale.ID())}, orders, gateway, time.Hour)
+	if _, err := useCase.ApplyGatewayEvent(ctx, &GatewayEvent{ID: "evt-2", Type: GatewayEventPaymentFailed, PaymentID: sale.ID(), Reason: "declined"}); err != nil {
+		t.Fatalf("ApplyGatewayEvent(failed) error = %v", err)
+	}
+	if _, err := stale.ApplyGatewayEvent(ctx, &GatewayEvent{ID: "evt-3", Type: GatewayEventPaymentApproved, PaymentID: sale.ID(), TransactionID: "txn-1"}); !errors.Is(err, domain.ErrPaymentNotPending) {
+		t.Errorf("ApplyGatewayEvent(stale approved) error = %v, want %v", err, domain.ErrPaymentNotPending)
+	}
+	if sale = repo.stored(sale.ID()); sale.Status() != domain.PaymentStatusRejected {
+		t.Errorf("payment status = %v, want %v", sale.Status(), domain.PaymentStatusRejected)
+	}
+}
+
+func TestGatewayUnavailableKeepsPaymentProcessing(t *testing.T) {
+	repo := NewFakePaymentRepository()
+	gateway := NewFakePaymentGateway()
+	gateway.unavailable = true
+	orders := NewFakeOrderDirectory()
+	orders.orders["ord-1"] = &PayableOrder{ID: "ord-1", TotalAmount: 1000, AcceptsPayment: true}
+	useCase := NewPaymentUseCase(repo, orders, gateway, time.Hour)
+	ctx := context.Background()
+
+	// 게이트웨이 장애는 결제를 거절하지 않으므로 회복 후 같은 시도로 다시 처리할 수 있습니다
+	sale, _ := useCase.CreatePayment(ctx, "ord-1", 600, domain.PaymentMethodCreditCard, map[string]string{})
+	hold, _ := useCase.CreatePayment(ctx, "ord-1", 400, domain.PaymentMethodCreditCard, map[string]string{})
+	if _, err := useCase.ProcessPayment(ctx, sale.ID()); !errors.Is(err, ErrGatewayUnavailable) {
+		t.Errorf("ProcessPayment() error = %v, want %v", err, ErrGatewayUnavailable)
+	}
+	if _, err := useCase.AuthorizePayment(ctx, hold.ID()); !errors.Is(err, ErrGatewayUnavailable) {
+		t.Errorf("AuthorizePayment() error = %v, want %v", err, ErrGatewayUnavailable)
+	}
+	sale, hold = repo.stored(sale.ID()), repo.stored(hold.ID())
+	if !sale.IsProcessing(domain.AttemptKindSale) || !hold.IsProcessing(domain.AttemptKindAuthorization) {
+		t.Fatalf("statuses = %v, %v, want processing", sale.Status(), hold.Status())
+	}
+	saleAttemptID := sale.Attempt().ID
+
+	gateway.unavailable = false
+	if processed, err := useCase.ProcessPayment(ctx, sale.ID()); err != nil || processed.Status() != domain.PaymentStatusApproved {
+		t.Errorf("ProcessPayment(recovered) = %v, %v, want approved", processed.Status(), err)
+	}
+	if authorized, err := useCase.AuthorizePayment(ctx, hold.ID()); err != nil || authorized.Status() != domain.PaymentStatusAuthorized {
+		t.Errorf("AuthorizePayment(recovered) = %v, %v, want authorized", authorized.Status(), err)
+	}
+	if want := []string{saleAttemptID, hold.Attempt().ID, saleAttemptID, hold.Attempt().ID}; fmt.Sprint(gateway.requestIDs) != fmt.Sprint(want) {
+		t.Errorf("gateway request IDs = %v, want %v", gateway.requestIDs, want)
+	}
+}
+
+func TestReconcileProcessingPayments(t *testing.T) {
+	repo := NewFakePaymentRepository()
+	gateway := NewFakePaymentGateway()
+	orders := NewFakeOrderDirectory()
+	orders.orders["ord-1"] = &PayableOrder{ID: "ord-1", TotalAmount: 1000, AcceptsPayment: true}
+	useCase := NewPaymentUseCase(repo, orders, gateway, time.Hour)
+	ctx := context.Background()
+
+	// 게이트웨이가 청구했지만 응답을 잃어버린 판매와, 게이트웨이에 닿지 못한 가승인
+	sale, _ := useCase.CreatePayment(ctx, "ord-1", 600, domain.PaymentMethodCreditCard, map[string]string{})
+	hold, _ := useCase.CreatePayment(ctx, "ord-1", 400, domain.PaymentMethodCreditCard, map[string]string{})
+	gateway.lostResponses = true
+	if _, err := useCase.ProcessPayment(ctx, sale.ID()); !errors.Is(err, ErrGatewayUnavailable) {
+		t.Fatalf("ProcessPayment() error = %v, want %v", err, ErrGatewayUnavailable)
+	}
+	gateway.unavailable = true
+	if _, err := useCase.AuthorizePayment(ctx, hold.ID()); !errors.Is(err, ErrGatewayUnavailable) {
+		t.Fatalf("AuthorizePayment() error = %v, want %v", err, ErrGatewayUnavailable)
+	}
+
+	// 아직 진행 중일 수 있는 시도는 마무리하지 않습니다
+	if reconciled, err := useCase.ReconcileProcessingPayments(ctx, time.Now().Add(-time.Minute)); err != nil || reconciled != 0 {
+		t.Fatalf("ReconcileProcessingPayments(recent) = %d, %v, want 0", reconciled, err)
+	}
+
+	reconciled, err := useCase.ReconcileProcessingPayments(ctx, time.Now())
+	if err != nil || reconciled != 2 {
+		t.Fatalf("ReconcileProcessingPayments() = %d, %v, want 2", reconciled, err)
+	}
+	sale, hold = repo.stored(sale.ID()), repo.stored(hold.ID())
+	if sale.Status() != domain.PaymentStatusApproved || sale.TransactionID() != "txn_"+sale.ID() {
+		t.Errorf("sale = %v (%s), want approved with the charged transaction", sale.Status(), sale.TransactionID())
+	}
+	if hold.Status() != domain.PaymentStatusRejected {
+		t.Errorf("hold status = %v, want rejected", hold.Status())
+	}
+
+	if reconciled, err := useCase.ReconcileProcessingPayments(ctx, time.Now()); err != nil || reconciled != 0 {
+		t.Errorf("ReconcileProcessingPayments(again) = %d, %v, want 0", reconciled, err)
+	}
+}
+
+func TestCaptureIsRecordedBeforeCallingGateway(t *testing.T) {
+	repo := NewFakePaymentRepository()
+	gateway := NewFakePaymentGateway()
+	orders := NewFakeOrderDirectory()
+	orders.orders["ord-1"] = &PayableOrder{ID: "ord-1", TotalAmount: 1000, AcceptsPayment: true}
+	useCase := NewPaymentUseCase(repo, orders, gateway, time.Hour)
+	ctx := context.Background()
+
+	authorize := func(amount float64) *domain.Payment {
+		payment, _ := useCase.CreatePayment(ctx, "ord-1", amount, domain.PaymentMethodCreditCard, map[string]string{})
+		payment, err := useCase.AuthorizePayment(ctx, payment.ID())
+		if err != nil {
+			t.Fatalf("AuthorizePayment() error = %v", err)
+		}
+		return payment
+	}
+	first, second := authorize(600), authorize(400)
+	gateway.requestIDs = nil
+
+	// 게이트웨이 장애로 결과를 모르는 매입은 처리 중으로 남고, 매입 전까지 가승인 금액으로 계산됩니다
+	gateway.captureUnavailable = true
+	if _, err := useCase.CapturePayment(ctx, first.ID(), 500); !errors.Is(err, ErrGatewayUnavailable) {
+		t.Fatalf("CapturePayment() error = %v, want %v", err, ErrGatewayUnavailable)
+	}
+	first = repo.stored(first.ID())
+	if !first.IsProcessing(domain.AttemptKindCapture) || first.CapturedAmount() != 500 {
+		t.Fatalf("payment = %v, %v, want processing capture of 500", first.Status(), first.CapturedAmount())
+	}
+	if summary, _ := useCase.GetOrderPaymentSummary(ctx, "ord-1"); summary.Authorized != 1000 || summary.AmountDue != 0 {
+		t.Errorf("summary = authorized %v, due %v, want 1000, 0", summary.Authorized, summary.AmountDue)
+	}
+
+	// 다시 요청하면 새 금액이 아니라 저장된 시도를 같은 요청 ID로 이어서 매입합니다
+	gateway.captureUnavailable = false
+	captured, err := useCase.CapturePayment(ctx, first.ID(), 0)
+	if err != nil || captured.Status() != domain.PaymentStatusCaptured || captured.CapturedAmount() != 500 {
+		t.Fatalf("CapturePayment(retry) = %v, %v, want captured 500", captured, err)
+	}
+	if _, err := useCase.CapturePayment(ctx, first.ID(), 0); err != nil {
+		t.Fatalf("CapturePayment(again) error = %v", err)
+	}
+	if want := []string{first.Attempt().ID, first.Attempt().ID}; fmt.Sprint(gateway.requestIDs) != fmt.Sprint(want) || fmt.Sprint(gateway.captures) != "[500]" {
+		t.Errorf("gateway request IDs = %v, captures = %v, want %v, [500]", gateway.requestIDs, gateway.captures, want)
+	}
+
+	// 거절된 매입과 게이트웨이에 닿지 않은 매입은 가승인으로 되돌아갑니다
+	gateway.captureDeclined = true
+	if _, err := useCase.CapturePayment(ctx, second.ID(), 0); err == nil {
+		t.Fatal("CapturePayment(declined) error = nil")
+	}
+	second = repo.stored(second.ID())
+	if second.Status() != domain.PaymentStatusAuthorized || second.CapturedAmount() != 0 {
+		t.Errorf("payment = %v, captured %v, want authorized, 0", second.Status(), second.CapturedAmount())
+	}
+	gateway.captureDeclined = false
+	gateway.captureUnavailable = true
+	if _, err := useCase.CapturePayment(ctx, second.ID(), 0); !errors.Is(err, ErrGatewayUnavailable) {
+		t.Fatalf("CapturePayment() error = %v, want %v", err, ErrGatewayUnavailable)
+	}
+	if reconciled, err := useCase.ReconcileProcessingPayments(ctx, time.Now()); err != nil || reconciled != 1 {
+		t.Fatalf("ReconcileProcessingPayments() = %d, %v, want 1", reconciled, err)
+	}
+	second = repo.stored(second.ID())
+	if second.Status() != domain.PaymentStatusAuthorized {
+		t.Errorf("payment status = %v, want authorized", second.Status())
+	}
+}
+
+func TestReconcileSettlementFlagsExceptions(t *testing.T) {
+	repo := NewFakePaymentRepository()
+	gateway := NewFakePaymentGateway()
+	orders := NewFakeOrderDirectory()
+	orders.orders["ord-1"] = &PayableOrder{ID: "ord-1", TotalAmount: 10000, AcceptsPayment: true}
+	useCase := NewPaymentUseCase(repo, orders, gateway, time.Hour)
+	reconciliations := NewReconciliationUseCase(NewFakeReconciliationRepository(), repo)
+	ctx := context.Background()
+
+	approve := func(amount float64) *domain.Payment {
+		payment, _ := useCase.CreatePayment(ctx, "ord-1", amount, domain.PaymentMethodCreditCard, map[string]string{})
+		processed, err := useCase.ProcessPayment(ctx, payment.ID())
+		if err != nil {
+			t.Fatalf("ProcessPayment() error = %v", err)
+		}
+		return processed
+	}
+	matched := approve(1000)
+	shortPaid := approve(2000)
+	refunded := approve(500)
+	missing := approve(700)
+	if _, err := useCase.RefundPayment(ctx, refunded.ID(), "customer request"); err != nil {
+		t.Fatalf("RefundPayment() error = %v", err)
+	}
+	pending, _ := useCase.CreatePayment(ctx, "ord-1", 300, domain.PaymentMethodCreditCard, map[string]string{})
+	pending.SetTransactionID("txn_pending")
+	if err := repo.Update(ctx, pending); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// 열 이름과 순서는 PG사마다 다르므로 매핑으로 지정합니다
+	file := strings.Join([]string{
+		"Status,TID,Net Amount",
+		"SETTLED," + matched.TransactionID() + ",\"1,000.00\"",
+		"settled," + shortPaid.TransactionID() + ",1999.00",
+		"settled," + refunded.TransactionID() + ",500",
+		"settled,txn_pending,300",
+		"settled,txn_unknown,100",
+		"settled," + matched.TransactionID() + ",1000",
+		"settled,,100",
+		"on_hold,txn_other,100",
+	}, "\n")
+	columns, err := ParseSettlementColumns("transaction_id=TID, amount=Net Amount, status=Status")
+	if err != nil {
+		t.Fatalf("ParseSettlementColumns() error = %v", err)
+	}
+	now := time.Now()
+	result, err := reconciliations.ReconcileSettlement(ctx, strings.NewReader(file), SettlementOptions{
+		Source: "settlement.csv", Columns: columns, From: now.Add(-time.Hour), To: now.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("ReconcileSettlement() error = %v", err)
+	}
+
+	if result.Rows != 8 || result.Matched != 1 || result.Missing != 1 || result.Extra != 2 ||
+		result.AmountMismatched != 1 || result.StatusMismatched != 2 || result.Invalid != 2 {
+		t.Errorf("summary = %+v, want rows 8, matched 1, missing 1, extra 2, amount 1, status 2, invalid 2", result)
+	}
+	want := map[ReconciliationExceptionType][]string{
+		ReconciliationMissing:        {missing.TransactionID()},
+		ReconciliationExtra:          {"txn_unknown", matched.TransactionID()},
+		ReconciliationAmountMismatch: {shortPaid.TransactionID()},
+		ReconciliationStatusMismatch: {refunded.TransactionID(), "txn_pending"},
+		ReconciliationInvalidRow:     {"", "txn_other"},
+	}
+	got := map[ReconciliationExceptionType][]string{}
+	for _, exception := range result.Exceptions {
+		got[exception.Type] = append(got[exception.Type], exception.TransactionID)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("exceptions = %v, want %v", got, want)
+	}
+
+	stored, err := reconciliations.GetReconciliation(ctx, result.ID)
+	if err != nil || stored.Source != "settlement.csv" {
+		t.Errorf("GetReconciliation() = %v, %v", stored, err)
+	}
+	if _, err := reconciliations.GetReconciliation(ctx, "missing"); !errors.Is(err, ErrReconciliationNotFound) {
+		t.Errorf("GetReconciliation(missing) error = %v, want %v", err, ErrReconciliationNotFound)
+	}
+}
+
+func TestVoidAndCaptureOfSameAuthorizationDoNotBothReachGateway(t *testing.T) {
+	repo := NewFakePaymentRepository()
+	gateway := NewFakePaymentGateway()
+	orders := NewFakeOrderDirectory()
+	useCase := NewPaymentUseCase(repo, orders, gateway, time.Hour)
+	ctx := context.Background()
+
+	authorize := func(orderID string) *domain.Payment {
+		orders.orders[orderID] = &PayableOrder{ID: orderID, TotalAmount: 1000, AcceptsPayment: true}
+		payment, _ := useCase.CreatePayment(ctx, orderID, 1000, domain.PaymentMethodCreditCard, map[string]string{})
+		payment, err := useCase.AuthorizePayment(ctx, payment.ID())
+		if err != nil {
+			t.Fatalf("AuthorizePayment() error = %v", err)
+		}
+		return payment
+	}
+
+	// 가승인 상태로 조회한 뒤 다른 요청이 매입을 마쳤으면 취소는 게이트웨이를 호출하지 않습니다
+	payment := authorize("ord-stale")
+	stale, _ := repo.FindByID(ctx, payment.ID())
+	if _, err := useCase.CapturePayment(ctx, payment.ID(), 0); err != nil {
+		t.Fatalf("CapturePayment() error = %v", err)
+	}
+	if err := useCase.void(ctx, stale, "stale void"); !errors.Is(err, domain.ErrPaymentNotAuthorized) {
+		t.Errorf("void(stale) error = %v, want %v", err, domain.ErrPaymentNotAuthorized)
+	}
+	if stored := repo.stored(payment.ID()); stored.Status() != domain.PaymentStatusCaptured || len(gateway.voids) != 0 {
+		t.Errorf("payment = %v, gateway voids = %v, want captured, none", stored.Status(), gateway.voids)
+	}
+
+	// 동시에 매입하고 취소하면 하나만 게이트웨이에 도달하고, 저장된 상태가 게이트웨이 결과와 같습니다
+	for i := 0; i < 20; i++ {
+		payment := authorize(fmt.Sprintf("ord-%d", i))
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			<-start
+			useCase.CapturePayment(ctx, payment.ID(), 0)
+		}()
+		go func() {
+			defer wg.Done()
+			<-start
+			useCase.VoidPayment(ctx, payment.ID(), "order canceled")
+		}()
+		close(start)
+		wg.Wait()
+
+		captured, voided := 0, 0
+		for _, id := range gateway.capturedIDs {
+			if id == payment.ID() {
+				captured++
+			}
+		}
+		for _, id := range gateway.voids {
+			if id == payment.ID() {
+				voided++
+			}
+		}
+		stored := repo.stored(payment.ID())
+		switch {
+		case captured == 1 && voided == 0 && stored.Status() == domain.PaymentStatusCaptured:
+		case captured == 0 && voided == 1 && stored.Status() == domain.PaymentStatusVoided:
+		default:
+			t.Fatalf("payment = %v, gateway captures %d, voids %d, want exactly one of capture or void", stored.Status(), captured, voided)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package application
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrGatewayUnavailable은 게이트웨이가 일시적으로 응답하지 않아 결과를 알 수 없음을 나타냅니다.
+// 결제는 거절하지 않고 처리 대기 상태로 두며, 같은 요청 ID로 다시 시도하면 게이트웨이가 중복 처리하지 않습니다.
+var ErrGatewayUnavailable = errors.New("payment gateway is temporarily unavailable")
+
+// gatewayRequestIDKey는 게이트웨이 요청 ID를 담는 컨텍스트 키입니다.
+type gatewayRequestIDKey struct{}
+
+// WithGatewayRequestID는 게이트웨이 호출에 쓸 멱등성 요청 ID를 컨텍스트에 담습니다.
+// 게이트웨이는 같은 요청 ID로 다시 온 호출을 새로 처리하지 않고 이전 결과를 돌려줘야 합니다.
+func WithGatewayRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, gatewayRequestIDKey{}, requestID)
+}
+
+// GatewayRequestID는 컨텍스트에 담긴 게이트웨이 요청 ID를 반환합니다.
+func GatewayRequestID(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(gatewayRequestIDKey{}).(string)
+	return requestID, ok && requestID != ""
+}
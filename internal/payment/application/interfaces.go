@@ -2,6 +2,8 @@ package application
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"example.com/myapp/payment/domain"
 )
@@ -9,36 +11,356 @@ import (
 // PaymentRepository는 결제 관련 영속성 인터페이스를 정의합니다.
 type PaymentRepository interface {
 	Save(ctx context.Context, payment *domain.Payment) error
+	// SaveWithinOrderTotal은 주문의 거절·취소되지 않은 결제 합계(청구된 결제는 매입 금액)에 payment를 더해도
+	// orderTotal을 넘지 않을 때만 저장하며, 넘으면 ErrPaymentExceedsAmountDue를 반환합니다.
+	// 합계 확인과 저장은 주문 단위로 직렬화되어야 합니다.
+	SaveWithinOrderTotal(ctx context.Context, payment *domain.Payment, orderTotal float64) error
 	FindByID(ctx context.Context, id string) (*domain.Payment, error)
-	FindByOrderID(ctx context.Context, orderID string) (*domain.Payment, error)
+	// FindByOrderID는 주문의 결제 목록을 생성 순서대로 조회합니다. 결제가 없으면 빈 목록을 반환합니다.
+	FindByOrderID(ctx context.Context, orderID string) ([]*domain.Payment, error)
+	// FindByTransactionID는 게이트웨이 트랜잭션 ID로 결제를 조회합니다. 없으면 domain.ErrPaymentNotFound를 반환합니다.
+	FindByTransactionID(ctx context.Context, transactionID string) (*domain.Payment, error)
+	// Update는 결제 상태와 환불 내역을 저장합니다.
+	// 실패하지 않은 환불 금액의 합이 결제 금액을 넘으면 domain.ErrRefundExceedsPayment를 반환합니다.
 	Update(ctx context.Context, payment *domain.Payment) error
+	// ClaimExpiredAuthorizations는 now 이전에 만료된 승인 상태의 결제를 최대 limit건 선점하고 ID를 반환합니다.
+	// 선점한 결제는 lease 동안 다른 인스턴스가 선점하지 않습니다.
+	ClaimExpiredAuthorizations(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]string, error)
+	// MarkProcessing은 저장된 결제가 아직 처리 대기 상태일 때만 처리 중 상태와 시도를 저장합니다.
+	// 다른 요청이 먼저 처리를 시작했거나 결제가 이미 처리되었으면 domain.ErrPaymentNotPending을 반환합니다.
+	// 매입 시도는 저장된 결제가 승인 상태일 때만 매입 요청 금액과 함께 저장하며, 아니면 domain.ErrPaymentNotAuthorized를 반환합니다.
+	// 취소 시도도 저장된 결제가 승인 상태일 때만 저장하므로 같은 가승인을 동시에 매입하고 취소할 수 없습니다.
+	MarkProcessing(ctx context.Context, payment *domain.Payment) error
+	// Transition은 저장된 결제가 아직 from 상태이고 마지막 시도 ID가 attemptID일 때만 결제 상태를 저장합니다.
+	// 그 사이 다른 요청이 결제를 처리했거나 새 시도를 시작했으면 domain.ErrPaymentNotPending을 반환합니다.
+	// 환불 내역은 저장하지 않으므로 처리 대기·처리 중 결제의 상태 변경에만 사용합니다.
+	Transition(ctx context.Context, payment *domain.Payment, from domain.PaymentStatus, attemptID string) error
+	// ClaimStuckProcessing은 cutoff 이전에 시작해 아직 처리 중인 결제를 최대 limit건 선점하고 ID를 반환합니다.
+	// 선점한 결제는 lease 동안 다른 인스턴스가 선점하지 않습니다.
+	ClaimStuckProcessing(ctx context.Context, cutoff, now time.Time, lease time.Duration, limit int) ([]string, error)
+	// ClaimPendingRefunds는 cutoff 이전에 요청해 아직 진행 중인 환불을 최대 limit건 선점하고 환불한 결제 ID를 중복 없이 반환합니다.
+	// 선점한 환불은 lease 동안 다른 인스턴스가 선점하지 않습니다.
+	ClaimPendingRefunds(ctx context.Context, cutoff, now time.Time, lease time.Duration, limit int) ([]string, error)
+	// FindSettledBetween은 [from, to) 사이에 생성되어 청구(매입)된 결제를 생성 순서대로 조회합니다.
+	// 청구된 결제는 승인, 매입, 부분 환불, 환불 상태이며 트랜잭션 ID가 있는 결제입니다.
+	FindSettledBetween(ctx context.Context, from, to time.Time) ([]*domain.Payment, error)
+}
+
+// OrderDirectory는 결제할 주문의 금액과 상태를 조회하는 주문 포트를 정의합니다.
+// 주문이 없으면 ErrOrderNotFound를 반환해야 합니다.
+type OrderDirectory interface {
+	FindOrder(ctx context.Context, orderID string) (*PayableOrder, error)
+}
+
+// PayableOrder는 결제에 필요한 주문 정보를 정의합니다.
+// AcceptsPayment는 주문이 아직 결제를 받을 수 있는 상태(결제 대기)인지 나타냅니다.
+type PayableOrder struct {
+	ID             string
+	TotalAmount    float64
+	AcceptsPayment bool
+}
+
+// OrderPaymentSummary는 주문 하나의 결제 현황을 정의합니다.
+// Paid는 청구(매입)된 금액 합계(이후 환불 포함), Authorized는 가승인만 된 금액 합계, Pending은 아직 처리되지 않은(처리 중 포함) 결제 금액 합계이며
+// AmountDue는 주문 금액에서 Paid와 Authorized를 뺀 남은 결제 금액입니다. 환불은 AmountDue를 늘리지 않습니다.
+type OrderPaymentSummary struct {
+	OrderID    string
+	OrderTotal float64
+	Paid       float64
+	Authorized float64
+	Pending    float64
+	Refunded   float64
+	AmountDue  float64
+	Payments   []*domain.Payment
 }
 
 // PaymentGateway는 외부 결제 게이트웨이와의 통합을 정의합니다.
+// 구현체는 GatewayRequestID로 받은 요청 ID가 같은 호출을 한 번만 처리해야 하며,
+// 결과를 알 수 없는 일시적 장애는 ErrGatewayUnavailable로 감싸 반환해야 결제가 거절되지 않습니다.
 type PaymentGateway interface {
 	ProcessPayment(ctx context.Context, payment *domain.Payment) (string, error)
-	RefundPayment(ctx context.Context, payment *domain.Payment, reason string) error
+	// Authorize는 결제 금액만큼 가승인하고 게이트웨이의 트랜잭션 ID를 반환합니다.
+	Authorize(ctx context.Context, payment *domain.Payment) (string, error)
+	// Capture는 가승인된 결제에서 amount만큼 매입합니다. 남은 승인 금액은 게이트웨이가 해제합니다.
+	Capture(ctx context.Context, payment *domain.Payment, amount float64) error
+	// Void는 가승인을 매입하지 않고 취소합니다.
+	Void(ctx context.Context, payment *domain.Payment) error
+	// RefundPayment는 결제에서 amount만큼 환불하고 게이트웨이의 환불 ID를 반환합니다.
+	RefundPayment(ctx context.Context, payment *domain.Payment, amount float64, reason string) (string, error)
+	// LookupAttempt는 결제의 마지막 시도(payment.Attempt())를 게이트웨이가 어떻게 처리했는지 조회합니다.
+	LookupAttempt(ctx context.Context, payment *domain.Payment) (*GatewayAttempt, error)
+	// LookupRefund는 환불 ID를 요청 ID로 보낸 환불 요청을 게이트웨이가 어떻게 처리했는지 조회합니다.
+	// 승인된 환불이면 TransactionID에 게이트웨이의 환불 ID를 담습니다.
+	LookupRefund(ctx context.Context, payment *domain.Payment, refundID string) (*GatewayAttempt, error)
+}
+
+// GatewayAttemptStatus는 게이트웨이에 조회한 결제 시도의 결과입니다.
+type GatewayAttemptStatus string
+
+const (
+	GatewayAttemptApproved GatewayAttemptStatus = "approved"
+	GatewayAttemptDeclined GatewayAttemptStatus = "declined"
+	// GatewayAttemptNotFound는 게이트웨이가 시도를 받지 못했거나 일시 오류로 처리하지 않았음을 나타냅니다.
+	GatewayAttemptNotFound GatewayAttemptStatus = "not_found"
+)
+
+// GatewayAttempt는 게이트웨이에 조회한 결제 시도 결과를 정의합니다.
+type GatewayAttempt struct {
+	Status        GatewayAttemptStatus
+	TransactionID string
+	DeclineReason string
 }
 
 // PaymentService는 결제 관련 비즈니스 로직을 정의합니다.
 type PaymentService interface {
 	CreatePayment(ctx context.Context, orderID string, amount float64, method domain.PaymentMethod, paymentData map[string]string) (*domain.Payment, error)
 	ProcessPayment(ctx context.Context, paymentID string) (*domain.Payment, error)
+
+	// 2단계 결제 (주문 시 가승인, 출고 시 매입)
+	AuthorizePayment(ctx context.Context, paymentID string) (*domain.Payment, error)
+	// CapturePayment는 가승인된 결제에서 amount만큼 매입합니다. amount가 0이면 승인 금액 전체를 매입합니다.
+	CapturePayment(ctx context.Context, paymentID string, amount float64) (*domain.Payment, error)
+	VoidPayment(ctx context.Context, paymentID string, reason string) (*domain.Payment, error)
+	// VoidExpiredAuthorizations는 now 기준으로 만료된 가승인을 취소하고 취소한 결제 목록을 반환합니다.
+	// 오류가 나도 그 전까지 취소한 결제는 함께 반환합니다.
+	VoidExpiredAuthorizations(ctx context.Context, now time.Time) ([]*domain.Payment, error)
+	// ReconcileProcessingPayments는 cutoff 이전에 시작해 아직 처리 중인 결제를 게이트웨이에 조회해
+	// 승인(가승인) 또는 거절로 마무리하고 마무리한 건수를 반환합니다.
+	ReconcileProcessingPayments(ctx context.Context, cutoff time.Time) (int, error)
+	// ReconcilePendingRefunds는 cutoff 이전에 요청해 아직 진행 중인 환불을 게이트웨이에 조회해
+	// 완료 또는 실패로 마무리하고 마무리한 건수를 반환합니다. 게이트웨이가 받지 못한 환불은 같은 요청 ID로 다시 보냅니다.
+	ReconcilePendingRefunds(ctx context.Context, cutoff time.Time) (int, error)
+
 	GetPayment(ctx context.Context, id string) (*domain.Payment, error)
-	GetPaymentByOrderID(ctx context.Context, orderID string) (*domain.Payment, error)
+	// GetPaymentsByOrderID는 주문의 모든 결제(거절된 결제 포함)를 생성 순서대로 조회합니다.
+	GetPaymentsByOrderID(ctx context.Context, orderID string) ([]*domain.Payment, error)
+	GetOrderPaymentSummary(ctx context.Context, orderID string) (*OrderPaymentSummary, error)
+	// RefundPayment는 아직 환불되지 않은 결제 금액 전체를 환불합니다.
 	RefundPayment(ctx context.Context, id string, reason string) (*domain.Payment, error)
+
+	// 부분 환불 (한 결제에 결제 금액까지 여러 번 환불할 수 있습니다)
+	// 게이트웨이 장애로 결과를 알 수 없으면 진행 중인 환불을 ErrGatewayUnavailable과 함께 반환합니다.
+	CreateRefund(ctx context.Context, paymentID string, amount float64, reason string) (*domain.Refund, error)
+	GetRefunds(ctx context.Context, paymentID string) ([]*domain.Refund, error)
+	// RefundOrder는 주문의 결제들에서 생성 순서대로 amount만큼 나누어 환불합니다.
+	// 같은 사유로 이미 완료된 환불 금액은 빼고 남은 금액만 환불하므로 같은 요청을 다시 보내도 안전합니다.
+	RefundOrder(ctx context.Context, orderID string, amount float64, reason string) ([]*domain.Refund, error)
+
+	// ApplyGatewayEvent는 게이트웨이가 비동기로 알린 이벤트(입금, 승인, 거절, 차지백)를 결제에 반영합니다.
+	// 이미 반영된 이벤트는 다시 반영하지 않으므로 같은 이벤트로 여러 번 호출해도 안전합니다.
+	ApplyGatewayEvent(ctx context.Context, event *GatewayEvent) (*domain.Payment, error)
+}
+
+// GatewayEventType은 게이트웨이 웹훅 이벤트 종류를 정의합니다.
+type GatewayEventType string
+
+const (
+	// GatewayEventPaymentApproved는 비동기로 처리된 결제가 승인되었음을 알립니다.
+	GatewayEventPaymentApproved GatewayEventType = "payment.approved"
+	// GatewayEventPaymentFailed는 비동기로 처리된 결제가 거절되었음을 알립니다.
+	GatewayEventPaymentFailed GatewayEventType = "payment.failed"
+	// GatewayEventDepositReceived는 가상계좌에 입금되었음을 알립니다.
+	GatewayEventDepositReceived GatewayEventType = "deposit.received"
+	// GatewayEventChargeback은 카드사가 결제 금액을 돌려받았음(차지백)을 알립니다.
+	GatewayEventChargeback GatewayEventType = "chargeback.created"
+)
+
+// GatewayEvent는 게이트웨이 웹훅에서 읽은 이벤트를 정의합니다.
+// 결제는 PaymentID로 찾고, 비어 있으면 TransactionID로 찾습니다.
+// Amount는 입금액이나 차지백 금액이며, 0이면 결제 금액 전체로 봅니다.
+type GatewayEvent struct {
+	ID            string
+	Type          GatewayEventType
+	PaymentID     string
+	TransactionID string
+	Amount        float64
+	Reason        string
+	OccurredAt    time.Time
+}
+
+// WebhookProvider는 결제 게이트웨이(PG사) 하나의 웹훅 서명 검증과 본문 해석을 정의합니다.
+type WebhookProvider interface {
+	// Verify는 서명과 타임스탬프가 원본 본문에 맞고 허용 시간 안에 있는지 확인합니다.
+	Verify(signature, timestamp string, payload []byte, now time.Time) error
+	// ParseEvent는 웹훅 본문을 이벤트로 해석합니다.
+	ParseEvent(payload []byte) (*GatewayEvent, error)
+}
+
+// WebhookEventStatus는 받은 웹훅 이벤트의 처리 상태를 정의합니다.
+type WebhookEventStatus string
+
+const (
+	WebhookEventStatusReceived  WebhookEventStatus = "received"
+	WebhookEventStatusProcessed WebhookEventStatus = "processed"
+	// WebhookEventStatusFailed는 반영에 실패해 다시 시도할 이벤트입니다.
+	WebhookEventStatusFailed WebhookEventStatus = "failed"
+	// WebhookEventStatusIgnored는 처리하지 않는 종류의 이벤트입니다.
+	WebhookEventStatusIgnored WebhookEventStatus = "ignored"
+)
+
+// WebhookEvent는 저장된 웹훅 이벤트 한 건을 정의합니다. Payload는 서명을 검증한 원본 본문입니다.
+type WebhookEvent struct {
+	Provider    string
+	EventID     string
+	Type        GatewayEventType
+	Payload     []byte
+	Status      WebhookEventStatus
+	Attempts    int
+	LastError   string
+	ReceivedAt  time.Time
+	ProcessedAt time.Time
+}
+
+// WebhookEventRepository는 웹훅 이벤트의 영속성 인터페이스를 정의합니다.
+// 이벤트는 (Provider, EventID)로 구분합니다.
+type WebhookEventRepository interface {
+	// Save는 처음 받은 이벤트를 저장하고, 이미 저장된 이벤트면 아무것도 하지 않고 false를 반환합니다.
+	Save(ctx context.Context, event *WebhookEvent) (bool, error)
+	// Claim은 저장된 이벤트를 반환하고, 아직 처리되지 않았으면 lease 동안 선점합니다.
+	// 이미 처리되었거나 다른 요청이 선점 중이면 false를 반환합니다.
+	Claim(ctx context.Context, provider, eventID string, now time.Time, lease time.Duration) (*WebhookEvent, bool, error)
+	// ClaimRetryable은 처리되지 않은(실패했거나 처리 도중 중단된) 이벤트 중 시도 횟수가 maxAttempts보다 적은 이벤트를
+	// 최대 limit건 선점하고 반환합니다.
+	ClaimRetryable(ctx context.Context, now time.Time, lease time.Duration, maxAttempts, limit int) ([]*WebhookEvent, error)
+	// Finish는 선점한 이벤트의 처리 결과(상태, 시도 횟수, 오류, 처리 시간)를 저장하고 선점을 풉니다.
+	Finish(ctx context.Context, event *WebhookEvent) error
+}
+
+// WebhookService는 게이트웨이 웹훅 수신을 정의합니다.
+type WebhookService interface {
+	// ReceiveWebhook은 서명을 검증한 웹훅을 저장하고 결제에 반영합니다.
+	// 이미 처리한 이벤트는 다시 반영하지 않고 저장된 이벤트를 반환합니다.
+	ReceiveWebhook(ctx context.Context, provider, signature, timestamp string, payload []byte) (*WebhookEvent, error)
+	// RetryFailedWebhooks는 반영에 실패한 이벤트를 다시 처리하고 처리에 성공한 건수를 반환합니다.
+	RetryFailedWebhooks(ctx context.Context, now time.Time) (int, error)
+}
+
+// WebhookUseCase는 WebhookService 구현체를 정의합니다.
+type WebhookUseCase struct {
+	events    WebhookEventRepository
+	payments  PaymentService
+	providers map[string]WebhookProvider
+}
+
+// NewWebhookUseCase는 새로운 WebhookUseCase 인스턴스를 생성합니다.
+// providers는 PG사 이름(소문자)별 웹훅 검증기입니다.
+func NewWebhookUseCase(events WebhookEventRepository, payments PaymentService, providers map[string]WebhookProvider) *WebhookUseCase {
+	return &WebhookUseCase{
+		events:    events,
+		payments:  payments,
+		providers: providers,
+	}
+}
+
+// SettlementColumns는 정산 파일 CSV에서 각 값을 읽을 열 이름을 정의합니다. Status가 비어 있으면 상태를 대조하지 않습니다.
+type SettlementColumns struct {
+	TransactionID string
+	Amount        string
+	Status        string
+}
+
+// SettlementOptions는 정산 대사 옵션을 정의합니다.
+// 정산 파일은 [From, To) 사이에 생성된 결제를 다루며, 이 기간에 청구되었지만 파일에 없는 결제를 누락으로 봅니다.
+type SettlementOptions struct {
+	Source  string
+	Columns SettlementColumns
+	From    time.Time
+	To      time.Time
+}
+
+// ReconciliationExceptionType은 정산 대사에서 발견한 불일치 종류를 정의합니다.
+type ReconciliationExceptionType string
+
+const (
+	// ReconciliationMissing은 청구된 결제가 정산 파일에 없음을 나타냅니다.
+	ReconciliationMissing ReconciliationExceptionType = "missing"
+	// ReconciliationExtra는 정산 파일의 행에 해당하는 결제가 없거나 같은 트랜잭션의 행이 중복되었음을 나타냅니다.
+	ReconciliationExtra ReconciliationExceptionType = "extra"
+	// ReconciliationAmountMismatch는 정산 금액이 청구 금액과 다름을 나타냅니다.
+	ReconciliationAmountMismatch ReconciliationExceptionType = "amount_mismatch"
+	// ReconciliationStatusMismatch는 정산 상태가 결제 상태와 맞지 않음을 나타냅니다.
+	ReconciliationStatusMismatch ReconciliationExceptionType = "status_mismatch"
+	// ReconciliationInvalidRow는 읽을 수 없는 행(트랜잭션 ID 누락, 잘못된 금액이나 상태)을 나타냅니다.
+	ReconciliationInvalidRow ReconciliationExceptionType = "invalid_row"
+)
+
+// ReconciliationException은 정산 대사에서 발견한 불일치 한 건을 정의합니다.
+// Line은 정산 파일의 줄 번호(헤더가 1)이며 누락된 결제는 0입니다.
+// Expected는 결제 기준 값, Actual은 정산 파일의 값입니다.
+type ReconciliationException struct {
+	Type           ReconciliationExceptionType
+	Line           int
+	TransactionID  string
+	PaymentID      string
+	ExpectedAmount float64
+	ActualAmount   float64
+	ExpectedStatus string
+	ActualStatus   string
+	Message        string
+}
+
+// Reconciliation은 정산 파일 하나를 대사한 결과를 정의합니다.
+// Matched는 불일치 없이 결제와 맞은 행 수이며, 나머지 수는 종류별 불일치 건수입니다.
+type Reconciliation struct {
+	ID               string
+	Source           string
+	PeriodStart      time.Time
+	PeriodEnd        time.Time
+	Rows             int
+	Matched          int
+	Missing          int
+	Extra            int
+	AmountMismatched int
+	StatusMismatched int
+	Invalid          int
+	Exceptions       []ReconciliationException
+	CreatedAt        time.Time
+}
+
+// ReconciliationRepository는 정산 대사 결과의 영속성 인터페이스를 정의합니다.
+type ReconciliationRepository interface {
+	// Save는 대사 결과와 불일치 목록을 함께 저장합니다.
+	Save(ctx context.Context, reconciliation *Reconciliation) error
+	// FindByID는 대사 결과를 불일치 목록과 함께 조회합니다. 없으면 ErrReconciliationNotFound를 반환합니다.
+	FindByID(ctx context.Context, id string) (*Reconciliation, error)
+}
+
+// ReconciliationService는 게이트웨이 정산 파일 대사를 정의합니다.
+type ReconciliationService interface {
+	// ReconcileSettlement는 정산 파일 CSV를 트랜잭션 ID로 결제와 대사하고 결과를 저장합니다.
+	ReconcileSettlement(ctx context.Context, r io.Reader, opts SettlementOptions) (*Reconciliation, error)
+	GetReconciliation(ctx context.Context, id string) (*Reconciliation, error)
+}
+
+// ReconciliationUseCase는 ReconciliationService 구현체를 정의합니다.
+type ReconciliationUseCase struct {
+	reconciliations ReconciliationRepository
+	payments        PaymentRepository
+}
+
+// NewReconciliationUseCase는 새로운 ReconciliationUseCase 인스턴스를 생성합니다.
+func NewReconciliationUseCase(reconciliations ReconciliationRepository, payments PaymentRepository) *ReconciliationUseCase {
+	return &ReconciliationUseCase{
+		reconciliations: reconciliations,
+		payments:        payments,
+	}
 }
 
 // PaymentUseCase는 PaymentService 구현체를 정의합니다.
 type PaymentUseCase struct {
-	repo    PaymentRepository
-	gateway PaymentGateway
+	repo             PaymentRepository
+	orders           OrderDirectory
+	gateway          PaymentGateway
+	authorizationTTL time.Duration
 }
 
 // NewPaymentUseCase는 새로운 PaymentUseCase 인스턴스를 생성합니다.
-func NewPaymentUseCase(repo PaymentRepository, gateway PaymentGateway) *PaymentUseCase {
+// authorizationTTL은 가승인을 매입하지 않고 유지하는 기간이며, 지나면 VoidExpiredAuthorizations가 취소합니다.
+func NewPaymentUseCase(repo PaymentRepository, orders OrderDirectory, gateway PaymentGateway, authorizationTTL time.Duration) *PaymentUseCase {
 	return &PaymentUseCase{
-		repo:    repo,
-		gateway: gateway,
+		repo:             repo,
+		orders:           orders,
+		gateway:          gateway,
+		authorizationTTL: authorizationTTL,
 	}
 }
\ No newline at end of file
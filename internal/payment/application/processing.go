@@ -0,0 +1,140 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"example.com/myapp/payment/domain"
+)
+
+const (
+	// stuckProcessingBatchSize는 한 번에 마무리할 처리 중 결제 수입니다.
+	stuckProcessingBatchSize = 100
+	// stuckProcessingClaimLease는 선점한 결제를 다른 인스턴스가 건너뛰는 시간입니다.
+	stuckProcessingClaimLease = 5 * time.Minute
+)
+
+// ErrAttemptNotReceived는 게이트웨이가 결제 시도를 받지 못해 청구되지 않았음을 나타냅니다.
+var ErrAttemptNotReceived = errors.New("payment attempt was not received by the gateway")
+
+// ReconcileProcessingPayments는 cutoff 이전에 시작해 아직 처리 중인 결제를 게이트웨이에 조회해 마무리합니다.
+// 게이트웨이가 승인한 시도는 승인(가승인, 매입)하고, 거절했거나 받지 못한 시도는 거절합니다.
+// 매입과 취소 시도는 거절하지 않고 가승인 상태로 되돌려 다시 매입하거나 만료 시 취소할 수 있게 합니다.
+// cutoff는 게이트웨이 호출이 재시도까지 끝나기에 충분히 지난 시간이어야 진행 중인 시도를 거절하지 않습니다.
+func (uc *PaymentUseCase) ReconcileProcessingPayments(ctx context.Context, cutoff time.Time) (int, error) {
+	paymentIDs, err := uc.repo.ClaimStuckProcessing(ctx, cutoff, time.Now(), stuckProcessingClaimLease, stuckProcessingBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	reconciled := 0
+	for _, paymentID := range paymentIDs {
+		payment, err := uc.repo.FindByID(ctx, paymentID)
+		if err != nil {
+			if errors.Is(err, domain.ErrPaymentNotFound) {
+				continue
+			}
+			return reconciled, err
+		}
+		// 선점 후 다른 요청이 마무리한 결제는 건너뜁니다
+		if payment.Status() != domain.PaymentStatusProcessing || payment.Attempt().StartedAt.After(cutoff) {
+			continue
+		}
+
+		attempt, err := uc.gateway.LookupAttempt(ctx, payment)
+		if err != nil {
+			return reconciled, fmt.Errorf("failed to look up payment attempt %s: %w", paymentID, err)
+		}
+
+		switch {
+		case attempt.Status == GatewayAttemptApproved:
+			err = uc.completeAttempt(ctx, payment, attempt.TransactionID)
+		case payment.Attempt().Kind == domain.AttemptKindCapture:
+			err = uc.failCapture(ctx, payment)
+		case payment.Attempt().Kind == domain.AttemptKindVoid:
+			err = uc.failVoid(ctx, payment)
+		case attempt.Status == GatewayAttemptDeclined:
+			_, err = uc.reject(ctx, payment, errors.New(attempt.DeclineReason), nil)
+		default:
+			_, err = uc.reject(ctx, payment, ErrAttemptNotReceived, nil)
+		}
+		if err != nil {
+			return reconciled, fmt.Errorf("failed to reconcile payment %s: %w", paymentID, err)
+		}
+		reconciled++
+	}
+
+	return reconciled, nil
+}
+
+// beginAttempt는 처리 대기 중인 결제를 처리 중 상태로 저장하거나, 같은 종류의 시도가 처리 중이면 그 시도를 이어갑니다.
+// 반환한 컨텍스트에는 시도 ID가 게이트웨이 요청 ID로 담깁니다.
+// 다른 요청이 먼저 처리를 시작했으면 started가 false이고 저장된 결제를 반환합니다.
+func (uc *PaymentUseCase) beginAttempt(ctx context.Context, payment *domain.Payment, kind domain.AttemptKind) (context.Context, *domain.Payment, bool, error) {
+	if !payment.IsProcessing(kind) {
+		if err := payment.StartProcessing(kind, time.Now()); err != nil {
+			return ctx, nil, false, err
+		}
+		return uc.markProcessing(ctx, payment)
+	}
+	return WithGatewayRequestID(ctx, payment.Attempt().ID), payment, true, nil
+}
+
+// markProcessing은 새로 시작한 시도를 처리 중 상태로 저장하고, 시도 ID를 게이트웨이 요청 ID로 담은 컨텍스트를 반환합니다.
+// 다른 요청이 먼저 처리를 시작했으면 started가 false이고 저장된 결제를 반환합니다.
+func (uc *PaymentUseCase) markProcessing(ctx context.Context, payment *domain.Payment) (context.Context, *domain.Payment, bool, error) {
+	if err := uc.repo.MarkProcessing(ctx, payment); err != nil {
+		if !errors.Is(err, domain.ErrPaymentNotPending) && !errors.Is(err, domain.ErrPaymentNotAuthorized) {
+			return ctx, nil, false, fmt.Errorf("failed to save processing payment: %w", err)
+		}
+		current, err := uc.repo.FindByID(ctx, payment.ID())
+		if err != nil {
+			return ctx, nil, false, err
+		}
+		return ctx, current, false, nil
+	}
+	return WithGatewayRequestID(ctx, payment.Attempt().ID), payment, true, nil
+}
+
+// completeAttempt는 게이트웨이가 승인한 시도의 결과를 결제에 반영하고 저장합니다.
+// 가승인 시도는 가승인하고, 매입 시도는 매입하고, 취소 시도는 취소하며, 판매 시도는 승인합니다.
+// 가상계좌는 발급된 계좌의 트랜잭션 ID만 저장하고 입금을 기다립니다.
+func (uc *PaymentUseCase) completeAttempt(ctx context.Context, payment *domain.Payment, transactionID string) error {
+	switch {
+	case payment.Attempt().Kind == domain.AttemptKindCapture:
+		if err := payment.CompleteCapture(time.Now()); err != nil {
+			return err
+		}
+		if err := uc.repo.Update(ctx, payment); err != nil {
+			return fmt.Errorf("failed to update payment status after capture: %w", err)
+		}
+	case payment.Attempt().Kind == domain.AttemptKindVoid:
+		// 취소 요청의 사유는 처리 중 상태와 함께 저장되지 않으므로 점검에서 마무리한 취소임을 남깁니다
+		if err := payment.Void("void confirmed by gateway"); err != nil {
+			return err
+		}
+		if err := uc.repo.Update(ctx, payment); err != nil {
+			return fmt.Errorf("failed to update payment status after void: %w", err)
+		}
+	case payment.Attempt().Kind == domain.AttemptKindAuthorization:
+		if err := payment.Authorize(transactionID, time.Now().Add(uc.authorizationTTL)); err != nil {
+			return err
+		}
+		if err := uc.repo.Update(ctx, payment); err != nil {
+			return fmt.Errorf("failed to update payment status after authorization: %w", err)
+		}
+	case payment.Method() == domain.PaymentMethodVirtualAccount:
+		payment.AwaitDeposit(transactionID)
+		if err := uc.repo.Update(ctx, payment); err != nil {
+			return fmt.Errorf("failed to update payment after issuing virtual account: %w", err)
+		}
+	default:
+		payment.Approve(transactionID)
+		if err := uc.repo.Update(ctx, payment); err != nil {
+			return fmt.Errorf("failed to update payment status after approval: %w", err)
+		}
+	}
+	return nil
+}
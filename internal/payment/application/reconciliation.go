@@ -0,0 +1,299 @@
+package application
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"example.com/myapp/payment/domain"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidSettlementFile   = errors.New("invalid settlement file")
+	ErrInvalidSettlementPeriod = errors.New("settlement period start must be before its end")
+	ErrReconciliationNotFound  = errors.New("reconciliation not found")
+)
+
+// DefaultSettlementColumns는 열 매핑을 지정하지 않았을 때 사용하는 정산 파일 열 이름입니다.
+var DefaultSettlementColumns = SettlementColumns{
+	TransactionID: "transaction_id",
+	Amount:        "amount",
+	Status:        "status",
+}
+
+// settlementStatuses는 정산 파일의 상태 값별로 맞는 결제 상태입니다.
+// 청구된 거래(settled 등)는 매입된 결제, 환불 거래는 환불된 결제, 취소 거래는 청구되지 않은 결제와 맞습니다.
+var settlementStatuses = map[string][]domain.PaymentStatus{
+	"settled":            {domain.PaymentStatusApproved, domain.PaymentStatusCaptured, domain.PaymentStatusPartiallyRefunded},
+	"approved":           {domain.PaymentStatusApproved, domain.PaymentStatusCaptured, domain.PaymentStatusPartiallyRefunded},
+	"captured":           {domain.PaymentStatusApproved, domain.PaymentStatusCaptured, domain.PaymentStatusPartiallyRefunded},
+	"paid":               {domain.PaymentStatusApproved, domain.PaymentStatusCaptured, domain.PaymentStatusPartiallyRefunded},
+	"partially_refunded": {domain.PaymentStatusPartiallyRefunded},
+	"refunded":           {domain.PaymentStatusPartiallyRefunded, domain.PaymentStatusRefunded},
+	"canceled":           {domain.PaymentStatusVoided, domain.PaymentStatusRejected},
+	"cancelled":          {domain.PaymentStatusVoided, domain.PaymentStatusRejected},
+	"voided":             {domain.PaymentStatusVoided, domain.PaymentStatusRejected},
+}
+
+// canceledSettlementStatuses는 청구 없이 취소된 거래의 정산 상태 값입니다.
+var canceledSettlementStatuses = map[string]bool{"canceled": true, "cancelled": true, "voided": true}
+
+// ParseSettlementColumns는 "transaction_id=TID,amount=AMT,status=STATUS" 형식의 열 매핑을 읽습니다.
+// 지정하지 않은 값은 DefaultSettlementColumns의 열 이름을 사용하고, status를 비우면(status=) 상태를 대조하지 않습니다.
+func ParseSettlementColumns(spec string) (SettlementColumns, error) {
+	columns := DefaultSettlementColumns
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, column, ok := strings.Cut(pair, "=")
+		if !ok {
+			return SettlementColumns{}, fmt.Errorf("invalid settlement column mapping %q (use field=column)", pair)
+		}
+		column = strings.TrimSpace(column)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "transaction_id":
+			columns.TransactionID = column
+		case "amount":
+			columns.Amount = column
+		case "status":
+			columns.Status = column
+		default:
+			return SettlementColumns{}, fmt.Errorf("unknown settlement field %q (use transaction_id, amount or status)", key)
+		}
+	}
+	if columns.TransactionID == "" || columns.Amount == "" {
+		return SettlementColumns{}, errors.New("transaction_id and amount columns are required")
+	}
+	return columns, nil
+}
+
+// settlementRow는 정산 파일의 거래 한 건을 정의합니다.
+type settlementRow struct {
+	line          int
+	transactionID string
+	amount        float64
+	status        string
+}
+
+// ReconcileSettlement는 정산 파일 CSV를 트랜잭션 ID로 결제와 대사하고 결과를 저장합니다.
+// 행마다 결제가 없거나 중복이면 extra, 정산 금액이 청구 금액과 다르면 amount_mismatch,
+// 정산 상태가 결제 상태와 맞지 않으면 status_mismatch로 기록합니다. 취소 거래는 청구 금액이 없으므로 금액을 대조하지 않습니다.
+// 기간 안에 청구되었지만 파일에 없는 결제는 missing으로 기록합니다.
+func (uc *ReconciliationUseCase) ReconcileSettlement(ctx context.Context, r io.Reader, opts SettlementOptions) (*Reconciliation, error) {
+	if opts.From.IsZero() || !opts.From.Before(opts.To) {
+		return nil, ErrInvalidSettlementPeriod
+	}
+	if opts.Columns == (SettlementColumns{}) {
+		opts.Columns = DefaultSettlementColumns
+	}
+
+	reconciliation := &Reconciliation{
+		ID:          uuid.New().String(),
+		Source:      opts.Source,
+		PeriodStart: opts.From,
+		PeriodEnd:   opts.To,
+		Exceptions:  []ReconciliationException{},
+		CreatedAt:   time.Now(),
+	}
+	rows, err := parseSettlementFile(r, opts.Columns, reconciliation)
+	if err != nil {
+		return nil, err
+	}
+
+	settled, err := uc.payments.FindSettledBetween(ctx, opts.From, opts.To)
+	if err != nil {
+		return nil, err
+	}
+	payments := make(map[string]*domain.Payment, len(settled))
+	for _, payment := range settled {
+		payments[payment.TransactionID()] = payment
+	}
+
+	// 읽지 못한 행의 결제는 이미 invalid_row로 기록했으므로 누락으로 다시 기록하지 않습니다
+	seen := map[string]bool{}
+	for _, exception := range reconciliation.Exceptions {
+		if exception.TransactionID != "" {
+			seen[exception.TransactionID] = true
+		}
+	}
+	for _, row := range rows {
+		if seen[row.transactionID] {
+			reconciliation.addException(ReconciliationException{
+				Type: ReconciliationExtra, Line: row.line, TransactionID: row.transactionID, ActualAmount: row.amount,
+				ActualStatus: row.status, Message: "duplicate settlement row",
+			})
+			continue
+		}
+		seen[row.transactionID] = true
+
+		// 기간 밖에 생성되었거나 아직 청구되지 않은 결제도 트랜잭션 ID로 찾아 상태를 대조합니다
+		payment, ok := payments[row.transactionID]
+		if !ok {
+			payment, err = uc.payments.FindByTransactionID(ctx, row.transactionID)
+			if errors.Is(err, domain.ErrPaymentNotFound) {
+				reconciliation.addException(ReconciliationException{
+					Type: ReconciliationExtra, Line: row.line, TransactionID: row.transactionID, ActualAmount: row.amount,
+					ActualStatus: row.status, Message: "no payment with this transaction ID",
+				})
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !reconcileSettlementRow(reconciliation, row, payment) {
+			reconciliation.Matched++
+		}
+	}
+
+	for _, payment := range settled {
+		if seen[payment.TransactionID()] {
+			continue
+		}
+		reconciliation.addException(ReconciliationException{
+			Type: ReconciliationMissing, TransactionID: payment.TransactionID(), PaymentID: payment.ID(),
+			ExpectedAmount: payment.CapturedAmount(), ExpectedStatus: string(payment.Status()),
+			Message: "settled payment is not in the settlement file",
+		})
+	}
+
+	if err := uc.reconciliations.Save(ctx, reconciliation); err != nil {
+		return nil, fmt.Errorf("failed to save reconciliation: %w", err)
+	}
+	return reconciliation, nil
+}
+
+// GetReconciliation은 저장된 대사 결과를 불일치 목록과 함께 조회합니다.
+func (uc *ReconciliationUseCase) GetReconciliation(ctx context.Context, id string) (*Reconciliation, error) {
+	if id == "" {
+		return nil, ErrReconciliationNotFound
+	}
+	return uc.reconciliations.FindByID(ctx, id)
+}
+
+// reconcileSettlementRow는 정산 행과 결제의 상태와 금액을 대조해 불일치를 기록하고, 불일치가 있었는지 반환합니다.
+// 상태가 맞지 않으면 청구 금액도 의미가 없으므로 금액은 대조하지 않습니다.
+func reconcileSettlementRow(reconciliation *Reconciliation, row settlementRow, payment *domain.Payment) bool {
+	statuses, hasStatus := settlementStatuses[row.status]
+	if hasStatus && !containsPaymentStatus(statuses, payment.Status()) {
+		reconciliation.addException(ReconciliationException{
+			Type: ReconciliationStatusMismatch, Line: row.line, TransactionID: row.transactionID, PaymentID: payment.ID(),
+			ExpectedStatus: string(payment.Status()), ActualStatus: row.status,
+			Message: fmt.Sprintf("settlement status %s does not match payment status %s", row.status, payment.Status()),
+		})
+		return true
+	}
+
+	if !canceledSettlementStatuses[row.status] && math.Abs(row.amount-payment.CapturedAmount()) >= 0.005 {
+		reconciliation.addException(ReconciliationException{
+			Type: ReconciliationAmountMismatch, Line: row.line, TransactionID: row.transactionID, PaymentID: payment.ID(),
+			ExpectedAmount: payment.CapturedAmount(), ActualAmount: row.amount,
+			Message: fmt.Sprintf("settlement amount %.2f does not match charged amount %.2f", row.amount, payment.CapturedAmount()),
+		})
+		return true
+	}
+	return false
+}
+
+// addException은 불일치를 기록하고 종류별 건수를 늘립니다.
+func (r *Reconciliation) addException(exception ReconciliationException) {
+	r.Exceptions = append(r.Exceptions, exception)
+	switch exception.Type {
+	case ReconciliationMissing:
+		r.Missing++
+	case ReconciliationExtra:
+		r.Extra++
+	case ReconciliationAmountMismatch:
+		r.AmountMismatched++
+	case ReconciliationStatusMismatch:
+		r.StatusMismatched++
+	case ReconciliationInvalidRow:
+		r.Invalid++
+	}
+}
+
+// parseSettlementFile은 정산 파일 CSV를 읽습니다.
+// 행 단위 형식 오류는 invalid_row로 기록하고, 헤더에 필요한 열이 없거나 CSV를 읽을 수 없으면 ErrInvalidSettlementFile을 반환합니다.
+func parseSettlementFile(r io.Reader, columns SettlementColumns, reconciliation *Reconciliation) ([]settlementRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read header: %v", ErrInvalidSettlementFile, err)
+	}
+	index := map[string]int{}
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range []string{columns.TransactionID, columns.Amount, columns.Status} {
+		if _, ok := index[strings.ToLower(name)]; !ok && name != "" {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidSettlementFile, name)
+		}
+	}
+
+	rows := []settlementRow{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSettlementFile, err)
+		}
+		line, _ := reader.FieldPos(0)
+		reconciliation.Rows++
+
+		field := func(name string) string {
+			if i, ok := index[strings.ToLower(name)]; ok && name != "" && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := settlementRow{
+			line:          line,
+			transactionID: field(columns.TransactionID),
+			status:        strings.ToLower(field(columns.Status)),
+		}
+		var message string
+		amount, err := strconv.ParseFloat(strings.ReplaceAll(field(columns.Amount), ",", ""), 64)
+		switch {
+		case row.transactionID == "":
+			message = "transaction ID is required"
+		case err != nil:
+			message = fmt.Sprintf("invalid amount %q", field(columns.Amount))
+		case columns.Status != "" && settlementStatuses[row.status] == nil:
+			message = fmt.Sprintf("unknown settlement status %q", field(columns.Status))
+		}
+		if message != "" {
+			reconciliation.addException(ReconciliationException{
+				Type: ReconciliationInvalidRow, Line: line, TransactionID: row.transactionID, Message: message,
+			})
+			continue
+		}
+		row.amount = roundAmount(amount)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// containsPaymentStatus는 statuses에 status가 있는지 확인합니다.
+func containsPaymentStatus(statuses []domain.PaymentStatus, status domain.PaymentStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"example.com/myapp/payment/domain"
+)
+
+// RefundPayment는 아직 환불되지 않은 결제 금액 전체를 환불합니다.
+func (uc *PaymentUseCase) RefundPayment(ctx context.Context, id string, reason string) (*domain.Payment, error) {
+	if id == "" {
+		return nil, ErrInvalidPaymentID
+	}
+
+	// 결제 정보 조회
+	payment, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.refund(ctx, payment, payment.RefundableAmount(), reason); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// CreateRefund는 결제에서 amount만큼 환불합니다.
+// 환불 요청을 먼저 저장한 뒤 게이트웨이를 호출하므로, 게이트웨이가 실패해도 실패한 환불이 내역에 남습니다.
+// 게이트웨이가 일시적으로 응답하지 않으면 진행 중으로 둔 환불을 ErrGatewayUnavailable과 함께 반환합니다.
+func (uc *PaymentUseCase) CreateRefund(ctx context.Context, paymentID string, amount float64, reason string) (*domain.Refund, error) {
+	if paymentID == "" {
+		return nil, ErrInvalidPaymentID
+	}
+
+	// 결제 정보 조회
+	payment, err := uc.repo.FindByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.refund(ctx, payment, amount, reason)
+}
+
+// GetRefunds는 결제의 환불 내역을 요청 순서대로 조회합니다.
+func (uc *PaymentUseCase) GetRefunds(ctx context.Context, paymentID string) ([]*domain.Refund, error) {
+	if paymentID == "" {
+		return nil, ErrInvalidPaymentID
+	}
+
+	payment, err := uc.repo.FindByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	return payment.Refunds(), nil
+}
+
+// ReconcilePendingRefunds는 cutoff 이전에 요청해 아직 진행 중인 환불을 게이트웨이에 조회해 마무리합니다.
+// 게이트웨이가 승인한 환불은 완료하고, 거절한 환불은 실패로 기록하며, 받지 못한 환불은 같은 요청 ID로 다시 보냅니다.
+// cutoff는 게이트웨이 호출이 재시도까지 끝나기에 충분히 지난 시간이어야 진행 중인 환불을 다시 보내지 않습니다.
+func (uc *PaymentUseCase) ReconcilePendingRefunds(ctx context.Context, cutoff time.Time) (int, error) {
+	paymentIDs, err := uc.repo.ClaimPendingRefunds(ctx, cutoff, time.Now(), stuckProcessingClaimLease, stuckProcessingBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	reconciled := 0
+	for _, paymentID := range paymentIDs {
+		payment, err := uc.repo.FindByID(ctx, paymentID)
+		if err != nil {
+			if errors.Is(err, domain.ErrPaymentNotFound) {
+				continue
+			}
+			return reconciled, err
+		}
+
+		for _, refund := range payment.Refunds() {
+			// 선점 후 다른 요청이 마무리한 환불은 건너뜁니다
+			if refund.Status() != domain.RefundStatusPending || refund.CreatedAt().After(cutoff) {
+				continue
+			}
+
+			attempt, err := uc.gateway.LookupRefund(ctx, payment, refund.ID())
+			if err != nil {
+				return reconciled, fmt.Errorf("failed to look up refund %s: %w", refund.ID(), err)
+			}
+
+			switch attempt.Status {
+			case GatewayAttemptApproved:
+				if err := payment.CompleteRefund(refund.ID(), attempt.TransactionID); err != nil {
+					return reconciled, err
+				}
+				err = uc.repo.Update(ctx, payment)
+			case GatewayAttemptDeclined:
+				if err := payment.FailRefund(refund.ID(), attempt.DeclineReason); err != nil {
+					return reconciled, err
+				}
+				err = uc.repo.Update(ctx, payment)
+			default:
+				// 거절되어 실패로 기록된 환불도 마무리한 것으로 봅니다
+				if err = uc.sendRefund(ctx, payment, refund); refund.Status() == domain.RefundStatusFailed {
+					err = nil
+				}
+			}
+			if err != nil {
+				return reconciled, fmt.Errorf("failed to reconcile refund %s: %w", refund.ID(), err)
+			}
+			reconciled++
+		}
+	}
+
+	return reconciled, nil
+}
+
+// refund는 환불 요청을 기록하고 게이트웨이로 환불한 뒤 결과를 저장합니다.
+func (uc *PaymentUseCase) refund(ctx context.Context, payment *domain.Payment, amount float64, reason string) (*domain.Refund, error) {
+	refund, err := payment.RequestRefund(amount, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	// 진행 중인 환불 저장 (동시에 요청된 환불이 결제 금액을 넘으면 저장소가 거절합니다)
+	if err := uc.repo.Update(ctx, payment); err != nil {
+		return nil, fmt.Errorf("failed to save pending refund: %w", err)
+	}
+
+	if err := uc.sendRefund(ctx, payment, refund); err != nil {
+		if errors.Is(err, ErrGatewayUnavailable) {
+			// 진행 중인 환불은 마무리 작업이 처리하므로 호출한 쪽이 환불 ID를 알 수 있게 함께 반환합니다
+			return refund, err
+		}
+		return nil, err
+	}
+	return refund, nil
+}
+
+// sendRefund는 저장된 진행 중 환불을 게이트웨이로 보내고 결과를 저장합니다.
+// 저장된 환불 ID를 요청 ID로 보내므로 다시 호출되어도 게이트웨이는 한 번만 환불합니다.
+// 게이트웨이가 일시적으로 응답하지 않으면 결과를 알 수 없으므로 환불을 진행 중으로 둡니다.
+func (uc *PaymentUseCase) sendRefund(ctx context.Context, payment *domain.Payment, refund *domain.Refund) error {
+	refundCtx := WithGatewayRequestID(ctx, refund.ID())
+	gatewayRefundID, err := uc.gateway.RefundPayment(refundCtx, payment, refund.Amount(), refund.Reason())
+	if errors.Is(err, ErrGatewayUnavailable) {
+		return fmt.Errorf("refund processing failed: %w", err)
+	}
+	if err != nil {
+		if failErr := payment.FailRefund(refund.ID(), err.Error()); failErr != nil {
+			return failErr
+		}
+		if updateErr := uc.repo.Update(ctx, payment); updateErr != nil {
+			return fmt.Errorf("failed to update refund status after rejection: %w", updateErr)
+		}
+		return fmt.Errorf("refund processing failed: %w", err)
+	}
+
+	// 환불 완료 처리
+	if err := payment.CompleteRefund(refund.ID(), gatewayRefundID); err != nil {
+		return err
+	}
+	if err := uc.repo.Update(ctx, payment); err != nil {
+		return fmt.Errorf("failed to update payment status after refund: %w", err)
+	}
+	return nil
+}
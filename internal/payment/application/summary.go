@@ -0,0 +1,141 @@
+package application
+
+import (
+	"context"
+	"math"
+
+	"example.com/myapp/payment/domain"
+)
+
+// GetOrderPaymentSummary는 주문 금액과 결제·환불 합계로 주문의 결제 현황을 계산합니다.
+func (uc *PaymentUseCase) GetOrderPaymentSummary(ctx context.Context, orderID string) (*OrderPaymentSummary, error) {
+	if orderID == "" {
+		return nil, domain.ErrInvalidOrderID
+	}
+	summary, _, err := uc.orderPaymentSummary(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// orderPaymentSummary는 주문과 결제 목록을 조회하여 결제 현황을 계산합니다.
+func (uc *PaymentUseCase) orderPaymentSummary(ctx context.Context, orderID string) (*OrderPaymentSummary, *PayableOrder, error) {
+	order, err := uc.orders.FindOrder(ctx, orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	payments, err := uc.repo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	summary := &OrderPaymentSummary{
+		OrderID:    orderID,
+		OrderTotal: order.TotalAmount,
+		Payments:   payments,
+	}
+	for _, payment := range payments {
+		if payment.IsProcessing(domain.AttemptKindCapture) || payment.IsProcessing(domain.AttemptKindVoid) {
+			// 매입이나 취소가 끝나기 전까지는 가승인 금액으로 봅니다
+			summary.Authorized += payment.Amount()
+			continue
+		}
+		switch payment.Status() {
+		case domain.PaymentStatusPending, domain.PaymentStatusProcessing:
+			summary.Pending += payment.Amount()
+		case domain.PaymentStatusAuthorized:
+			summary.Authorized += payment.Amount()
+		case domain.PaymentStatusApproved, domain.PaymentStatusCaptured, domain.PaymentStatusPartiallyRefunded, domain.PaymentStatusRefunded:
+			summary.Paid += payment.CapturedAmount()
+		}
+		summary.Refunded += payment.RefundedAmount()
+	}
+	summary.Paid = roundAmount(summary.Paid)
+	summary.Pending = roundAmount(summary.Pending)
+	summary.Authorized = roundAmount(summary.Authorized)
+	summary.Refunded = roundAmount(summary.Refunded)
+	summary.AmountDue = math.Max(0, roundAmount(summary.OrderTotal-summary.Paid-summary.Authorized))
+	return summary, order, nil
+}
+
+// checkPayable은 처리 대기 중인 결제를 승인하거나 가승인해도 주문 금액을 넘지 않는지 확인합니다.
+// 결제 생성이 주문 금액 안에서 직렬화되므로 동시에 처리되는 결제들도 합계가 주문 금액을 넘지 않으며,
+// 이 확인은 생성 이후 주문이 취소되었거나 항목 취소로 주문 금액이 줄어든 경우를 걸러냅니다.
+func (uc *PaymentUseCase) checkPayable(ctx context.Context, payment *domain.Payment) error {
+	summary, order, err := uc.orderPaymentSummary(ctx, payment.OrderID())
+	if err != nil {
+		return err
+	}
+	if !order.AcceptsPayment {
+		return ErrOrderNotPayable
+	}
+	if payment.Amount() > summary.AmountDue+0.005 {
+		return ErrPaymentExceedsAmountDue
+	}
+	return nil
+}
+
+// RefundOrder는 주문의 결제들에서 생성 순서대로 amount만큼 나누어 환불합니다.
+// 같은 사유로 이미 완료된 환불 금액은 빼고 남은 금액만 환불하므로, 중간에 실패한 요청을 다시 보내도 중복 환불되지 않습니다.
+func (uc *PaymentUseCase) RefundOrder(ctx context.Context, orderID string, amount float64, reason string) ([]*domain.Refund, error) {
+	if orderID == "" {
+		return nil, domain.ErrInvalidOrderID
+	}
+	if amount <= 0 {
+		return nil, domain.ErrInvalidRefundAmount
+	}
+
+	payments, err := uc.repo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 같은 사유로 이미 완료된 환불과 환불 가능한 금액 확인
+	refunds := []*domain.Refund{}
+	remaining := roundAmount(amount)
+	var refundable float64
+	for _, payment := range payments {
+		for _, refund := range payment.Refunds() {
+			if refund.Status() == domain.RefundStatusSucceeded && refund.Reason() == reason {
+				refunds = append(refunds, refund)
+				remaining = roundAmount(remaining - refund.Amount())
+			}
+		}
+		if payment.IsRefundable() {
+			refundable += payment.RefundableAmount()
+		}
+	}
+	if remaining <= 0 {
+		return refunds, nil
+	}
+	if remaining > roundAmount(refundable) {
+		return nil, domain.ErrRefundExceedsPayment
+	}
+
+	for _, payment := range payments {
+		if remaining <= 0 {
+			break
+		}
+		if !payment.IsRefundable() {
+			continue
+		}
+		portion := math.Min(remaining, payment.RefundableAmount())
+		if portion <= 0 {
+			continue
+		}
+
+		refund, err := uc.refund(ctx, payment, portion, reason)
+		if err != nil {
+			return nil, err
+		}
+		refunds = append(refunds, refund)
+		remaining = roundAmount(remaining - portion)
+	}
+	return refunds, nil
+}
+
+// roundAmount는 금액을 소수점 둘째 자리로 반올림합니다.
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
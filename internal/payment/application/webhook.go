@@ -0,0 +1,233 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"example.com/myapp/payment/domain"
+)
+
+const (
+	// webhookClaimLease는 이벤트를 선점한 요청이 처리를 마칠 때까지 다른 요청이 건너뛰는 시간입니다.
+	webhookClaimLease = time.Minute
+	// webhookRetryBatchSize는 한 번에 다시 처리할 이벤트 수입니다.
+	webhookRetryBatchSize = 100
+	// maxWebhookAttempts는 이벤트 하나를 처리하는 최대 시도 횟수입니다. 넘으면 실패 상태로 남겨 수동으로 확인합니다.
+	maxWebhookAttempts = 10
+)
+
+var (
+	ErrUnknownWebhookProvider  = errors.New("unknown webhook provider")
+	ErrInvalidWebhook          = errors.New("invalid webhook")
+	ErrUnsupportedGatewayEvent = errors.New("unsupported gateway event")
+	ErrDepositAmountMismatch   = errors.New("deposit amount does not match the payment amount")
+)
+
+// ReceiveWebhook은 서명을 검증한 웹훅을 원본 그대로 저장한 뒤 결제에 반영합니다.
+// 이벤트 ID로 중복을 걸러내므로 게이트웨이가 같은 이벤트를 여러 번 보내도 한 번만 반영합니다.
+// 반영에 실패한 이벤트는 저장해 두고 오류를 반환하며, 게이트웨이의 재전송이나 RetryFailedWebhooks가 다시 처리합니다.
+func (uc *WebhookUseCase) ReceiveWebhook(ctx context.Context, providerName, signature, timestamp string, payload []byte) (*WebhookEvent, error) {
+	providerName = strings.ToLower(providerName)
+	provider, ok := uc.providers[providerName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownWebhookProvider, providerName)
+	}
+
+	now := time.Now()
+	if err := provider.Verify(signature, timestamp, payload, now); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	gatewayEvent, err := provider.ParseEvent(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	if gatewayEvent.ID == "" {
+		return nil, fmt.Errorf("%w: missing event ID", ErrInvalidWebhook)
+	}
+
+	// 원본 본문 저장 (이미 받은 이벤트면 그대로 둡니다)
+	_, err = uc.events.Save(ctx, &WebhookEvent{
+		Provider:   providerName,
+		EventID:    gatewayEvent.ID,
+		Type:       gatewayEvent.Type,
+		Payload:    payload,
+		Status:     WebhookEventStatusReceived,
+		ReceivedAt: now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save webhook event: %w", err)
+	}
+
+	// 이미 처리했거나 다른 요청이 처리 중이면 저장된 이벤트만 반환합니다
+	event, claimed, err := uc.events.Claim(ctx, providerName, gatewayEvent.ID, now, webhookClaimLease)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook event: %w", err)
+	}
+	if !claimed {
+		return event, nil
+	}
+
+	if err := uc.process(ctx, event, gatewayEvent); err != nil {
+		return event, err
+	}
+	return event, nil
+}
+
+// RetryFailedWebhooks는 반영에 실패했거나 처리 도중 중단된 이벤트를 저장된 원본 본문으로 다시 처리합니다.
+// 서명은 받을 때 검증했으므로 다시 검증하지 않습니다.
+func (uc *WebhookUseCase) RetryFailedWebhooks(ctx context.Context, now time.Time) (int, error) {
+	events, err := uc.events.ClaimRetryable(ctx, now, webhookClaimLease, maxWebhookAttempts, webhookRetryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim webhook events: %w", err)
+	}
+
+	processed := 0
+	var errs []error
+	for _, event := range events {
+		provider, ok := uc.providers[event.Provider]
+		if !ok {
+			errs = append(errs, fmt.Errorf("webhook event %s: %w: %s", event.EventID, ErrUnknownWebhookProvider, event.Provider))
+			continue
+		}
+		gatewayEvent, err := provider.ParseEvent(event.Payload)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("webhook event %s: %w: %v", event.EventID, ErrInvalidWebhook, err))
+			continue
+		}
+
+		if err := uc.process(ctx, event, gatewayEvent); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		processed++
+	}
+
+	return processed, errors.Join(errs...)
+}
+
+// process는 선점한 이벤트를 결제에 반영하고 결과를 저장합니다.
+// 처리하지 않는 종류의 이벤트는 무시한 것으로 기록합니다.
+func (uc *WebhookUseCase) process(ctx context.Context, event *WebhookEvent, gatewayEvent *GatewayEvent) error {
+	event.Attempts++
+	_, applyErr := uc.payments.ApplyGatewayEvent(ctx, gatewayEvent)
+	switch {
+	case applyErr == nil:
+		event.Status = WebhookEventStatusProcessed
+		event.LastError = ""
+		event.ProcessedAt = time.Now()
+	case errors.Is(applyErr, ErrUnsupportedGatewayEvent):
+		event.Status = WebhookEventStatusIgnored
+		event.LastError = applyErr.Error()
+		event.ProcessedAt = time.Now()
+		applyErr = nil
+	default:
+		event.Status = WebhookEventStatusFailed
+		event.LastError = applyErr.Error()
+	}
+
+	// 클라이언트가 연결을 끊어도 처리 결과는 저장합니다
+	if err := uc.events.Finish(context.WithoutCancel(ctx), event); err != nil {
+		return fmt.Errorf("failed to save webhook event result: %w", err)
+	}
+	if applyErr != nil {
+		return fmt.Errorf("failed to apply webhook event %s: %w", event.EventID, applyErr)
+	}
+	return nil
+}
+
+// ApplyGatewayEvent는 게이트웨이 이벤트를 결제에 반영합니다.
+//   - 승인, 입금: 처리 대기 중이거나 처리 중인 결제를 승인합니다. 가승인 시도는 가승인합니다. 이미 승인된 결제는 그대로 둡니다.
+//   - 거절: 처리 대기 중이거나 처리 중인 결제를 거절합니다. 이미 처리된 결제는 그대로 둡니다.
+//   - 차지백: 돌려받은 금액을 완료된 환불로 기록합니다. 같은 이벤트는 한 번만 기록합니다.
+//
+// 승인과 거절은 조회한 상태와 시도 ID가 그대로일 때만 저장하므로, 그 사이 결제 처리 요청이나 처리 중 결제 점검이
+// 결제를 마무리했으면 덮어쓰지 않고 domain.ErrPaymentNotPending을 반환합니다 (웹훅 재처리가 바뀐 상태로 다시 반영합니다).
+// 거절되거나 취소된 결제에 승인이나 입금이 오면 자동으로 되살리지 않고 domain.ErrPaymentNotPending을 반환합니다.
+func (uc *PaymentUseCase) ApplyGatewayEvent(ctx context.Context, event *GatewayEvent) (*domain.Payment, error) {
+	switch event.Type {
+	case GatewayEventPaymentApproved, GatewayEventDepositReceived, GatewayEventPaymentFailed, GatewayEventChargeback:
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedGatewayEvent, event.Type)
+	}
+
+	payment, err := uc.findEventPayment(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	// 매입이나 취소 중인 결제의 결과는 매입·취소 요청과 처리 중 결제 점검이 반영합니다
+	if (payment.IsProcessing(domain.AttemptKindCapture) || payment.IsProcessing(domain.AttemptKindVoid)) && event.Type != GatewayEventChargeback {
+		return payment, nil
+	}
+
+	switch event.Type {
+	case GatewayEventPaymentApproved, GatewayEventDepositReceived:
+		switch payment.Status() {
+		case domain.PaymentStatusPending, domain.PaymentStatusProcessing:
+		case domain.PaymentStatusRejected, domain.PaymentStatusVoided:
+			return nil, domain.ErrPaymentNotPending
+		default:
+			return payment, nil
+		}
+		if event.Amount > 0 && math.Abs(event.Amount-payment.Amount()) > 0.005 {
+			return nil, fmt.Errorf("%w: received %.2f, expected %.2f", ErrDepositAmountMismatch, event.Amount, payment.Amount())
+		}
+
+		transactionID := event.TransactionID
+		if transactionID == "" {
+			transactionID = payment.TransactionID()
+		}
+		from := payment.Status()
+		if payment.Attempt().Kind == domain.AttemptKindAuthorization {
+			if err := payment.Authorize(transactionID, time.Now().Add(uc.authorizationTTL)); err != nil {
+				return nil, err
+			}
+		} else {
+			payment.Approve(transactionID)
+		}
+		if err := uc.repo.Transition(ctx, payment, from, payment.Attempt().ID); err != nil {
+			return nil, fmt.Errorf("failed to update payment status after approval: %w", err)
+		}
+		return payment, nil
+
+	case GatewayEventPaymentFailed:
+		if payment.Status() != domain.PaymentStatusPending && payment.Status() != domain.PaymentStatusProcessing {
+			return payment, nil
+		}
+		reason := event.Reason
+		if reason == "" {
+			reason = "payment failed"
+		}
+		from := payment.Status()
+		payment.Reject(reason)
+		if err := uc.repo.Transition(ctx, payment, from, payment.Attempt().ID); err != nil {
+			return nil, fmt.Errorf("failed to update payment status after rejection: %w", err)
+		}
+		return payment, nil
+
+	default:
+		if _, err := payment.RecordChargeback(event.Amount, event.Reason, event.ID); err != nil {
+			return nil, err
+		}
+		if err := uc.repo.Update(ctx, payment); err != nil {
+			return nil, fmt.Errorf("failed to update payment status after chargeback: %w", err)
+		}
+		return payment, nil
+	}
+}
+
+// findEventPayment는 이벤트의 결제 ID로, 없으면 트랜잭션 ID로 결제를 찾습니다.
+func (uc *PaymentUseCase) findEventPayment(ctx context.Context, event *GatewayEvent) (*domain.Payment, error) {
+	switch {
+	case event.PaymentID != "":
+		return uc.repo.FindByID(ctx, event.PaymentID)
+	case event.TransactionID != "":
+		return uc.repo.FindByTransactionID(ctx, event.TransactionID)
+	default:
+		return nil, ErrInvalidPaymentID
+	}
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrPaymentNotPending           = errors.New("payment is not pending")
+	ErrPaymentNotAuthorized        = errors.New("payment is not authorized")
+	ErrInvalidCaptureAmount        = errors.New("capture amount must be positive")
+	ErrCaptureExceedsAuthorization = errors.New("capture amount exceeds the authorized amount")
+	ErrAuthorizationExpired        = errors.New("payment authorization has expired")
+	ErrAuthorizationNotSupported   = errors.New("only card payments can be authorized")
+)
+
+// AuthorizationExpiresAt은 승인(가승인)이 만료되는 시간을 반환합니다. 승인된 적이 없으면 zero 값입니다.
+func (p *Payment) AuthorizationExpiresAt() time.Time {
+	return p.authorizationExpiresAt
+}
+
+// CapturedAmount는 실제로 청구(매입)된 금액을 반환합니다.
+// 즉시 결제(Approve)는 결제 금액 전체, 부분 매입은 매입한 금액이며 환불은 이 금액까지 할 수 있습니다.
+// 매입 중인 결제는 매입을 요청한 금액을 반환합니다.
+func (p *Payment) CapturedAmount() float64 {
+	return p.capturedAmount
+}
+
+// IsAuthorizationExpired는 승인 상태의 결제가 now 기준으로 만료되었는지 확인합니다.
+func (p *Payment) IsAuthorizationExpired(now time.Time) bool {
+	return p.status == PaymentStatusAuthorized && !p.authorizationExpiresAt.After(now)
+}
+
+// Authorize는 처리 대기 중이거나 가승인을 처리 중인 결제를 승인(가승인) 상태로 변경합니다.
+// 승인된 금액은 expiresAt까지 Capture로 매입하거나 Void로 취소해야 합니다.
+func (p *Payment) Authorize(transactionID string, expiresAt time.Time) error {
+	if p.status != PaymentStatusPending && !p.IsProcessing(AttemptKindAuthorization) {
+		return ErrPaymentNotPending
+	}
+
+	p.status = PaymentStatusAuthorized
+	p.transactionID = transactionID
+	p.authorizationExpiresAt = expiresAt
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// StartCapture는 승인된 결제에서 amount만큼 매입하는 시도를 만들고 처리 중 상태로 변경합니다.
+// 승인 금액보다 적게 매입하면(부분 매입) 남은 승인 금액은 해제되며, 한 결제는 한 번만 매입할 수 있습니다.
+// 게이트웨이를 호출하기 전에 저장해야 같은 시도 ID로 다시 보내도 중복 매입되지 않습니다.
+func (p *Payment) StartCapture(amount float64, now time.Time) error {
+	if p.status != PaymentStatusAuthorized {
+		return ErrPaymentNotAuthorized
+	}
+	if p.IsAuthorizationExpired(now) {
+		return ErrAuthorizationExpired
+	}
+	amount = roundAmount(amount)
+	if amount <= 0 {
+		return ErrInvalidCaptureAmount
+	}
+	if amount > roundAmount(p.amount) {
+		return ErrCaptureExceedsAuthorization
+	}
+
+	p.capturedAmount = amount
+	p.startAttempt(AttemptKindCapture, now)
+	return nil
+}
+
+// CompleteCapture는 게이트웨이가 매입한 시도를 반영하여 결제를 매입 상태로 변경합니다.
+func (p *Payment) CompleteCapture(now time.Time) error {
+	if !p.IsProcessing(AttemptKindCapture) {
+		return ErrPaymentNotAuthorized
+	}
+
+	p.status = PaymentStatusCaptured
+	p.updatedAt = now
+	return nil
+}
+
+// FailCapture는 매입되지 않은 시도를 버리고 결제를 승인 상태로 되돌립니다.
+// 승인이 만료되기 전이면 다시 매입할 수 있고, 만료되었으면 만료된 가승인과 함께 취소됩니다.
+func (p *Payment) FailCapture(now time.Time) error {
+	if !p.IsProcessing(AttemptKindCapture) {
+		return ErrPaymentNotAuthorized
+	}
+
+	p.status = PaymentStatusAuthorized
+	p.capturedAmount = 0
+	p.updatedAt = now
+	return nil
+}
+
+// StartVoid는 승인된 결제를 취소하는 시도를 만들고 처리 중 상태로 변경합니다.
+// 게이트웨이를 호출하기 전에 저장해야 같은 결제를 동시에 매입하거나 취소하려는 요청 중 하나만 게이트웨이를 호출합니다.
+func (p *Payment) StartVoid(now time.Time) error {
+	if p.status != PaymentStatusAuthorized {
+		return ErrPaymentNotAuthorized
+	}
+
+	p.startAttempt(AttemptKindVoid, now)
+	return nil
+}
+
+// FailVoid는 게이트웨이가 취소하지 않은 시도를 버리고 결제를 승인 상태로 되돌립니다.
+func (p *Payment) FailVoid(now time.Time) error {
+	if !p.IsProcessing(AttemptKindVoid) {
+		return ErrPaymentNotAuthorized
+	}
+
+	p.status = PaymentStatusAuthorized
+	p.updatedAt = now
+	return nil
+}
+
+// Void는 승인된 결제나 취소를 처리 중인 결제를 매입하지 않고 취소합니다.
+func (p *Payment) Void(reason string) error {
+	if p.status != PaymentStatusAuthorized && !p.IsProcessing(AttemptKindVoid) {
+		return ErrPaymentNotAuthorized
+	}
+
+	p.status = PaymentStatusVoided
+	if p.paymentData == nil {
+		p.paymentData = map[string]string{}
+	}
+	p.paymentData["void_reason"] = reason
+	p.updatedAt = time.Now()
+	return nil
+}
@@ -15,6 +15,17 @@ const (
 	PaymentStatusApproved PaymentStatus = "approved"
 	PaymentStatusRejected PaymentStatus = "rejected"
 	PaymentStatusRefunded PaymentStatus = "refunded"
+	// PaymentStatusProcessing은 게이트웨이에 요청을 보냈지만 결과를 아직 저장하지 못한 상태입니다.
+	// 같은 시도 ID로 다시 요청하거나 게이트웨이에 결과를 조회해 승인 또는 거절로 마무리합니다.
+	PaymentStatusProcessing PaymentStatus = "processing"
+	// PaymentStatusPartiallyRefunded는 결제 금액 일부만 환불된 상태입니다.
+	PaymentStatusPartiallyRefunded PaymentStatus = "partially_refunded"
+	// PaymentStatusAuthorized는 카드 한도만 잡아 둔(가승인) 상태로, 매입하거나 취소해야 합니다.
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	// PaymentStatusCaptured는 승인된 금액을 매입(청구)한 상태입니다.
+	PaymentStatusCaptured PaymentStatus = "captured"
+	// PaymentStatusVoided는 승인을 매입하지 않고 취소한 상태입니다.
+	PaymentStatusVoided PaymentStatus = "voided"
 )
 
 // PaymentMethod는 결제 방법을 정의합니다.
@@ -42,8 +53,13 @@ type Payment struct {
 	status        PaymentStatus
 	transactionID string
 	paymentData   map[string]string // 결제 방법별 추가 데이터
+	refunds       []*Refund
 	createdAt     time.Time
 	updatedAt     time.Time
+
+	capturedAmount         float64
+	authorizationExpiresAt time.Time
+	attempt                PaymentAttempt
 }
 
 // NewPayment는 새로운 결제를 생성합니다.
@@ -66,11 +82,43 @@ func NewPayment(orderID string, amount float64, method PaymentMethod, paymentDat
 		method:      method,
 		status:      PaymentStatusPending,
 		paymentData: paymentData,
+		refunds:     []*Refund{},
 		createdAt:   now,
 		updatedAt:   now,
 	}, nil
 }
 
+// RestorePayment는 저장된 데이터로부터 결제를 복원합니다.
+func RestorePayment(
+	id, orderID string,
+	amount float64,
+	method PaymentMethod,
+	status PaymentStatus,
+	transactionID string,
+	paymentData map[string]string,
+	refunds []*Refund,
+	capturedAmount float64,
+	authorizationExpiresAt time.Time,
+	attempt PaymentAttempt,
+	createdAt, updatedAt time.Time,
+) *Payment {
+	return &Payment{
+		id:                     id,
+		orderID:                orderID,
+		amount:                 amount,
+		method:                 method,
+		status:                 status,
+		transactionID:          transactionID,
+		paymentData:            paymentData,
+		refunds:                refunds,
+		capturedAmount:         capturedAmount,
+		authorizationExpiresAt: authorizationExpiresAt,
+		attempt:                attempt,
+		createdAt:              createdAt,
+		updatedAt:              updatedAt,
+	}
+}
+
 // ID는 결제의 고유 식별자를 반환합니다.
 func (p *Payment) ID() string {
 	return p.id
@@ -122,10 +170,11 @@ func (p *Payment) SetTransactionID(transactionID string) {
 	p.updatedAt = time.Now()
 }
 
-// Approve는 결제를 승인 상태로 변경합니다.
+// Approve는 결제를 승인 상태로 변경합니다. 승인과 매입을 한 번에 하므로 결제 금액 전체가 청구됩니다.
 func (p *Payment) Approve(transactionID string) {
 	p.status = PaymentStatusApproved
 	p.transactionID = transactionID
+	p.capturedAmount = p.amount
 	p.updatedAt = time.Now()
 }
 
@@ -135,15 +184,3 @@ func (p *Payment) Reject(reason string) {
 	p.paymentData["reject_reason"] = reason
 	p.updatedAt = time.Now()
 }
-
-// Refund는 결제를 환불 상태로 변경합니다.
-func (p *Payment) Refund(reason string) error {
-	if p.status != PaymentStatusApproved {
-		return errors.New("only approved payments can be refunded")
-	}
-	
-	p.status = PaymentStatusRefunded
-	p.paymentData["refund_reason"] = reason
-	p.updatedAt = time.Now()
-	return nil
-}
\ No newline at end of file
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AttemptKind는 게이트웨이에 보낸 결제 시도의 종류입니다.
+type AttemptKind string
+
+const (
+	// AttemptKindSale은 승인과 매입을 한 번에 하는 판매 시도입니다.
+	AttemptKindSale AttemptKind = "sale"
+	// AttemptKindAuthorization은 가승인 시도입니다.
+	AttemptKindAuthorization AttemptKind = "authorization"
+	// AttemptKindCapture는 가승인된 금액의 매입 시도입니다.
+	AttemptKindCapture AttemptKind = "capture"
+	// AttemptKindVoid는 가승인된 금액의 취소 시도입니다.
+	AttemptKindVoid AttemptKind = "void"
+)
+
+// PaymentAttempt는 결제의 마지막 게이트웨이 시도입니다.
+// ID는 게이트웨이 요청 ID로 보내므로, 같은 시도를 다시 보내거나 결과를 조회해도 중복 청구되지 않습니다.
+type PaymentAttempt struct {
+	ID        string
+	Kind      AttemptKind
+	StartedAt time.Time
+}
+
+// Attempt는 결제의 마지막 게이트웨이 시도를 반환합니다. 시도한 적이 없으면 zero 값입니다.
+func (p *Payment) Attempt() PaymentAttempt {
+	return p.attempt
+}
+
+// StartProcessing은 처리 대기 중인 결제에 새 시도 ID를 만들고 처리 중 상태로 변경합니다.
+// 게이트웨이를 호출하기 전에 저장해야 호출 도중 프로세스가 종료되어도 시도를 찾아 마무리할 수 있습니다.
+func (p *Payment) StartProcessing(kind AttemptKind, now time.Time) error {
+	if p.status != PaymentStatusPending {
+		return ErrPaymentNotPending
+	}
+
+	p.startAttempt(kind, now)
+	return nil
+}
+
+// startAttempt는 새 시도 ID를 만들고 결제를 처리 중 상태로 변경합니다.
+func (p *Payment) startAttempt(kind AttemptKind, now time.Time) {
+	p.status = PaymentStatusProcessing
+	p.attempt = PaymentAttempt{
+		ID:        uuid.New().String(),
+		Kind:      kind,
+		StartedAt: now,
+	}
+	p.updatedAt = now
+}
+
+// IsProcessing은 kind 종류의 시도가 처리 중인지 확인합니다.
+func (p *Payment) IsProcessing(kind AttemptKind) bool {
+	return p.status == PaymentStatusProcessing && p.attempt.Kind == kind
+}
+
+// AwaitDeposit은 계좌를 발급받은 가상계좌 결제를 입금을 기다리는 처리 대기 상태로 되돌립니다.
+func (p *Payment) AwaitDeposit(transactionID string) {
+	p.status = PaymentStatusPending
+	p.transactionID = transactionID
+	p.updatedAt = time.Now()
+}
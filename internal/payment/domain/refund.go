@@ -0,0 +1,252 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefundStatus는 환불 상태를 정의합니다.
+type RefundStatus string
+
+const (
+	// RefundStatusPending은 게이트웨이에 환불을 요청하기 전이거나 결과를 기다리는 상태입니다.
+	RefundStatusPending   RefundStatus = "pending"
+	RefundStatusSucceeded RefundStatus = "succeeded"
+	RefundStatusFailed    RefundStatus = "failed"
+)
+
+var (
+	ErrInvalidRefundAmount  = errors.New("refund amount must be positive")
+	ErrRefundExceedsPayment = errors.New("refund amount exceeds the refundable amount of the payment")
+	ErrPaymentNotRefundable = errors.New("only approved or captured payments can be refunded")
+	ErrRefundNotFound       = errors.New("refund not found")
+	ErrRefundNotPending     = errors.New("refund is already completed")
+)
+
+// Refund는 결제의 환불 한 건을 나타냅니다.
+// 한 결제에 여러 번 환불할 수 있으며, 실패하지 않은 환불 금액의 합은 매입된 금액을 넘을 수 없습니다.
+type Refund struct {
+	id              string
+	paymentID       string
+	amount          float64
+	reason          string
+	gatewayRefundID string
+	status          RefundStatus
+	failureReason   string
+	createdAt       time.Time
+	updatedAt       time.Time
+}
+
+// RestoreRefund는 저장된 데이터로부터 환불을 복원합니다.
+func RestoreRefund(id, paymentID string, amount float64, reason, gatewayRefundID string, status RefundStatus, failureReason string, createdAt, updatedAt time.Time) *Refund {
+	return &Refund{
+		id:              id,
+		paymentID:       paymentID,
+		amount:          amount,
+		reason:          reason,
+		gatewayRefundID: gatewayRefundID,
+		status:          status,
+		failureReason:   failureReason,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
+	}
+}
+
+// ID는 환불의 고유 식별자를 반환합니다.
+func (r *Refund) ID() string {
+	return r.id
+}
+
+// PaymentID는 환불한 결제 ID를 반환합니다.
+func (r *Refund) PaymentID() string {
+	return r.paymentID
+}
+
+// Amount는 환불 금액을 반환합니다.
+func (r *Refund) Amount() float64 {
+	return r.amount
+}
+
+// Reason은 환불 사유를 반환합니다.
+func (r *Refund) Reason() string {
+	return r.reason
+}
+
+// GatewayRefundID는 결제 게이트웨이의 환불 ID를 반환합니다. 완료 전에는 비어 있습니다.
+func (r *Refund) GatewayRefundID() string {
+	return r.gatewayRefundID
+}
+
+// Status는 환불 상태를 반환합니다.
+func (r *Refund) Status() RefundStatus {
+	return r.status
+}
+
+// FailureReason은 환불이 실패한 사유를 반환합니다.
+func (r *Refund) FailureReason() string {
+	return r.failureReason
+}
+
+// CreatedAt은 환불을 요청한 시간을 반환합니다.
+func (r *Refund) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// UpdatedAt은 환불 정보가 마지막으로 업데이트된 시간을 반환합니다.
+func (r *Refund) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// Refunds는 결제의 환불 내역을 요청 순서대로 반환합니다.
+func (p *Payment) Refunds() []*Refund {
+	return p.refunds
+}
+
+// RefundedAmount는 완료된 환불 금액의 합계를 반환합니다.
+func (p *Payment) RefundedAmount() float64 {
+	return p.refundTotal(func(r *Refund) bool { return r.status == RefundStatusSucceeded })
+}
+
+// RefundableAmount는 매입된 금액 중 더 환불할 수 있는 금액을 반환합니다. 진행 중인 환불도 차감합니다.
+func (p *Payment) RefundableAmount() float64 {
+	return roundAmount(p.capturedAmount - p.refundTotal(func(r *Refund) bool { return r.status != RefundStatusFailed }))
+}
+
+// IsRefundable은 결제가 환불할 수 있는 상태(승인, 매입, 부분 환불)인지 확인합니다.
+func (p *Payment) IsRefundable() bool {
+	switch p.status {
+	case PaymentStatusApproved, PaymentStatusCaptured, PaymentStatusPartiallyRefunded:
+		return true
+	default:
+		return false
+	}
+}
+
+// refundTotal은 조건에 맞는 환불 금액의 합계를 반환합니다.
+func (p *Payment) refundTotal(match func(*Refund) bool) float64 {
+	var total float64
+	for _, refund := range p.refunds {
+		if match(refund) {
+			total += refund.amount
+		}
+	}
+	return roundAmount(total)
+}
+
+// RequestRefund는 승인되거나 매입된 결제에 진행 중인 환불을 추가합니다.
+// 게이트웨이 결과에 따라 CompleteRefund나 FailRefund로 환불을 마무리해야 합니다.
+func (p *Payment) RequestRefund(amount float64, reason string) (*Refund, error) {
+	if !p.IsRefundable() {
+		return nil, ErrPaymentNotRefundable
+	}
+	amount = roundAmount(amount)
+	if amount <= 0 {
+		return nil, ErrInvalidRefundAmount
+	}
+	if amount > p.RefundableAmount() {
+		return nil, ErrRefundExceedsPayment
+	}
+
+	now := time.Now()
+	refund := &Refund{
+		id:        uuid.New().String(),
+		paymentID: p.id,
+		amount:    amount,
+		reason:    reason,
+		status:    RefundStatusPending,
+		createdAt: now,
+		updatedAt: now,
+	}
+	p.refunds = append(p.refunds, refund)
+	p.updatedAt = now
+	return refund, nil
+}
+
+// CompleteRefund는 진행 중인 환불을 완료하고 환불 합계에 따라 결제 상태를 부분 환불이나 환불로 바꿉니다.
+func (p *Payment) CompleteRefund(refundID, gatewayRefundID string) error {
+	refund, err := p.pendingRefund(refundID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	refund.status = RefundStatusSucceeded
+	refund.gatewayRefundID = gatewayRefundID
+	refund.updatedAt = now
+
+	p.status = PaymentStatusPartiallyRefunded
+	if p.RefundedAmount() >= roundAmount(p.capturedAmount) {
+		p.status = PaymentStatusRefunded
+	}
+	p.updatedAt = now
+	return nil
+}
+
+// RecordChargeback은 카드사가 돌려받은 금액(차지백)을 완료된 환불로 기록합니다.
+// 차지백은 거절할 수 없으므로 amount가 환불 가능 금액을 넘거나 0이면 환불 가능 금액 전체를 기록합니다.
+// 같은 gatewayReference로 이미 기록된 차지백이면 기존 환불을 반환하고 아무것도 바꾸지 않습니다.
+func (p *Payment) RecordChargeback(amount float64, reason, gatewayReference string) (*Refund, error) {
+	for _, refund := range p.refunds {
+		if gatewayReference != "" && refund.gatewayRefundID == gatewayReference {
+			return refund, nil
+		}
+	}
+	if !p.IsRefundable() {
+		return nil, ErrPaymentNotRefundable
+	}
+
+	refundable := p.RefundableAmount()
+	amount = roundAmount(amount)
+	if amount <= 0 || amount > refundable {
+		amount = refundable
+	}
+	if amount <= 0 {
+		return nil, ErrRefundExceedsPayment
+	}
+
+	refund, err := p.RequestRefund(amount, "chargeback: "+reason)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CompleteRefund(refund.id, gatewayReference); err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+// FailRefund는 진행 중인 환불을 실패로 기록합니다. 실패한 금액은 다시 환불할 수 있습니다.
+func (p *Payment) FailRefund(refundID, reason string) error {
+	refund, err := p.pendingRefund(refundID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	refund.status = RefundStatusFailed
+	refund.failureReason = reason
+	refund.updatedAt = now
+	p.updatedAt = now
+	return nil
+}
+
+// pendingRefund는 ID로 진행 중인 환불을 찾습니다.
+func (p *Payment) pendingRefund(refundID string) (*Refund, error) {
+	for _, refund := range p.refunds {
+		if refund.id != refundID {
+			continue
+		}
+		if refund.status != RefundStatusPending {
+			return nil, ErrRefundNotPending
+		}
+		return refund, nil
+	}
+	return nil, ErrRefundNotFound
+}
+
+// roundAmount는 금액을 소수점 둘째 자리로 반올림합니다.
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,455 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"example.com/myapp/payment/application"
+	"example.com/myapp/payment/domain"
+	"github.com/google/uuid"
+)
+
+// 시뮬레이터가 결과를 정하는 결제 데이터 키입니다.
+const (
+	// SimulatorCardNumberKey는 카드 번호가 담기는 결제 데이터 키입니다.
+	SimulatorCardNumberKey = "cardNumber"
+	// SimulatorThreeDSecureKey는 3-D Secure 인증 결과가 담기는 결제 데이터 키입니다.
+	// 인증이 필요한 카드는 이 값이 "authenticated"여야 승인됩니다.
+	SimulatorThreeDSecureKey = "threeDSecure"
+)
+
+// 시뮬레이터 거절 코드입니다.
+const (
+	DeclineCodeCardDeclined           = "card_declined"
+	DeclineCodeInsufficientFunds      = "insufficient_funds"
+	DeclineCodeExpiredCard            = "expired_card"
+	DeclineCodeAuthenticationRequired = "authentication_required"
+	DeclineCodeProcessingError        = "processing_error"
+	DeclineCodeTimeout                = "timeout"
+	DeclineCodeRefundDeclined         = "refund_declined"
+)
+
+// simulatorCards는 결과가 정해진 테스트 카드 번호입니다.
+var simulatorCards = map[string]string{
+	"4000000000000002": DeclineCodeCardDeclined,
+	"4000000000009995": DeclineCodeInsufficientFunds,
+	"4000000000000069": DeclineCodeExpiredCard,
+	"4000000000003220": DeclineCodeAuthenticationRequired,
+	"4000000000000119": DeclineCodeProcessingError,
+	"4000000000000341": DeclineCodeTimeout,
+	"4000000000005126": DeclineCodeRefundDeclined,
+}
+
+var ErrInvalidSimulatorOutcome = errors.New("invalid simulator outcome")
+
+// SimulatorError는 시뮬레이터가 결제를 거절하거나 처리하지 못했을 때 반환하는 오류입니다.
+type SimulatorError struct {
+	Code string
+	// Retryable은 같은 요청을 다시 보내면 성공할 수 있는 일시적인 오류인지 나타냅니다.
+	Retryable bool
+}
+
+func (e *SimulatorError) Error() string {
+	return "payment gateway simulator: " + e.Code
+}
+
+// Temporary는 일시적인 오류인지 반환합니다.
+func (e *SimulatorError) Temporary() bool {
+	return e.Retryable
+}
+
+// SimulatorConfig는 결제 게이트웨이 시뮬레이터 설정을 정의합니다.
+type SimulatorConfig struct {
+	// Latency는 모든 호출에 더하는 지연 시간이며, LatencyJitter 안에서 무작위로 늘어납니다.
+	Latency       time.Duration
+	LatencyJitter time.Duration
+	// FailureRate는 일시적인 processing_error를 반환할 확률(0~1)입니다.
+	FailureRate float64
+	// Timeout은 timeout 결과를 반환하기 전에 기다리는 시간입니다. 요청 컨텍스트가 먼저 끝나면 그때 반환합니다.
+	Timeout time.Duration
+	// SettlementDelay는 승인되거나 매입된 금액이 정산 완료되기까지의 시간입니다.
+	// 계좌이체와 가상계좌는 이 시간이 지나야 입금이 확인됩니다.
+	SettlementDelay time.Duration
+	// AmountOutcomes는 결제 금액별로 정해진 거절 코드입니다. 카드 번호가 없는 결제 수단도 이 설정으로 실패시킬 수 있습니다.
+	AmountOutcomes map[float64]string
+	// Seed는 지연과 일시 오류의 난수 시드입니다. 0이면 현재 시간을 사용합니다.
+	Seed int64
+}
+
+// ParseAmountOutcomes는 "금액:거절코드" 목록(예: 4444:insufficient_funds,5555:timeout)을 파싱합니다.
+func ParseAmountOutcomes(spec string) (map[float64]string, error) {
+	outcomes := map[float64]string{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		amountStr, code, ok := strings.Cut(entry, ":")
+		amount, err := strconv.ParseFloat(strings.TrimSpace(amountStr), 64)
+		if !ok || err != nil || strings.TrimSpace(code) == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSimulatorOutcome, entry)
+		}
+		outcomes[roundCents(amount)] = strings.TrimSpace(code)
+	}
+	return outcomes, nil
+}
+
+// SimulatorOperation은 시뮬레이터가 처리한 요청 종류입니다.
+type SimulatorOperation string
+
+const (
+	SimulatorOperationSale      SimulatorOperation = "sale"
+	SimulatorOperationAuthorize SimulatorOperation = "authorize"
+	SimulatorOperationCapture   SimulatorOperation = "capture"
+	SimulatorOperationVoid      SimulatorOperation = "void"
+	SimulatorOperationRefund    SimulatorOperation = "refund"
+)
+
+// LedgerEntry는 시뮬레이터가 처리한 요청 한 건의 기록입니다.
+type LedgerEntry struct {
+	ID            string
+	Operation     SimulatorOperation
+	PaymentID     string
+	TransactionID string
+	Method        domain.PaymentMethod
+	Amount        float64
+	// RequestID는 호출자가 보낸 게이트웨이 요청 ID입니다. 같은 요청 ID로 다시 온 호출은 이 기록의 결과를 돌려줍니다.
+	RequestID string
+	// Approved가 false이면 DeclineCode에 거절 사유가 담깁니다.
+	Approved    bool
+	DeclineCode string
+	// SettleAt은 승인된 금액이 정산 완료되는 시간입니다. 정산 대상이 아니면 zero 값입니다.
+	SettleAt  time.Time
+	CreatedAt time.Time
+}
+
+// result는 기록된 처리 결과를 오류로 되돌립니다.
+func (e LedgerEntry) result() error {
+	if e.Approved {
+		return nil
+	}
+	return &SimulatorError{Code: e.DeclineCode}
+}
+
+// Settled는 now 기준으로 정산이 완료되었는지 확인합니다.
+func (e LedgerEntry) Settled(now time.Time) bool {
+	return !e.SettleAt.IsZero() && !e.SettleAt.After(now)
+}
+
+// GatewaySimulator는 외부 결제 게이트웨이 없이 승인, 거절, 지연, 일시 오류, 3-D Secure, 비동기 정산을 흉내 내는
+// PaymentGateway 구현체입니다. 결과는 테스트 카드 번호, 금액별 설정, 무작위 일시 오류 순서로 정해지며
+// 처리한 모든 요청을 Ledger로 조회할 수 있습니다.
+type GatewaySimulator struct {
+	config SimulatorConfig
+
+	mu     sync.Mutex
+	random *rand.Rand
+	ledger []LedgerEntry
+}
+
+// NewGatewaySimulator는 새로운 GatewaySimulator 인스턴스를 생성합니다.
+func NewGatewaySimulator(config SimulatorConfig) *GatewaySimulator {
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &GatewaySimulator{
+		config: config,
+		random: rand.New(rand.NewSource(seed)),
+		ledger: []LedgerEntry{},
+	}
+}
+
+var _ application.PaymentGateway = (*GatewaySimulator)(nil)
+
+// ProcessPayment는 승인과 매입을 한 번에 처리합니다.
+func (g *GatewaySimulator) ProcessPayment(ctx context.Context, payment *domain.Payment) (string, error) {
+	return g.charge(ctx, SimulatorOperationSale, payment)
+}
+
+// Authorize는 결제 금액만큼 가승인합니다.
+func (g *GatewaySimulator) Authorize(ctx context.Context, payment *domain.Payment) (string, error) {
+	return g.charge(ctx, SimulatorOperationAuthorize, payment)
+}
+
+// Capture는 가승인된 금액에서 amount만큼 매입합니다.
+func (g *GatewaySimulator) Capture(ctx context.Context, payment *domain.Payment, amount float64) error {
+	if entry, ok := g.replay(ctx, SimulatorOperationCapture); ok {
+		return entry.result()
+	}
+	if err := g.wait(ctx, payment); err != nil {
+		g.record(ctx, SimulatorOperationCapture, payment, payment.TransactionID(), amount, err)
+		return err
+	}
+
+	authorized, ok := g.entry(payment.TransactionID(), SimulatorOperationAuthorize)
+	if ok && roundCents(amount) > roundCents(authorized.Amount) {
+		err := &SimulatorError{Code: DeclineCodeCardDeclined}
+		g.record(ctx, SimulatorOperationCapture, payment, payment.TransactionID(), amount, err)
+		return err
+	}
+
+	g.record(ctx, SimulatorOperationCapture, payment, payment.TransactionID(), amount, nil)
+	return nil
+}
+
+// Void는 가승인을 취소합니다.
+func (g *GatewaySimulator) Void(ctx context.Context, payment *domain.Payment) error {
+	if entry, ok := g.replay(ctx, SimulatorOperationVoid); ok {
+		return entry.result()
+	}
+	err := g.wait(ctx, payment)
+	g.record(ctx, SimulatorOperationVoid, payment, payment.TransactionID(), payment.Amount(), err)
+	return err
+}
+
+// RefundPayment는 결제에서 amount만큼 환불하고 환불 ID를 반환합니다.
+func (g *GatewaySimulator) RefundPayment(ctx context.Context, payment *domain.Payment, amount float64, reason string) (string, error) {
+	if entry, ok := g.replay(ctx, SimulatorOperationRefund); ok {
+		if err := entry.result(); err != nil {
+			return "", err
+		}
+		return entry.ID, nil
+	}
+	err := g.wait(ctx, payment)
+	if err == nil && g.scenario(payment) == DeclineCodeRefundDeclined {
+		err = &SimulatorError{Code: DeclineCodeRefundDeclined}
+	}
+	entry := g.record(ctx, SimulatorOperationRefund, payment, payment.TransactionID(), amount, err)
+	if err != nil {
+		return "", err
+	}
+	return entry.ID, nil
+}
+
+// LookupAttempt는 결제의 마지막 시도 ID로 기록된 판매, 가승인, 매입, 취소 요청의 결과를 조회합니다.
+// 기록이 없거나 일시 오류로 끝난 기록뿐이면 처리하지 않은 것으로 봅니다.
+func (g *GatewaySimulator) LookupAttempt(ctx context.Context, payment *domain.Payment) (*application.GatewayAttempt, error) {
+	operation := SimulatorOperationSale
+	switch payment.Attempt().Kind {
+	case domain.AttemptKindAuthorization:
+		operation = SimulatorOperationAuthorize
+	case domain.AttemptKindCapture:
+		operation = SimulatorOperationCapture
+	case domain.AttemptKindVoid:
+		operation = SimulatorOperationVoid
+	}
+
+	entry, ok := g.replay(application.WithGatewayRequestID(ctx, payment.Attempt().ID), operation)
+	switch {
+	case !ok:
+		return &application.GatewayAttempt{Status: application.GatewayAttemptNotFound}, nil
+	case entry.Approved:
+		return &application.GatewayAttempt{Status: application.GatewayAttemptApproved, TransactionID: entry.TransactionID}, nil
+	default:
+		return &application.GatewayAttempt{Status: application.GatewayAttemptDeclined, DeclineReason: entry.DeclineCode}, nil
+	}
+}
+
+// LookupRefund는 환불 ID를 요청 ID로 기록된 환불 요청의 결과를 조회합니다.
+// 기록이 없거나 일시 오류로 끝난 기록뿐이면 처리하지 않은 것으로 봅니다.
+func (g *GatewaySimulator) LookupRefund(ctx context.Context, payment *domain.Payment, refundID string) (*application.GatewayAttempt, error) {
+	entry, ok := g.replay(application.WithGatewayRequestID(ctx, refundID), SimulatorOperationRefund)
+	switch {
+	case !ok:
+		return &application.GatewayAttempt{Status: application.GatewayAttemptNotFound}, nil
+	case entry.Approved:
+		return &application.GatewayAttempt{Status: application.GatewayAttemptApproved, TransactionID: entry.ID}, nil
+	default:
+		return &application.GatewayAttempt{Status: application.GatewayAttemptDeclined, DeclineReason: entry.DeclineCode}, nil
+	}
+}
+
+// Ledger는 시뮬레이터가 처리한 요청을 처리 순서대로 반환합니다.
+func (g *GatewaySimulator) Ledger() []LedgerEntry {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return append([]LedgerEntry(nil), g.ledger...)
+}
+
+// charge는 판매(승인+매입)나 가승인 요청의 결과를 정하고 기록합니다.
+func (g *GatewaySimulator) charge(ctx context.Context, operation SimulatorOperation, payment *domain.Payment) (string, error) {
+	if entry, ok := g.replay(ctx, operation); ok {
+		return entry.TransactionID, entry.result()
+	}
+	err := g.wait(ctx, payment)
+	if err == nil {
+		err = g.outcome(payment)
+	}
+	// 승인된 판매와 가승인은 원장 기록 ID를 트랜잭션 ID로 사용합니다
+	entry := g.record(ctx, operation, payment, "", payment.Amount(), err)
+	return entry.TransactionID, err
+}
+
+// scenario는 카드 번호와 금액별 설정으로 정해진 결과 코드를 반환합니다. 금액 설정이 카드 번호보다 우선합니다.
+func (g *GatewaySimulator) scenario(payment *domain.Payment) string {
+	if code, ok := g.config.AmountOutcomes[roundCents(payment.Amount())]; ok {
+		return code
+	}
+	return simulatorCards[payment.PaymentData()[SimulatorCardNumberKey]]
+}
+
+// outcome은 정해진 결과 코드로 승인 여부를 정합니다.
+func (g *GatewaySimulator) outcome(payment *domain.Payment) error {
+	code := g.scenario(payment)
+	switch code {
+	case "", DeclineCodeRefundDeclined, DeclineCodeTimeout, DeclineCodeProcessingError:
+		// 지연과 일시 오류는 wait에서 처리합니다
+		return nil
+	case DeclineCodeAuthenticationRequired:
+		if payment.PaymentData()[SimulatorThreeDSecureKey] == "authenticated" {
+			return nil
+		}
+		return &SimulatorError{Code: code}
+	default:
+		return &SimulatorError{Code: code}
+	}
+}
+
+// wait는 설정된 지연을 흉내 내고, timeout이나 일시 오류 대상이면 오류를 반환합니다.
+func (g *GatewaySimulator) wait(ctx context.Context, payment *domain.Payment) error {
+	code := g.scenario(payment)
+
+	g.mu.Lock()
+	latency := g.config.Latency
+	if g.config.LatencyJitter > 0 {
+		latency += time.Duration(g.random.Int63n(int64(g.config.LatencyJitter)))
+	}
+	flaky := g.config.FailureRate > 0 && g.random.Float64() < g.config.FailureRate
+	g.mu.Unlock()
+
+	if code == DeclineCodeTimeout {
+		latency = g.config.Timeout
+	}
+	if err := sleep(ctx, latency); err != nil {
+		return &SimulatorError{Code: DeclineCodeTimeout, Retryable: true}
+	}
+
+	switch {
+	case code == DeclineCodeTimeout:
+		return &SimulatorError{Code: DeclineCodeTimeout, Retryable: true}
+	case code == DeclineCodeProcessingError || flaky:
+		return &SimulatorError{Code: DeclineCodeProcessingError, Retryable: true}
+	default:
+		return nil
+	}
+}
+
+// record는 처리 결과를 원장에 추가합니다.
+func (g *GatewaySimulator) record(ctx context.Context, operation SimulatorOperation, payment *domain.Payment, transactionID string, amount float64, err error) LedgerEntry {
+	now := time.Now()
+	requestID, _ := application.GatewayRequestID(ctx)
+	entry := LedgerEntry{
+		ID:            operationPrefix(operation) + "_sim_" + strings.ReplaceAll(uuid.New().String(), "-", "")[:16],
+		Operation:     operation,
+		PaymentID:     payment.ID(),
+		TransactionID: transactionID,
+		Method:        payment.Method(),
+		Amount:        roundCents(amount),
+		RequestID:     requestID,
+		Approved:      err == nil,
+		CreatedAt:     now,
+	}
+
+	if err == nil && (operation == SimulatorOperationSale || operation == SimulatorOperationAuthorize) {
+		entry.TransactionID = entry.ID
+	}
+
+	var simErr *SimulatorError
+	if errors.As(err, &simErr) {
+		entry.DeclineCode = simErr.Code
+	} else if err != nil {
+		entry.DeclineCode = err.Error()
+	}
+	// 판매와 매입은 정산 대상이며, 계좌이체와 가상계좌는 입금 확인까지 정산이 늦어집니다
+	if err == nil && (operation == SimulatorOperationSale || operation == SimulatorOperationCapture) {
+		entry.SettleAt = now
+		if payment.Method() != domain.PaymentMethodCreditCard || operation == SimulatorOperationCapture {
+			entry.SettleAt = now.Add(g.config.SettlementDelay)
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.ledger = append(g.ledger, entry)
+	return entry
+}
+
+// replay는 ctx의 요청 ID로 이미 끝난 같은 종류의 요청 기록을 찾습니다.
+// 일시 오류(timeout, processing_error)로 끝난 요청은 처리되지 않은 것으로 보고 다시 처리합니다.
+func (g *GatewaySimulator) replay(ctx context.Context, operation SimulatorOperation) (LedgerEntry, bool) {
+	requestID, ok := application.GatewayRequestID(ctx)
+	if !ok {
+		return LedgerEntry{}, false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, entry := range g.ledger {
+		if entry.RequestID != requestID || entry.Operation != operation {
+			continue
+		}
+		if entry.DeclineCode == DeclineCodeTimeout || entry.DeclineCode == DeclineCodeProcessingError {
+			continue
+		}
+		return entry, true
+	}
+	return LedgerEntry{}, false
+}
+
+// entry는 트랜잭션 ID와 요청 종류로 승인된 원장 기록을 찾습니다.
+func (g *GatewaySimulator) entry(transactionID string, operation SimulatorOperation) (LedgerEntry, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, entry := range g.ledger {
+		if entry.TransactionID == transactionID && entry.Operation == operation && entry.Approved {
+			return entry, true
+		}
+	}
+	return LedgerEntry{}, false
+}
+
+// operationPrefix는 원장 기록 ID에 붙일 요청 종류 접두사를 반환합니다.
+func operationPrefix(operation SimulatorOperation) string {
+	switch operation {
+	case SimulatorOperationRefund:
+		return "rfnd"
+	case SimulatorOperationCapture:
+		return "cap"
+	case SimulatorOperationVoid:
+		return "void"
+	default:
+		return "txn"
+	}
+}
+
+// sleep은 d만큼 기다리며, 그 전에 ctx가 끝나면 ctx의 오류를 반환합니다.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// roundCents는 금액을 소수점 둘째 자리로 반올림합니다.
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
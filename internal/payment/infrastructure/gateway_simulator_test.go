@@ -0,0 +1,230 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"example.com/myapp/payment/application"
+	"example.com/myapp/payment/domain"
+)
+
+func newSimulatorPayment(t *testing.T, amount float64, data map[string]string) *domain.Payment {
+	t.Helper()
+	payment, err := domain.NewPayment("ord-1", amount, domain.PaymentMethodCreditCard, data)
+	if err != nil {
+		t.Fatalf("NewPayment() error = %v", err)
+	}
+	return payment
+}
+
+func TestGatewaySimulatorTestCards(t *testing.T) {
+	tests := []struct {
+		name          string
+		card          string
+		threeDSecure  string
+		wantCode      string
+		wantRetryable bool
+	}{
+		{"일반 카드 승인", "4242424242424242", "", "", false},
+		{"카드 거절", "4000000000000002", "", DeclineCodeCardDeclined, false},
+		{"잔액 부족", "4000000000009995", "", DeclineCodeInsufficientFunds, false},
+		{"만료된 카드", "4000000000000069", "", DeclineCodeExpiredCard, false},
+		{"3-D Secure 미인증", "4000000000003220", "", DeclineCodeAuthenticationRequired, false},
+		{"3-D Secure 인증 완료", "4000000000003220", "authenticated", "", false},
+		{"일시적 처리 오류", "4000000000000119", "", DeclineCodeProcessingError, true},
+		{"시간 초과", "4000000000000341", "", DeclineCodeTimeout, true},
+		{"환불만 거절되는 카드는 승인", "4000000000005126", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			simulator := NewGatewaySimulator(SimulatorConfig{Timeout: time.Millisecond, Seed: 1})
+			payment := newSimulatorPayment(t, 10000, map[string]string{
+				SimulatorCardNumberKey:   tt.card,
+				SimulatorThreeDSecureKey: tt.threeDSecure,
+			})
+
+			transactionID, err := simulator.ProcessPayment(context.Background(), payment)
+			if tt.wantCode == "" {
+				if err != nil || transactionID == "" {
+					t.Fatalf("ProcessPayment() = %q, %v, want transaction ID", transactionID, err)
+				}
+			} else {
+				var simErr *SimulatorError
+				if !errors.As(err, &simErr) || simErr.Code != tt.wantCode || simErr.Temporary() != tt.wantRetryable {
+					t.Fatalf("ProcessPayment() error = %v, want %s (retryable %v)", err, tt.wantCode, tt.wantRetryable)
+				}
+			}
+
+			ledger := simulator.Ledger()
+			if len(ledger) != 1 || ledger[0].Approved != (tt.wantCode == "") || ledger[0].DeclineCode != tt.wantCode {
+				t.Errorf("ledger = %+v, want one entry with decline code %q", ledger, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestGatewaySimulatorRefundDeclinedCard(t *testing.T) {
+	simulator := NewGatewaySimulator(SimulatorConfig{Seed: 1})
+	payment := newSimulatorPayment(t, 10000, map[string]string{SimulatorCardNumberKey: "4000000000005126"})
+	ctx := context.Background()
+
+	transactionID, err := simulator.ProcessPayment(ctx, payment)
+	if err != nil {
+		t.Fatalf("ProcessPayment() error = %v", err)
+	}
+	payment.Approve(transactionID)
+
+	var simErr *SimulatorError
+	if _, err := simulator.RefundPayment(ctx, payment, 3000, "고객 요청"); !errors.As(err, &simErr) || simErr.Code != DeclineCodeRefundDeclined {
+		t.Errorf("RefundPayment() error = %v, want %s", err, DeclineCodeRefundDeclined)
+	}
+}
+
+func TestGatewaySimulatorAmountOutcomeOverridesCard(t *testing.T) {
+	simulator := NewGatewaySimulator(SimulatorConfig{
+		AmountOutcomes: map[float64]string{4444: DeclineCodeInsufficientFunds},
+		Seed:           1,
+	})
+	payment := newSimulatorPayment(t, 4444, map[string]string{SimulatorCardNumberKey: "4242424242424242"})
+
+	var simErr *SimulatorError
+	if _, err := simulator.ProcessPayment(context.Background(), payment); !errors.As(err, &simErr) || simErr.Code != DeclineCodeInsufficientFunds {
+		t.Errorf("ProcessPayment() error = %v, want %s", err, DeclineCodeInsufficientFunds)
+	}
+}
+
+func TestGatewaySimulatorRetryWithSameRequestID(t *testing.T) {
+	tests := []struct {
+		name        string
+		card        string
+		wantCode    string
+		wantEntries int
+	}{
+		// 끝난 요청은 다시 처리하지 않고 기록된 결과를 돌려줍니다
+		{"승인된 요청", "4242424242424242", "", 1},
+		{"거절된 요청", "4000000000000002", DeclineCodeCardDeclined, 1},
+		// 일시 오류로 끝난 요청은 처리되지 않은 것으로 보고 다시 처리합니다
+		{"일시 오류로 끝난 요청", "4000000000000119", DeclineCodeProcessingError, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			simulator := NewGatewaySimulator(SimulatorConfig{Seed: 1})
+			payment := newSimulatorPayment(t, 10000, map[string]string{SimulatorCardNumberKey: tt.card})
+			ctx := application.WithGatewayRequestID(context.Background(), "attempt-1")
+
+			first, firstErr := simulator.ProcessPayment(ctx, payment)
+			second, secondErr := simulator.ProcessPayment(ctx, payment)
+			if first != second || (firstErr == nil) != (secondErr == nil) {
+				t.Errorf("retry = %q, %v, want %q, %v", second, secondErr, first, firstErr)
+			}
+			var simErr *SimulatorError
+			if tt.wantCode != "" && (!errors.As(secondErr, &simErr) || simErr.Code != tt.wantCode) {
+				t.Errorf("retry error = %v, want %s", secondErr, tt.wantCode)
+			}
+			if len(simulator.Ledger()) != tt.wantEntries {
+				t.Errorf("ledger entries = %d, want %d", len(simulator.Ledger()), tt.wantEntries)
+			}
+		})
+	}
+}
+
+func TestGatewaySimulatorRetryAfterTimeoutIsProcessedOnce(t *testing.T) {
+	simulator := NewGatewaySimulator(SimulatorConfig{Latency: 20 * time.Millisecond, Seed: 1})
+	payment := newSimulatorPayment(t, 10000, map[string]string{SimulatorCardNumberKey: "4242424242424242"})
+	ctx := application.WithGatewayRequestID(context.Background(), "attempt-1")
+
+	// 응답을 받기 전에 호출자가 포기하면 시간 초과로 기록됩니다
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	var simErr *SimulatorError
+	if _, err := simulator.ProcessPayment(timeoutCtx, payment); !errors.As(err, &simErr) || simErr.Code != DeclineCodeTimeout || !simErr.Temporary() {
+		t.Fatalf("ProcessPayment() error = %v, want retryable %s", err, DeclineCodeTimeout)
+	}
+
+	transactionID, err := simulator.ProcessPayment(ctx, payment)
+	if err != nil || transactionID == "" {
+		t.Fatalf("retry = %q, %v, want transaction ID", transactionID, err)
+	}
+	replayed, err := simulator.ProcessPayment(ctx, payment)
+	if err != nil || replayed != transactionID {
+		t.Errorf("second retry = %q, %v, want %q", replayed, err, transactionID)
+	}
+
+	approved := 0
+	for _, entry := range simulator.Ledger() {
+		if entry.Approved {
+			approved++
+		}
+	}
+	if approved != 1 {
+		t.Errorf("approved entries = %d, want 1", approved)
+	}
+}
+
+func TestGatewaySimulatorCaptureRetryAndLookup(t *testing.T) {
+	simulator := NewGatewaySimulator(SimulatorConfig{Seed: 1})
+	payment := newSimulatorPayment(t, 10000, map[string]string{SimulatorCardNumberKey: "4242424242424242"})
+	ctx := context.Background()
+
+	transactionID, err := simulator.Authorize(application.WithGatewayRequestID(ctx, "auth-1"), payment)
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	if err := payment.Authorize(transactionID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("payment.Authorize() error = %v", err)
+	}
+
+	// 승인 금액을 넘는 매입은 거절됩니다
+	var simErr *SimulatorError
+	if err := simulator.Capture(application.WithGatewayRequestID(ctx, "cap-over"), payment, 10001); !errors.As(err, &simErr) || simErr.Code != DeclineCodeCardDeclined {
+		t.Errorf("Capture(10001) error = %v, want %s", err, DeclineCodeCardDeclined)
+	}
+
+	if err := payment.StartCapture(7000, time.Now()); err != nil {
+		t.Fatalf("StartCapture() error = %v", err)
+	}
+	captureCtx := application.WithGatewayRequestID(ctx, payment.Attempt().ID)
+	for i := 0; i < 2; i++ {
+		if err := simulator.Capture(captureCtx, payment, 7000); err != nil {
+			t.Fatalf("Capture() #%d error = %v", i+1, err)
+		}
+	}
+
+	captures := 0
+	for _, entry := range simulator.Ledger() {
+		if entry.Operation == SimulatorOperationCapture && entry.Approved {
+			captures++
+		}
+	}
+	if captures != 1 {
+		t.Errorf("approved captures = %d, want 1", captures)
+	}
+
+	attempt, err := simulator.LookupAttempt(ctx, payment)
+	if err != nil {
+		t.Fatalf("LookupAttempt() error = %v", err)
+	}
+	if attempt.Status != application.GatewayAttemptApproved || attempt.TransactionID != transactionID {
+		t.Errorf("LookupAttempt() = %+v, want approved %s", attempt, transactionID)
+	}
+}
+
+func TestParseAmountOutcomes(t *testing.T) {
+	outcomes, err := ParseAmountOutcomes(" 4444:insufficient_funds, 5555.5:timeout ,")
+	if err != nil {
+		t.Fatalf("ParseAmountOutcomes() error = %v", err)
+	}
+	if outcomes[4444] != DeclineCodeInsufficientFunds || outcomes[5555.5] != DeclineCodeTimeout || len(outcomes) != 2 {
+		t.Errorf("outcomes = %v", outcomes)
+	}
+
+	for _, spec := range []string{"4444", "abc:timeout", "4444:"} {
+		if _, err := ParseAmountOutcomes(spec); !errors.Is(err, ErrInvalidSimulatorOutcome) {
+			t.Errorf("ParseAmountOutcomes(%q) error = %v, want %v", spec, err, ErrInvalidSimulatorOutcome)
+		}
+	}
+}
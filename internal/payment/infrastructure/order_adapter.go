@@ -0,0 +1,39 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	orderApp "example.com/myapp/order/application"
+	orderDomain "example.com/myapp/order/domain"
+	"example.com/myapp/payment/application"
+)
+
+// OrderDirectoryAdapter는 주문 모듈의 공개 API로 OrderDirectory 포트를 구현합니다.
+type OrderDirectoryAdapter struct {
+	orders orderApp.OrderService
+}
+
+// NewOrderDirectoryAdapter는 새로운 OrderDirectoryAdapter 인스턴스를 생성합니다.
+func NewOrderDirectoryAdapter(orders orderApp.OrderService) application.OrderDirectory {
+	return &OrderDirectoryAdapter{
+		orders: orders,
+	}
+}
+
+// FindOrder는 주문 모듈에서 주문 금액과 결제 대기 여부를 조회합니다.
+func (a *OrderDirectoryAdapter) FindOrder(ctx context.Context, orderID string) (*application.PayableOrder, error) {
+	order, err := a.orders.GetOrder(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, orderDomain.ErrOrderNotFound) {
+			return nil, application.ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	return &application.PayableOrder{
+		ID:             order.ID(),
+		TotalAmount:    order.TotalAmount(),
+		AcceptsPayment: order.Status() == orderDomain.StatusPending,
+	}, nil
+}
@@ -0,0 +1,164 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"example.com/myapp/payment/application"
+	"example.com/myapp/shared/db"
+	"github.com/jackc/pgx/v4"
+)
+
+// PostgresReconciliationRepository는 PostgreSQL을 사용하는 정산 대사 결과 저장소 구현체입니다.
+type PostgresReconciliationRepository struct {
+	db *db.Database
+}
+
+// NewPostgresReconciliationRepository는 새로운 PostgresReconciliationRepository 인스턴스를 생성합니다.
+func NewPostgresReconciliationRepository(database *db.Database) application.ReconciliationRepository {
+	return &PostgresReconciliationRepository{
+		db: database,
+	}
+}
+
+// Save는 대사 결과와 불일치 목록을 한 트랜잭션으로 저장합니다.
+func (r *PostgresReconciliationRepository) Save(ctx context.Context, reconciliation *application.Reconciliation) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	query := `
+		INSERT INTO payment_reconciliations (id, source, period_start, period_end, row_count, matched_count, missing_count,
+			extra_count, amount_mismatch_count, status_mismatch_count, invalid_count, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err = tx.Exec(
+		ctx,
+		query,
+		reconciliation.ID,
+		reconciliation.Source,
+		reconciliation.PeriodStart,
+		reconciliation.PeriodEnd,
+		reconciliation.Rows,
+		reconciliation.Matched,
+		reconciliation.Missing,
+		reconciliation.Extra,
+		reconciliation.AmountMismatched,
+		reconciliation.StatusMismatched,
+		reconciliation.Invalid,
+		reconciliation.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert reconciliation: %w", err)
+	}
+
+	exceptionQuery := `
+		INSERT INTO payment_reconciliation_exceptions (reconciliation_id, seq, type, line, transaction_id, payment_id,
+			expected_amount, actual_amount, expected_status, actual_status, message)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	for i, exception := range reconciliation.Exceptions {
+		_, err = tx.Exec(
+			ctx,
+			exceptionQuery,
+			reconciliation.ID,
+			i+1,
+			string(exception.Type),
+			exception.Line,
+			exception.TransactionID,
+			exception.PaymentID,
+			exception.ExpectedAmount,
+			exception.ActualAmount,
+			exception.ExpectedStatus,
+			exception.ActualStatus,
+			exception.Message,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert reconciliation exception: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID는 대사 결과를 불일치 목록과 함께 조회합니다.
+func (r *PostgresReconciliationRepository) FindByID(ctx context.Context, id string) (*application.Reconciliation, error) {
+	query := `
+		SELECT id, source, period_start, period_end, row_count, matched_count, missing_count,
+			extra_count, amount_mismatch_count, status_mismatch_count, invalid_count, created_at
+		FROM payment_reconciliations
+		WHERE id = $1
+	`
+
+	reconciliation := &application.Reconciliation{}
+	err := r.db.Pool.QueryRow(ctx, query, id).Scan(
+		&reconciliation.ID,
+		&reconciliation.Source,
+		&reconciliation.PeriodStart,
+		&reconciliation.PeriodEnd,
+		&reconciliation.Rows,
+		&reconciliation.Matched,
+		&reconciliation.Missing,
+		&reconciliation.Extra,
+		&reconciliation.AmountMismatched,
+		&reconciliation.StatusMismatched,
+		&reconciliation.Invalid,
+		&reconciliation.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, application.ErrReconciliationNotFound
+		}
+		return nil, fmt.Errorf("failed to find reconciliation: %w", err)
+	}
+
+	exceptionQuery := `
+		SELECT type, line, transaction_id, payment_id, expected_amount, actual_amount, expected_status, actual_status, message
+		FROM payment_reconciliation_exceptions
+		WHERE reconciliation_id = $1
+		ORDER BY seq
+	`
+
+	rows, err := r.db.Pool.Query(ctx, exceptionQuery, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reconciliation exceptions: %w", err)
+	}
+	defer rows.Close()
+
+	reconciliation.Exceptions = []application.ReconciliationException{}
+	for rows.Next() {
+		var exception application.ReconciliationException
+		var exceptionType string
+		err := rows.Scan(
+			&exceptionType,
+			&exception.Line,
+			&exception.TransactionID,
+			&exception.PaymentID,
+			&exception.ExpectedAmount,
+			&exception.ActualAmount,
+			&exception.ExpectedStatus,
+			&exception.ActualStatus,
+			&exception.Message,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reconciliation exception: %w", err)
+		}
+		exception.Type = application.ReconciliationExceptionType(exceptionType)
+		reconciliation.Exceptions = append(reconciliation.Exceptions, exception)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reconciliation exceptions: %w", err)
+	}
+
+	return reconciliation, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"example.com/myapp/payment/application"
 	"example.com/myapp/payment/domain"
@@ -26,6 +27,26 @@ func NewPostgresPaymentRepository(database *db.Database) application.PaymentRepo
 
 // Save는 결제 정보를 데이터베이스에 저장합니다.
 func (r *PostgresPaymentRepository) Save(ctx context.Context, payment *domain.Payment) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	if err := r.insert(ctx, tx, payment); err != nil {
+		return err
+	}
+
+	// 트랜잭션 커밋
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// insert는 트랜잭션 안에서 결제 행을 추가합니다.
+func (r *PostgresPaymentRepository) insert(ctx context.Context, tx pgx.Tx, payment *domain.Payment) error {
 	// 추가 결제 데이터를 JSON으로 변환
 	paymentDataJSON, err := json.Marshal(payment.PaymentData())
 	if err != nil {
@@ -33,11 +54,12 @@ func (r *PostgresPaymentRepository) Save(ctx context.Context, payment *domain.Pa
 	}
 
 	query := `
-		INSERT INTO payments (id, order_id, amount, method, status, transaction_id, payment_data, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO payments (id, order_id, amount, method, status, transaction_id, payment_data, captured_amount, authorization_expires_at,
+			attempt_id, attempt_kind, attempt_started_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
 
-	_, err = r.db.Pool.Exec(
+	_, err = tx.Exec(
 		ctx,
 		query,
 		payment.ID(),
@@ -47,6 +69,11 @@ func (r *PostgresPaymentRepository) Save(ctx context.Context, payment *domain.Pa
 		string(payment.Status()),
 		payment.TransactionID(),
 		paymentDataJSON,
+		payment.CapturedAmount(),
+		nullableTime(payment.AuthorizationExpiresAt()),
+		payment.Attempt().ID,
+		string(payment.Attempt().Kind),
+		nullableTime(payment.Attempt().StartedAt),
 		payment.CreatedAt(),
 		payment.UpdatedAt(),
 	)
@@ -58,96 +85,271 @@ func (r *PostgresPaymentRepository) Save(ctx context.Context, payment *domain.Pa
 	return nil
 }
 
+// SaveWithinOrderTotal은 주문의 결제 합계가 orderTotal을 넘지 않을 때만 결제를 저장합니다.
+// 주문별 트랜잭션 잠금을 잡은 뒤 합계를 다시 계산하므로, 같은 주문에 동시에 결제를 만들어도 주문 금액을 넘지 않습니다.
+// 주문의 첫 결제는 잠글 행이 없어 SELECT ... FOR UPDATE로는 막을 수 없으므로 주문 ID로 잡는 advisory 잠금을 사용합니다.
+func (r *PostgresPaymentRepository) SaveWithinOrderTotal(ctx context.Context, payment *domain.Payment, orderTotal float64) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	// 1. 주문 잠금 (트랜잭션이 끝나면 풀립니다)
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext('payments:' || $1))", payment.OrderID()); err != nil {
+		return fmt.Errorf("failed to lock order payments: %w", err)
+	}
+
+	// 2. 거절·취소되지 않은 결제 합계 확인 (청구된 결제는 매입 금액, 나머지는 결제 금액)
+	var committed float64
+	err = tx.QueryRow(ctx, `
+		SELECT COALESCE(SUM(CASE WHEN status IN ($2, $3, $4) THEN amount ELSE captured_amount END), 0)
+		FROM payments
+		WHERE order_id = $1 AND status IN ($2, $3, $4, $5, $6, $7, $8)
+	`,
+		payment.OrderID(),
+		string(domain.PaymentStatusPending),
+		string(domain.PaymentStatusProcessing),
+		string(domain.PaymentStatusAuthorized),
+		string(domain.PaymentStatusApproved),
+		string(domain.PaymentStatusCaptured),
+		string(domain.PaymentStatusPartiallyRefunded),
+		string(domain.PaymentStatusRefunded),
+	).Scan(&committed)
+	if err != nil {
+		return fmt.Errorf("failed to sum order payments: %w", err)
+	}
+	if committed+payment.Amount() > orderTotal+0.005 {
+		return application.ErrPaymentExceedsAmountDue
+	}
+
+	// 3. 결제 저장
+	if err := r.insert(ctx, tx, payment); err != nil {
+		return err
+	}
+
+	// 트랜잭션 커밋
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 // FindByID는 ID로 결제를 조회합니다.
 func (r *PostgresPaymentRepository) FindByID(ctx context.Context, id string) (*domain.Payment, error) {
 	query := `
-		SELECT id, order_id, amount, method, status, transaction_id, payment_data, created_at, updated_at
+		SELECT id, order_id, amount, method, status, transaction_id, payment_data,
+			captured_amount, authorization_expires_at, attempt_id, attempt_kind, attempt_started_at, created_at, updated_at
 		FROM payments
 		WHERE id = $1
 	`
 
-	row := r.db.Pool.QueryRow(ctx, query, id)
-
-	var paymentID, orderID, methodStr, statusStr, transactionID string
-	var amount float64
-	var paymentDataJSON []byte
-	var createdAt, updatedAt string
-
-	err := row.Scan(
-		&paymentID,
-		&orderID,
-		&amount,
-		&methodStr,
-		&statusStr,
-		&transactionID,
-		&paymentDataJSON,
-		&createdAt,
-		&updatedAt,
-	)
-
+	payment, err := r.findOne(ctx, query, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrPaymentNotFound
 		}
 		return nil, fmt.Errorf("failed to find payment by ID: %w", err)
 	}
+	return payment, nil
+}
 
-	// JSON에서 결제 데이터 파싱
-	var paymentData map[string]string
-	if err := json.Unmarshal(paymentDataJSON, &paymentData); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal payment data: %w", err)
-	}
+// FindByTransactionID는 게이트웨이 트랜잭션 ID로 결제를 조회합니다.
+func (r *PostgresPaymentRepository) FindByTransactionID(ctx context.Context, transactionID string) (*domain.Payment, error) {
+	query := `
+		SELECT id, order_id, amount, method, status, transaction_id, payment_data,
+			captured_amount, authorization_expires_at, attempt_id, attempt_kind, attempt_started_at, created_at, updated_at
+		FROM payments
+		WHERE transaction_id = $1
+		ORDER BY created_at
+		LIMIT 1
+	`
 
-	// 실제 구현에서는 DB 레코드를 도메인 엔티티로 변환하는 로직이 필요합니다.
-	// 여기서는 코드 예시를 간략하게 하기 위해 생략합니다.
-	return &domain.Payment{}, nil
+	payment, err := r.findOne(ctx, query, transactionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("failed to find payment by transaction ID: %w", err)
+	}
+	return payment, nil
 }
 
-// FindByOrderID는 주문 ID로 결제를 조회합니다.
-func (r *PostgresPaymentRepository) FindByOrderID(ctx context.Context, orderID string) (*domain.Payment, error) {
+// FindByOrderID는 주문의 결제 목록을 생성 순서대로 조회합니다.
+func (r *PostgresPaymentRepository) FindByOrderID(ctx context.Context, orderID string) ([]*domain.Payment, error) {
 	query := `
-		SELECT id, order_id, amount, method, status, transaction_id, payment_data, created_at, updated_at
+		SELECT id
 		FROM payments
 		WHERE order_id = $1
+		ORDER BY created_at, id
+	`
+
+	payments, err := r.findAll(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payments by order ID: %w", err)
+	}
+	return payments, nil
+}
+
+// FindSettledBetween은 [from, to) 사이에 생성되어 청구된 결제를 생성 순서대로 조회합니다.
+func (r *PostgresPaymentRepository) FindSettledBetween(ctx context.Context, from, to time.Time) ([]*domain.Payment, error) {
+	query := `
+		SELECT id
+		FROM payments
+		WHERE created_at >= $1 AND created_at < $2 AND transaction_id <> ''
+			AND status IN ($3, $4, $5, $6)
+		ORDER BY created_at, id
 	`
 
-	row := r.db.Pool.QueryRow(ctx, query, orderID)
+	payments, err := r.findAll(
+		ctx,
+		query,
+		from,
+		to,
+		string(domain.PaymentStatusApproved),
+		string(domain.PaymentStatusCaptured),
+		string(domain.PaymentStatusPartiallyRefunded),
+		string(domain.PaymentStatusRefunded),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settled payments: %w", err)
+	}
+	return payments, nil
+}
+
+// findAll은 결제 ID를 조회하는 query를 실행하고 결제 ID별로 환불 내역과 함께 조회합니다.
+func (r *PostgresPaymentRepository) findAll(ctx context.Context, query string, args ...interface{}) ([]*domain.Payment, error) {
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	paymentIDs := []string{}
+	for rows.Next() {
+		var paymentID string
+		if err := rows.Scan(&paymentID); err != nil {
+			return nil, fmt.Errorf("failed to scan payment ID: %w", err)
+		}
+		paymentIDs = append(paymentIDs, paymentID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payment IDs: %w", err)
+	}
+
+	// 결제 ID별로 환불 내역과 함께 조회
+	payments := []*domain.Payment{}
+	for _, paymentID := range paymentIDs {
+		payment, err := r.FindByID(ctx, paymentID)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+
+	return payments, nil
+}
+
+// findOne은 결제 한 건을 조회하고 환불 내역과 함께 도메인 엔티티로 복원합니다.
+func (r *PostgresPaymentRepository) findOne(ctx context.Context, query string, arg interface{}) (*domain.Payment, error) {
+	row := r.db.Pool.QueryRow(ctx, query, arg)
 
-	var paymentID, retrievedOrderID, methodStr, statusStr, transactionID string
-	var amount float64
+	var paymentID, orderID, methodStr, statusStr, transactionID, attemptID, attemptKind string
+	var amount, capturedAmount float64
 	var paymentDataJSON []byte
-	var createdAt, updatedAt string
+	var authorizationExpiresAt, attemptStartedAt *time.Time
+	var createdAt, updatedAt time.Time
 
 	err := row.Scan(
 		&paymentID,
-		&retrievedOrderID,
+		&orderID,
 		&amount,
 		&methodStr,
 		&statusStr,
 		&transactionID,
 		&paymentDataJSON,
+		&capturedAmount,
+		&authorizationExpiresAt,
+		&attemptID,
+		&attemptKind,
+		&attemptStartedAt,
 		&createdAt,
 		&updatedAt,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON에서 결제 데이터 파싱
+	paymentData := map[string]string{}
+	if len(paymentDataJSON) > 0 {
+		if err := json.Unmarshal(paymentDataJSON, &paymentData); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal payment data: %w", err)
+		}
+		if paymentData == nil {
+			paymentData = map[string]string{}
+		}
+	}
 
+	refunds, err := r.findRefunds(ctx, paymentID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, domain.ErrPaymentNotFound
+		return nil, err
+	}
+
+	var expiresAt time.Time
+	if authorizationExpiresAt != nil {
+		expiresAt = *authorizationExpiresAt
+	}
+	attempt := domain.PaymentAttempt{ID: attemptID, Kind: domain.AttemptKind(attemptKind)}
+	if attemptStartedAt != nil {
+		attempt.StartedAt = *attemptStartedAt
+	}
+
+	return domain.RestorePayment(
+		paymentID, orderID, amount, domain.PaymentMethod(methodStr), domain.PaymentStatus(statusStr),
+		transactionID, paymentData, refunds, capturedAmount, expiresAt, attempt, createdAt, updatedAt,
+	), nil
+}
+
+// findRefunds는 결제의 환불 내역을 요청 순서대로 조회합니다.
+func (r *PostgresPaymentRepository) findRefunds(ctx context.Context, paymentID string) ([]*domain.Refund, error) {
+	query := `
+		SELECT id, amount, reason, gateway_refund_id, status, failure_reason, created_at, updated_at
+		FROM payment_refunds
+		WHERE payment_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, paymentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payment refunds: %w", err)
+	}
+	defer rows.Close()
+
+	refunds := []*domain.Refund{}
+	for rows.Next() {
+		var id, reason, gatewayRefundID, status, failureReason string
+		var amount float64
+		var createdAt, updatedAt time.Time
+
+		if err := rows.Scan(&id, &amount, &reason, &gatewayRefundID, &status, &failureReason, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan payment refund: %w", err)
 		}
-		return nil, fmt.Errorf("failed to find payment by order ID: %w", err)
+
+		refunds = append(refunds, domain.RestoreRefund(id, paymentID, amount, reason, gatewayRefundID, domain.RefundStatus(status), failureReason, createdAt, updatedAt))
 	}
 
-	// JSON에서 결제 데이터 파싱
-	var paymentData map[string]string
-	if err := json.Unmarshal(paymentDataJSON, &paymentData); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal payment data: %w", err)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payment refunds: %w", err)
 	}
 
-	// 실제 구현에서는 DB 레코드를 도메인 엔티티로 변환하는 로직이 필요합니다.
-	return &domain.Payment{}, nil
+	return refunds, nil
 }
 
-// Update는 결제 정보를 업데이트합니다.
+// Update는 결제 상태와 환불 내역을 한 트랜잭션으로 저장합니다.
+// 결제 행을 잠근 뒤 환불 합계를 확인하므로 동시에 요청된 환불이 매입된 금액을 넘지 않습니다.
 func (r *PostgresPaymentRepository) Update(ctx context.Context, payment *domain.Payment) error {
 	// 추가 결제 데이터를 JSON으로 변환
 	paymentDataJSON, err := json.Marshal(payment.PaymentData())
@@ -155,18 +357,81 @@ func (r *PostgresPaymentRepository) Update(ctx context.Context, payment *domain.
 		return fmt.Errorf("failed to marshal payment data: %w", err)
 	}
 
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	// 1. 결제 행 잠금 (환불 한도는 이번에 저장할 매입 금액입니다)
+	err = tx.QueryRow(ctx, "SELECT id FROM payments WHERE id = $1 FOR UPDATE", payment.ID()).Scan(new(string))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrPaymentNotFound
+		}
+		return fmt.Errorf("failed to lock payment: %w", err)
+	}
+
+	// 2. 환불 내역 저장 (새 환불은 추가하고 기존 환불은 상태를 갱신)
+	refundQuery := `
+		INSERT INTO payment_refunds (id, payment_id, amount, reason, gateway_refund_id, status, failure_reason, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE
+		SET gateway_refund_id = EXCLUDED.gateway_refund_id, status = EXCLUDED.status,
+			failure_reason = EXCLUDED.failure_reason, updated_at = EXCLUDED.updated_at
+	`
+	for _, refund := range payment.Refunds() {
+		_, err = tx.Exec(
+			ctx,
+			refundQuery,
+			refund.ID(),
+			payment.ID(),
+			refund.Amount(),
+			refund.Reason(),
+			refund.GatewayRefundID(),
+			string(refund.Status()),
+			refund.FailureReason(),
+			refund.CreatedAt(),
+			refund.UpdatedAt(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save payment refund: %w", err)
+		}
+	}
+
+	// 3. 실패하지 않은 환불 합계가 매입된 금액을 넘지 않는지 확인
+	var refundTotal float64
+	err = tx.QueryRow(ctx, `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM payment_refunds
+		WHERE payment_id = $1 AND status <> $2
+	`, payment.ID(), string(domain.RefundStatusFailed)).Scan(&refundTotal)
+	if err != nil {
+		return fmt.Errorf("failed to sum payment refunds: %w", err)
+	}
+	if refundTotal > payment.CapturedAmount()+0.005 {
+		return domain.ErrRefundExceedsPayment
+	}
+
+	// 4. 결제 상태 저장
 	query := `
 		UPDATE payments
-		SET status = $1, transaction_id = $2, payment_data = $3, updated_at = $4
-		WHERE id = $5
+		SET status = $1, transaction_id = $2, payment_data = $3, captured_amount = $4,
+			authorization_expires_at = $5, attempt_id = $6, attempt_kind = $7, attempt_started_at = $8, updated_at = $9
+		WHERE id = $10
 	`
 
-	_, err = r.db.Pool.Exec(
+	_, err = tx.Exec(
 		ctx,
 		query,
 		string(payment.Status()),
 		payment.TransactionID(),
 		paymentDataJSON,
+		payment.CapturedAmount(),
+		nullableTime(payment.AuthorizationExpiresAt()),
+		payment.Attempt().ID,
+		string(payment.Attempt().Kind),
+		nullableTime(payment.Attempt().StartedAt),
 		payment.UpdatedAt(),
 		payment.ID(),
 	)
@@ -175,5 +440,215 @@ func (r *PostgresPaymentRepository) Update(ctx context.Context, payment *domain.
 		return fmt.Errorf("failed to update payment: %w", err)
 	}
 
+	// 트랜잭션 커밋
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimExpiredAuthorizations는 now 이전에 만료된 가승인 결제를 최대 limit건 선점하고 ID를 반환합니다.
+// FOR UPDATE SKIP LOCKED와 선점 기한(void_claimed_until)으로 여러 인스턴스가 같은 결제를 동시에 취소하지 않게 합니다.
+func (r *PostgresPaymentRepository) ClaimExpiredAuthorizations(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]string, error) {
+	query := `
+		UPDATE payments
+		SET void_claimed_until = $1
+		WHERE id IN (
+			SELECT id
+			FROM payments
+			WHERE status = $2 AND authorization_expires_at <= $3
+				AND (void_claimed_until IS NULL OR void_claimed_until < $3)
+			ORDER BY authorization_expires_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, now.Add(lease), string(domain.PaymentStatusAuthorized), now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim expired authorizations: %w", err)
+	}
+	defer rows.Close()
+
+	paymentIDs := []string{}
+	for rows.Next() {
+		var paymentID string
+		if err := rows.Scan(&paymentID); err != nil {
+			return nil, fmt.Errorf("failed to scan payment ID: %w", err)
+		}
+		paymentIDs = append(paymentIDs, paymentID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating claimed payment IDs: %w", err)
+	}
+
+	return paymentIDs, nil
+}
+
+// MarkProcessing은 결제가 아직 처리 대기 상태(매입과 취소 시도는 승인 상태)일 때만 처리 중 상태와 시도를 저장합니다.
+// 조건부 UPDATE 한 문장으로 저장하므로 같은 결제를 동시에 처리하려는 요청 중 하나만 성공합니다.
+func (r *PostgresPaymentRepository) MarkProcessing(ctx context.Context, payment *domain.Payment) error {
+	query := `
+		UPDATE payments
+		SET status = $1, captured_amount = $2, attempt_id = $3, attempt_kind = $4, attempt_started_at = $5, updated_at = $6
+		WHERE id = $7 AND status = $8
+	`
+
+	expected, notReady := domain.PaymentStatusPending, domain.ErrPaymentNotPending
+	switch payment.Attempt().Kind {
+	case domain.AttemptKindCapture, domain.AttemptKindVoid:
+		expected, notReady = domain.PaymentStatusAuthorized, domain.ErrPaymentNotAuthorized
+	}
+
+	tag, err := r.db.Pool.Exec(
+		ctx,
+		query,
+		string(payment.Status()),
+		payment.CapturedAmount(),
+		payment.Attempt().ID,
+		string(payment.Attempt().Kind),
+		payment.Attempt().StartedAt,
+		payment.UpdatedAt(),
+		payment.ID(),
+		string(expected),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark payment as processing: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return notReady
+	}
 	return nil
-}
\ No newline at end of file
+}
+
+// Transition은 결제가 아직 from 상태이고 마지막 시도 ID가 attemptID일 때만 결제 상태를 저장합니다.
+// 조건부 UPDATE 한 문장으로 저장하므로 그 사이 다른 요청이 마무리한 결제를 덮어쓰지 않습니다.
+func (r *PostgresPaymentRepository) Transition(ctx context.Context, payment *domain.Payment, from domain.PaymentStatus, attemptID string) error {
+	paymentDataJSON, err := json.Marshal(payment.PaymentData())
+	if err != nil {
+		return fmt.Errorf("failed to marshal payment data: %w", err)
+	}
+
+	query := `
+		UPDATE payments
+		SET status = $1, transaction_id = $2, payment_data = $3, captured_amount = $4,
+			authorization_expires_at = $5, updated_at = $6
+		WHERE id = $7 AND status = $8 AND attempt_id = $9
+	`
+
+	tag, err := r.db.Pool.Exec(
+		ctx,
+		query,
+		string(payment.Status()),
+		payment.TransactionID(),
+		paymentDataJSON,
+		payment.CapturedAmount(),
+		nullableTime(payment.AuthorizationExpiresAt()),
+		payment.UpdatedAt(),
+		payment.ID(),
+		string(from),
+		attemptID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to transition payment: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrPaymentNotPending
+	}
+	return nil
+}
+
+// ClaimStuckProcessing은 cutoff 이전에 시작해 아직 처리 중인 결제를 최대 limit건 선점하고 ID를 반환합니다.
+// FOR UPDATE SKIP LOCKED와 선점 기한(attempt_claimed_until)으로 여러 인스턴스가 같은 결제를 동시에 마무리하지 않게 합니다.
+func (r *PostgresPaymentRepository) ClaimStuckProcessing(ctx context.Context, cutoff, now time.Time, lease time.Duration, limit int) ([]string, error) {
+	query := `
+		UPDATE payments
+		SET attempt_claimed_until = $1
+		WHERE id IN (
+			SELECT id
+			FROM payments
+			WHERE status = $2 AND attempt_started_at <= $3
+				AND (attempt_claimed_until IS NULL OR attempt_claimed_until < $4)
+			ORDER BY attempt_started_at
+			LIMIT $5
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, now.Add(lease), string(domain.PaymentStatusProcessing), cutoff, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim processing payments: %w", err)
+	}
+	defer rows.Close()
+
+	paymentIDs := []string{}
+	for rows.Next() {
+		var paymentID string
+		if err := rows.Scan(&paymentID); err != nil {
+			return nil, fmt.Errorf("failed to scan payment ID: %w", err)
+		}
+		paymentIDs = append(paymentIDs, paymentID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating claimed payment IDs: %w", err)
+	}
+
+	return paymentIDs, nil
+}
+
+// ClaimPendingRefunds는 cutoff 이전에 요청해 아직 진행 중인 환불을 최대 limit건 선점하고 환불한 결제 ID를 중복 없이 반환합니다.
+// FOR UPDATE SKIP LOCKED와 선점 기한(claimed_until)으로 여러 인스턴스가 같은 환불을 동시에 마무리하지 않게 합니다.
+func (r *PostgresPaymentRepository) ClaimPendingRefunds(ctx context.Context, cutoff, now time.Time, lease time.Duration, limit int) ([]string, error) {
+	query := `
+		UPDATE payment_refunds
+		SET claimed_until = $1
+		WHERE id IN (
+			SELECT id
+			FROM payment_refunds
+			WHERE status = $2 AND created_at <= $3
+				AND (claimed_until IS NULL OR claimed_until < $4)
+			ORDER BY created_at
+			LIMIT $5
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING payment_id
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, now.Add(lease), string(domain.RefundStatusPending), cutoff, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim pending refunds: %w", err)
+	}
+	defer rows.Close()
+
+	paymentIDs := []string{}
+	seen := make(map[string]bool)
+	for rows.Next() {
+		var paymentID string
+		if err := rows.Scan(&paymentID); err != nil {
+			return nil, fmt.Errorf("failed to scan payment ID: %w", err)
+		}
+		if !seen[paymentID] {
+			seen[paymentID] = true
+			paymentIDs = append(paymentIDs, paymentID)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating claimed refund payment IDs: %w", err)
+	}
+
+	return paymentIDs, nil
+}
+
+// nullableTime은 zero 값 시간을 NULL로 저장하기 위해 nil로 바꿉니다.
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,194 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"example.com/myapp/payment/application"
+	"example.com/myapp/shared/db"
+	"github.com/jackc/pgx/v4"
+)
+
+// PostgresWebhookEventRepository는 PostgreSQL을 사용하는 웹훅 이벤트 저장소 구현체입니다.
+type PostgresWebhookEventRepository struct {
+	db *db.Database
+}
+
+// NewPostgresWebhookEventRepository는 새로운 PostgresWebhookEventRepository 인스턴스를 생성합니다.
+func NewPostgresWebhookEventRepository(database *db.Database) application.WebhookEventRepository {
+	return &PostgresWebhookEventRepository{
+		db: database,
+	}
+}
+
+// Save는 처음 받은 이벤트를 저장합니다. (provider, event_id)가 이미 있으면 기존 기록을 그대로 둡니다.
+func (r *PostgresWebhookEventRepository) Save(ctx context.Context, event *application.WebhookEvent) (bool, error) {
+	query := `
+		INSERT INTO payment_webhook_events (provider, event_id, event_type, payload, status, attempts, last_error, received_at)
+		VALUES ($1, $2, $3, $4, $5, 0, '', $6)
+		ON CONFLICT (provider, event_id) DO NOTHING
+	`
+
+	tag, err := r.db.Pool.Exec(
+		ctx,
+		query,
+		event.Provider,
+		event.EventID,
+		string(event.Type),
+		event.Payload,
+		string(event.Status),
+		event.ReceivedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to save webhook event: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Claim은 처리되지 않았고 선점 기한이 지난 이벤트를 한 문장으로 선점합니다.
+// 선점하지 못하면 저장된 이벤트를 조회해 반환합니다.
+func (r *PostgresWebhookEventRepository) Claim(ctx context.Context, provider, eventID string, now time.Time, lease time.Duration) (*application.WebhookEvent, bool, error) {
+	query := `
+		UPDATE payment_webhook_events
+		SET claimed_until = $1
+		WHERE provider = $2 AND event_id = $3 AND status IN ($4, $5)
+			AND (claimed_until IS NULL OR claimed_until < $6)
+		RETURNING ` + webhookEventColumns
+
+	event, err := scanWebhookEvent(r.db.Pool.QueryRow(
+		ctx,
+		query,
+		now.Add(lease),
+		provider,
+		eventID,
+		string(application.WebhookEventStatusReceived),
+		string(application.WebhookEventStatusFailed),
+		now,
+	))
+	if err == nil {
+		return event, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, fmt.Errorf("failed to claim webhook event: %w", err)
+	}
+
+	// 이미 처리되었거나 다른 요청이 선점 중인 이벤트 조회
+	event, err = scanWebhookEvent(r.db.Pool.QueryRow(
+		ctx,
+		"SELECT "+webhookEventColumns+" FROM payment_webhook_events WHERE provider = $1 AND event_id = $2",
+		provider,
+		eventID,
+	))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find webhook event: %w", err)
+	}
+	return event, false, nil
+}
+
+// ClaimRetryable은 처리되지 않은 이벤트를 최대 limit건 선점하고 반환합니다.
+// FOR UPDATE SKIP LOCKED와 선점 기한(claimed_until)으로 여러 인스턴스가 같은 이벤트를 동시에 처리하지 않게 합니다.
+func (r *PostgresWebhookEventRepository) ClaimRetryable(ctx context.Context, now time.Time, lease time.Duration, maxAttempts, limit int) ([]*application.WebhookEvent, error) {
+	query := `
+		UPDATE payment_webhook_events
+		SET claimed_until = $1
+		WHERE (provider, event_id) IN (
+			SELECT provider, event_id
+			FROM payment_webhook_events
+			WHERE status IN ($2, $3) AND attempts < $4
+				AND (claimed_until IS NULL OR claimed_until < $5)
+			ORDER BY received_at
+			LIMIT $6
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookEventColumns
+
+	rows, err := r.db.Pool.Query(
+		ctx,
+		query,
+		now.Add(lease),
+		string(application.WebhookEventStatusReceived),
+		string(application.WebhookEventStatusFailed),
+		maxAttempts,
+		now,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim retryable webhook events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*application.WebhookEvent{}
+	for rows.Next() {
+		event, err := scanWebhookEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook events: %w", err)
+	}
+
+	return events, nil
+}
+
+// Finish는 이벤트의 처리 결과를 저장하고 선점을 풉니다.
+func (r *PostgresWebhookEventRepository) Finish(ctx context.Context, event *application.WebhookEvent) error {
+	query := `
+		UPDATE payment_webhook_events
+		SET status = $1, attempts = $2, last_error = $3, processed_at = $4, claimed_until = NULL
+		WHERE provider = $5 AND event_id = $6
+	`
+
+	tag, err := r.db.Pool.Exec(
+		ctx,
+		query,
+		string(event.Status),
+		event.Attempts,
+		event.LastError,
+		nullableTime(event.ProcessedAt),
+		event.Provider,
+		event.EventID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook event: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("webhook event %s/%s not found", event.Provider, event.EventID)
+	}
+	return nil
+}
+
+// webhookEventColumns는 scanWebhookEvent가 읽는 열 목록입니다.
+const webhookEventColumns = `provider, event_id, event_type, payload, status, attempts, last_error, received_at, processed_at`
+
+// scanWebhookEvent는 웹훅 이벤트 행 하나를 읽습니다.
+func scanWebhookEvent(row pgx.Row) (*application.WebhookEvent, error) {
+	event := &application.WebhookEvent{}
+	var eventType, status string
+	var processedAt *time.Time
+	err := row.Scan(
+		&event.Provider,
+		&event.EventID,
+		&eventType,
+		&event.Payload,
+		&status,
+		&event.Attempts,
+		&event.LastError,
+		&event.ReceivedAt,
+		&processedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	event.Type = application.GatewayEventType(eventType)
+	event.Status = application.WebhookEventStatus(status)
+	if processedAt != nil {
+		event.ProcessedAt = *processedAt
+	}
+	return event, nil
+}
@@ -0,0 +1,362 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"example.com/myapp/payment/application"
+	"example.com/myapp/payment/domain"
+	"github.com/google/uuid"
+)
+
+// ErrCircuitOpen은 게이트웨이 장애로 회로 차단기가 열려 호출하지 않았음을 나타냅니다.
+var ErrCircuitOpen = fmt.Errorf("%w: circuit breaker is open", application.ErrGatewayUnavailable)
+
+// CircuitState는 회로 차단기 상태입니다.
+type CircuitState string
+
+const (
+	// CircuitClosed는 모든 호출을 게이트웨이로 보내는 정상 상태입니다.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen은 호출을 보내지 않고 바로 실패시키는 상태입니다.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen은 열린 기간이 지나 시험 호출 하나만 보내는 상태입니다.
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// ResilienceConfig는 게이트웨이 호출의 타임아웃, 재시도, 회로 차단기 설정을 정의합니다.
+type ResilienceConfig struct {
+	// CallTimeout은 게이트웨이 호출 한 번의 최대 시간입니다.
+	CallTimeout time.Duration
+	// MaxAttempts는 일시적 오류가 났을 때 호출하는 최대 횟수(첫 호출 포함)입니다.
+	MaxAttempts int
+	// BaseBackoff는 첫 재시도 전 대기 시간이며, 재시도마다 두 배로 늘어나 MaxBackoff를 넘지 않습니다.
+	// 실제 대기 시간은 계산된 시간의 절반에서 전체 사이에서 무작위로 정합니다.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// FailureThreshold는 회로를 여는 연속 일시 오류 횟수입니다.
+	FailureThreshold int
+	// OpenDuration은 회로를 열어 두는 시간이며, 지나면 시험 호출 하나로 게이트웨이 회복을 확인합니다.
+	OpenDuration time.Duration
+	// Metrics는 호출 지표를 기록할 expvar 맵입니다. nil이면 기록하지 않습니다.
+	Metrics *expvar.Map
+	// Seed는 재시도 대기 시간의 난수 시드입니다. 0이면 현재 시간을 사용합니다.
+	Seed int64
+}
+
+// ResilientGateway는 PaymentGateway 호출에 타임아웃, 지수 백오프 재시도, 회로 차단기를 더하는 데코레이터입니다.
+// 재시도는 같은 게이트웨이 요청 ID로 보내므로 게이트웨이가 먼저 보낸 요청을 처리했더라도 중복 청구되지 않습니다.
+// 재시도해도 실패한 일시적 오류와 회로가 열려 보내지 않은 호출은 application.ErrGatewayUnavailable로 감싸 반환합니다.
+type ResilientGateway struct {
+	next   application.PaymentGateway
+	config ResilienceConfig
+	state  *expvar.String
+
+	mu                  sync.Mutex
+	random              *rand.Rand
+	circuit             CircuitState
+	consecutiveFailures int
+	openedAt            time.Time
+	probing             bool
+}
+
+// NewResilientGateway는 새로운 ResilientGateway 인스턴스를 생성합니다.
+// 0 이하인 설정은 기본값(호출 10초, 3회, 200ms~2s 백오프, 연속 5회 실패 시 30초 차단)을 사용합니다.
+func NewResilientGateway(next application.PaymentGateway, config ResilienceConfig) *ResilientGateway {
+	if config.CallTimeout <= 0 {
+		config.CallTimeout = 10 * time.Second
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 3
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = 200 * time.Millisecond
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = 2 * time.Second
+	}
+	if config.MaxBackoff < config.BaseBackoff {
+		config.MaxBackoff = config.BaseBackoff
+	}
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 5
+	}
+	if config.OpenDuration <= 0 {
+		config.OpenDuration = 30 * time.Second
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	g := &ResilientGateway{
+		next:    next,
+		config:  config,
+		state:   new(expvar.String),
+		random:  rand.New(rand.NewSource(seed)),
+		circuit: CircuitClosed,
+	}
+	g.state.Set(string(CircuitClosed))
+	if config.Metrics != nil {
+		config.Metrics.Set("circuit_state", g.state)
+	}
+	return g
+}
+
+var _ application.PaymentGateway = (*ResilientGateway)(nil)
+
+// ProcessPayment는 승인과 매입을 한 번에 처리합니다. 결제당 한 번만 판매하므로 요청 ID는 결제 ID로 정합니다.
+func (g *ResilientGateway) ProcessPayment(ctx context.Context, payment *domain.Payment) (string, error) {
+	var transactionID string
+	err := g.call(ctx, "sale", "sale:"+payment.ID(), func(ctx context.Context) error {
+		var err error
+		transactionID, err = g.next.ProcessPayment(ctx, payment)
+		return err
+	})
+	return transactionID, err
+}
+
+// Authorize는 결제 금액만큼 가승인합니다.
+func (g *ResilientGateway) Authorize(ctx context.Context, payment *domain.Payment) (string, error) {
+	var transactionID string
+	err := g.call(ctx, "authorize", "authorize:"+payment.ID(), func(ctx context.Context) error {
+		var err error
+		transactionID, err = g.next.Authorize(ctx, payment)
+		return err
+	})
+	return transactionID, err
+}
+
+// Capture는 가승인된 결제에서 amount만큼 매입합니다. 결제당 한 번만 매입하므로 요청 ID는 결제 ID로 정합니다.
+func (g *ResilientGateway) Capture(ctx context.Context, payment *domain.Payment, amount float64) error {
+	return g.call(ctx, "capture", "capture:"+payment.ID(), func(ctx context.Context) error {
+		return g.next.Capture(ctx, payment, amount)
+	})
+}
+
+// Void는 가승인을 취소합니다.
+func (g *ResilientGateway) Void(ctx context.Context, payment *domain.Payment) error {
+	return g.call(ctx, "void", "void:"+payment.ID(), func(ctx context.Context) error {
+		return g.next.Void(ctx, payment)
+	})
+}
+
+// RefundPayment는 결제에서 amount만큼 환불합니다.
+// 한 결제에 여러 번 환불할 수 있으므로 결제 ID 대신 호출자가 컨텍스트에 담은 환불 ID를 요청 ID로 씁니다.
+// 요청 ID가 없으면 같은 환불을 알아볼 수 없으므로 호출마다 새 요청 ID를 만듭니다.
+func (g *ResilientGateway) RefundPayment(ctx context.Context, payment *domain.Payment, amount float64, reason string) (string, error) {
+	var refundID string
+	err := g.call(ctx, "refund", "refund:"+uuid.New().String(), func(ctx context.Context) error {
+		var err error
+		refundID, err = g.next.RefundPayment(ctx, payment, amount, reason)
+		return err
+	})
+	return refundID, err
+}
+
+// LookupAttempt는 결제의 마지막 시도 결과를 조회합니다. 조회는 상태를 바꾸지 않으므로 그대로 재시도합니다.
+func (g *ResilientGateway) LookupAttempt(ctx context.Context, payment *domain.Payment) (*application.GatewayAttempt, error) {
+	var attempt *application.GatewayAttempt
+	err := g.call(ctx, "lookup", "lookup:"+payment.Attempt().ID, func(ctx context.Context) error {
+		var err error
+		attempt, err = g.next.LookupAttempt(ctx, payment)
+		return err
+	})
+	return attempt, err
+}
+
+// LookupRefund는 환불 요청의 결과를 조회합니다. 조회는 상태를 바꾸지 않으므로 그대로 재시도합니다.
+func (g *ResilientGateway) LookupRefund(ctx context.Context, payment *domain.Payment, refundID string) (*application.GatewayAttempt, error) {
+	var attempt *application.GatewayAttempt
+	err := g.call(ctx, "lookup", "lookup:"+refundID, func(ctx context.Context) error {
+		var err error
+		attempt, err = g.next.LookupRefund(ctx, payment, refundID)
+		return err
+	})
+	return attempt, err
+}
+
+// CircuitState는 현재 회로 차단기 상태를 반환합니다.
+func (g *ResilientGateway) CircuitState() CircuitState {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.currentState(time.Now())
+}
+
+// call은 회로 차단기를 거쳐 fn을 호출하고, 일시적 오류면 백오프 후 같은 요청 ID로 다시 호출합니다.
+// 호출자가 컨텍스트에 요청 ID를 담았으면 defaultRequestID 대신 그 값을 사용합니다.
+func (g *ResilientGateway) call(ctx context.Context, operation, defaultRequestID string, fn func(ctx context.Context) error) error {
+	if _, ok := application.GatewayRequestID(ctx); !ok {
+		ctx = application.WithGatewayRequestID(ctx, defaultRequestID)
+	}
+	g.count("calls_total", operation)
+
+	var err error
+	for attempt := 1; attempt <= g.config.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			g.count("retries_total", operation)
+			if sleepErr := sleep(ctx, g.backoff(attempt-1)); sleepErr != nil {
+				return fmt.Errorf("%w: %v", application.ErrGatewayUnavailable, err)
+			}
+		}
+
+		if !g.allow(time.Now()) {
+			g.count("rejected_total", operation)
+			return ErrCircuitOpen
+		}
+
+		g.count("attempts_total", operation)
+		started := time.Now()
+		callCtx, cancel := context.WithTimeout(ctx, g.config.CallTimeout)
+		err = fn(callCtx)
+		cancel()
+		g.add("latency_ms_total", operation, time.Since(started).Milliseconds())
+
+		// 호출자가 요청을 취소했으면 더 시도하지 않습니다 (게이트웨이 장애로 보지 않습니다)
+		if ctx.Err() != nil && err != nil {
+			g.release()
+			return fmt.Errorf("%w: %v", application.ErrGatewayUnavailable, err)
+		}
+
+		if err == nil || !isRetryable(err) {
+			// 거절 같은 최종 응답은 게이트웨이가 정상적으로 응답한 것입니다
+			g.succeed()
+			if err != nil {
+				g.count("declines_total", operation)
+				return err
+			}
+			g.count("successes_total", operation)
+			return nil
+		}
+
+		g.count("transient_errors_total", operation)
+		if errors.Is(err, context.DeadlineExceeded) {
+			g.count("timeouts_total", operation)
+		}
+		g.fail(time.Now())
+	}
+
+	g.count("exhausted_total", operation)
+	return fmt.Errorf("%w: %v", application.ErrGatewayUnavailable, err)
+}
+
+// isRetryable은 같은 요청을 다시 보내면 성공할 수 있는 일시적 오류인지 확인합니다.
+// 호출 타임아웃, Temporary()가 true인 오류, 네트워크 타임아웃을 일시적 오류로 봅니다.
+func isRetryable(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) {
+		return temporary.Temporary()
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+	return false
+}
+
+// backoff는 retry번째 재시도 전 대기 시간을 계산합니다.
+func (g *ResilientGateway) backoff(retry int) time.Duration {
+	delay := g.config.BaseBackoff
+	for i := 1; i < retry && delay < g.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > g.config.MaxBackoff {
+		delay = g.config.MaxBackoff
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return delay/2 + time.Duration(g.random.Int63n(int64(delay/2)+1))
+}
+
+// allow는 회로 상태에 따라 호출을 보낼지 정합니다. 반열림 상태에서는 시험 호출 하나만 보냅니다.
+func (g *ResilientGateway) allow(now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	switch g.currentState(now) {
+	case CircuitOpen:
+		return false
+	case CircuitHalfOpen:
+		if g.probing {
+			return false
+		}
+		g.probing = true
+		g.setState(CircuitHalfOpen)
+		return true
+	default:
+		return true
+	}
+}
+
+// succeed는 정상 응답을 기록하고 회로를 닫습니다.
+func (g *ResilientGateway) succeed() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.consecutiveFailures = 0
+	g.probing = false
+	g.setState(CircuitClosed)
+}
+
+// fail은 일시 오류를 기록하고, 시험 호출이 실패했거나 연속 실패가 기준을 넘으면 회로를 엽니다.
+func (g *ResilientGateway) fail(now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.consecutiveFailures++
+	if g.probing || g.consecutiveFailures >= g.config.FailureThreshold {
+		if g.circuit != CircuitOpen && g.config.Metrics != nil {
+			g.config.Metrics.Add("circuit_opened_total", 1)
+		}
+		g.probing = false
+		g.openedAt = now
+		g.setState(CircuitOpen)
+	}
+}
+
+// release는 결과 없이 끝난 시험 호출의 차례를 돌려줍니다.
+func (g *ResilientGateway) release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.probing = false
+}
+
+// currentState는 열린 기간이 지났으면 반열림으로 본 회로 상태를 반환합니다. g.mu를 잡은 채 호출해야 합니다.
+func (g *ResilientGateway) currentState(now time.Time) CircuitState {
+	if g.circuit == CircuitOpen && !now.Before(g.openedAt.Add(g.config.OpenDuration)) {
+		return CircuitHalfOpen
+	}
+	return g.circuit
+}
+
+// setState는 회로 상태를 바꾸고 지표에 반영합니다. g.mu를 잡은 채 호출해야 합니다.
+func (g *ResilientGateway) setState(state CircuitState) {
+	g.circuit = state
+	g.state.Set(string(state))
+}
+
+// count는 전체와 요청 종류별 지표를 1 늘립니다.
+func (g *ResilientGateway) count(name, operation string) {
+	g.add(name, operation, 1)
+}
+
+// add는 전체와 요청 종류별 지표를 delta만큼 늘립니다.
+func (g *ResilientGateway) add(name, operation string, delta int64) {
+	if g.config.Metrics == nil {
+		return
+	}
+	g.config.Metrics.Add(name, delta)
+	g.config.Metrics.Add(operation+"_"+name, delta)
+}
@@ -0,0 +1,122 @@
+package infrastructure
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"example.com/myapp/payment/application"
+)
+
+// DefaultWebhookTolerance는 웹훅 타임스탬프와 현재 시간의 허용 차이입니다.
+const DefaultWebhookTolerance = 5 * time.Minute
+
+var (
+	ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+	ErrWebhookTimestampExpired = errors.New("webhook timestamp is outside the tolerance")
+	ErrInvalidWebhookSecrets   = errors.New("invalid webhook secrets")
+)
+
+// HMACWebhookProvider는 PG사 공통 형식의 웹훅을 검증하고 해석하는 WebhookProvider 구현체입니다.
+// 서명은 "타임스탬프.본문"의 HMAC-SHA256을 16진수로 나타낸 값이며, "sha256=" 접두사를 붙여도 됩니다.
+// 타임스탬프는 유닉스 초이며 tolerance를 넘게 차이 나면 재전송 공격으로 보고 거절합니다.
+type HMACWebhookProvider struct {
+	secret    []byte
+	tolerance time.Duration
+}
+
+// NewHMACWebhookProvider는 새로운 HMACWebhookProvider 인스턴스를 생성합니다.
+// tolerance가 0 이하이면 DefaultWebhookTolerance를 사용합니다.
+func NewHMACWebhookProvider(secret string, tolerance time.Duration) *HMACWebhookProvider {
+	if tolerance <= 0 {
+		tolerance = DefaultWebhookTolerance
+	}
+	return &HMACWebhookProvider{
+		secret:    []byte(secret),
+		tolerance: tolerance,
+	}
+}
+
+// webhookPayload는 웹훅 본문 형식입니다.
+type webhookPayload struct {
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	PaymentID     string    `json:"paymentId"`
+	TransactionID string    `json:"transactionId"`
+	Amount        float64   `json:"amount"`
+	Reason        string    `json:"reason"`
+	OccurredAt    time.Time `json:"occurredAt"`
+}
+
+// Verify는 서명과 타임스탬프를 검증합니다.
+func (p *HMACWebhookProvider) Verify(signature, timestamp string, payload []byte, now time.Time) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrWebhookTimestampExpired, timestamp)
+	}
+	sentAt := time.Unix(seconds, 0)
+	if sentAt.Before(now.Add(-p.tolerance)) || sentAt.After(now.Add(p.tolerance)) {
+		return ErrWebhookTimestampExpired
+	}
+
+	signature = strings.TrimPrefix(signature, "sha256=")
+	if !hmac.Equal([]byte(signature), []byte(p.Sign(timestamp, payload))) {
+		return ErrInvalidWebhookSignature
+	}
+	return nil
+}
+
+// ParseEvent는 웹훅 본문을 게이트웨이 이벤트로 해석합니다.
+func (p *HMACWebhookProvider) ParseEvent(payload []byte) (*application.GatewayEvent, error) {
+	var body webhookPayload
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook payload: %w", err)
+	}
+	if body.ID == "" || body.Type == "" {
+		return nil, errors.New("webhook payload requires id and type")
+	}
+
+	return &application.GatewayEvent{
+		ID:            body.ID,
+		Type:          application.GatewayEventType(body.Type),
+		PaymentID:     body.PaymentID,
+		TransactionID: body.TransactionID,
+		Amount:        body.Amount,
+		Reason:        body.Reason,
+		OccurredAt:    body.OccurredAt,
+	}, nil
+}
+
+// Sign은 타임스탬프와 본문의 HMAC-SHA256 서명을 16진수 문자열로 계산합니다.
+func (p *HMACWebhookProvider) Sign(timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ParseWebhookSecrets는 "PG사:비밀키" 목록(예: simulator:whsec_1,tosspayments:whsec_2)을 PG사 이름(소문자)별 비밀키로 파싱합니다.
+func ParseWebhookSecrets(spec string) (map[string]string, error) {
+	secrets := map[string]string{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		provider, secret, ok := strings.Cut(entry, ":")
+		provider = strings.ToLower(strings.TrimSpace(provider))
+		secret = strings.TrimSpace(secret)
+		if !ok || provider == "" || secret == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidWebhookSecrets, provider)
+		}
+		secrets[provider] = secret
+	}
+	return secrets, nil
+}
@@ -0,0 +1,155 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"example.com/myapp/promotion/domain"
+)
+
+var (
+	ErrInvalidOrderID = errors.New("invalid order ID")
+)
+
+// CreateCoupon은 새로운 쿠폰을 생성합니다.
+func (uc *PromotionUseCase) CreateCoupon(ctx context.Context, spec domain.CouponSpec) (*domain.Coupon, error) {
+	coupon, err := domain.NewCoupon(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Save(ctx, coupon); err != nil {
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+// GetCoupon은 코드로 쿠폰을 조회합니다.
+func (uc *PromotionUseCase) GetCoupon(ctx context.Context, code string) (*domain.Coupon, error) {
+	code = domain.NormalizeCode(code)
+	if code == "" {
+		return nil, domain.ErrInvalidCouponCode
+	}
+	return uc.repo.FindByCode(ctx, code)
+}
+
+// DeactivateCoupon은 쿠폰 사용을 중지합니다. 이미 적용된 주문에는 영향을 주지 않습니다.
+func (uc *PromotionUseCase) DeactivateCoupon(ctx context.Context, code string) (*domain.Coupon, error) {
+	return uc.changeCoupon(ctx, code, (*domain.Coupon).Deactivate)
+}
+
+// ActivateCoupon은 중지된 쿠폰을 다시 사용할 수 있게 합니다.
+func (uc *PromotionUseCase) ActivateCoupon(ctx context.Context, code string) (*domain.Coupon, error) {
+	return uc.changeCoupon(ctx, code, (*domain.Coupon).Activate)
+}
+
+func (uc *PromotionUseCase) changeCoupon(ctx context.Context, code string, change func(*domain.Coupon)) (*domain.Coupon, error) {
+	coupon, err := uc.GetCoupon(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	change(coupon)
+
+	if err := uc.repo.Update(ctx, coupon); err != nil {
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+// PriceDiscounts는 쿠폰 코드들을 주문에 적용했을 때의 할인 내역을 계산합니다.
+// 사용 한도는 여기서 미리 확인하지만, 실제 차감은 RedeemDiscounts에서 원자적으로 이루어집니다.
+func (uc *PromotionUseCase) PriceDiscounts(ctx context.Context, req DiscountRequest) ([]domain.Discount, error) {
+	if len(req.Codes) == 0 {
+		return []domain.Discount{}, nil
+	}
+
+	coupons := make([]*domain.Coupon, 0, len(req.Codes))
+	needsTier := false
+	for _, code := range req.Codes {
+		coupon, err := uc.GetCoupon(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.checkUsage(ctx, coupon, req.MemberID, req.OrderID); err != nil {
+			return nil, err
+		}
+		if len(coupon.Spec().Conditions.MemberTiers) > 0 {
+			needsTier = true
+		}
+		coupons = append(coupons, coupon)
+	}
+
+	pc := domain.PricingContext{
+		FirstOrder:  req.FirstOrder,
+		Lines:       req.Lines,
+		ShippingFee: req.ShippingFee,
+		Now:         time.Now(),
+	}
+
+	// 회원 등급 조건이 있는 쿠폰이 있을 때만 회원 정보를 조회합니다
+	if needsTier {
+		tier, err := uc.members.TierOf(ctx, req.MemberID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up member tier: %w", err)
+		}
+		pc.MemberTier = tier
+	}
+
+	return domain.ApplyCoupons(coupons, pc)
+}
+
+// RedeemDiscounts는 주문에 적용된 쿠폰 사용을 기록합니다.
+// 같은 주문으로 다시 호출해도 사용 횟수가 중복으로 늘지 않으며, 주문 수정으로 바뀐 할인 금액만 갱신됩니다.
+func (uc *PromotionUseCase) RedeemDiscounts(ctx context.Context, orderID, memberID string, discounts []domain.Discount) error {
+	if orderID == "" {
+		return ErrInvalidOrderID
+	}
+	if len(discounts) == 0 {
+		return nil
+	}
+
+	redemptions := make([]*domain.Redemption, 0, len(discounts))
+	for _, discount := range discounts {
+		coupon, err := uc.GetCoupon(ctx, discount.Code)
+		if err != nil {
+			return err
+		}
+		redemptions = append(redemptions, domain.NewRedemption(coupon, memberID, orderID, discount.Amount))
+	}
+
+	return uc.repo.Redeem(ctx, redemptions)
+}
+
+// ReleaseDiscounts는 취소된 주문의 쿠폰 사용을 해제하여 다시 사용할 수 있게 합니다.
+func (uc *PromotionUseCase) ReleaseDiscounts(ctx context.Context, orderID string) error {
+	if orderID == "" {
+		return ErrInvalidOrderID
+	}
+	return uc.repo.ReleaseRedemptions(ctx, orderID)
+}
+
+// checkUsage는 쿠폰의 전체 및 회원별 사용 한도를 확인합니다. orderID 주문의 사용 기록은 세지 않습니다.
+func (uc *PromotionUseCase) checkUsage(ctx context.Context, coupon *domain.Coupon, memberID, orderID string) error {
+	spec := coupon.Spec()
+	if spec.UsageLimit == 0 && spec.PerMemberLimit == 0 {
+		return nil
+	}
+
+	total, byMember, err := uc.repo.CountRedemptions(ctx, coupon.ID(), memberID, orderID)
+	if err != nil {
+		return err
+	}
+
+	if spec.UsageLimit > 0 && total >= spec.UsageLimit {
+		return fmt.Errorf("%s: %w", coupon.Code(), domain.ErrUsageLimitReached)
+	}
+	if spec.PerMemberLimit > 0 && byMember >= spec.PerMemberLimit {
+		return fmt.Errorf("%s: %w", coupon.Code(), domain.ErrMemberUsageLimitReached)
+	}
+	return nil
+}
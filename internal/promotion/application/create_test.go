@@ -0,0 +1,354 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"example.com/myapp/promotion/domain"
+)
+
+// FakeCouponRepository는 테스트를 위한 가짜 CouponRepository 구현체입니다.
+// Postgres 구현과 마찬가지로 사용 한도 확인과 기록을 잠금 안에서 원자적으로 처리합니다.
+type FakeCouponRepository struct {
+	mu          sync.Mutex
+	coupons     map[string]*domain.Coupon
+	redemptions map[string]*domain.Redemption
+	released    map[string]bool
+}
+
+// NewFakeCouponRepository는 새로운 FakeCouponRepository 인스턴스를 생성합니다.
+func NewFakeCouponRepository() *FakeCouponRepository {
+	return &FakeCouponRepository{
+		coupons:     make(map[string]*domain.Coupon),
+		redemptions: make(map[string]*domain.Redemption),
+		released:    make(map[string]bool),
+	}
+}
+
+func (f *FakeCouponRepository) Save(ctx context.Context, coupon *domain.Coupon) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.coupons[coupon.Code()]; ok {
+		return domain.ErrDuplicateCouponCode
+	}
+	f.coupons[coupon.Code()] = coupon
+	return nil
+}
+
+func (f *FakeCouponRepository) FindByCode(ctx context.Context, code string) (*domain.Coupon, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	coupon, ok := f.coupons[code]
+	if !ok {
+		return nil, domain.ErrCouponNotFound
+	}
+	return coupon, nil
+}
+
+func (f *FakeCouponRepository) Update(ctx context.Context, coupon *domain.Coupon) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.coupons[coupon.Code()]; !ok {
+		return domain.ErrCouponNotFound
+	}
+	f.coupons[coupon.Code()] = coupon
+	return nil
+}
+
+func (f *FakeCouponRepository) CountRedemptions(ctx context.Context, couponID, memberID, excludeOrderID string) (int, int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	total, byMember := f.count(couponID, memberID, excludeOrderID)
+	return total, byMember, nil
+}
+
+func (f *FakeCouponRepository) Redeem(ctx context.Context, redemptions []*domain.Redemption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, redemption := range redemptions {
+		key := redemption.CouponID() + "/" + redemption.OrderID()
+		if _, ok := f.redemptions[key]; ok && !f.released[key] {
+			continue
+		}
+
+		coupon := f.coupons[redemption.Code()]
+		total, byMember := f.count(redemption.CouponID(), redemption.MemberID(), "")
+		if limit := coupon.Spec().UsageLimit; limit > 0 && total >= limit {
+			return domain.ErrUsageLimitReached
+		}
+		if limit := coupon.Spec().PerMemberLimit; limit > 0 && byMember >= limit {
+			return domain.ErrMemberUsageLimitReached
+		}
+	}
+
+	for _, redemption := range redemptions {
+		key := redemption.CouponID() + "/" + redemption.OrderID()
+		f.redemptions[key] = redemption
+		f.released[key] = false
+	}
+	return nil
+}
+
+func (f *FakeCouponRepository) ReleaseRedemptions(ctx context.Context, orderID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for key, redemption := range f.redemptions {
+		if redemption.OrderID() == orderID {
+			f.released[key] = true
+		}
+	}
+	return nil
+}
+
+func (f *FakeCouponRepository) count(couponID, memberID, excludeOrderID string) (int, int) {
+	total, byMember := 0, 0
+	for key, redemption := range f.redemptions {
+		if redemption.CouponID() != couponID || f.released[key] || redemption.OrderID() == excludeOrderID {
+			continue
+		}
+		total++
+		if redemption.MemberID() == memberID {
+			byMember++
+		}
+	}
+	return total, byMember
+}
+
+// FakeMemberTierLookup은 테스트를 위한 가짜 MemberTierLookup 구현체입니다.
+type FakeMemberTierLookup struct {
+	tiers map[string]string
+}
+
+func (f *FakeMemberTierLookup) TierOf(ctx context.Context, memberID string) (string, error) {
+	return f.tiers[memberID], nil
+}
+
+func newTestUseCase(t *testing.T, specs ...domain.CouponSpec) *PromotionUseCase {
+	t.Helper()
+
+	useCase := NewPromotionUseCase(NewFakeCouponRepository(), &FakeMemberTierLookup{
+		tiers: map[string]string{"member-gold": "gold", "member-basic": "basic"},
+	})
+	for _, spec := range specs {
+		if _, err := useCase.CreateCoupon(context.Background(), spec); err != nil {
+			t.Fatalf("CreateCoupon(%s) error = %v", spec.Code, err)
+		}
+	}
+	return useCase
+}
+
+var testLines = []domain.PricingLine{
+	{ProductID: "prod-1", SKUID: "sku-1", UnitPrice: 10000, Quantity: 3},
+	{ProductID: "prod-2", SKUID: "sku-2", UnitPrice: 5000, Quantity: 2},
+}
+
+func TestPriceDiscountsCalculatesEachKind(t *testing.T) {
+	useCase := newTestUseCase(t,
+		domain.CouponSpec{Code: "bxgy", Name: "2+1", Kind: domain.KindBuyXGetY, BuyQuantity: 2, GetQuantity: 1,
+			Conditions: domain.Conditions{ProductIDs: []string{"prod-1"}}, Stackable: true},
+		domain.CouponSpec{Code: "TEN", Name: "10% 할인", Kind: domain.KindPercentage, Value: 10, Stackable: true},
+		domain.CouponSpec{Code: "FIXED", Name: "3000원 할인", Kind: domain.KindFixedAmount, Value: 3000, Stackable: true},
+	)
+
+	// 입력 순서와 관계없이 buy_x_get_y, 정률, 정액 순으로 적용됩니다
+	discounts, err := useCase.PriceDiscounts(context.Background(), DiscountRequest{
+		MemberID: "member-basic",
+		Codes:    []string{"fixed", "ten", "BXGY"},
+		Lines:    testLines,
+	})
+	if err != nil {
+		t.Fatalf("PriceDiscounts() error = %v", err)
+	}
+
+	// 상품 금액 40000 → 2+1로 10000 할인 → 남은 30000의 10% → 3000 정액
+	want := []struct {
+		code   string
+		amount float64
+	}{{"BXGY", 10000}, {"TEN", 3000}, {"FIXED", 3000}}
+	if len(discounts) != len(want) {
+		t.Fatalf("len(discounts) = %v, want %v", len(discounts), len(want))
+	}
+	for i, w := range want {
+		if discounts[i].Code != w.code || discounts[i].Amount != w.amount {
+			t.Errorf("discounts[%d] = %s %v, want %s %v", i, discounts[i].Code, discounts[i].Amount, w.code, w.amount)
+		}
+	}
+}
+
+func TestPriceDiscountsCapsAtSubtotal(t *testing.T) {
+	useCase := newTestUseCase(t,
+		domain.CouponSpec{Code: "BIG", Name: "큰 할인", Kind: domain.KindFixedAmount, Value: 100000},
+	)
+
+	discounts, err := useCase.PriceDiscounts(context.Background(), DiscountRequest{Codes: []string{"BIG"}, Lines: testLines})
+	if err != nil {
+		t.Fatalf("PriceDiscounts() error = %v", err)
+	}
+	if discounts[0].Amount != 40000 {
+		t.Errorf("Amount = %v, want 40000", discounts[0].Amount)
+	}
+}
+
+func TestPriceDiscountsRejectsInvalidCombinations(t *testing.T) {
+	useCase := newTestUseCase(t,
+		domain.CouponSpec{Code: "SOLO", Name: "단독 쿠폰", Kind: domain.KindPercentage, Value: 5},
+		domain.CouponSpec{Code: "STACK", Name: "중복 가능", Kind: domain.KindFixedAmount, Value: 1000, Stackable: true},
+		domain.CouponSpec{Code: "MIN", Name: "5만원 이상", Kind: domain.KindFixedAmount, Value: 1000,
+			Conditions: domain.Conditions{MinSpend: 50000}},
+		domain.CouponSpec{Code: "FIRST", Name: "첫 주문", Kind: domain.KindFixedAmount, Value: 1000,
+			Conditions: domain.Conditions{FirstOrderOnly: true}},
+		domain.CouponSpec{Code: "GOLD", Name: "골드 전용", Kind: domain.KindPercentage, Value: 20,
+			Conditions: domain.Conditions{MemberTiers: []string{"gold", "vip"}}},
+		domain.CouponSpec{Code: "SHIP", Name: "무료 배송", Kind: domain.KindFreeShipping},
+	)
+
+	tests := []struct {
+		name     string
+		memberID string
+		codes    []string
+		want     error
+	}{
+		{"단독 쿠폰은 함께 사용할 수 없음", "member-basic", []string{"SOLO", "STACK"}, domain.ErrCouponNotStackable},
+		{"같은 쿠폰 중복 적용", "member-basic", []string{"STACK", "stack"}, domain.ErrDuplicateCoupon},
+		{"최소 주문 금액 미달", "member-basic", []string{"MIN"}, domain.ErrMinSpendNotMet},
+		{"첫 주문이 아님", "member-basic", []string{"FIRST"}, domain.ErrFirstOrderOnly},
+		{"회원 등급 미달", "member-basic", []string{"GOLD"}, domain.ErrMemberTierNotEligible},
+		{"존재하지 않는 쿠폰", "member-basic", []string{"NONE"}, domain.ErrCouponNotFound},
+		{"배송비 없는 주문의 무료 배송", "member-basic", []string{"SHIP"}, domain.ErrNoShippingFee},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := useCase.PriceDiscounts(context.Background(), DiscountRequest{
+				MemberID: tt.memberID,
+				Codes:    tt.codes,
+				Lines:    testLines,
+			})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("PriceDiscounts() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	discounts, err := useCase.PriceDiscounts(context.Background(), DiscountRequest{
+		MemberID: "member-gold",
+		Codes:    []string{"GOLD"},
+		Lines:    testLines,
+	})
+	if err != nil {
+		t.Fatalf("골드 회원 PriceDiscounts() error = %v", err)
+	}
+	if discounts[0].Amount != 8000 {
+		t.Errorf("Amount = %v, want 8000", discounts[0].Amount)
+	}
+
+	discounts, err = useCase.PriceDiscounts(context.Background(), DiscountRequest{
+		MemberID:    "member-basic",
+		Codes:       []string{"SHIP"},
+		Lines:       testLines,
+		ShippingFee: 3000,
+	})
+	if err != nil {
+		t.Fatalf("배송비 있는 주문 PriceDiscounts() error = %v", err)
+	}
+	if discounts[0].Amount != 3000 || !discounts[0].FreeShipping {
+		t.Errorf("Amount = %v, FreeShipping = %v, want 3000 true", discounts[0].Amount, discounts[0].FreeShipping)
+	}
+}
+
+func TestRedeemDiscountsEnforcesUsageLimits(t *testing.T) {
+	useCase := newTestUseCase(t,
+		domain.CouponSpec{Code: "ONCE", Name: "1회 한정", Kind: domain.KindFixedAmount, Value: 1000, PerMemberLimit: 1},
+	)
+	ctx := context.Background()
+	req := DiscountRequest{MemberID: "member-basic", Codes: []string{"ONCE"}, Lines: testLines}
+
+	discounts, err := useCase.PriceDiscounts(ctx, req)
+	if err != nil {
+		t.Fatalf("PriceDiscounts() error = %v", err)
+	}
+	if err := useCase.RedeemDiscounts(ctx, "order-1", "member-basic", discounts); err != nil {
+		t.Fatalf("RedeemDiscounts() error = %v", err)
+	}
+	// 같은 주문으로 재시도해도 한 번만 기록됩니다
+	if err := useCase.RedeemDiscounts(ctx, "order-1", "member-basic", discounts); err != nil {
+		t.Fatalf("RedeemDiscounts() retry error = %v", err)
+	}
+
+	if _, err := useCase.PriceDiscounts(ctx, req); !errors.Is(err, domain.ErrMemberUsageLimitReached) {
+		t.Fatalf("PriceDiscounts() error = %v, want %v", err, domain.ErrMemberUsageLimitReached)
+	}
+	if err := useCase.RedeemDiscounts(ctx, "order-2", "member-basic", discounts); !errors.Is(err, domain.ErrMemberUsageLimitReached) {
+		t.Fatalf("RedeemDiscounts() error = %v, want %v", err, domain.ErrMemberUsageLimitReached)
+	}
+
+	// 주문이 취소되면 쿠폰을 다시 사용할 수 있습니다
+	if err := useCase.ReleaseDiscounts(ctx, "order-1"); err != nil {
+		t.Fatalf("ReleaseDiscounts() error = %v", err)
+	}
+	if _, err := useCase.PriceDiscounts(ctx, req); err != nil {
+		t.Errorf("해제 후 PriceDiscounts() error = %v", err)
+	}
+}
+
+func TestPriceDiscountsExcludesOwnOrderRedemption(t *testing.T) {
+	useCase := newTestUseCase(t,
+		domain.CouponSpec{Code: "FIRST10", Name: "선착순 10%", Kind: domain.KindPercentage, Value: 10, UsageLimit: 1},
+	)
+	ctx := context.Background()
+
+	discounts, err := useCase.PriceDiscounts(ctx, DiscountRequest{MemberID: "member-basic", Codes: []string{"FIRST10"}, Lines: testLines})
+	if err != nil {
+		t.Fatalf("PriceDiscounts() error = %v", err)
+	}
+	if err := useCase.RedeemDiscounts(ctx, "order-1", "member-basic", discounts); err != nil {
+		t.Fatalf("RedeemDiscounts() error = %v", err)
+	}
+
+	// 수정된 주문을 다시 계산할 때는 그 주문의 사용 기록을 한도에서 제외합니다
+	edited := []domain.PricingLine{{ProductID: "prod-1", SKUID: "sku-1", UnitPrice: 10000, Quantity: 1}}
+	discounts, err = useCase.PriceDiscounts(ctx, DiscountRequest{MemberID: "member-basic", OrderID: "order-1", Codes: []string{"FIRST10"}, Lines: edited})
+	if err != nil {
+		t.Fatalf("PriceDiscounts(order-1) error = %v", err)
+	}
+	if err := useCase.RedeemDiscounts(ctx, "order-1", "member-basic", discounts); err != nil {
+		t.Fatalf("RedeemDiscounts(order-1) error = %v", err)
+	}
+
+	repo := useCase.repo.(*FakeCouponRepository)
+	coupon, _ := repo.FindByCode(ctx, "FIRST10")
+	if total, _ := repo.count(coupon.ID(), "member-basic", ""); total != 1 {
+		t.Errorf("사용 횟수 = %d, want 1", total)
+	}
+	if amount := repo.redemptions[coupon.ID()+"/order-1"].Amount(); amount != 1000 {
+		t.Errorf("기록된 할인 금액 = %v, want 1000", amount)
+	}
+
+	// 다른 주문에는 여전히 한도가 적용됩니다
+	if _, err := useCase.PriceDiscounts(ctx, DiscountRequest{MemberID: "member-gold", OrderID: "order-2", Codes: []string{"FIRST10"}, Lines: testLines}); !errors.Is(err, domain.ErrUsageLimitReached) {
+		t.Errorf("PriceDiscounts(order-2) error = %v, want %v", err, domain.ErrUsageLimitReached)
+	}
+}
+
+func TestDeactivatedCouponIsRejected(t *testing.T) {
+	useCase := newTestUseCase(t,
+		domain.CouponSpec{Code: "OFF", Name: "중지될 쿠폰", Kind: domain.KindFixedAmount, Value: 1000},
+	)
+	ctx := context.Background()
+
+	if _, err := useCase.DeactivateCoupon(ctx, "off"); err != nil {
+		t.Fatalf("DeactivateCoupon() error = %v", err)
+	}
+	_, err := useCase.PriceDiscounts(ctx, DiscountRequest{Codes: []string{"OFF"}, Lines: testLines})
+	if !errors.Is(err, domain.ErrCouponInactive) {
+		t.Errorf("PriceDiscounts() error = %v, want %v", err, domain.ErrCouponInactive)
+	}
+}
@@ -0,0 +1,64 @@
+package application
+
+import (
+	"context"
+
+	"example.com/myapp/promotion/domain"
+)
+
+// CouponRepository는 쿠폰과 쿠폰 사용 기록의 영속성 인터페이스를 정의합니다.
+type CouponRepository interface {
+	Save(ctx context.Context, coupon *domain.Coupon) error
+	FindByCode(ctx context.Context, code string) (*domain.Coupon, error)
+	Update(ctx context.Context, coupon *domain.Coupon) error
+	// CountRedemptions는 해제되지 않은 쿠폰 사용 횟수를 전체와 회원별로 반환합니다.
+	// excludeOrderID가 주어지면 그 주문의 사용 기록은 세지 않습니다.
+	CountRedemptions(ctx context.Context, couponID, memberID, excludeOrderID string) (total int, byMember int, err error)
+	// Redeem은 사용 한도를 확인하며 주문의 쿠폰 사용 기록을 하나의 트랜잭션으로 저장합니다.
+	// 한도를 넘는 쿠폰이 하나라도 있으면 아무것도 저장하지 않으며, 같은 주문의 같은 쿠폰은 한 번만 기록되고 할인 금액만 갱신됩니다.
+	Redeem(ctx context.Context, redemptions []*domain.Redemption) error
+	// ReleaseRedemptions는 주문의 쿠폰 사용 기록을 해제하여 사용 한도를 되돌립니다.
+	ReleaseRedemptions(ctx context.Context, orderID string) error
+}
+
+// MemberTierLookup은 쿠폰 자격 확인에 필요한 회원 등급을 조회하는 포트를 정의합니다.
+type MemberTierLookup interface {
+	TierOf(ctx context.Context, memberID string) (string, error)
+}
+
+// PromotionService는 쿠폰 관리와 할인 계산 비즈니스 로직을 정의합니다.
+type PromotionService interface {
+	CreateCoupon(ctx context.Context, spec domain.CouponSpec) (*domain.Coupon, error)
+	GetCoupon(ctx context.Context, code string) (*domain.Coupon, error)
+	DeactivateCoupon(ctx context.Context, code string) (*domain.Coupon, error)
+	ActivateCoupon(ctx context.Context, code string) (*domain.Coupon, error)
+	PriceDiscounts(ctx context.Context, req DiscountRequest) ([]domain.Discount, error)
+	RedeemDiscounts(ctx context.Context, orderID, memberID string, discounts []domain.Discount) error
+	ReleaseDiscounts(ctx context.Context, orderID string) error
+}
+
+// DiscountRequest는 주문에 쿠폰을 적용하기 위한 요청 정보를 정의합니다.
+type DiscountRequest struct {
+	MemberID string
+	// OrderID는 이미 생성된 주문을 다시 계산할 때 지정하며, 이 주문의 쿠폰 사용 기록은 사용 한도에서 제외됩니다.
+	OrderID    string
+	FirstOrder bool
+	Codes      []string
+	Lines      []domain.PricingLine
+	// ShippingFee는 무료 배송 쿠폰이 면제할 배송비이며, 0이면 무료 배송 쿠폰은 domain.ErrNoShippingFee로 거절됩니다.
+	ShippingFee float64
+}
+
+// PromotionUseCase는 PromotionService 구현체를 정의합니다.
+type PromotionUseCase struct {
+	repo    CouponRepository
+	members MemberTierLookup
+}
+
+// NewPromotionUseCase는 새로운 PromotionUseCase 인스턴스를 생성합니다.
+func NewPromotionUseCase(repo CouponRepository, members MemberTierLookup) *PromotionUseCase {
+	return &PromotionUseCase{
+		repo:    repo,
+		members: members,
+	}
+}
@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DiscountKind는 할인 방식을 정의합니다.
+type DiscountKind string
+
+const (
+	KindPercentage   DiscountKind = "percentage"
+	KindFixedAmount  DiscountKind = "fixed_amount"
+	KindFreeShipping DiscountKind = "free_shipping"
+	KindBuyXGetY     DiscountKind = "buy_x_get_y"
+)
+
+var (
+	ErrInvalidCouponCode       = errors.New("invalid coupon code")
+	ErrInvalidDiscountKind     = errors.New("invalid discount kind")
+	ErrInvalidDiscountValue    = errors.New("invalid discount value")
+	ErrInvalidBuyXGetY         = errors.New("buy and get quantities must be positive")
+	ErrInvalidUsageLimit       = errors.New("usage limits must not be negative")
+	ErrInvalidValidityPeriod   = errors.New("coupon must start before it ends")
+	ErrCouponNotFound          = errors.New("coupon not found")
+	ErrDuplicateCouponCode     = errors.New("coupon code already exists")
+	ErrCouponInactive          = errors.New("coupon is not active")
+	ErrCouponNotStarted        = errors.New("coupon is not yet valid")
+	ErrCouponExpired           = errors.New("coupon has expired")
+	ErrMinSpendNotMet          = errors.New("order does not meet the minimum spend for this coupon")
+	ErrNoEligibleProducts      = errors.New("order has no products eligible for this coupon")
+	ErrNoShippingFee           = errors.New("order has no shipping fee for this coupon to waive")
+	ErrFirstOrderOnly          = errors.New("coupon is only valid for a first order")
+	ErrMemberTierNotEligible   = errors.New("coupon is not available for this member tier")
+	ErrCouponNotStackable      = errors.New("coupon cannot be combined with other coupons")
+	ErrDuplicateCoupon         = errors.New("the same coupon was applied more than once")
+	ErrUsageLimitReached       = errors.New("coupon usage limit reached")
+	ErrMemberUsageLimitReached = errors.New("coupon usage limit reached for this member")
+)
+
+// Conditions는 쿠폰 적용 자격 조건을 정의합니다. 비어 있는 조건은 검사하지 않습니다.
+type Conditions struct {
+	MinSpend       float64
+	ProductIDs     []string
+	FirstOrderOnly bool
+	MemberTiers    []string
+}
+
+// CouponSpec은 쿠폰 생성에 필요한 정보를 정의합니다.
+type CouponSpec struct {
+	Code           string
+	Name           string
+	Kind           DiscountKind
+	Value          float64 // 정률 할인은 퍼센트, 정액 할인은 금액
+	BuyQuantity    int     // buy_x_get_y에서 구매해야 하는 수량
+	GetQuantity    int     // buy_x_get_y에서 무료로 제공되는 수량
+	Conditions     Conditions
+	UsageLimit     int // 전체 사용 한도 (0이면 무제한)
+	PerMemberLimit int // 회원당 사용 한도 (0이면 무제한)
+	Stackable      bool
+	StartsAt       time.Time // 비어 있으면 즉시 사용 가능
+	EndsAt         time.Time // 비어 있으면 기한 없음
+}
+
+// Coupon은 프로모션 쿠폰 엔티티를 나타냅니다.
+type Coupon struct {
+	id        string
+	spec      CouponSpec
+	active    bool
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewCoupon은 새로운 쿠폰을 생성합니다.
+func NewCoupon(spec CouponSpec) (*Coupon, error) {
+	spec.Code = NormalizeCode(spec.Code)
+	if spec.Code == "" {
+		return nil, ErrInvalidCouponCode
+	}
+
+	switch spec.Kind {
+	case KindPercentage:
+		if spec.Value <= 0 || spec.Value > 100 {
+			return nil, ErrInvalidDiscountValue
+		}
+	case KindFixedAmount:
+		if spec.Value <= 0 {
+			return nil, ErrInvalidDiscountValue
+		}
+	case KindBuyXGetY:
+		if spec.BuyQuantity <= 0 || spec.GetQuantity <= 0 {
+			return nil, ErrInvalidBuyXGetY
+		}
+	case KindFreeShipping:
+	default:
+		return nil, ErrInvalidDiscountKind
+	}
+
+	if spec.UsageLimit < 0 || spec.PerMemberLimit < 0 {
+		return nil, ErrInvalidUsageLimit
+	}
+	if !spec.StartsAt.IsZero() && !spec.EndsAt.IsZero() && !spec.StartsAt.Before(spec.EndsAt) {
+		return nil, ErrInvalidValidityPeriod
+	}
+
+	now := time.Now()
+	return &Coupon{
+		id:        uuid.New().String(),
+		spec:      spec,
+		active:    true,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// RestoreCoupon은 저장된 데이터로부터 쿠폰을 복원합니다.
+func RestoreCoupon(id string, spec CouponSpec, active bool, createdAt, updatedAt time.Time) *Coupon {
+	return &Coupon{
+		id:        id,
+		spec:      spec,
+		active:    active,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+// NormalizeCode는 대소문자와 공백에 관계없이 쿠폰 코드를 비교할 수 있게 정규화합니다.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// ID는 쿠폰의 고유 식별자를 반환합니다.
+func (c *Coupon) ID() string {
+	return c.id
+}
+
+// Code는 쿠폰 코드를 반환합니다.
+func (c *Coupon) Code() string {
+	return c.spec.Code
+}
+
+// Name은 쿠폰 이름을 반환합니다.
+func (c *Coupon) Name() string {
+	return c.spec.Name
+}
+
+// Spec은 쿠폰의 할인 규칙과 조건을 반환합니다.
+func (c *Coupon) Spec() CouponSpec {
+	return c.spec
+}
+
+// IsActive는 쿠폰이 활성 상태인지 확인합니다.
+func (c *Coupon) IsActive() bool {
+	return c.active
+}
+
+// CreatedAt은 쿠폰이 생성된 시간을 반환합니다.
+func (c *Coupon) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// UpdatedAt은 쿠폰이 마지막으로 변경된 시간을 반환합니다.
+func (c *Coupon) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// Deactivate는 쿠폰을 더 이상 사용할 수 없게 합니다.
+func (c *Coupon) Deactivate() {
+	c.active = false
+	c.updatedAt = time.Now()
+}
+
+// Activate는 비활성화된 쿠폰을 다시 사용할 수 있게 합니다.
+func (c *Coupon) Activate() {
+	c.active = true
+	c.updatedAt = time.Now()
+}
+
+// Redemption은 주문에 적용된 쿠폰 사용 기록을 나타냅니다.
+type Redemption struct {
+	id         string
+	couponID   string
+	code       string
+	memberID   string
+	orderID    string
+	amount     float64
+	redeemedAt time.Time
+}
+
+// NewRedemption은 새로운 쿠폰 사용 기록을 생성합니다.
+func NewRedemption(coupon *Coupon, memberID, orderID string, amount float64) *Redemption {
+	return &Redemption{
+		id:         uuid.New().String(),
+		couponID:   coupon.ID(),
+		code:       coupon.Code(),
+		memberID:   memberID,
+		orderID:    orderID,
+		amount:     amount,
+		redeemedAt: time.Now(),
+	}
+}
+
+// ID는 사용 기록의 고유 식별자를 반환합니다.
+func (r *Redemption) ID() string {
+	return r.id
+}
+
+// CouponID는 사용된 쿠폰 ID를 반환합니다.
+func (r *Redemption) CouponID() string {
+	return r.couponID
+}
+
+// Code는 사용된 쿠폰 코드를 반환합니다.
+func (r *Redemption) Code() string {
+	return r.code
+}
+
+// MemberID는 쿠폰을 사용한 회원 ID를 반환합니다.
+func (r *Redemption) MemberID() string {
+	return r.memberID
+}
+
+// OrderID는 쿠폰이 적용된 주문 ID를 반환합니다.
+func (r *Redemption) OrderID() string {
+	return r.orderID
+}
+
+// Amount는 할인된 금액을 반환합니다.
+func (r *Redemption) Amount() float64 {
+	return r.amount
+}
+
+// RedeemedAt은 쿠폰이 사용된 시간을 반환합니다.
+func (r *Redemption) RedeemedAt() time.Time {
+	return r.redeemedAt
+}
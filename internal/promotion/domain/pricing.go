@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// PricingLine은 할인 계산 대상이 되는 주문 라인을 정의합니다.
+type PricingLine struct {
+	ProductID string
+	SKUID     string
+	UnitPrice float64
+	Quantity  int
+}
+
+// PricingContext는 쿠폰 자격 확인과 할인 계산에 필요한 주문 정보를 정의합니다.
+type PricingContext struct {
+	MemberTier  string
+	FirstOrder  bool
+	Lines       []PricingLine
+	ShippingFee float64
+	Now         time.Time
+}
+
+// Subtotal은 할인 전 상품 금액 합계를 반환합니다.
+func (p PricingContext) Subtotal() float64 {
+	var subtotal float64
+	for _, line := range p.Lines {
+		subtotal += line.UnitPrice * float64(line.Quantity)
+	}
+	return subtotal
+}
+
+// Discount는 쿠폰 하나가 주문에 적용된 결과를 나타냅니다.
+type Discount struct {
+	CouponID     string
+	Code         string
+	Kind         DiscountKind
+	Description  string
+	Amount       float64
+	FreeShipping bool
+}
+
+// kindPriority는 할인 적용 순서입니다.
+// 상품 단위 할인(buy_x_get_y)을 먼저 적용하고 남은 금액에 정률, 정액 순으로 할인합니다.
+var kindPriority = map[DiscountKind]int{
+	KindBuyXGetY:     0,
+	KindPercentage:   1,
+	KindFixedAmount:  2,
+	KindFreeShipping: 3,
+}
+
+// CheckEligibility는 쿠폰을 주문에 적용할 수 있는지 확인합니다.
+func (c *Coupon) CheckEligibility(pc PricingContext) error {
+	if !c.active {
+		return ErrCouponInactive
+	}
+	if !c.spec.StartsAt.IsZero() && pc.Now.Before(c.spec.StartsAt) {
+		return ErrCouponNotStarted
+	}
+	if !c.spec.EndsAt.IsZero() && !pc.Now.Before(c.spec.EndsAt) {
+		return ErrCouponExpired
+	}
+
+	cond := c.spec.Conditions
+	if cond.FirstOrderOnly && !pc.FirstOrder {
+		return ErrFirstOrderOnly
+	}
+	if len(cond.MemberTiers) > 0 && !contains(cond.MemberTiers, pc.MemberTier) {
+		return ErrMemberTierNotEligible
+	}
+	if pc.Subtotal() < cond.MinSpend {
+		return ErrMinSpendNotMet
+	}
+	if len(cond.ProductIDs) > 0 && c.eligibleSubtotal(pc.Lines) == 0 {
+		return ErrNoEligibleProducts
+	}
+	// 면제할 배송비가 없는 주문에 무료 배송 쿠폰을 적용하면 할인 없이 사용 횟수만 차감됩니다
+	if c.spec.Kind == KindFreeShipping && pc.ShippingFee <= 0 {
+		return ErrNoShippingFee
+	}
+	return nil
+}
+
+// ApplyCoupons는 쌓기 규칙과 자격 조건을 확인한 뒤 쿠폰들의 할인 금액을 계산합니다.
+// 상품 할인 합계는 상품 금액을 넘지 않습니다.
+func ApplyCoupons(coupons []*Coupon, pc PricingContext) ([]Discount, error) {
+	seen := make(map[string]bool, len(coupons))
+	for _, coupon := range coupons {
+		if seen[coupon.Code()] {
+			return nil, ErrDuplicateCoupon
+		}
+		seen[coupon.Code()] = true
+
+		if len(coupons) > 1 && !coupon.spec.Stackable {
+			return nil, fmt.Errorf("%s: %w", coupon.Code(), ErrCouponNotStackable)
+		}
+		if err := coupon.CheckEligibility(pc); err != nil {
+			return nil, fmt.Errorf("%s: %w", coupon.Code(), err)
+		}
+	}
+
+	ordered := make([]*Coupon, len(coupons))
+	copy(ordered, coupons)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return kindPriority[ordered[i].spec.Kind] < kindPriority[ordered[j].spec.Kind]
+	})
+
+	remaining := pc.Subtotal()
+	discounts := make([]Discount, 0, len(ordered))
+	for _, coupon := range ordered {
+		discount := Discount{
+			CouponID:    coupon.ID(),
+			Code:        coupon.Code(),
+			Kind:        coupon.spec.Kind,
+			Description: coupon.Name(),
+		}
+
+		if coupon.spec.Kind == KindFreeShipping {
+			discount.Amount = pc.ShippingFee
+			discount.FreeShipping = true
+		} else {
+			discount.Amount = math.Min(coupon.merchandiseDiscount(pc.Lines, remaining), remaining)
+			remaining -= discount.Amount
+		}
+
+		discounts = append(discounts, discount)
+	}
+
+	return discounts, nil
+}
+
+// merchandiseDiscount는 배송비를 제외한 상품 할인 금액을 계산합니다.
+func (c *Coupon) merchandiseDiscount(lines []PricingLine, remaining float64) float64 {
+	switch c.spec.Kind {
+	case KindPercentage:
+		base := math.Min(c.eligibleSubtotal(lines), remaining)
+		return roundAmount(base * c.spec.Value / 100)
+	case KindFixedAmount:
+		return c.spec.Value
+	case KindBuyXGetY:
+		var amount float64
+		bundle := c.spec.BuyQuantity + c.spec.GetQuantity
+		for _, line := range lines {
+			if c.appliesTo(line.ProductID) {
+				free := (line.Quantity / bundle) * c.spec.GetQuantity
+				amount += line.UnitPrice * float64(free)
+			}
+		}
+		return roundAmount(amount)
+	default:
+		return 0
+	}
+}
+
+// eligibleSubtotal은 쿠폰 대상 상품의 금액 합계를 반환합니다.
+func (c *Coupon) eligibleSubtotal(lines []PricingLine) float64 {
+	var subtotal float64
+	for _, line := range lines {
+		if c.appliesTo(line.ProductID) {
+			subtotal += line.UnitPrice * float64(line.Quantity)
+		}
+	}
+	return subtotal
+}
+
+func (c *Coupon) appliesTo(productID string) bool {
+	return len(c.spec.Conditions.ProductIDs) == 0 || contains(c.spec.Conditions.ProductIDs, productID)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// roundAmount는 금액을 소수점 둘째 자리까지 반올림합니다.
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
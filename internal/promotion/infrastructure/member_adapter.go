@@ -0,0 +1,29 @@
+package infrastructure
+
+import (
+	"context"
+
+	memberApp "example.com/myapp/member/application"
+	"example.com/myapp/promotion/application"
+)
+
+// MemberTierAdapter는 회원 모듈의 공개 API로 MemberTierLookup 포트를 구현합니다.
+type MemberTierAdapter struct {
+	members memberApp.MemberService
+}
+
+// NewMemberTierAdapter는 새로운 MemberTierAdapter 인스턴스를 생성합니다.
+func NewMemberTierAdapter(members memberApp.MemberService) application.MemberTierLookup {
+	return &MemberTierAdapter{
+		members: members,
+	}
+}
+
+// TierOf는 회원의 현재 등급을 조회합니다.
+func (a *MemberTierAdapter) TierOf(ctx context.Context, memberID string) (string, error) {
+	member, err := a.members.GetMember(ctx, memberID)
+	if err != nil {
+		return "", err
+	}
+	return string(member.Tier()), nil
+}
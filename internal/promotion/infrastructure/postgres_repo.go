@@ -0,0 +1,275 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"example.com/myapp/promotion/application"
+	"example.com/myapp/promotion/domain"
+	"example.com/myapp/shared/db"
+	"github.com/jackc/pgx/v4"
+)
+
+// PostgresCouponRepository는 PostgreSQL을 사용하는 쿠폰 저장소 구현체입니다.
+// 사용 한도는 쿠폰 행 잠금(SELECT ... FOR UPDATE)으로 동시 사용 시에도 지켜집니다.
+type PostgresCouponRepository struct {
+	db *db.Database
+}
+
+// NewPostgresCouponRepository는 새로운 PostgresCouponRepository 인스턴스를 생성합니다.
+func NewPostgresCouponRepository(database *db.Database) application.CouponRepository {
+	return &PostgresCouponRepository{
+		db: database,
+	}
+}
+
+// Save는 쿠폰 정보를 데이터베이스에 저장합니다.
+func (r *PostgresCouponRepository) Save(ctx context.Context, coupon *domain.Coupon) error {
+	query := `
+		INSERT INTO coupons (
+			id, code, name, kind, value, buy_quantity, get_quantity,
+			min_spend, product_ids, first_order_only, member_tiers,
+			usage_limit, per_member_limit, stackable, starts_at, ends_at,
+			active, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+		ON CONFLICT (code) DO NOTHING
+	`
+
+	spec := coupon.Spec()
+	result, err := r.db.Pool.Exec(
+		ctx,
+		query,
+		coupon.ID(),
+		spec.Code,
+		spec.Name,
+		string(spec.Kind),
+		spec.Value,
+		spec.BuyQuantity,
+		spec.GetQuantity,
+		spec.Conditions.MinSpend,
+		nonNilStrings(spec.Conditions.ProductIDs),
+		spec.Conditions.FirstOrderOnly,
+		nonNilStrings(spec.Conditions.MemberTiers),
+		spec.UsageLimit,
+		spec.PerMemberLimit,
+		spec.Stackable,
+		nullableTime(spec.StartsAt),
+		nullableTime(spec.EndsAt),
+		coupon.IsActive(),
+		coupon.CreatedAt(),
+		coupon.UpdatedAt(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save coupon: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrDuplicateCouponCode
+	}
+
+	return nil
+}
+
+// FindByCode는 코드로 쿠폰을 조회합니다.
+func (r *PostgresCouponRepository) FindByCode(ctx context.Context, code string) (*domain.Coupon, error) {
+	query := `
+		SELECT id, code, name, kind, value, buy_quantity, get_quantity,
+			min_spend, product_ids, first_order_only, member_tiers,
+			usage_limit, per_member_limit, stackable, starts_at, ends_at,
+			active, created_at, updated_at
+		FROM coupons
+		WHERE code = $1
+	`
+
+	row := r.db.Pool.QueryRow(ctx, query, code)
+
+	var id, kind string
+	var spec domain.CouponSpec
+	var startsAt, endsAt *time.Time
+	var active bool
+	var createdAt, updatedAt time.Time
+
+	err := row.Scan(
+		&id, &spec.Code, &spec.Name, &kind, &spec.Value, &spec.BuyQuantity, &spec.GetQuantity,
+		&spec.Conditions.MinSpend, &spec.Conditions.ProductIDs, &spec.Conditions.FirstOrderOnly, &spec.Conditions.MemberTiers,
+		&spec.UsageLimit, &spec.PerMemberLimit, &spec.Stackable, &startsAt, &endsAt,
+		&active, &createdAt, &updatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCouponNotFound
+		}
+		return nil, fmt.Errorf("failed to find coupon by code: %w", err)
+	}
+
+	spec.Kind = domain.DiscountKind(kind)
+	if startsAt != nil {
+		spec.StartsAt = *startsAt
+	}
+	if endsAt != nil {
+		spec.EndsAt = *endsAt
+	}
+
+	return domain.RestoreCoupon(id, spec, active, createdAt, updatedAt), nil
+}
+
+// Update는 쿠폰의 활성 상태를 업데이트합니다.
+func (r *PostgresCouponRepository) Update(ctx context.Context, coupon *domain.Coupon) error {
+	query := `
+		UPDATE coupons
+		SET active = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	result, err := r.db.Pool.Exec(ctx, query, coupon.IsActive(), coupon.UpdatedAt(), coupon.ID())
+	if err != nil {
+		return fmt.Errorf("failed to update coupon: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrCouponNotFound
+	}
+
+	return nil
+}
+
+// CountRedemptions는 해제되지 않은 쿠폰 사용 횟수를 전체와 회원별로 조회합니다.
+func (r *PostgresCouponRepository) CountRedemptions(ctx context.Context, couponID, memberID, excludeOrderID string) (int, int, error) {
+	return countRedemptions(ctx, r.db.Pool.QueryRow, couponID, memberID, excludeOrderID)
+}
+
+// Redeem은 쿠폰 행을 잠근 상태에서 사용 한도를 확인하고 사용 기록을 저장합니다.
+func (r *PostgresCouponRepository) Redeem(ctx context.Context, redemptions []*domain.Redemption) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	// 동시 주문 간 교착 상태를 피하기 위해 쿠폰 ID 순으로 잠급니다
+	ordered := make([]*domain.Redemption, len(redemptions))
+	copy(ordered, redemptions)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].CouponID() < ordered[j].CouponID() })
+
+	for _, redemption := range ordered {
+		// 1. 쿠폰 잠금 및 한도 조회
+		var usageLimit, perMemberLimit int
+		err := tx.QueryRow(
+			ctx,
+			"SELECT usage_limit, per_member_limit FROM coupons WHERE id = $1 FOR UPDATE",
+			redemption.CouponID(),
+		).Scan(&usageLimit, &perMemberLimit)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrCouponNotFound
+			}
+			return fmt.Errorf("failed to lock coupon: %w", err)
+		}
+
+		// 2. 같은 주문에 이미 사용된 쿠폰이면 수정된 주문의 할인 금액만 갱신
+		tag, err := tx.Exec(
+			ctx,
+			"UPDATE coupon_redemptions SET amount = $3 WHERE coupon_id = $1 AND order_id = $2 AND released_at IS NULL",
+			redemption.CouponID(), redemption.OrderID(), redemption.Amount(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update existing redemption: %w", err)
+		}
+		if tag.RowsAffected() > 0 {
+			continue
+		}
+
+		// 3. 사용 한도 확인
+		total, byMember, err := countRedemptions(ctx, tx.QueryRow, redemption.CouponID(), redemption.MemberID(), "")
+		if err != nil {
+			return err
+		}
+		if usageLimit > 0 && total >= usageLimit {
+			return fmt.Errorf("%s: %w", redemption.Code(), domain.ErrUsageLimitReached)
+		}
+		if perMemberLimit > 0 && byMember >= perMemberLimit {
+			return fmt.Errorf("%s: %w", redemption.Code(), domain.ErrMemberUsageLimitReached)
+		}
+
+		// 4. 사용 기록 저장 (해제되었던 기록은 다시 사용 상태로 전환)
+		_, err = tx.Exec(
+			ctx,
+			`
+			INSERT INTO coupon_redemptions (id, coupon_id, code, member_id, order_id, amount, redeemed_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (coupon_id, order_id) DO UPDATE
+			SET amount = EXCLUDED.amount, redeemed_at = EXCLUDED.redeemed_at, released_at = NULL
+			`,
+			redemption.ID(),
+			redemption.CouponID(),
+			redemption.Code(),
+			redemption.MemberID(),
+			redemption.OrderID(),
+			redemption.Amount(),
+			redemption.RedeemedAt(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save coupon redemption: %w", err)
+		}
+	}
+
+	// 트랜잭션 커밋
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseRedemptions는 주문의 쿠폰 사용 기록을 해제합니다.
+func (r *PostgresCouponRepository) ReleaseRedemptions(ctx context.Context, orderID string) error {
+	query := `
+		UPDATE coupon_redemptions
+		SET released_at = $1
+		WHERE order_id = $2 AND released_at IS NULL
+	`
+
+	if _, err := r.db.Pool.Exec(ctx, query, time.Now(), orderID); err != nil {
+		return fmt.Errorf("failed to release coupon redemptions: %w", err)
+	}
+
+	return nil
+}
+
+func countRedemptions(
+	ctx context.Context,
+	queryRow func(ctx context.Context, sql string, args ...interface{}) pgx.Row,
+	couponID, memberID, excludeOrderID string,
+) (int, int, error) {
+	query := `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE member_id = $2)
+		FROM coupon_redemptions
+		WHERE coupon_id = $1 AND released_at IS NULL AND order_id <> $3
+	`
+
+	var total, byMember int
+	if err := queryRow(ctx, query, couponID, memberID, excludeOrderID).Scan(&total, &byMember); err != nil {
+		return 0, 0, fmt.Errorf("failed to count coupon redemptions: %w", err)
+	}
+
+	return total, byMember, nil
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
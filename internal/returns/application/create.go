@@ -0,0 +1,216 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"example.com/myapp/returns/domain"
+)
+
+var (
+	ErrOrderNotReturnable        = errors.New("order must be delivered before it can be returned")
+	ErrNotOrderCustomer          = errors.New("only the customer who placed the order can request a return")
+	ErrItemNotInOrder            = errors.New("item is not part of the order")
+	ErrExceedsReturnableQuantity = errors.New("return quantity exceeds the quantity that can still be returned")
+	ErrRefundUnavailable         = errors.New("payment cannot be refunded for this amount")
+)
+
+// RequestReturn은 배송 완료된 주문에 대한 고객의 반품 요청을 생성합니다.
+// 진행 중인 다른 반품에 포함된 수량까지 더해 아직 반품되지 않은 수량을 넘을 수 없습니다.
+func (uc *ReturnUseCase) RequestReturn(ctx context.Context, req CreateReturnRequest) (*domain.Return, error) {
+	if req.OrderID == "" {
+		return nil, domain.ErrInvalidOrderID
+	}
+
+	order, err := uc.orders.FindOrder(ctx, req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.CustomerID != req.CustomerID {
+		return nil, ErrNotOrderCustomer
+	}
+	if !order.Returnable {
+		return nil, ErrOrderNotReturnable
+	}
+
+	returnable := make(map[string]ReturnableLine, len(order.Lines))
+	for _, line := range order.Lines {
+		returnable[line.ItemID] = line
+	}
+
+	lines := make([]*domain.ReturnLine, 0, len(req.Items))
+	for _, item := range req.Items {
+		orderLine, ok := returnable[item.ItemID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrItemNotInOrder, item.ItemID)
+		}
+		line, err := domain.NewReturnLine(item.ItemID, orderLine.SKUID, item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+
+	ret, err := domain.NewReturn(req.OrderID, req.CustomerID, req.Reason, req.Comment, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.repo.FindByOrderID(ctx, req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	pending := pendingQuantities(existing)
+	for _, line := range ret.Lines() {
+		if pending[line.ItemID()]+line.Quantity() > returnable[line.ItemID()].Quantity {
+			return nil, fmt.Errorf("%w: %s", ErrExceedsReturnableQuantity, line.ItemID())
+		}
+	}
+
+	if err := uc.repo.Save(ctx, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// GetReturn은 반품을 조회합니다.
+func (uc *ReturnUseCase) GetReturn(ctx context.Context, id string) (*domain.Return, error) {
+	return uc.repo.FindByID(ctx, id)
+}
+
+// GetOrderReturns는 주문의 반품 목록을 조회합니다.
+func (uc *ReturnUseCase) GetOrderReturns(ctx context.Context, orderID string) ([]*domain.Return, error) {
+	if orderID == "" {
+		return nil, domain.ErrInvalidOrderID
+	}
+	return uc.repo.FindByOrderID(ctx, orderID)
+}
+
+// ApproveReturn은 반품 요청을 승인합니다.
+func (uc *ReturnUseCase) ApproveReturn(ctx context.Context, id, note string) (*domain.Return, error) {
+	return uc.change(ctx, id, func(ret *domain.Return) error {
+		return ret.Approve(note)
+	})
+}
+
+// RejectReturn은 반품 요청을 거절합니다.
+func (uc *ReturnUseCase) RejectReturn(ctx context.Context, id, note string) (*domain.Return, error) {
+	return uc.change(ctx, id, func(ret *domain.Return) error {
+		return ret.Reject(note)
+	})
+}
+
+// ReceiveReturn은 승인된 반품의 입고 수량을 기록합니다.
+func (uc *ReturnUseCase) ReceiveReturn(ctx context.Context, id string, items []ReturnItemRequest) (*domain.Return, error) {
+	return uc.change(ctx, id, func(ret *domain.Return) error {
+		return ret.Receive(itemQuantities(items))
+	})
+}
+
+// InspectReturn은 검수 결과를 기록하고, 인정된 수량을 주문에 반품으로 반영한 뒤 그 금액을 환불합니다.
+// 환불에 실패해도 검수 결과는 저장되므로 RefundReturn으로 다시 시도할 수 있습니다.
+func (uc *ReturnUseCase) InspectReturn(ctx context.Context, id string, req InspectReturnRequest) (*domain.Return, error) {
+	ret, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ret.Inspect(itemQuantities(req.Items), req.Note); err != nil {
+		return nil, err
+	}
+
+	if ret.Status() == domain.StatusInspected {
+		amount, err := uc.orders.RecordReturn(ctx, ret.OrderID(), ret.AcceptedQuantities(), refundReason(ret))
+		if err != nil {
+			return nil, fmt.Errorf("failed to record return on order: %w", err)
+		}
+		if err := ret.AssignRefundAmount(amount); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.repo.Update(ctx, ret); err != nil {
+		return nil, err
+	}
+
+	if ret.Status() != domain.StatusInspected {
+		return ret, nil
+	}
+	return uc.refund(ctx, ret)
+}
+
+// RefundReturn은 검수가 끝났지만 환불되지 않은 반품의 환불을 다시 시도합니다.
+func (uc *ReturnUseCase) RefundReturn(ctx context.Context, id string) (*domain.Return, error) {
+	ret, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status() != domain.StatusInspected {
+		return nil, domain.ErrReturnStatusTransition
+	}
+	return uc.refund(ctx, ret)
+}
+
+// refund는 결제 모듈로 반품 금액을 환불하고 환불 완료를 저장합니다.
+func (uc *ReturnUseCase) refund(ctx context.Context, ret *domain.Return) (*domain.Return, error) {
+	reference := ""
+	if ret.RefundAmount() > 0 {
+		var err error
+		reference, err = uc.refunds.Refund(ctx, ret.OrderID(), ret.RefundAmount(), refundReason(ret))
+		if err != nil {
+			return nil, fmt.Errorf("failed to refund return: %w", err)
+		}
+	}
+
+	if err := ret.MarkRefunded(reference); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Update(ctx, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// change는 반품을 조회하여 상태를 바꾸고 저장합니다.
+func (uc *ReturnUseCase) change(ctx context.Context, id string, apply func(*domain.Return) error) (*domain.Return, error) {
+	ret, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := apply(ret); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Update(ctx, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// pendingQuantities는 진행 중인 반품에 포함된 주문 항목 ID별 요청 수량 합계를 계산합니다.
+func pendingQuantities(returns []*domain.Return) map[string]int {
+	quantities := make(map[string]int)
+	for _, ret := range returns {
+		if !ret.IsOpen() {
+			continue
+		}
+		for _, line := range ret.Lines() {
+			quantities[line.ItemID()] += line.Quantity()
+		}
+	}
+	return quantities
+}
+
+// itemQuantities는 요청 항목을 주문 항목 ID별 수량으로 합칩니다.
+func itemQuantities(items []ReturnItemRequest) map[string]int {
+	quantities := make(map[string]int, len(items))
+	for _, item := range items {
+		quantities[item.ItemID] += item.Quantity
+	}
+	return quantities
+}
+
+// refundReason은 주문 이력과 결제 환불에 남길 반품 사유를 만듭니다.
+func refundReason(ret *domain.Return) string {
+	return fmt.Sprintf("반품 %s (%s)", ret.ID(), ret.Reason())
+}
@@ -0,0 +1,262 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"example.com/myapp/returns/domain"
+)
+
+// FakeReturnRepository는 테스트를 위한 가짜 ReturnRepository 구현체입니다.
+type FakeReturnRepository struct {
+	returns map[string]*domain.Return
+	order   []string
+}
+
+// NewFakeReturnRepository는 새로운 FakeReturnRepository 인스턴스를 생성합니다.
+func NewFakeReturnRepository() *FakeReturnRepository {
+	return &FakeReturnRepository{
+		returns: make(map[string]*domain.Return),
+	}
+}
+
+func (f *FakeReturnRepository) Save(ctx context.Context, ret *domain.Return) error {
+	f.returns[ret.ID()] = ret
+	f.order = append(f.order, ret.ID())
+	return nil
+}
+
+func (f *FakeReturnRepository) FindByID(ctx context.Context, id string) (*domain.Return, error) {
+	ret, ok := f.returns[id]
+	if !ok {
+		return nil, domain.ErrReturnNotFound
+	}
+	return ret, nil
+}
+
+func (f *FakeReturnRepository) FindByOrderID(ctx context.Context, orderID string) ([]*domain.Return, error) {
+	returns := []*domain.Return{}
+	for _, id := range f.order {
+		if ret := f.returns[id]; ret.OrderID() == orderID {
+			returns = append(returns, ret)
+		}
+	}
+	return returns, nil
+}
+
+func (f *FakeReturnRepository) Update(ctx context.Context, ret *domain.Return) error {
+	if _, ok := f.returns[ret.ID()]; !ok {
+		return domain.ErrReturnNotFound
+	}
+	f.returns[ret.ID()] = ret
+	return nil
+}
+
+// FakeOrderReturns는 테스트를 위한 가짜 OrderReturns 구현체입니다.
+// 반품 수량마다 단가만큼 환불 금액을 계산합니다.
+type FakeOrderReturns struct {
+	orders    map[string]*ReturnableOrder
+	prices    map[string]float64
+	recorded  map[string]int
+	recordErr error
+}
+
+// NewFakeOrderReturns는 새로운 FakeOrderReturns 인스턴스를 생성합니다.
+func NewFakeOrderReturns() *FakeOrderReturns {
+	return &FakeOrderReturns{
+		orders:   make(map[string]*ReturnableOrder),
+		prices:   make(map[string]float64),
+		recorded: make(map[string]int),
+	}
+}
+
+func (f *FakeOrderReturns) FindOrder(ctx context.Context, orderID string) (*ReturnableOrder, error) {
+	order, ok := f.orders[orderID]
+	if !ok {
+		return nil, errors.New("order not found")
+	}
+	return order, nil
+}
+
+func (f *FakeOrderReturns) RecordReturn(ctx context.Context, orderID string, quantities map[string]int, reason string) (float64, error) {
+	if f.recordErr != nil {
+		return 0, f.recordErr
+	}
+	var amount float64
+	for itemID, quantity := range quantities {
+		f.recorded[itemID] += quantity
+		amount += f.prices[itemID] * float64(quantity)
+	}
+	return amount, nil
+}
+
+// FakeRefundIssuer는 테스트를 위한 가짜 RefundIssuer 구현체입니다.
+type FakeRefundIssuer struct {
+	refunds   []float64
+	refundErr error
+}
+
+func (f *FakeRefundIssuer) Refund(ctx context.Context, orderID string, amount float64, reason string) (string, error) {
+	if f.refundErr != nil {
+		return "", f.refundErr
+	}
+	f.refunds = append(f.refunds, amount)
+	return "refund-1", nil
+}
+
+// newDeliveredOrder는 스마트폰 2개와 케이스 1개를 배송 완료한 주문을 등록합니다.
+func newDeliveredOrder(orders *FakeOrderReturns) {
+	orders.orders["order-1"] = &ReturnableOrder{
+		ID:         "order-1",
+		CustomerID: "cust-1",
+		Returnable: true,
+		Lines: []ReturnableLine{
+			{ItemID: "item-1", SKUID: "sku-1", Quantity: 2},
+			{ItemID: "item-2", SKUID: "sku-2", Quantity: 1},
+		},
+	}
+	orders.prices["item-1"] = 1000
+	orders.prices["item-2"] = 200
+}
+
+func TestReturnWorkflowRefundsAcceptedQuantity(t *testing.T) {
+	orders := NewFakeOrderReturns()
+	newDeliveredOrder(orders)
+	refunds := &FakeRefundIssuer{}
+	useCase := NewReturnUseCase(NewFakeReturnRepository(), orders, refunds)
+	ctx := context.Background()
+
+	ret, err := useCase.RequestReturn(ctx, CreateReturnRequest{
+		OrderID:    "order-1",
+		CustomerID: "cust-1",
+		Reason:     domain.ReasonDefective,
+		Items:      []ReturnItemRequest{{ItemID: "item-1", Quantity: 2}},
+	})
+	if err != nil {
+		t.Fatalf("RequestReturn() error = %v", err)
+	}
+
+	// 승인 전에는 입고할 수 없습니다
+	if _, err := useCase.ReceiveReturn(ctx, ret.ID(), []ReturnItemRequest{{ItemID: "item-1", Quantity: 2}}); !errors.Is(err, domain.ErrReturnStatusTransition) {
+		t.Fatalf("ReceiveReturn(requested) error = %v, want %v", err, domain.ErrReturnStatusTransition)
+	}
+	if _, err := useCase.ApproveReturn(ctx, ret.ID(), "사진 확인"); err != nil {
+		t.Fatalf("ApproveReturn() error = %v", err)
+	}
+	if _, err := useCase.ReceiveReturn(ctx, ret.ID(), []ReturnItemRequest{{ItemID: "item-1", Quantity: 2}}); err != nil {
+		t.Fatalf("ReceiveReturn() error = %v", err)
+	}
+
+	// 검수에서 1개만 인정되면 그 수량만 주문에 반영하고 환불합니다
+	ret, err = useCase.InspectReturn(ctx, ret.ID(), InspectReturnRequest{Items: []ReturnItemRequest{{ItemID: "item-1", Quantity: 1}}, Note: "1개 사용 흔적"})
+	if err != nil {
+		t.Fatalf("InspectReturn() error = %v", err)
+	}
+	if ret.Status() != domain.StatusRefunded || ret.RefundAmount() != 1000 || ret.RefundReference() != "refund-1" {
+		t.Errorf("Status() = %v, RefundAmount() = %v, RefundReference() = %v, want refunded, 1000, refund-1",
+			ret.Status(), ret.RefundAmount(), ret.RefundReference())
+	}
+	if orders.recorded["item-1"] != 1 {
+		t.Errorf("주문에 반영된 반품 수량 = %d, want 1", orders.recorded["item-1"])
+	}
+	if len(refunds.refunds) != 1 || refunds.refunds[0] != 1000 {
+		t.Errorf("refunds = %v, want [1000]", refunds.refunds)
+	}
+}
+
+func TestRequestReturnValidatesOrder(t *testing.T) {
+	orders := NewFakeOrderReturns()
+	newDeliveredOrder(orders)
+	orders.orders["order-2"] = &ReturnableOrder{ID: "order-2", CustomerID: "cust-1", Lines: []ReturnableLine{{ItemID: "item-3", Quantity: 1}}}
+	useCase := NewReturnUseCase(NewFakeReturnRepository(), orders, &FakeRefundIssuer{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		req  CreateReturnRequest
+		want error
+	}{
+		{"배송 완료 전", CreateReturnRequest{OrderID: "order-2", CustomerID: "cust-1", Reason: domain.ReasonChangedMind, Items: []ReturnItemRequest{{ItemID: "item-3", Quantity: 1}}}, ErrOrderNotReturnable},
+		{"다른 고객", CreateReturnRequest{OrderID: "order-1", CustomerID: "cust-2", Reason: domain.ReasonChangedMind, Items: []ReturnItemRequest{{ItemID: "item-1", Quantity: 1}}}, ErrNotOrderCustomer},
+		{"주문에 없는 항목", CreateReturnRequest{OrderID: "order-1", CustomerID: "cust-1", Reason: domain.ReasonChangedMind, Items: []ReturnItemRequest{{ItemID: "item-9", Quantity: 1}}}, ErrItemNotInOrder},
+		{"사유 없음", CreateReturnRequest{OrderID: "order-1", CustomerID: "cust-1", Items: []ReturnItemRequest{{ItemID: "item-1", Quantity: 1}}}, domain.ErrInvalidReason},
+		{"주문 수량 초과", CreateReturnRequest{OrderID: "order-1", CustomerID: "cust-1", Reason: domain.ReasonChangedMind, Items: []ReturnItemRequest{{ItemID: "item-1", Quantity: 3}}}, ErrExceedsReturnableQuantity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := useCase.RequestReturn(ctx, tt.req); !errors.Is(err, tt.want) {
+				t.Errorf("RequestReturn() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// 진행 중인 반품의 수량도 반품 가능 수량에서 제외되며, 거절되면 다시 요청할 수 있습니다
+	first, err := useCase.RequestReturn(ctx, CreateReturnRequest{OrderID: "order-1", CustomerID: "cust-1", Reason: domain.ReasonWrongItem, Items: []ReturnItemRequest{{ItemID: "item-1", Quantity: 2}}})
+	if err != nil {
+		t.Fatalf("RequestReturn(first) error = %v", err)
+	}
+	again := CreateReturnRequest{OrderID: "order-1", CustomerID: "cust-1", Reason: domain.ReasonWrongItem, Items: []ReturnItemRequest{{ItemID: "item-1", Quantity: 1}}}
+	if _, err := useCase.RequestReturn(ctx, again); !errors.Is(err, ErrExceedsReturnableQuantity) {
+		t.Fatalf("RequestReturn(open) error = %v, want %v", err, ErrExceedsReturnableQuantity)
+	}
+	if _, err := useCase.RejectReturn(ctx, first.ID(), "오배송 아님"); err != nil {
+		t.Fatalf("RejectReturn() error = %v", err)
+	}
+	if _, err := useCase.RequestReturn(ctx, again); err != nil {
+		t.Errorf("RequestReturn(after reject) error = %v", err)
+	}
+}
+
+func TestInspectReturnKeepsResultWhenRefundFails(t *testing.T) {
+	orders := NewFakeOrderReturns()
+	newDeliveredOrder(orders)
+	refunds := &FakeRefundIssuer{refundErr: ErrRefundUnavailable}
+	repo := NewFakeReturnRepository()
+	useCase := NewReturnUseCase(repo, orders, refunds)
+	ctx := context.Background()
+
+	receive := func(items ...ReturnItemRequest) *domain.Return {
+		t.Helper()
+		ret, err := useCase.RequestReturn(ctx, CreateReturnRequest{OrderID: "order-1", CustomerID: "cust-1", Reason: domain.ReasonNotAsDescribed, Items: items})
+		if err != nil {
+			t.Fatalf("RequestReturn() error = %v", err)
+		}
+		if _, err := useCase.ApproveReturn(ctx, ret.ID(), ""); err != nil {
+			t.Fatalf("ApproveReturn() error = %v", err)
+		}
+		if _, err := useCase.ReceiveReturn(ctx, ret.ID(), items); err != nil {
+			t.Fatalf("ReceiveReturn() error = %v", err)
+		}
+		return ret
+	}
+
+	ret := receive(ReturnItemRequest{ItemID: "item-2", Quantity: 1})
+	if _, err := useCase.InspectReturn(ctx, ret.ID(), InspectReturnRequest{Items: []ReturnItemRequest{{ItemID: "item-2", Quantity: 1}}}); !errors.Is(err, ErrRefundUnavailable) {
+		t.Fatalf("InspectReturn() error = %v, want %v", err, ErrRefundUnavailable)
+	}
+	saved, _ := repo.FindByID(ctx, ret.ID())
+	if saved.Status() != domain.StatusInspected || saved.RefundAmount() != 200 {
+		t.Fatalf("Status() = %v, RefundAmount() = %v, want inspected, 200", saved.Status(), saved.RefundAmount())
+	}
+
+	// 환불을 다시 시도해도 주문에는 한 번만 반영됩니다
+	refunds.refundErr = nil
+	ret, err := useCase.RefundReturn(ctx, ret.ID())
+	if err != nil {
+		t.Fatalf("RefundReturn() error = %v", err)
+	}
+	if ret.Status() != domain.StatusRefunded || orders.recorded["item-2"] != 1 {
+		t.Errorf("Status() = %v, recorded = %d, want refunded, 1", ret.Status(), orders.recorded["item-2"])
+	}
+
+	// 인정된 수량이 없으면 주문과 결제에 반영하지 않고 종료합니다
+	ret = receive(ReturnItemRequest{ItemID: "item-1", Quantity: 1})
+	ret, err = useCase.InspectReturn(ctx, ret.ID(), InspectReturnRequest{Note: "파손된 상태로 입고"})
+	if err != nil {
+		t.Fatalf("InspectReturn(none) error = %v", err)
+	}
+	if ret.Status() != domain.StatusClosed || orders.recorded["item-1"] != 0 || len(refunds.refunds) != 1 {
+		t.Errorf("Status() = %v, recorded = %d, refunds = %v, want closed, 0, one refund", ret.Status(), orders.recorded["item-1"], refunds.refunds)
+	}
+}
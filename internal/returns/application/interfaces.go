@@ -0,0 +1,97 @@
+package application
+
+import (
+	"context"
+
+	"example.com/myapp/returns/domain"
+)
+
+// ReturnRepository는 반품 관련 영속성 인터페이스를 정의합니다.
+type ReturnRepository interface {
+	Save(ctx context.Context, ret *domain.Return) error
+	FindByID(ctx context.Context, id string) (*domain.Return, error)
+	// FindByOrderID는 주문의 반품 목록을 요청된 순서대로 조회합니다.
+	FindByOrderID(ctx context.Context, orderID string) ([]*domain.Return, error)
+	Update(ctx context.Context, ret *domain.Return) error
+}
+
+// OrderReturns는 반품 대상 주문을 조회하고 검수 결과를 주문에 반영하는 주문 포트를 정의합니다.
+type OrderReturns interface {
+	FindOrder(ctx context.Context, orderID string) (*ReturnableOrder, error)
+	// RecordReturn은 주문 항목 ID별 반품 수량을 주문에 기록하고 환불할 금액을 반환합니다.
+	RecordReturn(ctx context.Context, orderID string, quantities map[string]int, reason string) (float64, error)
+}
+
+// ReturnableOrder는 반품 요청 검증에 필요한 주문 정보를 정의합니다.
+type ReturnableOrder struct {
+	ID         string
+	CustomerID string
+	Returnable bool // 배송 완료되어 반품할 수 있는 상태인지 여부
+	Lines      []ReturnableLine
+}
+
+// ReturnableLine은 주문 항목별로 아직 반품되지 않은 수량을 정의합니다.
+type ReturnableLine struct {
+	ItemID   string
+	SKUID    string
+	Quantity int
+}
+
+// RefundIssuer는 반품 환불을 처리하는 결제 포트를 정의합니다.
+// 같은 주문에 대해 다시 호출되어도 이미 처리된 환불을 중복 처리하지 않아야 합니다.
+type RefundIssuer interface {
+	// Refund는 주문의 결제에서 amount만큼 환불하고 환불 참조 번호를 반환합니다.
+	Refund(ctx context.Context, orderID string, amount float64, reason string) (string, error)
+}
+
+// ReturnService는 반품 관련 비즈니스 로직을 정의합니다.
+type ReturnService interface {
+	RequestReturn(ctx context.Context, req CreateReturnRequest) (*domain.Return, error)
+	GetReturn(ctx context.Context, id string) (*domain.Return, error)
+	GetOrderReturns(ctx context.Context, orderID string) ([]*domain.Return, error)
+	ApproveReturn(ctx context.Context, id, note string) (*domain.Return, error)
+	RejectReturn(ctx context.Context, id, note string) (*domain.Return, error)
+	// ReceiveReturn은 승인된 반품의 입고 수량을 기록합니다.
+	ReceiveReturn(ctx context.Context, id string, items []ReturnItemRequest) (*domain.Return, error)
+	// InspectReturn은 검수에서 인정된 수량을 주문에 반영하고 그 금액을 환불합니다.
+	InspectReturn(ctx context.Context, id string, req InspectReturnRequest) (*domain.Return, error)
+	// RefundReturn은 검수 후 실패한 환불을 다시 시도합니다.
+	RefundReturn(ctx context.Context, id string) (*domain.Return, error)
+}
+
+// CreateReturnRequest는 고객의 반품 요청 정보를 정의합니다.
+type CreateReturnRequest struct {
+	OrderID    string
+	CustomerID string
+	Reason     domain.ReturnReason
+	Comment    string
+	Items      []ReturnItemRequest
+}
+
+// ReturnItemRequest는 주문 항목 ID와 수량을 정의합니다.
+type ReturnItemRequest struct {
+	ItemID   string
+	Quantity int
+}
+
+// InspectReturnRequest는 검수 결과를 정의합니다. Items의 수량은 반품이 인정된 수량입니다.
+type InspectReturnRequest struct {
+	Items []ReturnItemRequest
+	Note  string
+}
+
+// ReturnUseCase는 ReturnService 구현체를 정의합니다.
+type ReturnUseCase struct {
+	repo    ReturnRepository
+	orders  OrderReturns
+	refunds RefundIssuer
+}
+
+// NewReturnUseCase는 새로운 ReturnUseCase 인스턴스를 생성합니다.
+func NewReturnUseCase(repo ReturnRepository, orders OrderReturns, refunds RefundIssuer) *ReturnUseCase {
+	return &ReturnUseCase{
+		repo:    repo,
+		orders:  orders,
+		refunds: refunds,
+	}
+}
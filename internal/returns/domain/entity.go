@@ -0,0 +1,380 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReturnStatus는 반품 요청 상태를 정의합니다.
+type ReturnStatus string
+
+const (
+	StatusRequested ReturnStatus = "requested" // 고객이 반품을 요청함
+	StatusApproved  ReturnStatus = "approved"  // 상담원이 승인하여 상품 회수를 기다림
+	StatusRejected  ReturnStatus = "rejected"  // 상담원이 거절함
+	StatusReceived  ReturnStatus = "received"  // 반품 상품이 입고됨
+	StatusInspected ReturnStatus = "inspected" // 검수가 끝나 환불을 기다림
+	StatusRefunded  ReturnStatus = "refunded"  // 인정된 금액이 환불됨
+	StatusClosed    ReturnStatus = "closed"    // 검수에서 인정된 수량이 없어 환불 없이 종료됨
+)
+
+// ReturnReason은 고객이 선택한 반품 사유를 정의합니다.
+type ReturnReason string
+
+const (
+	ReasonDefective      ReturnReason = "defective"
+	ReasonWrongItem      ReturnReason = "wrong_item"
+	ReasonNotAsDescribed ReturnReason = "not_as_described"
+	ReasonChangedMind    ReturnReason = "changed_mind"
+	ReasonOther          ReturnReason = "other"
+)
+
+var (
+	ErrInvalidOrderID         = errors.New("invalid order ID")
+	ErrInvalidCustomerID      = errors.New("invalid customer ID")
+	ErrInvalidReason          = errors.New("invalid return reason")
+	ErrInvalidReturnLines     = errors.New("return must have at least one line with an item and a positive quantity")
+	ErrReturnLineNotFound     = errors.New("item is not part of this return")
+	ErrInvalidReceivedAmount  = errors.New("received quantity must not be negative or exceed the requested quantity")
+	ErrInvalidAcceptedAmount  = errors.New("accepted quantity must not be negative or exceed the received quantity")
+	ErrReturnStatusTransition = errors.New("invalid return status transition")
+	ErrReturnNotFound         = errors.New("return not found")
+)
+
+// IsValid는 반품 사유가 정의된 값인지 확인합니다.
+func (r ReturnReason) IsValid() bool {
+	switch r {
+	case ReasonDefective, ReasonWrongItem, ReasonNotAsDescribed, ReasonChangedMind, ReasonOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReturnLine은 반품할 주문 항목 하나와 입고·검수 수량을 나타냅니다.
+type ReturnLine struct {
+	itemID           string
+	skuID            string
+	quantity         int
+	receivedQuantity int
+	acceptedQuantity int
+}
+
+// NewReturnLine은 새로운 반품 라인을 생성합니다.
+func NewReturnLine(itemID, skuID string, quantity int) (*ReturnLine, error) {
+	if itemID == "" || quantity <= 0 {
+		return nil, ErrInvalidReturnLines
+	}
+	return &ReturnLine{itemID: itemID, skuID: skuID, quantity: quantity}, nil
+}
+
+// RestoreReturnLine은 저장된 데이터로부터 반품 라인을 복원합니다.
+func RestoreReturnLine(itemID, skuID string, quantity, receivedQuantity, acceptedQuantity int) *ReturnLine {
+	return &ReturnLine{
+		itemID:           itemID,
+		skuID:            skuID,
+		quantity:         quantity,
+		receivedQuantity: receivedQuantity,
+		acceptedQuantity: acceptedQuantity,
+	}
+}
+
+// ItemID는 반품할 주문 항목 ID를 반환합니다.
+func (l *ReturnLine) ItemID() string {
+	return l.itemID
+}
+
+// SKUID는 반품할 SKU ID를 반환합니다.
+func (l *ReturnLine) SKUID() string {
+	return l.skuID
+}
+
+// Quantity는 고객이 요청한 반품 수량을 반환합니다.
+func (l *ReturnLine) Quantity() int {
+	return l.quantity
+}
+
+// ReceivedQuantity는 입고된 수량을 반환합니다.
+func (l *ReturnLine) ReceivedQuantity() int {
+	return l.receivedQuantity
+}
+
+// AcceptedQuantity는 검수에서 반품이 인정된 수량을 반환합니다.
+func (l *ReturnLine) AcceptedQuantity() int {
+	return l.acceptedQuantity
+}
+
+// Return은 배송 완료된 주문의 반품 요청(RMA) 엔티티를 나타냅니다.
+// 요청 → 승인 → 입고 → 검수 → 환불 순서로 진행되며, 승인 전에 거절될 수 있습니다.
+type Return struct {
+	id              string
+	orderID         string
+	customerID      string
+	reason          ReturnReason
+	comment         string
+	status          ReturnStatus
+	lines           []*ReturnLine
+	note            string
+	refundAmount    float64
+	refundReference string
+	createdAt       time.Time
+	updatedAt       time.Time
+}
+
+// NewReturn은 새로운 반품 요청을 생성합니다. 같은 주문 항목의 라인은 하나로 합칩니다.
+func NewReturn(orderID, customerID string, reason ReturnReason, comment string, lines []*ReturnLine) (*Return, error) {
+	if orderID == "" {
+		return nil, ErrInvalidOrderID
+	}
+	if customerID == "" {
+		return nil, ErrInvalidCustomerID
+	}
+	if !reason.IsValid() {
+		return nil, ErrInvalidReason
+	}
+	if len(lines) == 0 {
+		return nil, ErrInvalidReturnLines
+	}
+
+	merged := make([]*ReturnLine, 0, len(lines))
+	byItem := make(map[string]*ReturnLine, len(lines))
+	for _, line := range lines {
+		if existing, ok := byItem[line.itemID]; ok {
+			existing.quantity += line.quantity
+			continue
+		}
+		copied := *line
+		byItem[line.itemID] = &copied
+		merged = append(merged, &copied)
+	}
+
+	now := time.Now()
+	return &Return{
+		id:         uuid.New().String(),
+		orderID:    orderID,
+		customerID: customerID,
+		reason:     reason,
+		comment:    strings.TrimSpace(comment),
+		status:     StatusRequested,
+		lines:      merged,
+		createdAt:  now,
+		updatedAt:  now,
+	}, nil
+}
+
+// RestoreReturn은 저장된 데이터로부터 반품 요청을 복원합니다.
+func RestoreReturn(
+	id, orderID, customerID string,
+	reason ReturnReason,
+	comment string,
+	status ReturnStatus,
+	lines []*ReturnLine,
+	note string,
+	refundAmount float64,
+	refundReference string,
+	createdAt, updatedAt time.Time,
+) *Return {
+	return &Return{
+		id:              id,
+		orderID:         orderID,
+		customerID:      customerID,
+		reason:          reason,
+		comment:         comment,
+		status:          status,
+		lines:           lines,
+		note:            note,
+		refundAmount:    refundAmount,
+		refundReference: refundReference,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
+	}
+}
+
+// ID는 반품 요청의 고유 식별자(RMA 번호)를 반환합니다.
+func (r *Return) ID() string {
+	return r.id
+}
+
+// OrderID는 주문 ID를 반환합니다.
+func (r *Return) OrderID() string {
+	return r.orderID
+}
+
+// CustomerID는 반품을 요청한 고객 ID를 반환합니다.
+func (r *Return) CustomerID() string {
+	return r.customerID
+}
+
+// Reason은 반품 사유를 반환합니다.
+func (r *Return) Reason() ReturnReason {
+	return r.reason
+}
+
+// Comment는 고객이 남긴 반품 설명을 반환합니다.
+func (r *Return) Comment() string {
+	return r.comment
+}
+
+// Status는 반품 상태를 반환합니다.
+func (r *Return) Status() ReturnStatus {
+	return r.status
+}
+
+// Lines는 반품 라인 목록을 반환합니다.
+func (r *Return) Lines() []*ReturnLine {
+	return r.lines
+}
+
+// Note는 승인·거절 또는 검수 시 상담원이 남긴 메모를 반환합니다.
+func (r *Return) Note() string {
+	return r.note
+}
+
+// RefundAmount는 검수 결과로 환불할 금액을 반환합니다.
+func (r *Return) RefundAmount() float64 {
+	return r.refundAmount
+}
+
+// RefundReference는 결제 모듈이 발급한 환불 참조 번호를 반환합니다.
+func (r *Return) RefundReference() string {
+	return r.refundReference
+}
+
+// CreatedAt은 반품이 요청된 시간을 반환합니다.
+func (r *Return) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// UpdatedAt은 반품 정보가 마지막으로 업데이트된 시간을 반환합니다.
+func (r *Return) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// IsOpen은 반품이 아직 진행 중이어서 요청 수량을 차지하고 있는지 확인합니다.
+func (r *Return) IsOpen() bool {
+	switch r.status {
+	case StatusRequested, StatusApproved, StatusReceived:
+		return true
+	default:
+		return false
+	}
+}
+
+// Approve는 반품 요청을 승인합니다.
+func (r *Return) Approve(note string) error {
+	return r.review(StatusApproved, note)
+}
+
+// Reject는 반품 요청을 거절합니다.
+func (r *Return) Reject(note string) error {
+	return r.review(StatusRejected, note)
+}
+
+func (r *Return) review(status ReturnStatus, note string) error {
+	if r.status != StatusRequested {
+		return ErrReturnStatusTransition
+	}
+	r.status = status
+	r.note = strings.TrimSpace(note)
+	r.updatedAt = time.Now()
+	return nil
+}
+
+// Receive는 승인된 반품의 입고 수량을 기록합니다.
+// quantities는 주문 항목 ID별 입고 수량이며, 없는 항목은 입고되지 않은 것으로 봅니다.
+func (r *Return) Receive(quantities map[string]int) error {
+	if r.status != StatusApproved {
+		return ErrReturnStatusTransition
+	}
+	if err := r.checkQuantities(quantities, ErrInvalidReceivedAmount, (*ReturnLine).Quantity); err != nil {
+		return err
+	}
+
+	for _, line := range r.lines {
+		line.receivedQuantity = quantities[line.itemID]
+	}
+	r.status = StatusReceived
+	r.updatedAt = time.Now()
+	return nil
+}
+
+// Inspect는 입고된 상품의 검수 결과로 반품이 인정된 수량을 기록합니다.
+// 인정된 수량이 있으면 환불을 기다리는 상태가 되고, 없으면 환불 없이 종료됩니다.
+func (r *Return) Inspect(accepted map[string]int, note string) error {
+	if r.status != StatusReceived {
+		return ErrReturnStatusTransition
+	}
+	if err := r.checkQuantities(accepted, ErrInvalidAcceptedAmount, (*ReturnLine).ReceivedQuantity); err != nil {
+		return err
+	}
+
+	for _, line := range r.lines {
+		line.acceptedQuantity = accepted[line.itemID]
+	}
+	r.note = strings.TrimSpace(note)
+	r.status = StatusInspected
+	if len(r.AcceptedQuantities()) == 0 {
+		r.status = StatusClosed
+	}
+	r.updatedAt = time.Now()
+	return nil
+}
+
+// AcceptedQuantities는 검수에서 인정된 수량이 있는 주문 항목 ID별 수량을 반환합니다.
+func (r *Return) AcceptedQuantities() map[string]int {
+	quantities := make(map[string]int, len(r.lines))
+	for _, line := range r.lines {
+		if line.acceptedQuantity > 0 {
+			quantities[line.itemID] = line.acceptedQuantity
+		}
+	}
+	return quantities
+}
+
+// AssignRefundAmount는 검수가 끝난 반품에 환불할 금액을 기록합니다.
+func (r *Return) AssignRefundAmount(amount float64) error {
+	if r.status != StatusInspected {
+		return ErrReturnStatusTransition
+	}
+	r.refundAmount = amount
+	r.updatedAt = time.Now()
+	return nil
+}
+
+// MarkRefunded는 환불이 끝났음을 기록합니다.
+func (r *Return) MarkRefunded(reference string) error {
+	if r.status != StatusInspected {
+		return ErrReturnStatusTransition
+	}
+	r.refundReference = reference
+	r.status = StatusRefunded
+	r.updatedAt = time.Now()
+	return nil
+}
+
+// checkQuantities는 항목 ID별 수량이 모두 이 반품의 라인이고 0 이상 limit 이하인지 확인합니다.
+func (r *Return) checkQuantities(quantities map[string]int, invalid error, limit func(*ReturnLine) int) error {
+	for itemID, quantity := range quantities {
+		line := r.findLine(itemID)
+		if line == nil {
+			return ErrReturnLineNotFound
+		}
+		if quantity < 0 || quantity > limit(line) {
+			return invalid
+		}
+	}
+	return nil
+}
+
+// findLine은 주문 항목 ID로 반품 라인을 찾습니다.
+func (r *Return) findLine(itemID string) *ReturnLine {
+	for _, line := range r.lines {
+		if line.itemID == itemID {
+			return line
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package infrastructure
+
+import (
+	"context"
+
+	orderApp "example.com/myapp/order/application"
+	orderDomain "example.com/myapp/order/domain"
+	"example.com/myapp/returns/application"
+)
+
+// returnsActor는 반품 검수로 바뀐 주문 상태 이력에 기록되는 행위자입니다.
+const returnsActor = "returns"
+
+// OrderReturnsAdapter는 주문 모듈의 공개 API로 OrderReturns 포트를 구현합니다.
+type OrderReturnsAdapter struct {
+	orders orderApp.OrderService
+}
+
+// NewOrderReturnsAdapter는 새로운 OrderReturnsAdapter 인스턴스를 생성합니다.
+func NewOrderReturnsAdapter(orders orderApp.OrderService) application.OrderReturns {
+	return &OrderReturnsAdapter{
+		orders: orders,
+	}
+}
+
+// FindOrder는 주문의 반품 가능 여부와 항목별 아직 반품되지 않은 수량을 조회합니다.
+func (a *OrderReturnsAdapter) FindOrder(ctx context.Context, orderID string) (*application.ReturnableOrder, error) {
+	order, err := a.orders.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]application.ReturnableLine, 0, len(order.Items()))
+	for _, item := range order.Items() {
+		lines = append(lines, application.ReturnableLine{
+			ItemID:   item.ID(),
+			SKUID:    item.SKUID(),
+			Quantity: item.Quantity() - item.ReturnedQuantity(),
+		})
+	}
+
+	status := order.Status()
+	return &application.ReturnableOrder{
+		ID:         order.ID(),
+		CustomerID: order.CustomerID(),
+		Returnable: status == orderDomain.StatusDelivered || status == orderDomain.StatusPartiallyReturned,
+		Lines:      lines,
+	}, nil
+}
+
+// RecordReturn은 검수에서 인정된 수량을 주문에 반품으로 기록하고 환불할 금액을 반환합니다.
+func (a *OrderReturnsAdapter) RecordReturn(ctx context.Context, orderID string, quantities map[string]int, reason string) (float64, error) {
+	items := make([]orderApp.ReturnItemRequest, 0, len(quantities))
+	for itemID, quantity := range quantities {
+		items = append(items, orderApp.ReturnItemRequest{ItemID: itemID, Quantity: quantity})
+	}
+
+	result, err := a.orders.ReturnOrderItems(ctx, orderID, orderApp.ReturnItemsRequest{
+		Items:  items,
+		Actor:  returnsActor,
+		Reason: reason,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.RefundAmount, nil
+}
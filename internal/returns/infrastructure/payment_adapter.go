@@ -0,0 +1,43 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	paymentApp "example.com/myapp/payment/application"
+	paymentDomain "example.com/myapp/payment/domain"
+	"example.com/myapp/returns/application"
+)
+
+// PaymentRefundAdapter는 결제 모듈의 공개 API로 RefundIssuer 포트를 구현합니다.
+type PaymentRefundAdapter struct {
+	payments paymentApp.PaymentService
+}
+
+// NewPaymentRefundAdapter는 새로운 PaymentRefundAdapter 인스턴스를 생성합니다.
+func NewPaymentRefundAdapter(payments paymentApp.PaymentService) application.RefundIssuer {
+	return &PaymentRefundAdapter{
+		payments: payments,
+	}
+}
+
+// Refund는 주문의 결제들에서 반품 금액을 환불하고 게이트웨이 환불 ID를 환불 참조 번호로 반환합니다.
+// 여러 결제에 나누어 환불하면 환불 ID를 쉼표로 이어 붙입니다.
+// 환불 사유에 반품 ID가 들어 있으므로 같은 반품으로 다시 호출되면 이미 환불된 금액은 다시 환불하지 않습니다.
+func (a *PaymentRefundAdapter) Refund(ctx context.Context, orderID string, amount float64, reason string) (string, error) {
+	refunds, err := a.payments.RefundOrder(ctx, orderID, amount, reason)
+	if err != nil {
+		if errors.Is(err, paymentDomain.ErrRefundExceedsPayment) {
+			return "", fmt.Errorf("%w: %v", application.ErrRefundUnavailable, err)
+		}
+		return "", err
+	}
+
+	references := make([]string, len(refunds))
+	for i, refund := range refunds {
+		references[i] = refund.GatewayRefundID()
+	}
+	return strings.Join(references, ","), nil
+}
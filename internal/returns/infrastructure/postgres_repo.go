@@ -0,0 +1,254 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"example.com/myapp/returns/application"
+	"example.com/myapp/returns/domain"
+	"example.com/myapp/shared/db"
+	"github.com/jackc/pgx/v4"
+)
+
+// PostgresReturnRepository는 PostgreSQL을 사용하는 반품 저장소 구현체입니다.
+type PostgresReturnRepository struct {
+	db *db.Database
+}
+
+// NewPostgresReturnRepository는 새로운 PostgresReturnRepository 인스턴스를 생성합니다.
+func NewPostgresReturnRepository(database *db.Database) application.ReturnRepository {
+	return &PostgresReturnRepository{
+		db: database,
+	}
+}
+
+// Save는 반품과 반품 라인을 하나의 트랜잭션으로 저장합니다.
+func (r *PostgresReturnRepository) Save(ctx context.Context, ret *domain.Return) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	query := `
+		INSERT INTO returns (
+			id, order_id, customer_id, reason, comment, status, note, refund_amount, refund_reference, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err = tx.Exec(
+		ctx,
+		query,
+		ret.ID(),
+		ret.OrderID(),
+		ret.CustomerID(),
+		string(ret.Reason()),
+		ret.Comment(),
+		string(ret.Status()),
+		ret.Note(),
+		ret.RefundAmount(),
+		ret.RefundReference(),
+		ret.CreatedAt(),
+		ret.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save return: %w", err)
+	}
+
+	if err := upsertLines(ctx, tx, ret); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID는 ID로 반품을 조회합니다.
+func (r *PostgresReturnRepository) FindByID(ctx context.Context, id string) (*domain.Return, error) {
+	query := `
+		SELECT id, order_id, customer_id, reason, comment, status, note, refund_amount, refund_reference, created_at, updated_at
+		FROM returns
+		WHERE id = $1
+	`
+
+	row := r.db.Pool.QueryRow(ctx, query, id)
+
+	var returnID, orderID, customerID, reason, comment, status, note, refundReference string
+	var refundAmount float64
+	var createdAt, updatedAt time.Time
+
+	err := row.Scan(&returnID, &orderID, &customerID, &reason, &comment, &status, &note, &refundAmount, &refundReference, &createdAt, &updatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrReturnNotFound
+		}
+		return nil, fmt.Errorf("failed to find return: %w", err)
+	}
+
+	lines, err := r.findLines(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.RestoreReturn(
+		returnID, orderID, customerID,
+		domain.ReturnReason(reason),
+		comment,
+		domain.ReturnStatus(status),
+		lines,
+		note,
+		refundAmount,
+		refundReference,
+		createdAt, updatedAt,
+	), nil
+}
+
+// FindByOrderID는 주문의 반품 목록을 요청된 순서대로 조회합니다.
+func (r *PostgresReturnRepository) FindByOrderID(ctx context.Context, orderID string) ([]*domain.Return, error) {
+	query := `
+		SELECT id
+		FROM returns
+		WHERE order_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query returns: %w", err)
+	}
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan return: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating returns: %w", err)
+	}
+
+	returns := make([]*domain.Return, 0, len(ids))
+	for _, id := range ids {
+		ret, err := r.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		returns = append(returns, ret)
+	}
+
+	return returns, nil
+}
+
+// Update는 반품 상태와 라인별 입고·검수 수량을 하나의 트랜잭션으로 업데이트합니다.
+func (r *PostgresReturnRepository) Update(ctx context.Context, ret *domain.Return) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	query := `
+		UPDATE returns
+		SET status = $1, note = $2, refund_amount = $3, refund_reference = $4, updated_at = $5
+		WHERE id = $6
+	`
+
+	result, err := tx.Exec(
+		ctx,
+		query,
+		string(ret.Status()),
+		ret.Note(),
+		ret.RefundAmount(),
+		ret.RefundReference(),
+		ret.UpdatedAt(),
+		ret.ID(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update return: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrReturnNotFound
+	}
+
+	if err := upsertLines(ctx, tx, ret); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresReturnRepository) findLines(ctx context.Context, returnID string) ([]*domain.ReturnLine, error) {
+	query := `
+		SELECT item_id, sku_id, quantity, received_quantity, accepted_quantity
+		FROM return_lines
+		WHERE return_id = $1
+		ORDER BY line_no
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, returnID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query return lines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := []*domain.ReturnLine{}
+	for rows.Next() {
+		var itemID, skuID string
+		var quantity, received, accepted int
+
+		if err := rows.Scan(&itemID, &skuID, &quantity, &received, &accepted); err != nil {
+			return nil, fmt.Errorf("failed to scan return line: %w", err)
+		}
+		lines = append(lines, domain.RestoreReturnLine(itemID, skuID, quantity, received, accepted))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating return lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// upsertLines는 반품 라인을 저장하고, 이미 있으면 입고·검수 수량을 갱신합니다.
+func upsertLines(ctx context.Context, tx pgx.Tx, ret *domain.Return) error {
+	query := `
+		INSERT INTO return_lines (return_id, line_no, item_id, sku_id, quantity, received_quantity, accepted_quantity)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (return_id, item_id) DO UPDATE
+		SET received_quantity = EXCLUDED.received_quantity,
+			accepted_quantity = EXCLUDED.accepted_quantity
+	`
+
+	for i, line := range ret.Lines() {
+		_, err := tx.Exec(
+			ctx,
+			query,
+			ret.ID(),
+			i+1,
+			line.ItemID(),
+			line.SKUID(),
+			line.Quantity(),
+			line.ReceivedQuantity(),
+			line.AcceptedQuantity(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save return line: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,395 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"example.com/myapp/saga/domain"
+)
+
+var (
+	// ErrOrderNotPayable은 주문이 취소되어 결제 완료로 바꿀 수 없을 때 Orders 포트가 반환합니다.
+	// 다시 시도해도 성공할 수 없으므로 사가는 재시도하지 않고 바로 보상 단계를 시작합니다.
+	ErrOrderNotPayable = errors.New("order can no longer be marked as paid")
+	// ErrOrderNotCancelable은 결제 금액이 빈 주문이 이미 출고되어 취소할 수 없을 때 Orders 포트가 반환합니다.
+	// 다시 시도해도 성공할 수 없으므로 사가는 실패로 끝나 수동 처리를 기다립니다.
+	ErrOrderNotCancelable = errors.New("order can no longer be canceled")
+	ErrInvalidSagaStatus  = errors.New("invalid saga status")
+)
+
+const (
+	// sagaClaimLease는 사가를 진행하는 동안 다른 인스턴스가 같은 사가를 선점하지 못하는 시간입니다.
+	sagaClaimLease = time.Minute
+	// sagaResumeBatchSize는 한 번에 이어서 진행하는 사가의 최대 수입니다.
+	sagaResumeBatchSize = 100
+	// sagaMaxStepAttempts는 단계를 실패로 기록하기 전까지 실행하는 최대 횟수입니다.
+	sagaMaxStepAttempts = 10
+	// 실패한 단계는 sagaRetryBaseDelay 뒤에 다시 실행하며, 재시도마다 두 배로 늘어나 sagaRetryMaxDelay를 넘지 않습니다.
+	sagaRetryBaseDelay = time.Minute
+	sagaRetryMaxDelay  = time.Hour
+
+	defaultSagaListLimit = 50
+	maxSagaListLimit     = 200
+)
+
+// OnPaymentSettled는 결제가 주문 금액을 모두 채웠으면 주문을 결제 완료로 바꾸는 사가를 시작합니다.
+func (uc *SagaUseCase) OnPaymentSettled(ctx context.Context, orderID string) (*domain.Saga, error) {
+	if orderID == "" {
+		return nil, domain.ErrInvalidOrderID
+	}
+	saga, _, err := uc.paymentSettled(ctx, orderID)
+	return saga, err
+}
+
+// OnOrderCanceled는 취소된 주문에 환불하거나 취소할 결제가 있으면 결제를 돌려주는 사가를 시작합니다.
+func (uc *SagaUseCase) OnOrderCanceled(ctx context.Context, orderID, reason string) (*domain.Saga, error) {
+	if orderID == "" {
+		return nil, domain.ErrInvalidOrderID
+	}
+	saga, _, err := uc.orderCanceled(ctx, orderID, reason)
+	return saga, err
+}
+
+// OnAuthorizationVoided는 가승인이 취소되어 결제 완료된 주문의 결제 금액이 비었으면 주문을 취소하는 사가를 시작합니다.
+// 결제 대기 주문은 다른 결제를 기다리거나 만료 처리로 취소되므로 사가를 시작하지 않습니다.
+func (uc *SagaUseCase) OnAuthorizationVoided(ctx context.Context, orderID, reason string) (*domain.Saga, error) {
+	if orderID == "" {
+		return nil, domain.ErrInvalidOrderID
+	}
+
+	order, err := uc.orders.FindOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Canceled || !order.Paid {
+		return nil, nil
+	}
+
+	payments, err := uc.payments.Summary(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if payments.AmountDue <= 0.005 {
+		return nil, nil
+	}
+
+	saga, _, err := uc.start(ctx, domain.KindAuthorizationVoided, orderID, reason)
+	return saga, err
+}
+
+// OnOrderItemsCanceled는 항목 일부 취소로 주문 금액이 줄어 청구 금액이 남으면 넘는 금액을 환불하는 사가를 시작합니다.
+// 부분 취소가 여러 번 일어날 수 있으므로 끝난 사가는 다시 열어 진행합니다.
+func (uc *SagaUseCase) OnOrderItemsCanceled(ctx context.Context, orderID, reason string) (*domain.Saga, error) {
+	if orderID == "" {
+		return nil, domain.ErrInvalidOrderID
+	}
+
+	payments, err := uc.payments.Summary(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if payments.Charged-payments.OrderTotal <= 0.005 {
+		return nil, nil
+	}
+
+	saga, _, err := uc.start(ctx, domain.KindItemCancellation, orderID, reason)
+	return saga, err
+}
+
+// OnOrderShipped는 출고된 주문에 가승인이 남아 있으면 매입하는 사가를 시작합니다.
+func (uc *SagaUseCase) OnOrderShipped(ctx context.Context, orderID string) (*domain.Saga, error) {
+	if orderID == "" {
+		return nil, domain.ErrInvalidOrderID
+	}
+
+	payments, err := uc.payments.Summary(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if payments.Authorized <= 0.005 {
+		return nil, nil
+	}
+
+	saga, _, err := uc.start(ctx, domain.KindOrderShipment, orderID, "order shipped")
+	return saga, err
+}
+
+// ResumeSagas는 다시 실행할 시간이 된 사가를 선점하여 이어서 진행하고 진행한 사가 수를 반환합니다.
+func (uc *SagaUseCase) ResumeSagas(ctx context.Context, now time.Time) (int, error) {
+	sagas, err := uc.repo.ClaimDue(ctx, now, sagaClaimLease, sagaResumeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	resumed := 0
+	for _, saga := range sagas {
+		if err := uc.advance(ctx, saga); err != nil {
+			return resumed, err
+		}
+		resumed++
+	}
+	return resumed, nil
+}
+
+// SweepOrders는 훅이 실패하거나 프로세스가 중단되어 시작되지 못한 사가를 찾아 시작합니다.
+// 결제가 주문 금액을 모두 채웠는데 아직 결제 대기인 주문과, 취소되었는데 돌려주지 않은 결제가 남은 주문이 대상입니다.
+func (uc *SagaUseCase) SweepOrders(ctx context.Context, since time.Time) (int, error) {
+	started := 0
+
+	pending, err := uc.orders.PendingOrderIDs(ctx, since)
+	if err != nil {
+		return 0, err
+	}
+	for _, orderID := range pending {
+		_, ok, err := uc.paymentSettled(ctx, orderID)
+		if err != nil {
+			return started, fmt.Errorf("order %s: %w", orderID, err)
+		}
+		if ok {
+			started++
+		}
+	}
+
+	canceled, err := uc.orders.CanceledOrderIDs(ctx, since)
+	if err != nil {
+		return started, err
+	}
+	for _, orderID := range canceled {
+		_, ok, err := uc.orderCanceled(ctx, orderID, "order canceled")
+		if err != nil {
+			return started, fmt.Errorf("order %s: %w", orderID, err)
+		}
+		if ok {
+			started++
+		}
+	}
+
+	return started, nil
+}
+
+// RetrySaga는 재시도 한도를 넘어 실패한 사가를 다시 열어 진행합니다.
+func (uc *SagaUseCase) RetrySaga(ctx context.Context, id string) (*domain.Saga, error) {
+	saga, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if saga.Status() == domain.StatusCompleted || saga.Status() == domain.StatusCompensated {
+		return nil, domain.ErrSagaFinished
+	}
+	if err := saga.Reopen(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Update(ctx, saga); err != nil {
+		return nil, err
+	}
+	return uc.run(ctx, saga)
+}
+
+// GetSaga는 사가를 단계 기록과 함께 조회합니다.
+func (uc *SagaUseCase) GetSaga(ctx context.Context, id string) (*domain.Saga, error) {
+	if id == "" {
+		return nil, domain.ErrSagaNotFound
+	}
+	return uc.repo.FindByID(ctx, id)
+}
+
+// GetOrderSagas는 주문의 사가 목록을 조회합니다.
+func (uc *SagaUseCase) GetOrderSagas(ctx context.Context, orderID string) ([]*domain.Saga, error) {
+	if orderID == "" {
+		return nil, domain.ErrInvalidOrderID
+	}
+	return uc.repo.FindByOrderID(ctx, orderID)
+}
+
+// ListSagas는 status 상태의 사가를 최근 수정 순서로 조회합니다. 실패한 사가를 확인하는 데 사용합니다.
+func (uc *SagaUseCase) ListSagas(ctx context.Context, status domain.SagaStatus, limit int) ([]*domain.Saga, error) {
+	switch status {
+	case domain.StatusRunning, domain.StatusCompleted, domain.StatusCompensating, domain.StatusCompensated, domain.StatusFailed:
+	default:
+		return nil, ErrInvalidSagaStatus
+	}
+	if limit <= 0 {
+		limit = defaultSagaListLimit
+	}
+	if limit > maxSagaListLimit {
+		limit = maxSagaListLimit
+	}
+	return uc.repo.FindByStatus(ctx, status, limit)
+}
+
+// paymentSettled는 주문 결제 사가를 시작해야 하는지 확인하고 시작합니다. 새로 시작하면 true를 반환합니다.
+func (uc *SagaUseCase) paymentSettled(ctx context.Context, orderID string) (*domain.Saga, bool, error) {
+	order, err := uc.orders.FindOrder(ctx, orderID)
+	if err != nil {
+		return nil, false, err
+	}
+	// 취소된 주문에 뒤늦게 승인된 결제는 주문을 결제 완료로 바꾸지 않고 돌려줍니다
+	if order.Canceled {
+		return uc.orderCanceled(ctx, orderID, "payment settled after order cancellation")
+	}
+
+	payments, err := uc.payments.Summary(ctx, orderID)
+	if err != nil {
+		return nil, false, err
+	}
+	if payments.AmountDue > 0.005 || payments.Charged+payments.Authorized <= 0.005 {
+		return nil, false, nil
+	}
+	return uc.start(ctx, domain.KindOrderPayment, orderID, "order amount fully paid")
+}
+
+// orderCanceled는 주문 취소 사가를 시작해야 하는지 확인하고 시작합니다. 새로 시작하거나 다시 열면 true를 반환합니다.
+func (uc *SagaUseCase) orderCanceled(ctx context.Context, orderID, reason string) (*domain.Saga, bool, error) {
+	payments, err := uc.payments.Summary(ctx, orderID)
+	if err != nil {
+		return nil, false, err
+	}
+	if payments.Charged+payments.Authorized <= 0.005 {
+		return nil, false, nil
+	}
+	return uc.start(ctx, domain.KindOrderCancellation, orderID, reason)
+}
+
+// start는 주문의 kind 사가를 저장하고 진행합니다.
+// 이미 있는 사가는 새로 만들지 않고, 끝난 주문 취소 사가와 항목 취소 사가는
+// 돌려줄 결제가 다시 생긴 것이므로 다시 열어 진행합니다.
+func (uc *SagaUseCase) start(ctx context.Context, kind domain.SagaKind, orderID, reason string) (*domain.Saga, bool, error) {
+	saga, err := domain.NewSaga(kind, orderID, reason)
+	if err != nil {
+		return nil, false, err
+	}
+
+	created, err := uc.repo.Save(ctx, saga)
+	if err != nil {
+		return nil, false, err
+	}
+	if !created {
+		saga, err = uc.repo.FindByOrderAndKind(ctx, orderID, kind)
+		if err != nil {
+			return nil, false, err
+		}
+		reopenable := kind == domain.KindOrderCancellation || kind == domain.KindItemCancellation
+		if !reopenable || saga.Status() != domain.StatusCompleted {
+			saga, err = uc.run(ctx, saga)
+			return saga, false, err
+		}
+		if err := saga.Reopen(time.Now()); err != nil {
+			return nil, false, err
+		}
+		if err := uc.repo.Update(ctx, saga); err != nil {
+			return nil, false, err
+		}
+	}
+
+	saga, err = uc.run(ctx, saga)
+	return saga, true, err
+}
+
+// run은 사가를 선점하여 남은 단계를 진행합니다. 끝났거나 다른 인스턴스가 진행 중이면 그대로 반환합니다.
+func (uc *SagaUseCase) run(ctx context.Context, saga *domain.Saga) (*domain.Saga, error) {
+	if saga.IsFinished() {
+		return saga, nil
+	}
+	claimed, err := uc.repo.Claim(ctx, saga.ID(), time.Now(), sagaClaimLease)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return saga, nil
+	}
+	if err := uc.advance(ctx, saga); err != nil {
+		return nil, err
+	}
+	return saga, nil
+}
+
+// advance는 선점한 사가의 단계를 사가가 끝나거나 재시도가 예약될 때까지 차례로 실행하고, 단계마다 결과를 저장합니다.
+// 되돌릴 수 없이 실패했거나 재시도 한도를 넘은 정방향 단계는 보상 단계로 이어집니다.
+func (uc *SagaUseCase) advance(ctx context.Context, saga *domain.Saga) error {
+	for {
+		step := saga.CurrentStep()
+		if step == nil {
+			return nil
+		}
+
+		stepErr := uc.execute(ctx, saga, step)
+		now := time.Now()
+		retrying := false
+		switch {
+		case stepErr == nil:
+			if err := saga.CompleteStep(now); err != nil {
+				return err
+			}
+		case errors.Is(stepErr, ErrOrderNotPayable), errors.Is(stepErr, ErrOrderNotCancelable), step.Attempts()+1 >= sagaMaxStepAttempts:
+			if err := saga.FailStep(stepErr.Error(), now); err != nil {
+				return err
+			}
+		default:
+			retrying = true
+			if err := saga.RetryStep(stepErr.Error(), now.Add(retryDelay(step.Attempts()+1)), now); err != nil {
+				return err
+			}
+		}
+
+		if err := uc.repo.Update(ctx, saga); err != nil {
+			return err
+		}
+		if retrying {
+			return nil
+		}
+	}
+}
+
+// execute는 단계 하나를 주문 포트나 결제 포트로 실행합니다. 모든 단계는 다시 실행되어도 안전해야 합니다.
+func (uc *SagaUseCase) execute(ctx context.Context, saga *domain.Saga, step *domain.Step) error {
+	switch step.Name() {
+	case domain.StepMarkOrderPaid:
+		return uc.orders.MarkPaid(ctx, saga.OrderID(), "payment completed")
+	case domain.StepCancelUnpaidOrder:
+		// 사가를 시작한 뒤 결제 금액이 다시 채워졌으면 주문을 취소하지 않습니다
+		payments, err := uc.payments.Summary(ctx, saga.OrderID())
+		if err != nil {
+			return err
+		}
+		if payments.AmountDue <= 0.005 {
+			return nil
+		}
+		return uc.orders.CancelUnpaidOrder(ctx, saga.OrderID(), saga.Reason())
+	case domain.StepReleasePayments:
+		// 가승인 취소 사가는 주문을 취소했을 때만 남은 결제를 돌려줍니다
+		if saga.Kind() == domain.KindAuthorizationVoided {
+			order, err := uc.orders.FindOrder(ctx, saga.OrderID())
+			if err != nil {
+				return err
+			}
+			if !order.Canceled {
+				return nil
+			}
+		}
+		reason := saga.Reason()
+		if step.IsCompensation() {
+			reason = "order could not be marked as paid: " + saga.LastError()
+		}
+		return uc.payments.ReleaseOrderPayments(ctx, saga.OrderID(), reason)
+	case domain.StepRefundExcessPayments:
+		return uc.payments.RefundExcessPayments(ctx, saga.OrderID(), saga.Reason())
+	case domain.StepCapturePayments:
+		return uc.payments.CaptureOrderPayments(ctx, saga.OrderID(), saga.Reason())
+	default:
+		return fmt.Errorf("unknown saga step: %s", step.Name())
+	}
+}
+
+// retryDelay는 attempt번째 실패 뒤 다시 실행하기까지 기다릴 시간을 계산합니다.
+func retryDelay(attempt int) time.Duration {
+	delay := sagaRetryBaseDelay
+	for i := 1; i < attempt && delay < sagaRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > sagaRetryMaxDelay {
+		delay = sagaRetryMaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,511 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"example.com/myapp/saga/domain"
+)
+
+// FakeSagaRepository는 테스트를 위한 가짜 SagaRepository 구현체입니다.
+type FakeSagaRepository struct {
+	sagas        map[string]*domain.Saga
+	order        []string
+	claimedUntil map[string]time.Time
+}
+
+// NewFakeSagaRepository는 새로운 FakeSagaRepository 인스턴스를 생성합니다.
+func NewFakeSagaRepository() *FakeSagaRepository {
+	return &FakeSagaRepository{
+		sagas:        make(map[string]*domain.Saga),
+		claimedUntil: make(map[string]time.Time),
+	}
+}
+
+func (f *FakeSagaRepository) Save(ctx context.Context, saga *domain.Saga) (bool, error) {
+	if _, err := f.FindByOrderAndKind(ctx, saga.OrderID(), saga.Kind()); err == nil {
+		return false, nil
+	}
+	f.sagas[saga.ID()] = saga
+	f.order = append(f.order, saga.ID())
+	return true, nil
+}
+
+func (f *FakeSagaRepository) FindByID(ctx context.Context, id string) (*domain.Saga, error) {
+	saga, ok := f.sagas[id]
+	if !ok {
+		return nil, domain.ErrSagaNotFound
+	}
+	return saga, nil
+}
+
+func (f *FakeSagaRepository) FindByOrderAndKind(ctx context.Context, orderID string, kind domain.SagaKind) (*domain.Saga, error) {
+	for _, id := range f.order {
+		if saga := f.sagas[id]; saga.OrderID() == orderID && saga.Kind() == kind {
+			return saga, nil
+		}
+	}
+	return nil, domain.ErrSagaNotFound
+}
+
+func (f *FakeSagaRepository) FindByOrderID(ctx context.Context, orderID string) ([]*domain.Saga, error) {
+	sagas := []*domain.Saga{}
+	for _, id := range f.order {
+		if saga := f.sagas[id]; saga.OrderID() == orderID {
+			sagas = append(sagas, saga)
+		}
+	}
+	return sagas, nil
+}
+
+func (f *FakeSagaRepository) FindByStatus(ctx context.Context, status domain.SagaStatus, limit int) ([]*domain.Saga, error) {
+	sagas := []*domain.Saga{}
+	for _, id := range f.order {
+		if saga := f.sagas[id]; saga.Status() == status && len(sagas) < limit {
+			sagas = append(sagas, saga)
+		}
+	}
+	return sagas, nil
+}
+
+func (f *FakeSagaRepository) Update(ctx context.Context, saga *domain.Saga) error {
+	if _, ok := f.sagas[saga.ID()]; !ok {
+		return domain.ErrSagaNotFound
+	}
+	f.sagas[saga.ID()] = saga
+	if saga.IsFinished() {
+		delete(f.claimedUntil, saga.ID())
+	}
+	return nil
+}
+
+func (f *FakeSagaRepository) Claim(ctx context.Context, id string, now time.Time, lease time.Duration) (bool, error) {
+	saga, ok := f.sagas[id]
+	if !ok || saga.IsFinished() || f.claimedUntil[id].After(now) {
+		return false, nil
+	}
+	f.claimedUntil[id] = now.Add(lease)
+	return true, nil
+}
+
+func (f *FakeSagaRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.Saga, error) {
+	sagas := []*domain.Saga{}
+	for _, id := range f.order {
+		saga := f.sagas[id]
+		if saga.IsFinished() || saga.NextAttemptAt().After(now) || f.claimedUntil[id].After(now) || len(sagas) >= limit {
+			continue
+		}
+		f.claimedUntil[id] = now.Add(lease)
+		sagas = append(sagas, saga)
+	}
+	return sagas, nil
+}
+
+// FakeOrders는 테스트를 위한 가짜 Orders 구현체입니다.
+type FakeOrders struct {
+	canceled   map[string]bool
+	paid       map[string]int
+	markErr    error
+	cancelErr  error
+	pendingIDs []string
+}
+
+// NewFakeOrders는 새로운 FakeOrders 인스턴스를 생성합니다.
+func NewFakeOrders() *FakeOrders {
+	return &FakeOrders{
+		canceled: make(map[string]bool),
+		paid:     make(map[string]int),
+	}
+}
+
+func (f *FakeOrders) FindOrder(ctx context.Context, orderID string) (*SagaOrder, error) {
+	return &SagaOrder{ID: orderID, Canceled: f.canceled[orderID], Paid: f.paid[orderID] > 0 && !f.canceled[orderID]}, nil
+}
+
+func (f *FakeOrders) MarkPaid(ctx context.Context, orderID, reason string) error {
+	if f.markErr != nil {
+		return f.markErr
+	}
+	f.paid[orderID]++
+	return nil
+}
+
+func (f *FakeOrders) CancelUnpaidOrder(ctx context.Context, orderID, reason string) error {
+	if f.cancelErr != nil {
+		return f.cancelErr
+	}
+	f.canceled[orderID] = true
+	return nil
+}
+
+func (f *FakeOrders) PendingOrderIDs(ctx context.Context, createdSince time.Time) ([]string, error) {
+	return f.pendingIDs, nil
+}
+
+func (f *FakeOrders) CanceledOrderIDs(ctx context.Context, updatedSince time.Time) ([]string, error) {
+	ids := []string{}
+	for orderID, canceled := range f.canceled {
+		if canceled {
+			ids = append(ids, orderID)
+		}
+	}
+	return ids, nil
+}
+
+// FakePayments는 테스트를 위한 가짜 Payments 구현체입니다.
+// ReleaseOrderPayments는 releaseErrs를 차례로 반환하고, 성공하면 청구와 가승인 금액을 0으로 만듭니다.
+// RefundExcessPayments는 청구 금액 중 주문 금액을 넘는 금액을 refunds에 기록하고 청구 금액에서 뺍니다.
+// CaptureOrderPayments는 청구되지 않은 주문 금액만큼 가승인을 매입하여 captures에 기록하고 남은 가승인을 없앱니다.
+type FakePayments struct {
+	summaries   map[string]*OrderPayments
+	releases    map[string][]string
+	releaseErrs []error
+	refunds     map[string][]float64
+	captures    map[string][]float64
+}
+
+// NewFakePayments는 새로운 FakePayments 인스턴스를 생성합니다.
+func NewFakePayments() *FakePayments {
+	return &FakePayments{
+		summaries: make(map[string]*OrderPayments),
+		releases:  make(map[string][]string),
+		refunds:   make(map[string][]float64),
+		captures:  make(map[string][]float64),
+	}
+}
+
+func (f *FakePayments) Summary(ctx context.Context, orderID string) (*OrderPayments, error) {
+	summary, ok := f.summaries[orderID]
+	if !ok {
+		return &OrderPayments{}, nil
+	}
+	return summary, nil
+}
+
+func (f *FakePayments) ReleaseOrderPayments(ctx context.Context, orderID, reason string) error {
+	if len(f.releaseErrs) > 0 {
+		err := f.releaseErrs[0]
+		f.releaseErrs = f.releaseErrs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	f.releases[orderID] = append(f.releases[orderID], reason)
+	if summary, ok := f.summaries[orderID]; ok {
+		summary.Charged = 0
+		summary.Authorized = 0
+	}
+	return nil
+}
+
+func (f *FakePayments) RefundExcessPayments(ctx context.Context, orderID, reason string) error {
+	summary, ok := f.summaries[orderID]
+	if !ok || summary.Charged-summary.OrderTotal <= 0.005 {
+		return nil
+	}
+	f.refunds[orderID] = append(f.refunds[orderID], summary.Charged-summary.OrderTotal)
+	summary.Charged = summary.OrderTotal
+	return nil
+}
+
+func (f *FakePayments) CaptureOrderPayments(ctx context.Context, orderID, reason string) error {
+	summary, ok := f.summaries[orderID]
+	if !ok || summary.Authorized <= 0.005 {
+		return nil
+	}
+	amount := math.Min(summary.OrderTotal-summary.Charged, summary.Authorized)
+	f.captures[orderID] = append(f.captures[orderID], amount)
+	summary.Charged += amount
+	summary.Authorized = 0
+	return nil
+}
+
+func newTestSagaUseCase() (*SagaUseCase, *FakeSagaRepository, *FakeOrders, *FakePayments) {
+	repo := NewFakeSagaRepository()
+	orders := NewFakeOrders()
+	payments := NewFakePayments()
+	return NewSagaUseCase(repo, orders, payments), repo, orders, payments
+}
+
+func TestPaymentSettledMarksOrderPaidOnce(t *testing.T) {
+	uc, repo, orders, payments := newTestSagaUseCase()
+	ctx := context.Background()
+
+	// 주문 금액이 남아 있으면 사가를 시작하지 않습니다
+	payments.summaries["order-1"] = &OrderPayments{AmountDue: 40, Charged: 60}
+	saga, err := uc.OnPaymentSettled(ctx, "order-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if saga != nil || len(repo.sagas) != 0 {
+		t.Fatalf("Expected no saga while an amount is due, got %d", len(repo.sagas))
+	}
+
+	payments.summaries["order-1"] = &OrderPayments{Charged: 60, Authorized: 40}
+	saga, err = uc.OnPaymentSettled(ctx, "order-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if saga.Kind() != domain.KindOrderPayment || saga.Status() != domain.StatusCompleted {
+		t.Errorf("Expected completed order_payment saga, got %s %s", saga.Kind(), saga.Status())
+	}
+	if orders.paid["order-1"] != 1 {
+		t.Errorf("Expected order to be marked paid once, got %d", orders.paid["order-1"])
+	}
+
+	// 같은 결제 알림이 다시 와도 사가를 새로 만들거나 다시 진행하지 않습니다
+	again, err := uc.OnPaymentSettled(ctx, "order-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if again.ID() != saga.ID() || len(repo.sagas) != 1 {
+		t.Errorf("Expected the existing saga to be returned, got %d sagas", len(repo.sagas))
+	}
+	if orders.paid["order-1"] != 1 {
+		t.Errorf("Expected order to stay marked paid once, got %d", orders.paid["order-1"])
+	}
+}
+
+func TestOrderPaymentSagaCompensatesWhenOrderCannotBePaid(t *testing.T) {
+	uc, _, orders, payments := newTestSagaUseCase()
+	ctx := context.Background()
+
+	// 결제가 승인되는 사이에 주문이 취소되어 결제 완료로 바꿀 수 없는 경우
+	orders.markErr = ErrOrderNotPayable
+	payments.summaries["order-1"] = &OrderPayments{Charged: 100}
+
+	saga, err := uc.OnPaymentSettled(ctx, "order-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if saga.Status() != domain.StatusCompensated {
+		t.Fatalf("Expected compensated saga, got %s", saga.Status())
+	}
+	if len(payments.releases["order-1"]) != 1 {
+		t.Fatalf("Expected payments to be released once, got %d", len(payments.releases["order-1"]))
+	}
+
+	steps := saga.Steps()
+	if steps[0].Status() != domain.StepFailed || steps[0].Attempts() != 1 {
+		t.Errorf("Expected mark_order_paid to fail without retries, got %s after %d attempts", steps[0].Status(), steps[0].Attempts())
+	}
+	if !steps[1].IsCompensation() || steps[1].Status() != domain.StepSucceeded {
+		t.Errorf("Expected release_payments compensation to succeed, got %s", steps[1].Status())
+	}
+	if saga.LastError() != ErrOrderNotPayable.Error() {
+		t.Errorf("Expected last error %q, got %q", ErrOrderNotPayable.Error(), saga.LastError())
+	}
+}
+
+func TestOrderCancellationSagaRetriesAndResumes(t *testing.T) {
+	uc, _, orders, payments := newTestSagaUseCase()
+	ctx := context.Background()
+
+	// 돌려줄 결제가 없으면 사가를 시작하지 않습니다
+	saga, err := uc.OnOrderCanceled(ctx, "order-0", "customer request")
+	if err != nil || saga != nil {
+		t.Fatalf("Expected no saga without payments, got %v, %v", saga, err)
+	}
+
+	orders.canceled["order-1"] = true
+	payments.summaries["order-1"] = &OrderPayments{Charged: 100}
+	payments.releaseErrs = []error{errors.New("gateway unavailable")}
+
+	saga, err = uc.OnOrderCanceled(ctx, "order-1", "customer request")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if saga.Status() != domain.StatusRunning || saga.Steps()[0].Attempts() != 1 {
+		t.Fatalf("Expected running saga with one failed attempt, got %s", saga.Status())
+	}
+	if saga.Steps()[0].LastError() != "gateway unavailable" {
+		t.Errorf("Expected step error to be recorded, got %q", saga.Steps()[0].LastError())
+	}
+
+	// 재시도 시간이 되기 전에는 다시 실행하지 않습니다
+	resumed, err := uc.ResumeSagas(ctx, time.Now())
+	if err != nil || resumed != 0 {
+		t.Fatalf("Expected nothing to resume yet, got %d, %v", resumed, err)
+	}
+
+	resumed, err = uc.ResumeSagas(ctx, saga.NextAttemptAt().Add(sagaClaimLease))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resumed != 1 || saga.Status() != domain.StatusCompleted {
+		t.Fatalf("Expected saga to complete on resume, got %d resumed, %s", resumed, saga.Status())
+	}
+	if reasons := payments.releases["order-1"]; len(reasons) != 1 || reasons[0] != "customer request" {
+		t.Errorf("Expected payments to be released with the cancel reason, got %v", reasons)
+	}
+
+	// 취소 후에 뒤늦게 승인된 결제는 완료된 사가를 다시 열어 돌려줍니다
+	payments.summaries["order-1"].Authorized = 30
+	reopened, err := uc.OnPaymentSettled(ctx, "order-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if reopened.ID() != saga.ID() || reopened.Status() != domain.StatusCompleted {
+		t.Errorf("Expected the cancellation saga to be reopened and completed, got %s", reopened.Status())
+	}
+	if len(payments.releases["order-1"]) != 2 {
+		t.Errorf("Expected late payment to be released, got %d releases", len(payments.releases["order-1"]))
+	}
+	if orders.paid["order-1"] != 0 {
+		t.Error("Expected canceled order not to be marked paid")
+	}
+}
+
+func TestSweepOrdersStartsMissedSagas(t *testing.T) {
+	uc, repo, orders, payments := newTestSagaUseCase()
+	ctx := context.Background()
+
+	orders.pendingIDs = []string{"order-paid", "order-due"}
+	payments.summaries["order-paid"] = &OrderPayments{Charged: 50}
+	payments.summaries["order-due"] = &OrderPayments{AmountDue: 50}
+	orders.canceled["order-canceled"] = true
+	payments.summaries["order-canceled"] = &OrderPayments{Authorized: 20}
+
+	started, err := uc.SweepOrders(ctx, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if started != 2 {
+		t.Errorf("Expected 2 sagas to be started, got %d", started)
+	}
+	if orders.paid["order-paid"] != 1 || len(payments.releases["order-canceled"]) != 1 {
+		t.Errorf("Expected paid order to be marked and canceled order to be released")
+	}
+
+	// 다시 점검해도 이미 끝난 사가는 시작하지 않습니다
+	started, err = uc.SweepOrders(ctx, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if started != 0 || len(repo.sagas) != 2 {
+		t.Errorf("Expected no new sagas, got %d started and %d total", started, len(repo.sagas))
+	}
+}
+
+func TestAuthorizationVoidedCancelsPaidOrder(t *testing.T) {
+	uc, repo, orders, payments := newTestSagaUseCase()
+	ctx := context.Background()
+
+	// 결제 대기 주문은 다른 결제를 기다리므로 사가를 시작하지 않습니다
+	payments.summaries["order-0"] = &OrderPayments{AmountDue: 100}
+	saga, err := uc.OnAuthorizationVoided(ctx, "order-0", "authorization expired")
+	if err != nil || saga != nil {
+		t.Fatalf("Expected no saga for a pending order, got %v, %v", saga, err)
+	}
+
+	// 가승인이 취소되어 결제 금액이 빈 결제 완료 주문은 취소하고 남은 결제를 돌려줍니다
+	orders.paid["order-1"] = 1
+	payments.summaries["order-1"] = &OrderPayments{AmountDue: 80, Charged: 20}
+	saga, err = uc.OnAuthorizationVoided(ctx, "order-1", "authorization expired")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if saga.Kind() != domain.KindAuthorizationVoided || saga.Status() != domain.StatusCompleted {
+		t.Fatalf("Expected completed authorization_voided saga, got %s %s", saga.Kind(), saga.Status())
+	}
+	if !orders.canceled["order-1"] {
+		t.Error("Expected unpaid order to be canceled")
+	}
+	if reasons := payments.releases["order-1"]; len(reasons) != 1 || reasons[0] != "authorization expired" {
+		t.Errorf("Expected remaining payments to be released, got %v", reasons)
+	}
+
+	// 출고되어 취소할 수 없는 주문은 재시도하지 않고 실패로 남겨 수동 처리합니다
+	orders.paid["order-2"] = 1
+	orders.cancelErr = ErrOrderNotCancelable
+	payments.summaries["order-2"] = &OrderPayments{AmountDue: 100}
+	saga, err = uc.OnAuthorizationVoided(ctx, "order-2", "authorization expired")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if saga.Status() != domain.StatusFailed || saga.Steps()[0].Attempts() != 1 {
+		t.Errorf("Expected failed saga after one attempt, got %s", saga.Status())
+	}
+	if len(payments.releases["order-2"]) != 0 {
+		t.Error("Expected payments of a shipped order not to be released")
+	}
+	if len(repo.sagas) != 2 {
+		t.Errorf("Expected 2 sagas, got %d", len(repo.sagas))
+	}
+}
+
+func TestItemCancellationRefundsExcessPayment(t *testing.T) {
+	uc, repo, _, payments := newTestSagaUseCase()
+	ctx := context.Background()
+
+	// 청구 금액이 주문 금액을 넘지 않으면 사가를 시작하지 않습니다
+	payments.summaries["order-1"] = &OrderPayments{Charged: 100, OrderTotal: 100}
+	saga, err := uc.OnOrderItemsCanceled(ctx, "order-1", "customer request")
+	if err != nil || saga != nil {
+		t.Fatalf("Expected no saga without excess payment, got %v, %v", saga, err)
+	}
+
+	// 항목 일부 취소로 주문 금액이 줄면 넘는 금액을 환불합니다
+	payments.summaries["order-1"].OrderTotal = 70
+	saga, err = uc.OnOrderItemsCanceled(ctx, "order-1", "customer request")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if saga.Kind() != domain.KindItemCancellation || saga.Status() != domain.StatusCompleted {
+		t.Fatalf("Expected completed item_cancellation saga, got %s %s", saga.Kind(), saga.Status())
+	}
+
+	// 다시 부분 취소하면 끝난 사가를 다시 열어 줄어든 만큼만 환불합니다
+	payments.summaries["order-1"].OrderTotal = 50
+	saga, err = uc.OnOrderItemsCanceled(ctx, "order-1", "customer request")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if saga.Status() != domain.StatusCompleted {
+		t.Errorf("Expected reopened saga to complete, got %s", saga.Status())
+	}
+
+	refunds := payments.refunds["order-1"]
+	if len(refunds) != 2 || refunds[0] != 30 || refunds[1] != 20 {
+		t.Errorf("Expected refunds of 30 and 20, got %v", refunds)
+	}
+	if len(repo.sagas) != 1 {
+		t.Errorf("Expected 1 saga, got %d", len(repo.sagas))
+	}
+}
+
+func TestOrderShipmentCapturesRemainingAmount(t *testing.T) {
+	uc, _, _, payments := newTestSagaUseCase()
+	ctx := context.Background()
+
+	// 가승인이 없으면 매입할 것이 없습니다
+	payments.summaries["order-1"] = &OrderPayments{Charged: 100, OrderTotal: 100}
+	saga, err := uc.OnOrderShipped(ctx, "order-1")
+	if err != nil || saga != nil {
+		t.Fatalf("Expected no saga without authorizations, got %v, %v", saga, err)
+	}
+
+	// 부분 취소로 줄어든 주문 금액만큼만 매입합니다
+	payments.summaries["order-2"] = &OrderPayments{Authorized: 100, OrderTotal: 70}
+	saga, err = uc.OnOrderShipped(ctx, "order-2")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if saga.Kind() != domain.KindOrderShipment || saga.Status() != domain.StatusCompleted {
+		t.Fatalf("Expected completed order_shipment saga, got %s %s", saga.Kind(), saga.Status())
+	}
+	if captures := payments.captures["order-2"]; len(captures) != 1 || captures[0] != 70 {
+		t.Errorf("Expected a capture of 70, got %v", captures)
+	}
+
+	// 다시 호출되어도 다시 매입하지 않습니다
+	if _, err := uc.OnOrderShipped(ctx, "order-2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(payments.captures["order-2"]) != 1 {
+		t.Errorf("Expected a single capture, got %v", payments.captures["order-2"])
+	}
+}
@@ -0,0 +1,125 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"example.com/myapp/saga/domain"
+)
+
+// SagaRepository는 사가 관련 영속성 인터페이스를 정의합니다.
+type SagaRepository interface {
+	// Save는 새 사가를 단계와 함께 저장합니다. 같은 주문에 같은 종류의 사가가 이미 있으면 저장하지 않고 false를 반환합니다.
+	Save(ctx context.Context, saga *domain.Saga) (bool, error)
+	FindByID(ctx context.Context, id string) (*domain.Saga, error)
+	// FindByOrderAndKind는 주문의 kind 종류 사가를 조회합니다. 없으면 domain.ErrSagaNotFound를 반환합니다.
+	FindByOrderAndKind(ctx context.Context, orderID string, kind domain.SagaKind) (*domain.Saga, error)
+	// FindByOrderID는 주문의 사가 목록을 생성 순서대로 조회합니다.
+	FindByOrderID(ctx context.Context, orderID string) ([]*domain.Saga, error)
+	// FindByStatus는 status 상태의 사가를 최근 수정 순서로 최대 limit건 조회합니다.
+	FindByStatus(ctx context.Context, status domain.SagaStatus, limit int) ([]*domain.Saga, error)
+	// Update는 사가 상태와 단계 기록을 저장합니다.
+	Update(ctx context.Context, saga *domain.Saga) error
+	// Claim은 끝나지 않은 사가를 lease 동안 선점합니다. 끝났거나 다른 인스턴스가 선점 중이면 false를 반환합니다.
+	Claim(ctx context.Context, id string, now time.Time, lease time.Duration) (bool, error)
+	// ClaimDue는 다시 실행할 시간이 된 끝나지 않은 사가를 최대 limit건 선점하고 반환합니다.
+	// 선점한 사가는 lease 동안 다른 인스턴스가 선점하지 않습니다.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.Saga, error)
+}
+
+// Orders는 사가가 주문 모듈에 요청하는 작업을 정의하는 주문 포트입니다.
+type Orders interface {
+	FindOrder(ctx context.Context, orderID string) (*SagaOrder, error)
+	// MarkPaid는 결제 대기 주문을 결제 완료로 바꿉니다. 이미 결제 완료 이후 상태면 아무것도 하지 않습니다.
+	// 주문이 취소되어 결제 완료로 바꿀 수 없으면 ErrOrderNotPayable을 반환해야 합니다.
+	MarkPaid(ctx context.Context, orderID, reason string) error
+	// CancelUnpaidOrder는 결제 금액이 빈 결제 완료 주문을 취소합니다. 이미 취소된 주문이면 아무것도 하지 않습니다.
+	// 출고된 뒤라 취소할 수 없으면 ErrOrderNotCancelable을 반환해야 합니다.
+	CancelUnpaidOrder(ctx context.Context, orderID, reason string) error
+	// PendingOrderIDs는 createdSince 이후에 생성되어 아직 결제되지 않은 주문의 ID 목록을 조회합니다.
+	PendingOrderIDs(ctx context.Context, createdSince time.Time) ([]string, error)
+	// CanceledOrderIDs는 updatedSince 이후에 취소된 주문의 ID 목록을 조회합니다.
+	CanceledOrderIDs(ctx context.Context, updatedSince time.Time) ([]string, error)
+}
+
+// SagaOrder는 사가 진행에 필요한 주문 정보를 정의합니다.
+// Paid는 주문이 결제 완료 이후 상태(결제 완료, 출고, 배송 완료 등)인지 나타냅니다.
+type SagaOrder struct {
+	ID       string
+	Canceled bool
+	Paid     bool
+}
+
+// Payments는 사가가 결제 모듈에 요청하는 작업을 정의하는 결제 포트입니다.
+type Payments interface {
+	// Summary는 주문의 결제 현황을 조회합니다.
+	Summary(ctx context.Context, orderID string) (*OrderPayments, error)
+	// ReleaseOrderPayments는 주문 결제의 환불되지 않은 청구 금액을 모두 환불하고 가승인을 취소합니다.
+	// 이미 환불되거나 취소된 결제는 건너뛰므로 다시 호출되어도 중복 환불하지 않아야 합니다.
+	// 아직 처리 중인 결제가 있으면 나중에 다시 시도하도록 오류를 반환해야 합니다.
+	ReleaseOrderPayments(ctx context.Context, orderID, reason string) error
+	// RefundExcessPayments는 환불되지 않은 청구 금액 중 주문 금액을 넘는 부분을 환불합니다.
+	// 남은 청구 금액을 보고 환불할 금액을 정하므로 다시 호출되어도 중복 환불하지 않아야 합니다.
+	// 아직 처리 중인 결제가 있으면 나중에 다시 시도하도록 오류를 반환해야 합니다.
+	RefundExcessPayments(ctx context.Context, orderID, reason string) error
+	// CaptureOrderPayments는 주문의 가승인을 청구되지 않은 주문 금액만큼 매입하고 남은 가승인은 취소합니다.
+	// 이미 매입된 결제는 건너뛰고 중단된 매입은 같은 시도로 이어서 처리하므로 다시 호출되어도 중복 매입하지 않아야 합니다.
+	CaptureOrderPayments(ctx context.Context, orderID, reason string) error
+}
+
+// OrderPayments는 사가 진행에 필요한 주문의 결제 현황을 정의합니다.
+// AmountDue는 아직 결제(가승인)되지 않은 주문 금액, Charged는 청구된 금액 중 환불되지 않은 금액,
+// Authorized는 가승인만 된 금액, OrderTotal은 현재 주문 금액입니다.
+type OrderPayments struct {
+	AmountDue  float64
+	Charged    float64
+	Authorized float64
+	OrderTotal float64
+}
+
+// SagaService는 결제 모듈과 주문 모듈의 결과를 맞추는 사가(프로세스 매니저)를 정의합니다.
+type SagaService interface {
+	// OnPaymentSettled는 주문의 결제가 승인되거나 가승인된 뒤 호출됩니다.
+	// 결제가 주문 금액을 모두 채웠으면 order_payment 사가를 시작하고, 아직 남았으면 nil을 반환합니다.
+	// 주문이 이미 취소되었으면 받은 결제를 돌려주도록 order_cancellation 사가를 진행합니다.
+	OnPaymentSettled(ctx context.Context, orderID string) (*domain.Saga, error)
+	// OnOrderCanceled는 주문이 취소된 뒤 호출됩니다.
+	// 환불하거나 취소할 결제가 있으면 order_cancellation 사가를 시작하고, 없으면 nil을 반환합니다.
+	OnOrderCanceled(ctx context.Context, orderID, reason string) (*domain.Saga, error)
+	// OnAuthorizationVoided는 주문의 가승인이 매입되지 않고 취소된 뒤 호출됩니다.
+	// 결제 완료된 주문의 결제 금액이 비었으면 authorization_voided 사가를 시작하고, 아니면 nil을 반환합니다.
+	OnAuthorizationVoided(ctx context.Context, orderID, reason string) (*domain.Saga, error)
+	// OnOrderItemsCanceled는 결제 완료된 주문의 항목 일부가 취소된 뒤 호출됩니다.
+	// 청구 금액이 줄어든 주문 금액을 넘으면 item_cancellation 사가를 시작하고, 아니면 nil을 반환합니다.
+	OnOrderItemsCanceled(ctx context.Context, orderID, reason string) (*domain.Saga, error)
+	// OnOrderShipped는 주문이 출고된 뒤 호출됩니다.
+	// 매입할 가승인이 있으면 order_shipment 사가를 시작하고, 없으면 nil을 반환합니다.
+	OnOrderShipped(ctx context.Context, orderID string) (*domain.Saga, error)
+	// ResumeSagas는 실패한 단계를 다시 실행할 시간이 되었거나 진행 도중 중단된 사가를 이어서 진행하고 그 수를 반환합니다.
+	ResumeSagas(ctx context.Context, now time.Time) (int, error)
+	// SweepOrders는 since 이후의 결제 대기 주문과 취소된 주문을 확인하여 놓친 사가를 시작하고 시작한 수를 반환합니다.
+	SweepOrders(ctx context.Context, since time.Time) (int, error)
+	// RetrySaga는 실패로 끝난 사가를 다시 진행합니다.
+	RetrySaga(ctx context.Context, id string) (*domain.Saga, error)
+
+	GetSaga(ctx context.Context, id string) (*domain.Saga, error)
+	GetOrderSagas(ctx context.Context, orderID string) ([]*domain.Saga, error)
+	// ListSagas는 status 상태의 사가를 최근 수정 순서로 최대 limit건 조회합니다.
+	ListSagas(ctx context.Context, status domain.SagaStatus, limit int) ([]*domain.Saga, error)
+}
+
+// SagaUseCase는 SagaService 구현체를 정의합니다.
+type SagaUseCase struct {
+	repo     SagaRepository
+	orders   Orders
+	payments Payments
+}
+
+// NewSagaUseCase는 새로운 SagaUseCase 인스턴스를 생성합니다.
+func NewSagaUseCase(repo SagaRepository, orders Orders, payments Payments) *SagaUseCase {
+	return &SagaUseCase{
+		repo:     repo,
+		orders:   orders,
+		payments: payments,
+	}
+}
@@ -0,0 +1,369 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SagaKind는 사가가 맞추는 모듈 간 결과의 종류를 정의합니다.
+type SagaKind string
+
+const (
+	// KindOrderPayment는 결제가 주문 금액을 채우면 주문을 결제 완료로 바꾸는 사가입니다.
+	// 주문을 결제 완료로 바꿀 수 없으면 받은 결제를 환불하고 가승인을 취소하여 보상합니다.
+	KindOrderPayment SagaKind = "order_payment"
+	// KindOrderCancellation은 취소된 주문의 결제를 환불하고 가승인을 취소하는 사가입니다.
+	KindOrderCancellation SagaKind = "order_cancellation"
+	// KindAuthorizationVoided는 결제 완료된 주문의 가승인이 매입 전에 취소되어 결제 금액이 비면
+	// 주문을 취소하고 남은 결제를 돌려주는 사가입니다.
+	KindAuthorizationVoided SagaKind = "authorization_voided"
+	// KindItemCancellation은 결제 완료된 주문의 항목 일부가 취소되어 청구 금액이 주문 금액을 넘으면
+	// 넘는 금액을 환불하는 사가입니다.
+	KindItemCancellation SagaKind = "item_cancellation"
+	// KindOrderShipment는 출고된 주문의 가승인을 남은 주문 금액만큼 매입하고 쓰지 않은 가승인을 취소하는 사가입니다.
+	KindOrderShipment SagaKind = "order_shipment"
+)
+
+// SagaStatus는 사가의 진행 상태를 정의합니다.
+type SagaStatus string
+
+const (
+	StatusRunning      SagaStatus = "running"      // 정방향 단계를 진행 중
+	StatusCompleted    SagaStatus = "completed"    // 모든 정방향 단계가 끝남
+	StatusCompensating SagaStatus = "compensating" // 정방향 단계가 실패하여 보상 단계를 진행 중
+	StatusCompensated  SagaStatus = "compensated"  // 보상 단계가 끝나 두 모듈이 다시 일치함
+	StatusFailed       SagaStatus = "failed"       // 재시도 한도를 넘어 수동 처리가 필요함
+)
+
+// StepName은 사가 단계의 이름을 정의합니다.
+type StepName string
+
+const (
+	// StepMarkOrderPaid는 주문을 결제 완료로 바꾸는 단계입니다.
+	StepMarkOrderPaid StepName = "mark_order_paid"
+	// StepCancelUnpaidOrder는 결제 금액이 빈 주문을 취소하는 단계입니다.
+	StepCancelUnpaidOrder StepName = "cancel_unpaid_order"
+	// StepReleasePayments는 주문의 결제를 환불하고 가승인을 취소하는 단계입니다.
+	StepReleasePayments StepName = "release_payments"
+	// StepRefundExcessPayments는 청구 금액 중 주문 금액을 넘는 부분을 환불하는 단계입니다.
+	StepRefundExcessPayments StepName = "refund_excess_payments"
+	// StepCapturePayments는 주문의 가승인을 매입하는 단계입니다.
+	StepCapturePayments StepName = "capture_payments"
+)
+
+// StepStatus는 사가 단계의 진행 상태를 정의합니다.
+type StepStatus string
+
+const (
+	StepPending   StepStatus = "pending"
+	StepSucceeded StepStatus = "succeeded"
+	// StepFailed는 재시도 한도를 넘었거나 되돌릴 수 없이 실패한 단계입니다.
+	StepFailed StepStatus = "failed"
+)
+
+var (
+	ErrInvalidOrderID  = errors.New("invalid order ID")
+	ErrInvalidSagaKind = errors.New("invalid saga kind")
+	ErrSagaFinished    = errors.New("saga is already finished")
+	ErrSagaNotFound    = errors.New("saga not found")
+	ErrSagaRunning     = errors.New("saga is still running")
+)
+
+// sagaSteps는 종류별 정방향 단계와 보상 단계입니다.
+var sagaSteps = map[SagaKind]struct {
+	forward      []StepName
+	compensation []StepName
+}{
+	KindOrderPayment:        {forward: []StepName{StepMarkOrderPaid}, compensation: []StepName{StepReleasePayments}},
+	KindOrderCancellation:   {forward: []StepName{StepReleasePayments}},
+	KindAuthorizationVoided: {forward: []StepName{StepCancelUnpaidOrder, StepReleasePayments}},
+	KindItemCancellation:    {forward: []StepName{StepRefundExcessPayments}},
+	KindOrderShipment:       {forward: []StepName{StepCapturePayments}},
+}
+
+// Step은 사가 단계 하나의 진행 기록입니다.
+type Step struct {
+	name         StepName
+	compensation bool
+	status       StepStatus
+	attempts     int
+	lastError    string
+	updatedAt    time.Time
+}
+
+// RestoreStep은 저장된 데이터로부터 사가 단계를 복원합니다.
+func RestoreStep(name StepName, compensation bool, status StepStatus, attempts int, lastError string, updatedAt time.Time) *Step {
+	return &Step{
+		name:         name,
+		compensation: compensation,
+		status:       status,
+		attempts:     attempts,
+		lastError:    lastError,
+		updatedAt:    updatedAt,
+	}
+}
+
+// Name은 단계 이름을 반환합니다.
+func (s *Step) Name() StepName {
+	return s.name
+}
+
+// IsCompensation은 보상 단계인지 확인합니다.
+func (s *Step) IsCompensation() bool {
+	return s.compensation
+}
+
+// Status는 단계 상태를 반환합니다.
+func (s *Step) Status() StepStatus {
+	return s.status
+}
+
+// Attempts는 단계를 실행한 횟수를 반환합니다.
+func (s *Step) Attempts() int {
+	return s.attempts
+}
+
+// LastError는 마지막 실패 사유를 반환합니다.
+func (s *Step) LastError() string {
+	return s.lastError
+}
+
+// UpdatedAt은 단계가 마지막으로 실행된 시간을 반환합니다.
+func (s *Step) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// Saga는 결제 모듈과 주문 모듈에 걸친 결과 하나를 단계별로 맞추는 프로세스 매니저 엔티티입니다.
+// 주문과 종류마다 하나만 만들어지며, 단계 상태를 저장하므로 중단되어도 마지막 단계부터 이어서 진행합니다.
+type Saga struct {
+	id            string
+	kind          SagaKind
+	orderID       string
+	reason        string
+	status        SagaStatus
+	steps         []*Step
+	lastError     string
+	nextAttemptAt time.Time
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+// NewSaga는 주문의 새로운 사가를 생성합니다. reason은 사가를 시작한 사유이며 환불 사유에도 사용합니다.
+func NewSaga(kind SagaKind, orderID, reason string) (*Saga, error) {
+	if orderID == "" {
+		return nil, ErrInvalidOrderID
+	}
+	definition, ok := sagaSteps[kind]
+	if !ok {
+		return nil, ErrInvalidSagaKind
+	}
+
+	now := time.Now()
+	steps := make([]*Step, 0, len(definition.forward)+len(definition.compensation))
+	for _, name := range definition.forward {
+		steps = append(steps, &Step{name: name, status: StepPending, updatedAt: now})
+	}
+	for _, name := range definition.compensation {
+		steps = append(steps, &Step{name: name, compensation: true, status: StepPending, updatedAt: now})
+	}
+
+	return &Saga{
+		id:            uuid.New().String(),
+		kind:          kind,
+		orderID:       orderID,
+		reason:        reason,
+		status:        StatusRunning,
+		steps:         steps,
+		nextAttemptAt: now,
+		createdAt:     now,
+		updatedAt:     now,
+	}, nil
+}
+
+// RestoreSaga는 저장된 데이터로부터 사가를 복원합니다.
+func RestoreSaga(id string, kind SagaKind, orderID, reason string, status SagaStatus, steps []*Step, lastError string, nextAttemptAt, createdAt, updatedAt time.Time) *Saga {
+	return &Saga{
+		id:            id,
+		kind:          kind,
+		orderID:       orderID,
+		reason:        reason,
+		status:        status,
+		steps:         steps,
+		lastError:     lastError,
+		nextAttemptAt: nextAttemptAt,
+		createdAt:     createdAt,
+		updatedAt:     updatedAt,
+	}
+}
+
+// ID는 사가 ID를 반환합니다.
+func (s *Saga) ID() string {
+	return s.id
+}
+
+// Kind는 사가 종류를 반환합니다.
+func (s *Saga) Kind() SagaKind {
+	return s.kind
+}
+
+// OrderID는 주문 ID를 반환합니다.
+func (s *Saga) OrderID() string {
+	return s.orderID
+}
+
+// Reason은 사가를 시작한 사유를 반환합니다.
+func (s *Saga) Reason() string {
+	return s.reason
+}
+
+// Status는 사가 상태를 반환합니다.
+func (s *Saga) Status() SagaStatus {
+	return s.status
+}
+
+// Steps는 정방향 단계와 보상 단계를 실행 순서대로 반환합니다.
+func (s *Saga) Steps() []*Step {
+	return s.steps
+}
+
+// LastError는 보상이나 실패로 이어진 마지막 오류를 반환합니다.
+func (s *Saga) LastError() string {
+	return s.lastError
+}
+
+// NextAttemptAt은 실패한 단계를 다시 실행할 시간을 반환합니다.
+func (s *Saga) NextAttemptAt() time.Time {
+	return s.nextAttemptAt
+}
+
+// CreatedAt은 사가 생성 시간을 반환합니다.
+func (s *Saga) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// UpdatedAt은 사가 수정 시간을 반환합니다.
+func (s *Saga) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// IsFinished는 사가가 더 진행할 단계 없이 끝났는지 확인합니다.
+func (s *Saga) IsFinished() bool {
+	return s.status != StatusRunning && s.status != StatusCompensating
+}
+
+// CurrentStep은 다음에 실행할 단계를 반환합니다. 진행 중인 단계가 없으면 nil입니다.
+func (s *Saga) CurrentStep() *Step {
+	if s.IsFinished() {
+		return nil
+	}
+	compensating := s.status == StatusCompensating
+	for _, step := range s.steps {
+		if step.compensation == compensating && step.status == StepPending {
+			return step
+		}
+	}
+	return nil
+}
+
+// CompleteStep은 현재 단계를 성공으로 기록하고, 남은 단계가 없으면 사가를 끝냅니다.
+func (s *Saga) CompleteStep(now time.Time) error {
+	step := s.CurrentStep()
+	if step == nil {
+		return ErrSagaFinished
+	}
+
+	step.status = StepSucceeded
+	step.attempts++
+	step.lastError = ""
+	step.updatedAt = now
+	s.touch(now)
+
+	if s.CurrentStep() == nil {
+		if s.status == StatusCompensating {
+			s.status = StatusCompensated
+		} else {
+			s.status = StatusCompleted
+		}
+	}
+	return nil
+}
+
+// RetryStep은 현재 단계의 일시적인 실패를 기록하고 retryAt에 다시 실행하도록 예약합니다.
+func (s *Saga) RetryStep(message string, retryAt, now time.Time) error {
+	step := s.CurrentStep()
+	if step == nil {
+		return ErrSagaFinished
+	}
+
+	step.attempts++
+	step.lastError = message
+	step.updatedAt = now
+	s.nextAttemptAt = retryAt
+	s.touch(now)
+	return nil
+}
+
+// FailStep은 현재 단계를 실패로 기록합니다.
+// 정방향 단계가 실패하면 보상 단계를 시작하고, 보상 단계가 없거나 보상 단계가 실패하면 사가를 실패로 끝냅니다.
+func (s *Saga) FailStep(message string, now time.Time) error {
+	step := s.CurrentStep()
+	if step == nil {
+		return ErrSagaFinished
+	}
+
+	step.status = StepFailed
+	step.attempts++
+	step.lastError = message
+	step.updatedAt = now
+	s.lastError = message
+	s.nextAttemptAt = now
+	s.touch(now)
+
+	if s.status == StatusRunning {
+		s.status = StatusCompensating
+		if s.CurrentStep() != nil {
+			return nil
+		}
+	}
+	s.status = StatusFailed
+	return nil
+}
+
+// Reopen은 끝난 사가를 다시 진행합니다.
+// 실패한 보상 단계가 있으면 보상 단계를 다시 실행하고, 없으면 정방향 단계를 처음부터 다시 실행합니다.
+func (s *Saga) Reopen(now time.Time) error {
+	if !s.IsFinished() {
+		return ErrSagaRunning
+	}
+
+	compensating := false
+	for _, step := range s.steps {
+		if step.compensation && step.status == StepFailed {
+			compensating = true
+		}
+	}
+	for _, step := range s.steps {
+		if step.compensation == compensating {
+			step.status = StepPending
+			step.attempts = 0
+			step.updatedAt = now
+		}
+	}
+
+	if compensating {
+		s.status = StatusCompensating
+	} else {
+		s.status = StatusRunning
+		s.lastError = ""
+	}
+	s.nextAttemptAt = now
+	s.touch(now)
+	return nil
+}
+
+// touch는 사가 수정 시간을 갱신합니다.
+func (s *Saga) touch(now time.Time) {
+	s.updatedAt = now
+}
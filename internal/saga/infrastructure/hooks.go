@@ -0,0 +1,200 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	orderApp "example.com/myapp/order/application"
+	orderDomain "example.com/myapp/order/domain"
+	paymentApp "example.com/myapp/payment/application"
+	paymentDomain "example.com/myapp/payment/domain"
+	"example.com/myapp/saga/application"
+)
+
+// SagaPaymentService는 결제가 승인되거나 가승인되면 주문 결제 사가를 시작하고,
+// 가승인이 매입되지 않고 취소되면 가승인 취소 사가를 시작하도록 PaymentService를 감쌉니다.
+// 사가 시작에 실패해도 결제 결과는 그대로 반환하고 onError로 알리며, 놓친 주문 결제 사가는 주기적인 점검이 시작합니다.
+type SagaPaymentService struct {
+	paymentApp.PaymentService
+	sagas   application.SagaService
+	onError func(err error, orderID string)
+}
+
+// NewSagaPaymentService는 새로운 SagaPaymentService 인스턴스를 생성합니다.
+func NewSagaPaymentService(payments paymentApp.PaymentService, sagas application.SagaService, onError func(err error, orderID string)) paymentApp.PaymentService {
+	return &SagaPaymentService{
+		PaymentService: payments,
+		sagas:          sagas,
+		onError:        onError,
+	}
+}
+
+// ProcessPayment는 결제를 처리하고 승인되면 주문 결제 사가를 시작합니다.
+func (s *SagaPaymentService) ProcessPayment(ctx context.Context, paymentID string) (*paymentDomain.Payment, error) {
+	payment, err := s.PaymentService.ProcessPayment(ctx, paymentID)
+	if err == nil {
+		s.settled(ctx, payment)
+	}
+	return payment, err
+}
+
+// AuthorizePayment는 결제를 가승인하고 가승인되면 주문 결제 사가를 시작합니다.
+func (s *SagaPaymentService) AuthorizePayment(ctx context.Context, paymentID string) (*paymentDomain.Payment, error) {
+	payment, err := s.PaymentService.AuthorizePayment(ctx, paymentID)
+	if err == nil {
+		s.settled(ctx, payment)
+	}
+	return payment, err
+}
+
+// ApplyGatewayEvent는 게이트웨이 이벤트를 반영하고 결제가 승인되면 주문 결제 사가를 시작합니다.
+func (s *SagaPaymentService) ApplyGatewayEvent(ctx context.Context, event *paymentApp.GatewayEvent) (*paymentDomain.Payment, error) {
+	payment, err := s.PaymentService.ApplyGatewayEvent(ctx, event)
+	if err == nil {
+		s.settled(ctx, payment)
+	}
+	return payment, err
+}
+
+// CapturePayment는 가승인을 매입하고, 만료되어 매입 대신 취소되었으면 가승인 취소 사가를 시작합니다.
+func (s *SagaPaymentService) CapturePayment(ctx context.Context, paymentID string, amount float64) (*paymentDomain.Payment, error) {
+	payment, err := s.PaymentService.CapturePayment(ctx, paymentID, amount)
+	if errors.Is(err, paymentDomain.ErrAuthorizationExpired) {
+		s.voided(ctx, payment)
+	}
+	return payment, err
+}
+
+// VoidPayment는 가승인을 취소하고 가승인 취소 사가를 시작합니다.
+func (s *SagaPaymentService) VoidPayment(ctx context.Context, paymentID string, reason string) (*paymentDomain.Payment, error) {
+	payment, err := s.PaymentService.VoidPayment(ctx, paymentID, reason)
+	if err == nil {
+		s.voided(ctx, payment)
+	}
+	return payment, err
+}
+
+// VoidExpiredAuthorizations는 만료된 가승인을 취소하고 취소한 결제마다 가승인 취소 사가를 시작합니다.
+func (s *SagaPaymentService) VoidExpiredAuthorizations(ctx context.Context, now time.Time) ([]*paymentDomain.Payment, error) {
+	voided, err := s.PaymentService.VoidExpiredAuthorizations(ctx, now)
+	for _, payment := range voided {
+		s.voided(ctx, payment)
+	}
+	return voided, err
+}
+
+func (s *SagaPaymentService) settled(ctx context.Context, payment *paymentDomain.Payment) {
+	if payment == nil {
+		return
+	}
+	switch payment.Status() {
+	case paymentDomain.PaymentStatusApproved, paymentDomain.PaymentStatusAuthorized:
+	default:
+		return
+	}
+	if _, err := s.sagas.OnPaymentSettled(ctx, payment.OrderID()); err != nil && s.onError != nil {
+		s.onError(err, payment.OrderID())
+	}
+}
+
+func (s *SagaPaymentService) voided(ctx context.Context, payment *paymentDomain.Payment) {
+	if payment == nil || payment.Status() != paymentDomain.PaymentStatusVoided {
+		return
+	}
+	if _, err := s.sagas.OnAuthorizationVoided(ctx, payment.OrderID(), "payment authorization voided before capture"); err != nil && s.onError != nil {
+		s.onError(err, payment.OrderID())
+	}
+}
+
+// SagaOrderService는 주문이 취소되거나 항목 일부가 취소되면 결제를 돌려주는 사가를,
+// 출고되면 가승인을 매입하는 사가를 시작하도록 OrderService를 감쌉니다.
+// 사가 시작에 실패해도 취소 결과는 그대로 반환하고 onError로 알리며, 놓친 사가는 주기적인 점검이 시작합니다.
+type SagaOrderService struct {
+	orderApp.OrderService
+	sagas   application.SagaService
+	onError func(err error, orderID string)
+}
+
+// NewSagaOrderService는 새로운 SagaOrderService 인스턴스를 생성합니다.
+func NewSagaOrderService(orders orderApp.OrderService, sagas application.SagaService, onError func(err error, orderID string)) orderApp.OrderService {
+	return &SagaOrderService{
+		OrderService: orders,
+		sagas:        sagas,
+		onError:      onError,
+	}
+}
+
+// UpdateOrderStatus는 주문 상태를 변경하고, 취소되면 주문 취소 사가를, 출고되면 가승인을 매입하는 사가를 시작합니다.
+func (s *SagaOrderService) UpdateOrderStatus(ctx context.Context, id string, status orderDomain.OrderStatus, actor, reason string) (*orderDomain.Order, error) {
+	order, err := s.OrderService.UpdateOrderStatus(ctx, id, status, actor, reason)
+	if err != nil {
+		return order, err
+	}
+	s.canceled(ctx, order, reason)
+	if order.Status() == orderDomain.StatusShipped {
+		if _, err := s.sagas.OnOrderShipped(ctx, order.ID()); err != nil && s.onError != nil {
+			s.onError(err, order.ID())
+		}
+	}
+	return order, nil
+}
+
+// CancelOrder는 주문을 취소하고 주문 취소 사가를 시작합니다.
+func (s *SagaOrderService) CancelOrder(ctx context.Context, id string, actor, reason string) (*orderDomain.Order, error) {
+	order, err := s.OrderService.CancelOrder(ctx, id, actor, reason)
+	if err == nil {
+		s.canceled(ctx, order, reason)
+	}
+	return order, err
+}
+
+// CancelGuestOrder는 비회원 주문을 취소하고 주문 취소 사가를 시작합니다.
+func (s *SagaOrderService) CancelGuestOrder(ctx context.Context, orderID, token, reason string) (*orderDomain.Order, error) {
+	order, err := s.OrderService.CancelGuestOrder(ctx, orderID, token, reason)
+	if err == nil {
+		s.canceled(ctx, order, reason)
+	}
+	return order, err
+}
+
+// CancelOrderItems는 주문 항목 일부를 취소하고, 모든 항목이 취소되어 주문이 취소되면 주문 취소 사가를,
+// 주문이 남으면 줄어든 주문 금액을 넘는 청구 금액을 환불하는 항목 취소 사가를 시작합니다.
+func (s *SagaOrderService) CancelOrderItems(ctx context.Context, orderID string, req orderApp.CancelItemsRequest) (*orderApp.ItemCancellation, error) {
+	result, err := s.OrderService.CancelOrderItems(ctx, orderID, req)
+	if err != nil {
+		return result, err
+	}
+	if result.Order.Status() == orderDomain.StatusCanceled {
+		s.canceled(ctx, result.Order, req.Reason)
+		return result, nil
+	}
+	if result.RefundAmount > 0 {
+		reason := req.Reason
+		if reason == "" {
+			reason = "order items canceled"
+		}
+		if _, err := s.sagas.OnOrderItemsCanceled(ctx, orderID, reason); err != nil && s.onError != nil {
+			s.onError(err, orderID)
+		}
+	}
+	return result, nil
+}
+
+// ExpirePendingOrders는 기한이 지난 결제 대기 주문을 취소하고, 취소한 주문마다 주문 취소 사가를 시작합니다.
+func (s *SagaOrderService) ExpirePendingOrders(ctx context.Context, cutoff time.Time) ([]*orderDomain.Order, error) {
+	expired, err := s.OrderService.ExpirePendingOrders(ctx, cutoff)
+	for _, order := range expired {
+		s.canceled(ctx, order, orderDomain.ReasonExpired)
+	}
+	return expired, err
+}
+
+func (s *SagaOrderService) canceled(ctx context.Context, order *orderDomain.Order, reason string) {
+	if order == nil || order.Status() != orderDomain.StatusCanceled {
+		return
+	}
+	if _, err := s.sagas.OnOrderCanceled(ctx, order.ID(), reason); err != nil && s.onError != nil {
+		s.onError(err, order.ID())
+	}
+}
@@ -0,0 +1,105 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	orderApp "example.com/myapp/order/application"
+	orderDomain "example.com/myapp/order/domain"
+	"example.com/myapp/saga/application"
+)
+
+// sagaActor는 사가가 바꾼 주문 상태 이력에 기록되는 행위자입니다.
+const sagaActor = "saga"
+
+// OrderSagaAdapter는 주문 모듈의 공개 API로 Orders 포트를 구현합니다.
+type OrderSagaAdapter struct {
+	orders orderApp.OrderService
+}
+
+// NewOrderSagaAdapter는 새로운 OrderSagaAdapter 인스턴스를 생성합니다.
+func NewOrderSagaAdapter(orders orderApp.OrderService) application.Orders {
+	return &OrderSagaAdapter{
+		orders: orders,
+	}
+}
+
+// FindOrder는 주문이 취소되었는지, 결제 완료 이후 상태인지 조회합니다.
+func (a *OrderSagaAdapter) FindOrder(ctx context.Context, orderID string) (*application.SagaOrder, error) {
+	order, err := a.orders.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return &application.SagaOrder{
+		ID:       order.ID(),
+		Canceled: order.Status() == orderDomain.StatusCanceled,
+		Paid:     order.Status() != orderDomain.StatusPending && order.Status() != orderDomain.StatusCanceled,
+	}, nil
+}
+
+// MarkPaid는 결제 대기 주문을 결제 완료로 바꿉니다. 이미 결제 완료 이후 상태인 주문은 그대로 둡니다.
+// 상태를 확인한 뒤 주문이 다른 요청으로 바뀌어 저장이 거절되면(orderDomain.ErrOrderConflict) 일시적인 실패로 보고, 다음 실행에서 바뀐 상태를 다시 확인합니다.
+func (a *OrderSagaAdapter) MarkPaid(ctx context.Context, orderID, reason string) error {
+	order, err := a.orders.GetOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	switch order.Status() {
+	case orderDomain.StatusPending:
+		_, err := a.orders.UpdateOrderStatus(ctx, orderID, orderDomain.StatusPaid, sagaActor, reason)
+		return err
+	case orderDomain.StatusCanceled:
+		return application.ErrOrderNotPayable
+	default:
+		return nil
+	}
+}
+
+// CancelUnpaidOrder는 결제 금액이 빈 결제 완료 주문을 취소합니다. 이미 취소된 주문은 그대로 둡니다.
+// 출고된 주문은 상품이 나갔으므로 자동으로 취소하지 않고 application.ErrOrderNotCancelable을 반환합니다.
+func (a *OrderSagaAdapter) CancelUnpaidOrder(ctx context.Context, orderID, reason string) error {
+	order, err := a.orders.GetOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	switch order.Status() {
+	case orderDomain.StatusPaid:
+		_, err := a.orders.CancelOrder(ctx, orderID, sagaActor, reason)
+		return err
+	case orderDomain.StatusCanceled:
+		return nil
+	default:
+		return application.ErrOrderNotCancelable
+	}
+}
+
+// PendingOrderIDs는 createdSince 이후에 생성된 결제 대기 주문의 ID 목록을 조회합니다.
+func (a *OrderSagaAdapter) PendingOrderIDs(ctx context.Context, createdSince time.Time) ([]string, error) {
+	return a.orderIDs(ctx, orderApp.OrderSearchCriteria{
+		Statuses:    []orderDomain.OrderStatus{orderDomain.StatusPending},
+		CreatedFrom: createdSince,
+	})
+}
+
+// CanceledOrderIDs는 updatedSince 이후에 수정된 취소 주문의 ID 목록을 조회합니다.
+func (a *OrderSagaAdapter) CanceledOrderIDs(ctx context.Context, updatedSince time.Time) ([]string, error) {
+	return a.orderIDs(ctx, orderApp.OrderSearchCriteria{
+		Statuses:    []orderDomain.OrderStatus{orderDomain.StatusCanceled},
+		UpdatedFrom: updatedSince,
+		SortBy:      orderApp.OrderSortUpdatedAt,
+	})
+}
+
+func (a *OrderSagaAdapter) orderIDs(ctx context.Context, criteria orderApp.OrderSearchCriteria) ([]string, error) {
+	ids := []string{}
+	err := a.orders.ExportOrders(ctx, criteria, func(order *orderDomain.Order) error {
+		ids = append(ids, order.ID())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
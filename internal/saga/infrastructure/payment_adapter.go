@@ -0,0 +1,151 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	paymentApp "example.com/myapp/payment/application"
+	paymentDomain "example.com/myapp/payment/domain"
+	"example.com/myapp/saga/application"
+)
+
+// PaymentSagaAdapter는 결제 모듈의 공개 API로 Payments 포트를 구현합니다.
+type PaymentSagaAdapter struct {
+	payments paymentApp.PaymentService
+}
+
+// NewPaymentSagaAdapter는 새로운 PaymentSagaAdapter 인스턴스를 생성합니다.
+func NewPaymentSagaAdapter(payments paymentApp.PaymentService) application.Payments {
+	return &PaymentSagaAdapter{
+		payments: payments,
+	}
+}
+
+// Summary는 주문의 결제 현황에서 남은 금액, 환불되지 않은 청구 금액, 가승인 금액과 주문 금액을 조회합니다.
+func (a *PaymentSagaAdapter) Summary(ctx context.Context, orderID string) (*application.OrderPayments, error) {
+	summary, err := a.payments.GetOrderPaymentSummary(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return &application.OrderPayments{
+		AmountDue:  summary.AmountDue,
+		Charged:    summary.Paid - summary.Refunded,
+		Authorized: summary.Authorized,
+		OrderTotal: summary.OrderTotal,
+	}, nil
+}
+
+// ReleaseOrderPayments는 주문의 가승인을 취소하고 청구된 결제의 남은 금액을 환불합니다.
+// 결제 상태와 환불 가능 금액을 보고 이미 돌려준 결제는 건너뛰므로 다시 호출되어도 중복 환불하지 않습니다.
+func (a *PaymentSagaAdapter) ReleaseOrderPayments(ctx context.Context, orderID, reason string) error {
+	payments, err := a.payments.GetPaymentsByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	for _, payment := range payments {
+		switch {
+		case payment.Status() == paymentDomain.PaymentStatusAuthorized || payment.IsProcessing(paymentDomain.AttemptKindVoid):
+			// 처리 중인 취소는 같은 시도로 이어서 취소합니다
+			if _, err := a.payments.VoidPayment(ctx, payment.ID(), reason); err != nil {
+				return fmt.Errorf("failed to void payment %s: %w", payment.ID(), err)
+			}
+		case payment.Status() == paymentDomain.PaymentStatusProcessing:
+			// 처리 중인 결제는 승인될 수 있으므로 결과가 나온 뒤 다시 시도합니다
+			return fmt.Errorf("payment %s is still processing", payment.ID())
+		case payment.IsRefundable() && payment.RefundableAmount() > 0.005:
+			if _, err := a.payments.RefundPayment(ctx, payment.ID(), reason); err != nil {
+				return fmt.Errorf("failed to refund payment %s: %w", payment.ID(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// RefundExcessPayments는 청구된 결제의 환불 가능 금액 합계 중 주문 금액을 넘는 부분을 생성 순서대로 나누어 환불합니다.
+// 진행 중인 환불은 환불 가능 금액에서 이미 빠지므로 다시 호출되어도 중복 환불하지 않습니다.
+func (a *PaymentSagaAdapter) RefundExcessPayments(ctx context.Context, orderID, reason string) error {
+	summary, err := a.payments.GetOrderPaymentSummary(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	refundable := 0.0
+	for _, payment := range summary.Payments {
+		if payment.Status() == paymentDomain.PaymentStatusProcessing {
+			// 처리 중인 결제는 승인될 수 있으므로 결과가 나온 뒤 다시 시도합니다
+			return fmt.Errorf("payment %s is still processing", payment.ID())
+		}
+		if payment.IsRefundable() {
+			refundable += payment.RefundableAmount()
+		}
+	}
+
+	excess := math.Round((refundable-summary.OrderTotal)*100) / 100
+	for _, payment := range summary.Payments {
+		if excess <= 0.005 {
+			break
+		}
+		if !payment.IsRefundable() || payment.RefundableAmount() <= 0.005 {
+			continue
+		}
+		amount := math.Min(excess, payment.RefundableAmount())
+		if _, err := a.payments.CreateRefund(ctx, payment.ID(), amount, reason); err != nil {
+			return fmt.Errorf("failed to refund payment %s: %w", payment.ID(), err)
+		}
+		excess = math.Round((excess-amount)*100) / 100
+	}
+	return nil
+}
+
+// CaptureOrderPayments는 주문 금액에서 환불되지 않은 청구 금액을 뺀 만큼 가승인을 생성 순서대로 매입하고,
+// 주문 금액을 채운 뒤 남은 가승인은 취소합니다. 처리 중인 매입은 저장된 금액으로 먼저 마무리합니다.
+// 만료 등으로 가승인이 모자라 주문 금액을 채우지 못하면 수동 처리하도록 오류를 반환합니다.
+func (a *PaymentSagaAdapter) CaptureOrderPayments(ctx context.Context, orderID, reason string) error {
+	summary, err := a.payments.GetOrderPaymentSummary(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	resumed := false
+	for _, payment := range summary.Payments {
+		switch {
+		case payment.IsProcessing(paymentDomain.AttemptKindCapture):
+			if _, err := a.payments.CapturePayment(ctx, payment.ID(), 0); err != nil {
+				return fmt.Errorf("failed to capture payment %s: %w", payment.ID(), err)
+			}
+			resumed = true
+		case payment.Status() == paymentDomain.PaymentStatusProcessing:
+			// 처리 중인 결제는 승인될 수 있으므로 결과가 나온 뒤 다시 시도합니다
+			return fmt.Errorf("payment %s is still processing", payment.ID())
+		}
+	}
+	if resumed {
+		if summary, err = a.payments.GetOrderPaymentSummary(ctx, orderID); err != nil {
+			return err
+		}
+	}
+
+	due := math.Round((summary.OrderTotal-(summary.Paid-summary.Refunded))*100) / 100
+	for _, payment := range summary.Payments {
+		if payment.Status() != paymentDomain.PaymentStatusAuthorized {
+			continue
+		}
+		if due <= 0.005 {
+			if _, err := a.payments.VoidPayment(ctx, payment.ID(), reason); err != nil {
+				return fmt.Errorf("failed to void payment %s: %w", payment.ID(), err)
+			}
+			continue
+		}
+		amount := math.Min(due, payment.Amount())
+		if _, err := a.payments.CapturePayment(ctx, payment.ID(), amount); err != nil {
+			return fmt.Errorf("failed to capture payment %s: %w", payment.ID(), err)
+		}
+		due = math.Round((due-amount)*100) / 100
+	}
+	if due > 0.005 {
+		return fmt.Errorf("order %s has %.2f left to capture without an authorization", orderID, due)
+	}
+	return nil
+}
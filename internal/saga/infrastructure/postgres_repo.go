@@ -0,0 +1,345 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"example.com/myapp/saga/application"
+	"example.com/myapp/saga/domain"
+	"example.com/myapp/shared/db"
+	"github.com/jackc/pgx/v4"
+)
+
+// PostgresSagaRepository는 PostgreSQL을 사용하는 사가 저장소 구현체입니다.
+type PostgresSagaRepository struct {
+	db *db.Database
+}
+
+// NewPostgresSagaRepository는 새로운 PostgresSagaRepository 인스턴스를 생성합니다.
+func NewPostgresSagaRepository(database *db.Database) application.SagaRepository {
+	return &PostgresSagaRepository{
+		db: database,
+	}
+}
+
+// Save는 사가와 단계를 하나의 트랜잭션으로 저장합니다.
+// 같은 주문에 같은 종류의 사가가 이미 있으면 저장하지 않고 false를 반환합니다.
+func (r *PostgresSagaRepository) Save(ctx context.Context, saga *domain.Saga) (bool, error) {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	query := `
+		INSERT INTO sagas (id, kind, order_id, reason, status, last_error, next_attempt_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (order_id, kind) DO NOTHING
+	`
+
+	result, err := tx.Exec(
+		ctx,
+		query,
+		saga.ID(),
+		string(saga.Kind()),
+		saga.OrderID(),
+		saga.Reason(),
+		string(saga.Status()),
+		saga.LastError(),
+		saga.NextAttemptAt(),
+		saga.CreatedAt(),
+		saga.UpdatedAt(),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to save saga: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	stepQuery := `
+		INSERT INTO saga_steps (saga_id, seq, name, compensation, status, attempts, last_error, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	for i, step := range saga.Steps() {
+		_, err = tx.Exec(
+			ctx,
+			stepQuery,
+			saga.ID(),
+			i+1,
+			string(step.Name()),
+			step.IsCompensation(),
+			string(step.Status()),
+			step.Attempts(),
+			step.LastError(),
+			step.UpdatedAt(),
+		)
+		if err != nil {
+			return false, fmt.Errorf("failed to save saga step: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+// FindByID는 ID로 사가를 단계와 함께 조회합니다.
+func (r *PostgresSagaRepository) FindByID(ctx context.Context, id string) (*domain.Saga, error) {
+	query := `
+		SELECT id, kind, order_id, reason, status, last_error, next_attempt_at, created_at, updated_at
+		FROM sagas
+		WHERE id = $1
+	`
+	return r.findOne(ctx, query, id)
+}
+
+// FindByOrderAndKind는 주문의 kind 종류 사가를 조회합니다.
+func (r *PostgresSagaRepository) FindByOrderAndKind(ctx context.Context, orderID string, kind domain.SagaKind) (*domain.Saga, error) {
+	query := `
+		SELECT id, kind, order_id, reason, status, last_error, next_attempt_at, created_at, updated_at
+		FROM sagas
+		WHERE order_id = $1 AND kind = $2
+	`
+	return r.findOne(ctx, query, orderID, string(kind))
+}
+
+// FindByOrderID는 주문의 사가 목록을 생성 순서대로 조회합니다.
+func (r *PostgresSagaRepository) FindByOrderID(ctx context.Context, orderID string) ([]*domain.Saga, error) {
+	query := `
+		SELECT id
+		FROM sagas
+		WHERE order_id = $1
+		ORDER BY created_at, id
+	`
+	return r.findAll(ctx, query, orderID)
+}
+
+// FindByStatus는 status 상태의 사가를 최근 수정 순서로 최대 limit건 조회합니다.
+func (r *PostgresSagaRepository) FindByStatus(ctx context.Context, status domain.SagaStatus, limit int) ([]*domain.Saga, error) {
+	query := `
+		SELECT id
+		FROM sagas
+		WHERE status = $1
+		ORDER BY updated_at DESC, id
+		LIMIT $2
+	`
+	return r.findAll(ctx, query, string(status), limit)
+}
+
+// Update는 사가 상태와 단계 기록을 하나의 트랜잭션으로 업데이트합니다.
+// 끝난 사가는 선점을 풀어 다시 열리면 바로 진행할 수 있게 합니다.
+func (r *PostgresSagaRepository) Update(ctx context.Context, saga *domain.Saga) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	query := `
+		UPDATE sagas
+		SET status = $1, last_error = $2, next_attempt_at = $3, updated_at = $4,
+			claimed_until = CASE WHEN $5 THEN NULL ELSE claimed_until END
+		WHERE id = $6
+	`
+
+	result, err := tx.Exec(
+		ctx,
+		query,
+		string(saga.Status()),
+		saga.LastError(),
+		saga.NextAttemptAt(),
+		saga.UpdatedAt(),
+		saga.IsFinished(),
+		saga.ID(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update saga: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrSagaNotFound
+	}
+
+	stepQuery := `
+		UPDATE saga_steps
+		SET status = $1, attempts = $2, last_error = $3, updated_at = $4
+		WHERE saga_id = $5 AND seq = $6
+	`
+
+	for i, step := range saga.Steps() {
+		_, err = tx.Exec(
+			ctx,
+			stepQuery,
+			string(step.Status()),
+			step.Attempts(),
+			step.LastError(),
+			step.UpdatedAt(),
+			saga.ID(),
+			i+1,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update saga step: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Claim은 끝나지 않은 사가를 lease 동안 선점합니다.
+func (r *PostgresSagaRepository) Claim(ctx context.Context, id string, now time.Time, lease time.Duration) (bool, error) {
+	query := `
+		UPDATE sagas
+		SET claimed_until = $1
+		WHERE id = $2 AND status IN ($3, $4)
+			AND (claimed_until IS NULL OR claimed_until < $5)
+	`
+
+	result, err := r.db.Pool.Exec(
+		ctx,
+		query,
+		now.Add(lease),
+		id,
+		string(domain.StatusRunning),
+		string(domain.StatusCompensating),
+		now,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim saga: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// ClaimDue는 다시 실행할 시간이 된 끝나지 않은 사가를 실행 예정 순서로 최대 limit건 선점하고 반환합니다.
+// 진행 도중 프로세스가 중단된 사가도 선점 기한이 지나면 다시 선점됩니다.
+func (r *PostgresSagaRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.Saga, error) {
+	query := `
+		UPDATE sagas
+		SET claimed_until = $1
+		WHERE id IN (
+			SELECT id
+			FROM sagas
+			WHERE status IN ($2, $3) AND next_attempt_at <= $4
+				AND (claimed_until IS NULL OR claimed_until < $4)
+			ORDER BY next_attempt_at
+			LIMIT $5
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id
+	`
+
+	return r.findAll(
+		ctx,
+		query,
+		now.Add(lease),
+		string(domain.StatusRunning),
+		string(domain.StatusCompensating),
+		now,
+		limit,
+	)
+}
+
+// findOne은 사가 한 건을 조회하는 쿼리를 실행하고 단계를 함께 조회합니다.
+func (r *PostgresSagaRepository) findOne(ctx context.Context, query string, args ...interface{}) (*domain.Saga, error) {
+	var id, kind, orderID, reason, status, lastError string
+	var nextAttemptAt, createdAt, updatedAt time.Time
+
+	err := r.db.Pool.QueryRow(ctx, query, args...).Scan(&id, &kind, &orderID, &reason, &status, &lastError, &nextAttemptAt, &createdAt, &updatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrSagaNotFound
+		}
+		return nil, fmt.Errorf("failed to find saga: %w", err)
+	}
+
+	steps, err := r.findSteps(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.RestoreSaga(
+		id,
+		domain.SagaKind(kind),
+		orderID,
+		reason,
+		domain.SagaStatus(status),
+		steps,
+		lastError,
+		nextAttemptAt, createdAt, updatedAt,
+	), nil
+}
+
+// findAll은 사가 ID를 반환하는 쿼리를 실행하고 각 사가를 단계와 함께 조회합니다.
+func (r *PostgresSagaRepository) findAll(ctx context.Context, query string, args ...interface{}) ([]*domain.Saga, error) {
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sagas: %w", err)
+	}
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan saga: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sagas: %w", err)
+	}
+
+	sagas := make([]*domain.Saga, 0, len(ids))
+	for _, id := range ids {
+		saga, err := r.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		sagas = append(sagas, saga)
+	}
+
+	return sagas, nil
+}
+
+func (r *PostgresSagaRepository) findSteps(ctx context.Context, sagaID string) ([]*domain.Step, error) {
+	query := `
+		SELECT name, compensation, status, attempts, last_error, updated_at
+		FROM saga_steps
+		WHERE saga_id = $1
+		ORDER BY seq
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, sagaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saga steps: %w", err)
+	}
+	defer rows.Close()
+
+	steps := []*domain.Step{}
+	for rows.Next() {
+		var name, status, lastError string
+		var compensation bool
+		var attempts int
+		var updatedAt time.Time
+		if err := rows.Scan(&name, &compensation, &status, &attempts, &lastError, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan saga step: %w", err)
+		}
+		steps = append(steps, domain.RestoreStep(domain.StepName(name), compensation, domain.StepStatus(status), attempts, lastError, updatedAt))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating saga steps: %w", err)
+	}
+
+	return steps, nil
+}
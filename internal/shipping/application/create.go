@@ -0,0 +1,239 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"example.com/myapp/shipping/domain"
+)
+
+// 주문 상태 값 (주문 모듈의 OrderStatus와 동일)
+const (
+	orderStatusPaid    = "paid"
+	orderStatusShipped = "shipped"
+)
+
+var (
+	ErrOrderNotShippable      = errors.New("order must be paid before it can be shipped")
+	ErrExceedsOrderedQuantity = errors.New("shipped quantity exceeds ordered quantity")
+	ErrUnknownCarrier         = errors.New("unknown carrier")
+	ErrInvalidWebhook         = errors.New("invalid tracking webhook")
+)
+
+// CreateShipment는 주문의 일부 또는 전체를 출고하는 배송을 생성하고 주문을 배송 중 상태로 바꿉니다.
+// 같은 주문의 모든 배송에 담긴 수량은 주문 수량을 넘을 수 없습니다.
+func (uc *ShippingUseCase) CreateShipment(ctx context.Context, req CreateShipmentRequest) (*domain.Shipment, error) {
+	if req.OrderID == "" {
+		return nil, domain.ErrInvalidOrderID
+	}
+
+	order, err := uc.orders.FindOrder(ctx, req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != orderStatusPaid && order.Status != orderStatusShipped {
+		return nil, ErrOrderNotShippable
+	}
+
+	packages, err := newPackages(req.Packages)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.repo.FindByOrderID(ctx, req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	shipped := shippedQuantities(existing)
+	for _, pkg := range packages {
+		for _, item := range pkg.Items() {
+			shipped[item.SKUID()] += item.Quantity()
+			if shipped[item.SKUID()] > order.Quantities[item.SKUID()] {
+				return nil, fmt.Errorf("%w: %s", ErrExceedsOrderedQuantity, item.SKUID())
+			}
+		}
+	}
+
+	carrierName := strings.ToLower(strings.TrimSpace(req.Carrier))
+	trackingNumber := req.TrackingNumber
+	if trackingNumber == "" {
+		carrier, ok := uc.carriers[carrierName]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownCarrier, req.Carrier)
+		}
+		trackingNumber, err = carrier.IssueTrackingNumber(ctx, req.OrderID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to issue tracking number: %w", err)
+		}
+	}
+
+	shipment, err := domain.NewShipment(req.OrderID, carrierName, trackingNumber, packages)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Save(ctx, shipment); err != nil {
+		return nil, err
+	}
+
+	if err := uc.syncOrder(ctx, shipment.OrderID()); err != nil {
+		return nil, err
+	}
+	return shipment, nil
+}
+
+// GetShipment는 배송을 조회합니다.
+func (uc *ShippingUseCase) GetShipment(ctx context.Context, id string) (*domain.Shipment, error) {
+	return uc.repo.FindByID(ctx, id)
+}
+
+// GetOrderShipments는 주문의 배송 목록을 조회합니다.
+func (uc *ShippingUseCase) GetOrderShipments(ctx context.Context, orderID string) ([]*domain.Shipment, error) {
+	if orderID == "" {
+		return nil, domain.ErrInvalidOrderID
+	}
+	return uc.repo.FindByOrderID(ctx, orderID)
+}
+
+// RecordEvent는 배송 추적 이벤트를 기록합니다.
+// 주문의 모든 수량이 출고되고 모든 배송이 완료되면 주문을 배송 완료 상태로 바꿉니다.
+func (uc *ShippingUseCase) RecordEvent(ctx context.Context, shipmentID string, req TrackingEventRequest) (*domain.Shipment, error) {
+	shipment, err := uc.repo.FindByID(ctx, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.applyEvent(ctx, shipment, req); err != nil {
+		return nil, err
+	}
+	return shipment, nil
+}
+
+// ReceiveTrackingWebhook은 택배사 웹훅의 배송 추적 갱신을 운송장별로 반영합니다.
+// 등록되지 않은 운송장의 갱신은 건너뛰며, 같은 웹훅이 다시 와도 이벤트는 한 번만 기록됩니다.
+func (uc *ShippingUseCase) ReceiveTrackingWebhook(ctx context.Context, carrierName, signature string, payload []byte) (int, error) {
+	carrierName = strings.ToLower(carrierName)
+	carrier, ok := uc.carriers[carrierName]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownCarrier, carrierName)
+	}
+
+	updates, err := carrier.ParseTrackingWebhook(signature, payload)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+
+	applied := 0
+	for _, update := range updates {
+		shipment, err := uc.repo.FindByTrackingNumber(ctx, carrierName, update.TrackingNumber)
+		if errors.Is(err, domain.ErrShipmentNotFound) {
+			continue
+		}
+		if err != nil {
+			return applied, err
+		}
+
+		err = uc.applyEvent(ctx, shipment, TrackingEventRequest{
+			Status:      update.Status,
+			Description: update.Description,
+			Location:    update.Location,
+			OccurredAt:  update.OccurredAt,
+		})
+		if err != nil {
+			return applied, err
+		}
+		applied++
+	}
+	return applied, nil
+}
+
+// applyEvent는 이벤트를 배송에 기록하고 저장한 뒤 주문 상태를 맞춥니다.
+// 이미 기록된 이벤트라도 이전에 실패했을 수 있는 주문 상태 반영은 다시 시도합니다.
+func (uc *ShippingUseCase) applyEvent(ctx context.Context, shipment *domain.Shipment, req TrackingEventRequest) error {
+	event, err := domain.NewTrackingEvent(req.Status, req.Description, req.Location, req.OccurredAt)
+	if err != nil {
+		return err
+	}
+
+	if shipment.RecordEvent(event) {
+		if err := uc.repo.Update(ctx, shipment); err != nil {
+			return err
+		}
+	}
+	return uc.syncOrder(ctx, shipment.OrderID())
+}
+
+// syncOrder는 주문의 배송 현황에 맞춰 주문 상태를 배송 중 또는 배송 완료로 바꿉니다.
+func (uc *ShippingUseCase) syncOrder(ctx context.Context, orderID string) error {
+	shipments, err := uc.repo.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if len(shipments) == 0 {
+		return nil
+	}
+
+	if err := uc.orders.MarkShipped(ctx, orderID); err != nil {
+		return fmt.Errorf("failed to mark order as shipped: %w", err)
+	}
+
+	for _, shipment := range shipments {
+		if !shipment.IsDelivered() {
+			return nil
+		}
+	}
+
+	// 아직 출고되지 않은 수량이 있으면 나머지 배송을 기다립니다
+	order, err := uc.orders.FindOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	shipped := shippedQuantities(shipments)
+	for skuID, quantity := range order.Quantities {
+		if shipped[skuID] < quantity {
+			return nil
+		}
+	}
+
+	if err := uc.orders.MarkDelivered(ctx, orderID); err != nil {
+		return fmt.Errorf("failed to mark order as delivered: %w", err)
+	}
+	return nil
+}
+
+// newPackages는 요청으로부터 상자 목록을 생성합니다.
+func newPackages(reqs []PackageRequest) ([]*domain.Package, error) {
+	if len(reqs) == 0 {
+		return nil, domain.ErrInvalidPackage
+	}
+
+	packages := make([]*domain.Package, 0, len(reqs))
+	for _, req := range reqs {
+		items := make([]*domain.PackageItem, 0, len(req.Items))
+		for _, itemReq := range req.Items {
+			item, err := domain.NewPackageItem(itemReq.SKUID, itemReq.Quantity)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+
+		pkg, err := domain.NewPackage(req.WeightGrams, items)
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
+
+// shippedQuantities는 배송 목록에 담긴 SKU별 수량 합계를 계산합니다.
+func shippedQuantities(shipments []*domain.Shipment) map[string]int {
+	quantities := make(map[string]int)
+	for _, shipment := range shipments {
+		for skuID, quantity := range shipment.ItemQuantities() {
+			quantities[skuID] += quantity
+		}
+	}
+	return quantities
+}
@@ -0,0 +1,247 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"example.com/myapp/shipping/domain"
+)
+
+// FakeShipmentRepository는 테스트를 위한 가짜 ShipmentRepository 구현체입니다.
+type FakeShipmentRepository struct {
+	mu        sync.Mutex
+	shipments []*domain.Shipment
+}
+
+// NewFakeShipmentRepository는 새로운 FakeShipmentRepository 인스턴스를 생성합니다.
+func NewFakeShipmentRepository() *FakeShipmentRepository {
+	return &FakeShipmentRepository{}
+}
+
+func (f *FakeShipmentRepository) Save(ctx context.Context, shipment *domain.Shipment) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, s := range f.shipments {
+		if s.Carrier() == shipment.Carrier() && s.TrackingNumber() == shipment.TrackingNumber() {
+			return domain.ErrDuplicateTracking
+		}
+	}
+	f.shipments = append(f.shipments, shipment)
+	return nil
+}
+
+func (f *FakeShipmentRepository) FindByID(ctx context.Context, id string) (*domain.Shipment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, s := range f.shipments {
+		if s.ID() == id {
+			return s, nil
+		}
+	}
+	return nil, domain.ErrShipmentNotFound
+}
+
+func (f *FakeShipmentRepository) FindByOrderID(ctx context.Context, orderID string) ([]*domain.Shipment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	shipments := []*domain.Shipment{}
+	for _, s := range f.shipments {
+		if s.OrderID() == orderID {
+			shipments = append(shipments, s)
+		}
+	}
+	return shipments, nil
+}
+
+func (f *FakeShipmentRepository) FindByTrackingNumber(ctx context.Context, carrier, trackingNumber string) (*domain.Shipment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, s := range f.shipments {
+		if s.Carrier() == carrier && s.TrackingNumber() == trackingNumber {
+			return s, nil
+		}
+	}
+	return nil, domain.ErrShipmentNotFound
+}
+
+func (f *FakeShipmentRepository) Update(ctx context.Context, shipment *domain.Shipment) error {
+	return nil
+}
+
+// FakeOrderFulfillment는 테스트를 위한 가짜 OrderFulfillment 구현체입니다.
+type FakeOrderFulfillment struct {
+	orders map[string]*FulfillmentOrder
+}
+
+// NewFakeOrderFulfillment는 새로운 FakeOrderFulfillment 인스턴스를 생성합니다.
+func NewFakeOrderFulfillment() *FakeOrderFulfillment {
+	return &FakeOrderFulfillment{
+		orders: make(map[string]*FulfillmentOrder),
+	}
+}
+
+func (f *FakeOrderFulfillment) Add(orderID, status string, quantities map[string]int) {
+	f.orders[orderID] = &FulfillmentOrder{ID: orderID, Status: status, Quantities: quantities}
+}
+
+func (f *FakeOrderFulfillment) FindOrder(ctx context.Context, orderID string) (*FulfillmentOrder, error) {
+	order, ok := f.orders[orderID]
+	if !ok {
+		return nil, errors.New("order not found")
+	}
+	return order, nil
+}
+
+func (f *FakeOrderFulfillment) MarkShipped(ctx context.Context, orderID string) error {
+	if f.orders[orderID].Status == orderStatusPaid {
+		f.orders[orderID].Status = orderStatusShipped
+	}
+	return nil
+}
+
+func (f *FakeOrderFulfillment) MarkDelivered(ctx context.Context, orderID string) error {
+	f.orders[orderID].Status = "delivered"
+	return nil
+}
+
+// FakeCarrier는 테스트를 위한 가짜 Carrier 구현체입니다.
+// 웹훅 본문은 TrackingUpdate 목록의 JSON이며 서명이 "valid"일 때만 받아들입니다.
+type FakeCarrier struct {
+	issued int
+}
+
+func (f *FakeCarrier) Name() string {
+	return "fake"
+}
+
+func (f *FakeCarrier) IssueTrackingNumber(ctx context.Context, orderID string) (string, error) {
+	f.issued++
+	return "FAKE-" + orderID + "-" + string(rune('0'+f.issued)), nil
+}
+
+func (f *FakeCarrier) ParseTrackingWebhook(signature string, payload []byte) ([]TrackingUpdate, error) {
+	if signature != "valid" {
+		return nil, errors.New("invalid signature")
+	}
+	var updates []TrackingUpdate
+	if err := json.Unmarshal(payload, &updates); err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+func newTestShippingUseCase() (*ShippingUseCase, *FakeOrderFulfillment) {
+	orders := NewFakeOrderFulfillment()
+	return NewShippingUseCase(NewFakeShipmentRepository(), orders, &FakeCarrier{}), orders
+}
+
+func packageOf(skuID string, quantity int) []PackageRequest {
+	return []PackageRequest{{WeightGrams: 500, Items: []PackageItemRequest{{SKUID: skuID, Quantity: quantity}}}}
+}
+
+func TestCreateShipmentsDeliversOrderWhenAllShipmentsDelivered(t *testing.T) {
+	uc, orders := newTestShippingUseCase()
+	ctx := context.Background()
+	orders.Add("order-1", orderStatusPaid, map[string]int{"sku-1": 2, "sku-2": 1})
+
+	first, err := uc.CreateShipment(ctx, CreateShipmentRequest{OrderID: "order-1", Carrier: "fake", Packages: packageOf("sku-1", 2)})
+	if err != nil {
+		t.Fatalf("Failed to create first shipment: %v", err)
+	}
+	if first.TrackingNumber() == "" {
+		t.Error("Expected carrier to issue a tracking number")
+	}
+	if orders.orders["order-1"].Status != orderStatusShipped {
+		t.Fatalf("Expected order to be shipped, got %s", orders.orders["order-1"].Status)
+	}
+
+	// 첫 배송만 완료되어서는 나머지 수량이 출고되지 않았으므로 주문은 배송 중으로 남습니다
+	_, err = uc.RecordEvent(ctx, first.ID(), TrackingEventRequest{Status: domain.ShipmentStatusDelivered})
+	if err != nil {
+		t.Fatalf("Failed to record delivery: %v", err)
+	}
+	if orders.orders["order-1"].Status != orderStatusShipped {
+		t.Fatalf("Expected order to stay shipped, got %s", orders.orders["order-1"].Status)
+	}
+
+	second, err := uc.CreateShipment(ctx, CreateShipmentRequest{OrderID: "order-1", Carrier: "fake", TrackingNumber: "T-2", Packages: packageOf("sku-2", 1)})
+	if err != nil {
+		t.Fatalf("Failed to create second shipment: %v", err)
+	}
+	if _, err := uc.RecordEvent(ctx, second.ID(), TrackingEventRequest{Status: domain.ShipmentStatusDelivered}); err != nil {
+		t.Fatalf("Failed to record delivery: %v", err)
+	}
+	if orders.orders["order-1"].Status != "delivered" {
+		t.Errorf("Expected order to be delivered, got %s", orders.orders["order-1"].Status)
+	}
+}
+
+func TestCreateShipmentRejectsOverShipmentAndUnpaidOrders(t *testing.T) {
+	uc, orders := newTestShippingUseCase()
+	ctx := context.Background()
+	orders.Add("order-1", orderStatusPaid, map[string]int{"sku-1": 2})
+	orders.Add("order-2", "pending", map[string]int{"sku-1": 1})
+
+	if _, err := uc.CreateShipment(ctx, CreateShipmentRequest{OrderID: "order-1", Carrier: "fake", Packages: packageOf("sku-1", 1)}); err != nil {
+		t.Fatalf("Failed to create shipment: %v", err)
+	}
+	_, err := uc.CreateShipment(ctx, CreateShipmentRequest{OrderID: "order-1", Carrier: "fake", Packages: packageOf("sku-1", 2)})
+	if !errors.Is(err, ErrExceedsOrderedQuantity) {
+		t.Errorf("Expected ErrExceedsOrderedQuantity, got %v", err)
+	}
+
+	_, err = uc.CreateShipment(ctx, CreateShipmentRequest{OrderID: "order-2", Carrier: "fake", Packages: packageOf("sku-1", 1)})
+	if !errors.Is(err, ErrOrderNotShippable) {
+		t.Errorf("Expected ErrOrderNotShippable, got %v", err)
+	}
+}
+
+func TestReceiveTrackingWebhookIsIdempotent(t *testing.T) {
+	uc, orders := newTestShippingUseCase()
+	ctx := context.Background()
+	orders.Add("order-1", orderStatusPaid, map[string]int{"sku-1": 1})
+
+	shipment, err := uc.CreateShipment(ctx, CreateShipmentRequest{OrderID: "order-1", Carrier: "fake", TrackingNumber: "T-1", Packages: packageOf("sku-1", 1)})
+	if err != nil {
+		t.Fatalf("Failed to create shipment: %v", err)
+	}
+
+	deliveredAt := time.Date(2024, 5, 2, 14, 0, 0, 0, time.UTC)
+	payload, _ := json.Marshal([]TrackingUpdate{
+		{TrackingNumber: "T-1", Status: domain.ShipmentStatusDelivered, Description: "배송 완료", OccurredAt: deliveredAt},
+		{TrackingNumber: "T-1", Status: domain.ShipmentStatusOutForDelivery, OccurredAt: deliveredAt.Add(-2 * time.Hour)},
+		{TrackingNumber: "UNKNOWN", Status: domain.ShipmentStatusInTransit, OccurredAt: deliveredAt},
+	})
+
+	if _, err := uc.ReceiveTrackingWebhook(ctx, "fake", "forged", payload); !errors.Is(err, ErrInvalidWebhook) {
+		t.Fatalf("Expected ErrInvalidWebhook, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		applied, err := uc.ReceiveTrackingWebhook(ctx, "fake", "valid", payload)
+		if err != nil {
+			t.Fatalf("Failed to receive webhook: %v", err)
+		}
+		if applied != 2 {
+			t.Errorf("Expected 2 applied updates, got %d", applied)
+		}
+	}
+
+	if len(shipment.Events()) != 3 {
+		t.Errorf("Expected 3 events after duplicate webhook, got %d", len(shipment.Events()))
+	}
+	if !shipment.IsDelivered() || !shipment.DeliveredAt().Equal(deliveredAt) {
+		t.Errorf("Expected shipment delivered at %v, got %s at %v", deliveredAt, shipment.Status(), shipment.DeliveredAt())
+	}
+	if orders.orders["order-1"].Status != "delivered" {
+		t.Errorf("Expected order to be delivered, got %s", orders.orders["order-1"].Status)
+	}
+}
@@ -0,0 +1,112 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"example.com/myapp/shipping/domain"
+)
+
+// ShipmentRepository는 배송 관련 영속성 인터페이스를 정의합니다.
+type ShipmentRepository interface {
+	// Save는 배송을 저장합니다. 같은 택배사의 운송장 번호가 이미 있으면 domain.ErrDuplicateTracking을 반환합니다.
+	Save(ctx context.Context, shipment *domain.Shipment) error
+	FindByID(ctx context.Context, id string) (*domain.Shipment, error)
+	FindByOrderID(ctx context.Context, orderID string) ([]*domain.Shipment, error)
+	FindByTrackingNumber(ctx context.Context, carrier, trackingNumber string) (*domain.Shipment, error)
+	// Update는 배송 상태를 저장하고 아직 저장되지 않은 추적 이벤트를 추가합니다.
+	Update(ctx context.Context, shipment *domain.Shipment) error
+}
+
+// OrderFulfillment는 배송 진행에 맞춰 주문 상태를 바꾸는 주문 포트를 정의합니다.
+// MarkShipped와 MarkDelivered는 이미 해당 상태인 주문에 대해 호출되어도 안전해야 합니다.
+type OrderFulfillment interface {
+	FindOrder(ctx context.Context, orderID string) (*FulfillmentOrder, error)
+	MarkShipped(ctx context.Context, orderID string) error
+	MarkDelivered(ctx context.Context, orderID string) error
+}
+
+// FulfillmentOrder는 배송에 필요한 주문 상태와 주문 수량을 정의합니다.
+type FulfillmentOrder struct {
+	ID         string
+	Status     string
+	Quantities map[string]int // SKU ID별 주문 수량
+}
+
+// Carrier는 운송장 발급과 배송 추적 웹훅 해석을 제공하는 택배사 포트를 정의합니다.
+type Carrier interface {
+	Name() string
+	// IssueTrackingNumber는 운송장 번호 없이 생성된 배송에 택배사 운송장 번호를 발급합니다.
+	IssueTrackingNumber(ctx context.Context, orderID string) (string, error)
+	// ParseTrackingWebhook은 서명을 검증하고 웹훅 본문을 배송 추적 갱신 목록으로 변환합니다.
+	ParseTrackingWebhook(signature string, payload []byte) ([]TrackingUpdate, error)
+}
+
+// TrackingUpdate는 택배사가 알려준 운송장별 배송 추적 갱신을 정의합니다.
+type TrackingUpdate struct {
+	TrackingNumber string
+	Status         domain.ShipmentStatus
+	Description    string
+	Location       string
+	OccurredAt     time.Time
+}
+
+// ShippingService는 배송 관련 비즈니스 로직을 정의합니다.
+type ShippingService interface {
+	CreateShipment(ctx context.Context, req CreateShipmentRequest) (*domain.Shipment, error)
+	GetShipment(ctx context.Context, id string) (*domain.Shipment, error)
+	GetOrderShipments(ctx context.Context, orderID string) ([]*domain.Shipment, error)
+	RecordEvent(ctx context.Context, shipmentID string, req TrackingEventRequest) (*domain.Shipment, error)
+	// ReceiveTrackingWebhook은 택배사 웹훅을 처리하고 반영한 갱신 건수를 반환합니다.
+	ReceiveTrackingWebhook(ctx context.Context, carrier, signature string, payload []byte) (int, error)
+}
+
+// CreateShipmentRequest는 배송 생성 요청 정보를 정의합니다.
+// 운송장 번호를 생략하면 택배사에서 발급받습니다.
+type CreateShipmentRequest struct {
+	OrderID        string
+	Carrier        string
+	TrackingNumber string
+	Packages       []PackageRequest
+}
+
+// PackageRequest는 상자 하나의 무게와 품목을 정의합니다.
+type PackageRequest struct {
+	WeightGrams int
+	Items       []PackageItemRequest
+}
+
+// PackageItemRequest는 상자에 담을 SKU와 수량을 정의합니다.
+type PackageItemRequest struct {
+	SKUID    string
+	Quantity int
+}
+
+// TrackingEventRequest는 배송 추적 이벤트 기록 요청 정보를 정의합니다.
+type TrackingEventRequest struct {
+	Status      domain.ShipmentStatus
+	Description string
+	Location    string
+	OccurredAt  time.Time
+}
+
+// ShippingUseCase는 ShippingService 구현체를 정의합니다.
+type ShippingUseCase struct {
+	repo     ShipmentRepository
+	orders   OrderFulfillment
+	carriers map[string]Carrier
+}
+
+// NewShippingUseCase는 새로운 ShippingUseCase 인스턴스를 생성합니다.
+// 등록된 택배사만 운송장 발급과 웹훅 수신에 사용할 수 있습니다.
+func NewShippingUseCase(repo ShipmentRepository, orders OrderFulfillment, carriers ...Carrier) *ShippingUseCase {
+	registry := make(map[string]Carrier, len(carriers))
+	for _, carrier := range carriers {
+		registry[carrier.Name()] = carrier
+	}
+	return &ShippingUseCase{
+		repo:     repo,
+		orders:   orders,
+		carriers: registry,
+	}
+}
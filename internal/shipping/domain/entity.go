@@ -0,0 +1,315 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShipmentStatus는 배송 상태를 정의합니다.
+type ShipmentStatus string
+
+const (
+	ShipmentStatusInTransit      ShipmentStatus = "in_transit"       // 출고되어 운송 중
+	ShipmentStatusOutForDelivery ShipmentStatus = "out_for_delivery" // 배송 기사가 배달 중
+	ShipmentStatusDelivered      ShipmentStatus = "delivered"        // 배송 완료
+	ShipmentStatusException      ShipmentStatus = "exception"        // 주소 불명 등 배송 문제 발생
+)
+
+var (
+	ErrInvalidOrderID        = errors.New("invalid order ID")
+	ErrInvalidCarrier        = errors.New("invalid carrier")
+	ErrInvalidTrackingNumber = errors.New("invalid tracking number")
+	ErrInvalidPackage        = errors.New("shipment must have at least one package with items")
+	ErrInvalidPackageItem    = errors.New("package item must have a SKU and a positive quantity")
+	ErrInvalidEventStatus    = errors.New("invalid tracking event status")
+	ErrShipmentNotFound      = errors.New("shipment not found")
+	ErrDuplicateTracking     = errors.New("tracking number is already registered for this carrier")
+)
+
+// IsValid는 배송 상태가 정의된 값인지 확인합니다.
+func (s ShipmentStatus) IsValid() bool {
+	switch s {
+	case ShipmentStatusInTransit, ShipmentStatusOutForDelivery, ShipmentStatusDelivered, ShipmentStatusException:
+		return true
+	default:
+		return false
+	}
+}
+
+// PackageItem은 상자에 담긴 SKU와 수량을 나타냅니다.
+type PackageItem struct {
+	skuID    string
+	quantity int
+}
+
+// NewPackageItem은 새로운 상자 품목을 생성합니다.
+func NewPackageItem(skuID string, quantity int) (*PackageItem, error) {
+	if skuID == "" || quantity <= 0 {
+		return nil, ErrInvalidPackageItem
+	}
+	return &PackageItem{skuID: skuID, quantity: quantity}, nil
+}
+
+// SKUID는 SKU ID를 반환합니다.
+func (i *PackageItem) SKUID() string {
+	return i.skuID
+}
+
+// Quantity는 수량을 반환합니다.
+func (i *PackageItem) Quantity() int {
+	return i.quantity
+}
+
+// Package는 배송에 포함된 상자 하나를 나타냅니다.
+type Package struct {
+	id          string
+	weightGrams int
+	items       []*PackageItem
+}
+
+// NewPackage는 새로운 상자를 생성합니다.
+func NewPackage(weightGrams int, items []*PackageItem) (*Package, error) {
+	if len(items) == 0 || weightGrams < 0 {
+		return nil, ErrInvalidPackage
+	}
+	return &Package{
+		id:          uuid.New().String(),
+		weightGrams: weightGrams,
+		items:       items,
+	}, nil
+}
+
+// RestorePackage는 저장된 데이터로부터 상자를 복원합니다.
+func RestorePackage(id string, weightGrams int, items []*PackageItem) *Package {
+	return &Package{
+		id:          id,
+		weightGrams: weightGrams,
+		items:       items,
+	}
+}
+
+// ID는 상자의 고유 식별자를 반환합니다.
+func (p *Package) ID() string {
+	return p.id
+}
+
+// WeightGrams는 상자 무게(그램)를 반환합니다.
+func (p *Package) WeightGrams() int {
+	return p.weightGrams
+}
+
+// Items는 상자에 담긴 품목을 반환합니다.
+func (p *Package) Items() []*PackageItem {
+	return p.items
+}
+
+// TrackingEvent는 택배사가 알려준 배송 추적 이벤트를 나타냅니다.
+type TrackingEvent struct {
+	status      ShipmentStatus
+	description string
+	location    string
+	occurredAt  time.Time
+}
+
+// NewTrackingEvent는 새로운 배송 추적 이벤트를 생성합니다.
+func NewTrackingEvent(status ShipmentStatus, description, location string, occurredAt time.Time) (*TrackingEvent, error) {
+	if !status.IsValid() {
+		return nil, ErrInvalidEventStatus
+	}
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+	return &TrackingEvent{
+		status:      status,
+		description: description,
+		location:    location,
+		occurredAt:  occurredAt,
+	}, nil
+}
+
+// Status는 이벤트가 나타내는 배송 상태를 반환합니다.
+func (e *TrackingEvent) Status() ShipmentStatus {
+	return e.status
+}
+
+// Description은 이벤트 설명을 반환합니다.
+func (e *TrackingEvent) Description() string {
+	return e.description
+}
+
+// Location은 이벤트가 발생한 위치를 반환합니다.
+func (e *TrackingEvent) Location() string {
+	return e.location
+}
+
+// OccurredAt은 이벤트가 발생한 시간을 반환합니다.
+func (e *TrackingEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// Shipment는 주문 일부 또는 전체를 담아 출고한 배송 엔티티를 나타냅니다.
+// 하나의 주문은 여러 배송으로 나누어 보낼 수 있습니다.
+type Shipment struct {
+	id             string
+	orderID        string
+	carrier        string
+	trackingNumber string
+	status         ShipmentStatus
+	packages       []*Package
+	events         []*TrackingEvent
+	createdAt      time.Time
+	updatedAt      time.Time
+	deliveredAt    time.Time
+}
+
+// NewShipment는 새로운 배송을 생성합니다. 배송은 출고된 상태(in_transit)로 시작합니다.
+func NewShipment(orderID, carrier, trackingNumber string, packages []*Package) (*Shipment, error) {
+	if orderID == "" {
+		return nil, ErrInvalidOrderID
+	}
+	carrier = strings.ToLower(strings.TrimSpace(carrier))
+	if carrier == "" {
+		return nil, ErrInvalidCarrier
+	}
+	trackingNumber = strings.TrimSpace(trackingNumber)
+	if trackingNumber == "" {
+		return nil, ErrInvalidTrackingNumber
+	}
+	if len(packages) == 0 {
+		return nil, ErrInvalidPackage
+	}
+
+	now := time.Now()
+	shipped, _ := NewTrackingEvent(ShipmentStatusInTransit, "출고", "", now)
+	return &Shipment{
+		id:             uuid.New().String(),
+		orderID:        orderID,
+		carrier:        carrier,
+		trackingNumber: trackingNumber,
+		status:         ShipmentStatusInTransit,
+		packages:       packages,
+		events:         []*TrackingEvent{shipped},
+		createdAt:      now,
+		updatedAt:      now,
+	}, nil
+}
+
+// RestoreShipment는 저장된 데이터로부터 배송을 복원합니다.
+func RestoreShipment(
+	id, orderID, carrier, trackingNumber string,
+	status ShipmentStatus,
+	packages []*Package,
+	events []*TrackingEvent,
+	createdAt, updatedAt, deliveredAt time.Time,
+) *Shipment {
+	return &Shipment{
+		id:             id,
+		orderID:        orderID,
+		carrier:        carrier,
+		trackingNumber: trackingNumber,
+		status:         status,
+		packages:       packages,
+		events:         events,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
+		deliveredAt:    deliveredAt,
+	}
+}
+
+// ID는 배송의 고유 식별자를 반환합니다.
+func (s *Shipment) ID() string {
+	return s.id
+}
+
+// OrderID는 주문 ID를 반환합니다.
+func (s *Shipment) OrderID() string {
+	return s.orderID
+}
+
+// Carrier는 택배사 코드를 반환합니다.
+func (s *Shipment) Carrier() string {
+	return s.carrier
+}
+
+// TrackingNumber는 운송장 번호를 반환합니다.
+func (s *Shipment) TrackingNumber() string {
+	return s.trackingNumber
+}
+
+// Status는 배송 상태를 반환합니다.
+func (s *Shipment) Status() ShipmentStatus {
+	return s.status
+}
+
+// Packages는 배송에 포함된 상자 목록을 반환합니다.
+func (s *Shipment) Packages() []*Package {
+	return s.packages
+}
+
+// Events는 배송 추적 이벤트를 기록된 순서대로 반환합니다.
+func (s *Shipment) Events() []*TrackingEvent {
+	return s.events
+}
+
+// CreatedAt은 배송이 생성된 시간을 반환합니다.
+func (s *Shipment) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// UpdatedAt은 배송 정보가 마지막으로 업데이트된 시간을 반환합니다.
+func (s *Shipment) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// DeliveredAt은 배송이 완료된 시간을 반환합니다. 배송 전이면 zero 값입니다.
+func (s *Shipment) DeliveredAt() time.Time {
+	return s.deliveredAt
+}
+
+// IsDelivered는 배송이 완료되었는지 확인합니다.
+func (s *Shipment) IsDelivered() bool {
+	return s.status == ShipmentStatusDelivered
+}
+
+// ItemQuantities는 배송에 담긴 SKU별 수량 합계를 반환합니다.
+func (s *Shipment) ItemQuantities() map[string]int {
+	quantities := make(map[string]int)
+	for _, pkg := range s.packages {
+		for _, item := range pkg.items {
+			quantities[item.skuID] += item.quantity
+		}
+	}
+	return quantities
+}
+
+// RecordEvent는 배송 추적 이벤트를 기록하고 배송 상태를 갱신합니다.
+// 같은 상태와 발생 시간의 이벤트가 이미 있으면 무시하고 false를 반환합니다.
+// 웹훅은 순서가 바뀌어 도착할 수 있으므로 가장 최근에 발생한 이벤트가 상태를 결정하며,
+// 배송 완료 후에는 이벤트는 기록하지만 상태는 바뀌지 않습니다.
+func (s *Shipment) RecordEvent(event *TrackingEvent) bool {
+	latest := time.Time{}
+	for _, e := range s.events {
+		if e.status == event.status && e.occurredAt.Equal(event.occurredAt) {
+			return false
+		}
+		if e.occurredAt.After(latest) {
+			latest = e.occurredAt
+		}
+	}
+
+	s.events = append(s.events, event)
+	switch {
+	case s.status == ShipmentStatusDelivered:
+		// 배송 완료 후에는 상태를 유지합니다
+	case event.status == ShipmentStatusDelivered:
+		s.status = ShipmentStatusDelivered
+		s.deliveredAt = event.occurredAt
+	case !event.occurredAt.Before(latest):
+		s.status = event.status
+	}
+	s.updatedAt = time.Now()
+	return true
+}
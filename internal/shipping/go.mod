@@ -0,0 +1,3 @@
+module example.com/myapp/shipping
+
+go 1.21
\ No newline at end of file
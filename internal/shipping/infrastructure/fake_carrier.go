@@ -0,0 +1,93 @@
+package infrastructure
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"example.com/myapp/shipping/application"
+	"example.com/myapp/shipping/domain"
+	"github.com/google/uuid"
+)
+
+// FakeCarrierName은 로컬 개발과 테스트용 택배사 코드입니다.
+const FakeCarrierName = "fake"
+
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// FakeCarrier는 외부 택배사 없이 운송장을 발급하고 배송 추적 웹훅을 받는 Carrier 구현체입니다.
+// 비밀 키가 설정되면 웹훅 본문의 HMAC-SHA256 서명(16진수)을 검증합니다.
+type FakeCarrier struct {
+	secret []byte
+}
+
+// NewFakeCarrier는 새로운 FakeCarrier 인스턴스를 생성합니다.
+// secret이 비어 있으면 웹훅 서명을 검증하지 않습니다.
+func NewFakeCarrier(secret string) application.Carrier {
+	return &FakeCarrier{
+		secret: []byte(secret),
+	}
+}
+
+// fakeWebhookPayload는 가짜 택배사의 배송 추적 웹훅 본문입니다.
+type fakeWebhookPayload struct {
+	Events []struct {
+		TrackingNumber string    `json:"trackingNumber"`
+		Status         string    `json:"status"`
+		Description    string    `json:"description"`
+		Location       string    `json:"location"`
+		OccurredAt     time.Time `json:"occurredAt"`
+	} `json:"events"`
+}
+
+// Name은 택배사 코드를 반환합니다.
+func (c *FakeCarrier) Name() string {
+	return FakeCarrierName
+}
+
+// IssueTrackingNumber는 "FAKE-"로 시작하는 운송장 번호를 발급합니다.
+func (c *FakeCarrier) IssueTrackingNumber(ctx context.Context, orderID string) (string, error) {
+	id := strings.ReplaceAll(uuid.New().String(), "-", "")
+	return "FAKE-" + strings.ToUpper(id[:12]), nil
+}
+
+// ParseTrackingWebhook은 서명을 검증하고 웹훅 본문의 이벤트를 배송 추적 갱신으로 변환합니다.
+func (c *FakeCarrier) ParseTrackingWebhook(signature string, payload []byte) ([]application.TrackingUpdate, error) {
+	if len(c.secret) > 0 && !hmac.Equal([]byte(signature), []byte(c.sign(payload))) {
+		return nil, ErrInvalidSignature
+	}
+
+	var body fakeWebhookPayload
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook payload: %w", err)
+	}
+
+	updates := make([]application.TrackingUpdate, 0, len(body.Events))
+	for _, event := range body.Events {
+		status := domain.ShipmentStatus(event.Status)
+		if event.TrackingNumber == "" || !status.IsValid() {
+			return nil, fmt.Errorf("%w: %q", domain.ErrInvalidEventStatus, event.Status)
+		}
+		updates = append(updates, application.TrackingUpdate{
+			TrackingNumber: event.TrackingNumber,
+			Status:         status,
+			Description:    event.Description,
+			Location:       event.Location,
+			OccurredAt:     event.OccurredAt,
+		})
+	}
+	return updates, nil
+}
+
+// sign은 웹훅 본문의 HMAC-SHA256 서명을 16진수 문자열로 계산합니다.
+func (c *FakeCarrier) sign(payload []byte) string {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
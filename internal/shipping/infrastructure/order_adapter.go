@@ -0,0 +1,72 @@
+package infrastructure
+
+import (
+	"context"
+
+	orderApp "example.com/myapp/order/application"
+	orderDomain "example.com/myapp/order/domain"
+	"example.com/myapp/shipping/application"
+)
+
+// shippingActor는 배송 진행으로 바뀐 주문 상태 이력에 기록되는 행위자입니다.
+const shippingActor = "shipping"
+
+// OrderFulfillmentAdapter는 주문 모듈의 공개 API로 OrderFulfillment 포트를 구현합니다.
+type OrderFulfillmentAdapter struct {
+	orders orderApp.OrderService
+}
+
+// NewOrderFulfillmentAdapter는 새로운 OrderFulfillmentAdapter 인스턴스를 생성합니다.
+func NewOrderFulfillmentAdapter(orders orderApp.OrderService) application.OrderFulfillment {
+	return &OrderFulfillmentAdapter{
+		orders: orders,
+	}
+}
+
+// FindOrder는 주문 상태와 SKU별 주문 수량을 조회합니다.
+func (a *OrderFulfillmentAdapter) FindOrder(ctx context.Context, orderID string) (*application.FulfillmentOrder, error) {
+	order, err := a.orders.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	quantities := make(map[string]int, len(order.Items()))
+	for _, item := range order.Items() {
+		quantities[item.SKUID()] += item.Quantity()
+	}
+	return &application.FulfillmentOrder{
+		ID:         order.ID(),
+		Status:     string(order.Status()),
+		Quantities: quantities,
+	}, nil
+}
+
+// MarkShipped는 결제 완료된 주문을 배송 중 상태로 바꿉니다.
+// 이미 배송 중이거나 배송 완료 또는 반품된 주문은 그대로 둡니다.
+func (a *OrderFulfillmentAdapter) MarkShipped(ctx context.Context, orderID string) error {
+	return a.advance(ctx, orderID, orderDomain.StatusShipped, "배송 출고",
+		orderDomain.StatusShipped, orderDomain.StatusDelivered, orderDomain.StatusPartiallyReturned, orderDomain.StatusReturned)
+}
+
+// MarkDelivered는 배송 중인 주문을 배송 완료 상태로 바꿉니다.
+// 이미 배송 완료 또는 반품된 주문은 그대로 둡니다.
+func (a *OrderFulfillmentAdapter) MarkDelivered(ctx context.Context, orderID string) error {
+	return a.advance(ctx, orderID, orderDomain.StatusDelivered, "모든 배송 완료",
+		orderDomain.StatusDelivered, orderDomain.StatusPartiallyReturned, orderDomain.StatusReturned)
+}
+
+// advance는 주문이 done 상태 중 하나가 아니면 target 상태로 바꾸고 배송 모듈을 행위자로 기록합니다.
+func (a *OrderFulfillmentAdapter) advance(ctx context.Context, orderID string, target orderDomain.OrderStatus, reason string, done ...orderDomain.OrderStatus) error {
+	order, err := a.orders.GetOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	for _, status := range done {
+		if order.Status() == status {
+			return nil
+		}
+	}
+
+	_, err = a.orders.UpdateOrderStatus(ctx, orderID, target, shippingActor, reason)
+	return err
+}
@@ -0,0 +1,359 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"example.com/myapp/shared/db"
+	"example.com/myapp/shipping/application"
+	"example.com/myapp/shipping/domain"
+	"github.com/jackc/pgx/v4"
+)
+
+// PostgresShipmentRepository는 PostgreSQL을 사용하는 배송 저장소 구현체입니다.
+// 추적 이벤트는 (배송, 상태, 발생 시간) 유니크 제약으로 중복 저장되지 않습니다.
+type PostgresShipmentRepository struct {
+	db *db.Database
+}
+
+// NewPostgresShipmentRepository는 새로운 PostgresShipmentRepository 인스턴스를 생성합니다.
+func NewPostgresShipmentRepository(database *db.Database) application.ShipmentRepository {
+	return &PostgresShipmentRepository{
+		db: database,
+	}
+}
+
+// Save는 배송과 상자, 추적 이벤트를 하나의 트랜잭션으로 저장합니다.
+func (r *PostgresShipmentRepository) Save(ctx context.Context, shipment *domain.Shipment) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	// 1. 배송 정보 저장 (같은 택배사의 운송장 번호는 하나만 허용)
+	query := `
+		INSERT INTO shipments (id, order_id, carrier, tracking_number, status, created_at, updated_at, delivered_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (carrier, tracking_number) DO NOTHING
+	`
+
+	result, err := tx.Exec(
+		ctx,
+		query,
+		shipment.ID(),
+		shipment.OrderID(),
+		shipment.Carrier(),
+		shipment.TrackingNumber(),
+		string(shipment.Status()),
+		shipment.CreatedAt(),
+		shipment.UpdatedAt(),
+		nullableTime(shipment.DeliveredAt()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save shipment: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrDuplicateTracking
+	}
+
+	// 2. 상자와 상자 품목 저장
+	packageQuery := `
+		INSERT INTO shipment_packages (id, shipment_id, position, weight_grams)
+		VALUES ($1, $2, $3, $4)
+	`
+	itemQuery := `
+		INSERT INTO shipment_package_items (package_id, sku_id, quantity)
+		VALUES ($1, $2, $3)
+	`
+
+	for i, pkg := range shipment.Packages() {
+		if _, err := tx.Exec(ctx, packageQuery, pkg.ID(), shipment.ID(), i, pkg.WeightGrams()); err != nil {
+			return fmt.Errorf("failed to save shipment package: %w", err)
+		}
+		for _, item := range pkg.Items() {
+			if _, err := tx.Exec(ctx, itemQuery, pkg.ID(), item.SKUID(), item.Quantity()); err != nil {
+				return fmt.Errorf("failed to save shipment package item: %w", err)
+			}
+		}
+	}
+
+	// 3. 추적 이벤트 저장
+	if err := insertEvents(ctx, tx, shipment); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID는 ID로 배송을 조회합니다.
+func (r *PostgresShipmentRepository) FindByID(ctx context.Context, id string) (*domain.Shipment, error) {
+	query := `
+		SELECT id, order_id, carrier, tracking_number, status, created_at, updated_at, delivered_at
+		FROM shipments
+		WHERE id = $1
+	`
+
+	return r.findOne(ctx, query, id)
+}
+
+// FindByTrackingNumber는 택배사와 운송장 번호로 배송을 조회합니다.
+func (r *PostgresShipmentRepository) FindByTrackingNumber(ctx context.Context, carrier, trackingNumber string) (*domain.Shipment, error) {
+	query := `
+		SELECT id, order_id, carrier, tracking_number, status, created_at, updated_at, delivered_at
+		FROM shipments
+		WHERE carrier = $1 AND tracking_number = $2
+	`
+
+	return r.findOne(ctx, query, carrier, trackingNumber)
+}
+
+// FindByOrderID는 주문의 배송 목록을 생성된 순서대로 조회합니다.
+func (r *PostgresShipmentRepository) FindByOrderID(ctx context.Context, orderID string) ([]*domain.Shipment, error) {
+	query := `
+		SELECT id
+		FROM shipments
+		WHERE order_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shipments: %w", err)
+	}
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan shipment: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating shipments: %w", err)
+	}
+
+	shipments := make([]*domain.Shipment, 0, len(ids))
+	for _, id := range ids {
+		shipment, err := r.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		shipments = append(shipments, shipment)
+	}
+
+	return shipments, nil
+}
+
+// Update는 배송 상태를 저장하고 아직 저장되지 않은 추적 이벤트를 추가합니다.
+// 이미 배송 완료로 저장된 배송의 상태는 되돌리지 않습니다.
+func (r *PostgresShipmentRepository) Update(ctx context.Context, shipment *domain.Shipment) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // 실패 시 트랜잭션 롤백
+
+	query := `
+		UPDATE shipments
+		SET status = CASE WHEN status = 'delivered' THEN status ELSE $1 END,
+			delivered_at = COALESCE(delivered_at, $2),
+			updated_at = $3
+		WHERE id = $4
+	`
+
+	result, err := tx.Exec(
+		ctx,
+		query,
+		string(shipment.Status()),
+		nullableTime(shipment.DeliveredAt()),
+		shipment.UpdatedAt(),
+		shipment.ID(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update shipment: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrShipmentNotFound
+	}
+
+	if err := insertEvents(ctx, tx, shipment); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresShipmentRepository) findOne(ctx context.Context, query string, args ...interface{}) (*domain.Shipment, error) {
+	row := r.db.Pool.QueryRow(ctx, query, args...)
+
+	var id, orderID, carrier, trackingNumber, status string
+	var createdAt, updatedAt time.Time
+	var deliveredAt *time.Time
+
+	err := row.Scan(&id, &orderID, &carrier, &trackingNumber, &status, &createdAt, &updatedAt, &deliveredAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrShipmentNotFound
+		}
+		return nil, fmt.Errorf("failed to find shipment: %w", err)
+	}
+
+	packages, err := r.findPackages(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	events, err := r.findEvents(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var delivered time.Time
+	if deliveredAt != nil {
+		delivered = *deliveredAt
+	}
+
+	return domain.RestoreShipment(
+		id, orderID, carrier, trackingNumber,
+		domain.ShipmentStatus(status),
+		packages,
+		events,
+		createdAt, updatedAt, delivered,
+	), nil
+}
+
+func (r *PostgresShipmentRepository) findPackages(ctx context.Context, shipmentID string) ([]*domain.Package, error) {
+	query := `
+		SELECT p.id, p.weight_grams, i.sku_id, i.quantity
+		FROM shipment_packages p
+		JOIN shipment_package_items i ON i.package_id = p.id
+		WHERE p.shipment_id = $1
+		ORDER BY p.position, i.sku_id
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, shipmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shipment packages: %w", err)
+	}
+	defer rows.Close()
+
+	packages := []*domain.Package{}
+	var currentID string
+	var weight int
+	var items []*domain.PackageItem
+	for rows.Next() {
+		var packageID, skuID string
+		var packageWeight, quantity int
+
+		if err := rows.Scan(&packageID, &packageWeight, &skuID, &quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan shipment package: %w", err)
+		}
+
+		if packageID != currentID {
+			if currentID != "" {
+				packages = append(packages, domain.RestorePackage(currentID, weight, items))
+			}
+			currentID, weight, items = packageID, packageWeight, nil
+		}
+		item, err := domain.NewPackageItem(skuID, quantity)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating shipment packages: %w", err)
+	}
+
+	if currentID != "" {
+		packages = append(packages, domain.RestorePackage(currentID, weight, items))
+	}
+	return packages, nil
+}
+
+func (r *PostgresShipmentRepository) findEvents(ctx context.Context, shipmentID string) ([]*domain.TrackingEvent, error) {
+	query := `
+		SELECT status, description, location, occurred_at
+		FROM shipment_events
+		WHERE shipment_id = $1
+		ORDER BY recorded_at, occurred_at
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, shipmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shipment events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*domain.TrackingEvent{}
+	for rows.Next() {
+		var status, description, location string
+		var occurredAt time.Time
+
+		if err := rows.Scan(&status, &description, &location, &occurredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan shipment event: %w", err)
+		}
+
+		event, err := domain.NewTrackingEvent(domain.ShipmentStatus(status), description, location, occurredAt)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating shipment events: %w", err)
+	}
+
+	return events, nil
+}
+
+// insertEvents는 배송의 추적 이벤트 중 아직 저장되지 않은 이벤트만 추가합니다.
+func insertEvents(ctx context.Context, tx pgx.Tx, shipment *domain.Shipment) error {
+	query := `
+		INSERT INTO shipment_events (shipment_id, status, description, location, occurred_at, recorded_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (shipment_id, status, occurred_at) DO NOTHING
+	`
+
+	recordedAt := time.Now()
+	for _, event := range shipment.Events() {
+		_, err := tx.Exec(
+			ctx,
+			query,
+			shipment.ID(),
+			string(event.Status()),
+			event.Description(),
+			event.Location(),
+			event.OccurredAt(),
+			recordedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save shipment event: %w", err)
+		}
+	}
+	return nil
+}
+
+// nullableTime은 zero 값 시간을 NULL로 저장하기 위해 변환합니다.
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
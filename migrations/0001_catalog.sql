@@ -0,0 +1,27 @@
+-- 상품 카탈로그
+CREATE TABLE IF NOT EXISTS products (
+    id          VARCHAR(36) PRIMARY KEY,
+    name        VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    category    VARCHAR(100) NOT NULL DEFAULT '',
+    status      VARCHAR(20) NOT NULL DEFAULT 'active',
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_products_category ON products (category);
+CREATE INDEX IF NOT EXISTS idx_products_status ON products (status);
+
+CREATE TABLE IF NOT EXISTS product_skus (
+    id         VARCHAR(36) PRIMARY KEY,
+    product_id VARCHAR(36) NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    code       VARCHAR(100) NOT NULL,
+    name       VARCHAR(255) NOT NULL DEFAULT '',
+    price      NUMERIC(15, 2) NOT NULL CHECK (price > 0),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (product_id, code)
+);
+
+-- 주문 항목에 주문 당시 SKU 스냅샷 보관
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS sku_id VARCHAR(36) NOT NULL DEFAULT '';
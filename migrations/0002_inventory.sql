@@ -0,0 +1,31 @@
+-- 창고별 SKU 재고
+CREATE TABLE IF NOT EXISTS stock_levels (
+    sku_id       VARCHAR(36) NOT NULL,
+    warehouse_id VARCHAR(36) NOT NULL,
+    on_hand      INTEGER NOT NULL DEFAULT 0,
+    reserved     INTEGER NOT NULL DEFAULT 0,
+    updated_at   TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (sku_id, warehouse_id),
+    CHECK (reserved >= 0),
+    CHECK (on_hand >= reserved)
+);
+
+-- 주문별 재고 예약
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id         VARCHAR(36) PRIMARY KEY,
+    order_id   VARCHAR(36) NOT NULL UNIQUE,
+    status     VARCHAR(20) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_expiry ON stock_reservations (status, expires_at);
+
+CREATE TABLE IF NOT EXISTS stock_reservation_lines (
+    reservation_id VARCHAR(36) NOT NULL REFERENCES stock_reservations (id) ON DELETE CASCADE,
+    sku_id         VARCHAR(36) NOT NULL,
+    warehouse_id   VARCHAR(36) NOT NULL,
+    quantity       INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (reservation_id, sku_id)
+);
@@ -0,0 +1,23 @@
+-- 장바구니 (member_id가 NULL이면 비회원 장바구니)
+CREATE TABLE IF NOT EXISTS carts (
+    id         VARCHAR(36) PRIMARY KEY,
+    member_id  VARCHAR(36),
+    status     VARCHAR(20) NOT NULL,
+    order_id   VARCHAR(36),
+    version    INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- 회원당 사용 중인 장바구니는 하나만 허용합니다
+CREATE UNIQUE INDEX IF NOT EXISTS idx_carts_member_active
+    ON carts (member_id) WHERE status IN ('active', 'checking_out');
+
+CREATE TABLE IF NOT EXISTS cart_lines (
+    cart_id    VARCHAR(36) NOT NULL REFERENCES carts (id) ON DELETE CASCADE,
+    product_id VARCHAR(36) NOT NULL,
+    sku_id     VARCHAR(36) NOT NULL,
+    quantity   INTEGER NOT NULL CHECK (quantity > 0),
+    added_at   TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (cart_id, sku_id)
+);
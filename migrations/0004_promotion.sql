@@ -0,0 +1,51 @@
+-- 회원 등급 (쿠폰 자격 조건에 사용)
+ALTER TABLE members ADD COLUMN IF NOT EXISTS tier VARCHAR(20) NOT NULL DEFAULT 'basic';
+
+-- 쿠폰 (비어 있는 조건 배열은 검사하지 않습니다)
+CREATE TABLE IF NOT EXISTS coupons (
+    id               VARCHAR(36) PRIMARY KEY,
+    code             VARCHAR(64) NOT NULL UNIQUE,
+    name             VARCHAR(255) NOT NULL,
+    kind             VARCHAR(20) NOT NULL,
+    value            NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    buy_quantity     INTEGER NOT NULL DEFAULT 0,
+    get_quantity     INTEGER NOT NULL DEFAULT 0,
+    min_spend        NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    product_ids      TEXT[] NOT NULL DEFAULT '{}',
+    first_order_only BOOLEAN NOT NULL DEFAULT FALSE,
+    member_tiers     TEXT[] NOT NULL DEFAULT '{}',
+    usage_limit      INTEGER NOT NULL DEFAULT 0,
+    per_member_limit INTEGER NOT NULL DEFAULT 0,
+    stackable        BOOLEAN NOT NULL DEFAULT FALSE,
+    starts_at        TIMESTAMPTZ,
+    ends_at          TIMESTAMPTZ,
+    active           BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at       TIMESTAMPTZ NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL
+);
+
+-- 쿠폰 사용 기록 (released_at이 있으면 주문 취소 등으로 한도에서 제외됩니다)
+CREATE TABLE IF NOT EXISTS coupon_redemptions (
+    id          VARCHAR(36) PRIMARY KEY,
+    coupon_id   VARCHAR(36) NOT NULL REFERENCES coupons (id),
+    code        VARCHAR(64) NOT NULL,
+    member_id   VARCHAR(36) NOT NULL,
+    order_id    VARCHAR(36) NOT NULL,
+    amount      NUMERIC(12, 2) NOT NULL,
+    redeemed_at TIMESTAMPTZ NOT NULL,
+    released_at TIMESTAMPTZ,
+    UNIQUE (coupon_id, order_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_coupon_redemptions_order ON coupon_redemptions (order_id);
+
+-- 주문에 적용된 할인 내역
+CREATE TABLE IF NOT EXISTS order_discounts (
+    order_id    VARCHAR(36) NOT NULL,
+    line_no     INTEGER NOT NULL,
+    code        VARCHAR(64) NOT NULL,
+    kind        VARCHAR(20) NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    amount      NUMERIC(12, 2) NOT NULL,
+    PRIMARY KEY (order_id, line_no)
+);
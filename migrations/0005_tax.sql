@@ -0,0 +1,11 @@
+-- 상품 과세 유형 (세율표 조회 키)
+ALTER TABLE products ADD COLUMN IF NOT EXISTS tax_category VARCHAR(50) NOT NULL DEFAULT 'standard';
+
+-- 주문 배송 국가와 세금 합계
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS destination_country CHAR(2) NOT NULL DEFAULT 'KR';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_inclusive BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_total NUMERIC(12, 2) NOT NULL DEFAULT 0;
+
+-- 주문 항목별 과세 유형과 세액
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS tax_category VARCHAR(50) NOT NULL DEFAULT 'standard';
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS tax_amount NUMERIC(12, 2) NOT NULL DEFAULT 0;
@@ -0,0 +1,39 @@
+-- 배송 (하나의 주문을 여러 배송으로 나누어 보낼 수 있습니다)
+CREATE TABLE IF NOT EXISTS shipments (
+    id              VARCHAR(36) PRIMARY KEY,
+    order_id        VARCHAR(36) NOT NULL,
+    carrier         VARCHAR(50) NOT NULL,
+    tracking_number VARCHAR(100) NOT NULL,
+    status          VARCHAR(20) NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL,
+    delivered_at    TIMESTAMPTZ,
+    UNIQUE (carrier, tracking_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_shipments_order_id ON shipments (order_id);
+
+CREATE TABLE IF NOT EXISTS shipment_packages (
+    id           VARCHAR(36) PRIMARY KEY,
+    shipment_id  VARCHAR(36) NOT NULL REFERENCES shipments (id) ON DELETE CASCADE,
+    position     INTEGER NOT NULL,
+    weight_grams INTEGER NOT NULL CHECK (weight_grams >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS shipment_package_items (
+    package_id VARCHAR(36) NOT NULL REFERENCES shipment_packages (id) ON DELETE CASCADE,
+    sku_id     VARCHAR(36) NOT NULL,
+    quantity   INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (package_id, sku_id)
+);
+
+-- 택배사 웹훅이 다시 와도 같은 이벤트는 한 번만 기록합니다
+CREATE TABLE IF NOT EXISTS shipment_events (
+    shipment_id VARCHAR(36) NOT NULL REFERENCES shipments (id) ON DELETE CASCADE,
+    status      VARCHAR(20) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    location    VARCHAR(200) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMPTZ NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (shipment_id, status, occurred_at)
+);
@@ -0,0 +1,13 @@
+-- 주문 상태 전환 이력 (주문 생성 시 from_status는 빈 값)
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id          VARCHAR(36) PRIMARY KEY,
+    order_id    VARCHAR(36) NOT NULL,
+    from_status VARCHAR(20) NOT NULL DEFAULT '',
+    to_status   VARCHAR(20) NOT NULL,
+    actor       VARCHAR(100) NOT NULL,
+    reason      TEXT NOT NULL DEFAULT '',
+    changed_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_status_history_order_id
+    ON order_status_history (order_id, changed_at);
@@ -0,0 +1,5 @@
+-- 결제 후 부분 취소된 수량 (quantity는 취소되지 않고 남은 수량)
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS canceled_quantity INTEGER NOT NULL DEFAULT 0;
+
+-- 결제 전 항목 변경 후에도 항목 순서를 유지하기 위한 순번
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS line_no INTEGER NOT NULL DEFAULT 0;
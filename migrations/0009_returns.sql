@@ -0,0 +1,30 @@
+-- 배송 완료 후 반품된 수량 (반품된 수량도 quantity에 포함)
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS returned_quantity INTEGER NOT NULL DEFAULT 0;
+
+-- 반품 요청 (RMA)
+CREATE TABLE IF NOT EXISTS returns (
+    id               VARCHAR(36) PRIMARY KEY,
+    order_id         VARCHAR(36) NOT NULL,
+    customer_id      VARCHAR(36) NOT NULL,
+    reason           VARCHAR(30) NOT NULL,
+    comment          TEXT NOT NULL DEFAULT '',
+    status           VARCHAR(20) NOT NULL,
+    note             TEXT NOT NULL DEFAULT '',
+    refund_amount    NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    refund_reference VARCHAR(100) NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_returns_order_id ON returns (order_id);
+
+CREATE TABLE IF NOT EXISTS return_lines (
+    return_id         VARCHAR(36) NOT NULL REFERENCES returns (id) ON DELETE CASCADE,
+    line_no           INTEGER NOT NULL,
+    item_id           VARCHAR(36) NOT NULL,
+    sku_id            VARCHAR(36) NOT NULL DEFAULT '',
+    quantity          INTEGER NOT NULL CHECK (quantity > 0),
+    received_quantity INTEGER NOT NULL DEFAULT 0 CHECK (received_quantity >= 0),
+    accepted_quantity INTEGER NOT NULL DEFAULT 0 CHECK (accepted_quantity >= 0),
+    PRIMARY KEY (return_id, item_id)
+);
@@ -0,0 +1,4 @@
+-- 결제 기한 만료 작업이 주문을 선점한 기한 (여러 인스턴스의 중복 처리 방지)
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS expiry_claimed_until TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_orders_status_created_at ON orders (status, created_at);
@@ -0,0 +1,6 @@
+-- 관리자 주문 검색 정렬과 필터용 인덱스
+CREATE INDEX IF NOT EXISTS idx_orders_created_at_id ON orders (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_orders_updated_at_id ON orders (updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_orders_total_amount_id ON orders (total_amount, id);
+CREATE INDEX IF NOT EXISTS idx_orders_customer_id ON orders (customer_id);
+CREATE INDEX IF NOT EXISTS idx_order_items_product_id ON order_items (product_id);
@@ -0,0 +1,14 @@
+-- 비회원 주문 연락처와 주문 조회 토큰 해시 (회원 계정에 연결되기 전까지 orders.customer_id는 빈 문자열)
+CREATE TABLE IF NOT EXISTS order_guest_contacts (
+    order_id          VARCHAR(36) PRIMARY KEY,
+    email             VARCHAR(255) NOT NULL,
+    name              VARCHAR(100) NOT NULL,
+    phone             VARCHAR(30) NOT NULL DEFAULT '',
+    address1          VARCHAR(255) NOT NULL,
+    address2          VARCHAR(255) NOT NULL DEFAULT '',
+    city              VARCHAR(100) NOT NULL,
+    postal_code       VARCHAR(20) NOT NULL,
+    lookup_token_hash CHAR(64) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_guest_contacts_email ON order_guest_contacts (email);
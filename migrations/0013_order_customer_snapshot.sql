@@ -0,0 +1,3 @@
+-- 주문 시점의 고객 이름과 이메일 스냅샷 (기존 주문은 빈 값)
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS customer_name VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS customer_email VARCHAR(255) NOT NULL DEFAULT '';
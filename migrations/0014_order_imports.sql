@@ -0,0 +1,8 @@
+-- CSV로 가져온 주문의 외부 주문 번호 (같은 파일을 다시 가져와도 주문이 중복 생성되지 않도록 기본 키로 사용)
+CREATE TABLE IF NOT EXISTS order_imports (
+    external_ref VARCHAR(100) PRIMARY KEY,
+    order_id     VARCHAR(36) NOT NULL,
+    imported_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_imports_order_id ON order_imports (order_id);
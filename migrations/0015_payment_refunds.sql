@@ -0,0 +1,21 @@
+-- 결제 환불 내역 (한 결제에 여러 번 부분 환불 가능)
+CREATE TABLE IF NOT EXISTS payment_refunds (
+    id                VARCHAR(36) PRIMARY KEY,
+    payment_id        VARCHAR(36) NOT NULL,
+    amount            NUMERIC(12, 2) NOT NULL CHECK (amount > 0),
+    reason            TEXT NOT NULL DEFAULT '',
+    gateway_refund_id VARCHAR(100) NOT NULL DEFAULT '',
+    status            VARCHAR(20) NOT NULL,
+    failure_reason    TEXT NOT NULL DEFAULT '',
+    created_at        TIMESTAMPTZ NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_refunds_payment_id ON payment_refunds (payment_id, created_at);
+
+-- 기존 전체 환불은 결제 ID를 환불 ID로 하는 완료된 환불 한 건으로 옮깁니다
+INSERT INTO payment_refunds (id, payment_id, amount, reason, gateway_refund_id, status, created_at, updated_at)
+SELECT id, id, amount, COALESCE(payment_data->>'refund_reason', ''), transaction_id, 'succeeded', updated_at, updated_at
+FROM payments
+WHERE status = 'refunded'
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,5 @@
+-- 한 주문에 여러 결제를 허용 (주문별 결제 목록 조회용 인덱스)
+CREATE INDEX IF NOT EXISTS idx_payments_order_id ON payments (order_id, created_at);
+
+-- 반품 환불이 여러 결제에 나뉘면 환불 참조 번호를 쉼표로 이어 저장합니다
+ALTER TABLE returns ALTER COLUMN refund_reference TYPE TEXT;
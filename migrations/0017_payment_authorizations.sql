@@ -0,0 +1,12 @@
+-- 2단계 결제 (가승인 → 매입/취소)
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS captured_amount NUMERIC(12, 2) NOT NULL DEFAULT 0;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS authorization_expires_at TIMESTAMPTZ;
+-- 만료 가승인 취소 작업이 결제를 선점한 기한 (여러 인스턴스의 중복 처리 방지)
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS void_claimed_until TIMESTAMPTZ;
+
+-- 기존 즉시 결제는 결제 금액 전체가 매입된 것으로 봅니다
+UPDATE payments
+SET captured_amount = amount
+WHERE status IN ('approved', 'partially_refunded', 'refunded') AND captured_amount = 0;
+
+CREATE INDEX IF NOT EXISTS idx_payments_authorization_expires_at ON payments (status, authorization_expires_at);
@@ -0,0 +1,14 @@
+-- Idempotency-Key 헤더로 받은 요청의 지문과 응답 (재시도 시 같은 응답 재전송)
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key          VARCHAR(255) PRIMARY KEY,
+    fingerprint  VARCHAR(64) NOT NULL,
+    status       VARCHAR(20) NOT NULL,
+    status_code  INTEGER NOT NULL DEFAULT 0,
+    header       JSONB NOT NULL DEFAULT '{}',
+    body         BYTEA NOT NULL DEFAULT '',
+    locked_until TIMESTAMPTZ NOT NULL,
+    expires_at   TIMESTAMPTZ NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
@@ -0,0 +1,20 @@
+-- 결제 게이트웨이 웹훅 이벤트 (PG사별 이벤트 ID로 중복 제거, 원본 본문 보관)
+CREATE TABLE IF NOT EXISTS payment_webhook_events (
+    provider      VARCHAR(50) NOT NULL,
+    event_id      VARCHAR(255) NOT NULL,
+    event_type    VARCHAR(100) NOT NULL,
+    payload       BYTEA NOT NULL,
+    status        VARCHAR(20) NOT NULL,
+    attempts      INTEGER NOT NULL DEFAULT 0,
+    last_error    TEXT NOT NULL DEFAULT '',
+    -- 이벤트를 처리 중인 요청이나 재처리 작업이 선점한 기한
+    claimed_until TIMESTAMPTZ,
+    received_at   TIMESTAMPTZ NOT NULL,
+    processed_at  TIMESTAMPTZ,
+    PRIMARY KEY (provider, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_webhook_events_status ON payment_webhook_events (status, received_at);
+
+-- 웹훅 이벤트의 결제를 트랜잭션 ID로 찾습니다
+CREATE INDEX IF NOT EXISTS idx_payments_transaction_id ON payments (transaction_id);
@@ -0,0 +1,8 @@
+-- 결제 처리 중 상태 (게이트웨이 호출 전에 시도 ID를 저장하고, 멈춘 시도는 게이트웨이에 조회해 마무리)
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS attempt_id VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS attempt_kind VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS attempt_started_at TIMESTAMPTZ;
+-- 처리 중 결제 마무리 작업이 결제를 선점한 기한 (여러 인스턴스의 중복 처리 방지)
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS attempt_claimed_until TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_payments_attempt_started_at ON payments (status, attempt_started_at);
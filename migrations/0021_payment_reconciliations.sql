@@ -0,0 +1,36 @@
+-- 게이트웨이 정산 파일 대사 결과 (건수 요약)
+CREATE TABLE IF NOT EXISTS payment_reconciliations (
+    id                    VARCHAR(36) PRIMARY KEY,
+    source                VARCHAR(255) NOT NULL DEFAULT '',
+    -- 정산 파일이 다루는 결제 생성 기간 [period_start, period_end)
+    period_start          TIMESTAMPTZ NOT NULL,
+    period_end            TIMESTAMPTZ NOT NULL,
+    row_count             INTEGER NOT NULL DEFAULT 0,
+    matched_count         INTEGER NOT NULL DEFAULT 0,
+    missing_count         INTEGER NOT NULL DEFAULT 0,
+    extra_count           INTEGER NOT NULL DEFAULT 0,
+    amount_mismatch_count INTEGER NOT NULL DEFAULT 0,
+    status_mismatch_count INTEGER NOT NULL DEFAULT 0,
+    invalid_count         INTEGER NOT NULL DEFAULT 0,
+    created_at            TIMESTAMPTZ NOT NULL
+);
+
+-- 대사에서 발견한 불일치 (missing, extra, amount_mismatch, status_mismatch, invalid_row)
+CREATE TABLE IF NOT EXISTS payment_reconciliation_exceptions (
+    reconciliation_id VARCHAR(36) NOT NULL REFERENCES payment_reconciliations (id) ON DELETE CASCADE,
+    seq               INTEGER NOT NULL,
+    type              VARCHAR(20) NOT NULL,
+    -- 정산 파일의 줄 번호 (누락된 결제는 0)
+    line              INTEGER NOT NULL DEFAULT 0,
+    transaction_id    VARCHAR(255) NOT NULL DEFAULT '',
+    payment_id        VARCHAR(36) NOT NULL DEFAULT '',
+    expected_amount   NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    actual_amount     NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    expected_status   VARCHAR(20) NOT NULL DEFAULT '',
+    actual_status     VARCHAR(50) NOT NULL DEFAULT '',
+    message           TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (reconciliation_id, seq)
+);
+
+-- 기간별 청구된 결제 조회 (누락 대사)
+CREATE INDEX IF NOT EXISTS idx_payments_created_at ON payments (created_at);
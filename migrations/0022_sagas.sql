@@ -0,0 +1,32 @@
+-- 결제 모듈과 주문 모듈의 결과를 맞추는 사가 (주문과 종류마다 하나)
+CREATE TABLE IF NOT EXISTS sagas (
+    id              VARCHAR(36) PRIMARY KEY,
+    kind            VARCHAR(30) NOT NULL,
+    order_id        VARCHAR(36) NOT NULL,
+    reason          TEXT NOT NULL DEFAULT '',
+    status          VARCHAR(20) NOT NULL,
+    last_error      TEXT NOT NULL DEFAULT '',
+    -- 실패한 단계를 다시 실행할 시간
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    -- 사가를 진행하는 인스턴스가 선점한 기한 (여러 인스턴스의 중복 진행 방지)
+    claimed_until   TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL,
+    UNIQUE (order_id, kind)
+);
+
+-- 사가 단계별 진행 기록 (정방향 단계 다음에 보상 단계)
+CREATE TABLE IF NOT EXISTS saga_steps (
+    saga_id      VARCHAR(36) NOT NULL REFERENCES sagas (id) ON DELETE CASCADE,
+    seq          INTEGER NOT NULL,
+    name         VARCHAR(50) NOT NULL,
+    compensation BOOLEAN NOT NULL DEFAULT FALSE,
+    status       VARCHAR(20) NOT NULL,
+    attempts     INTEGER NOT NULL DEFAULT 0,
+    last_error   TEXT NOT NULL DEFAULT '',
+    updated_at   TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (saga_id, seq)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sagas_status_next_attempt_at ON sagas (status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_sagas_status_updated_at ON sagas (status, updated_at);
@@ -0,0 +1,2 @@
+-- 주문 상태를 동시에 바꾸는 요청(결제 사가, 만료 처리, 배송 등)이 서로 덮어쓰지 않도록 하는 낙관적 잠금 버전
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 0;
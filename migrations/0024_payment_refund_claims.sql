@@ -0,0 +1,4 @@
+-- 진행 중 환불 마무리 작업이 환불을 선점한 기한 (여러 인스턴스의 중복 처리 방지)
+ALTER TABLE payment_refunds ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_payment_refunds_status_created_at ON payment_refunds (status, created_at);
@@ -0,0 +1,2 @@
+-- 주문의 할인 전 배송비 (무료 배송 쿠폰 할인은 order_discounts에 기록되며 총액에는 할인 후 배송비가 포함됨)
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_fee NUMERIC(12, 2) NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- 처리 권한을 얻은 요청의 토큰 (잠금이 풀린 뒤 늦게 끝난 요청이 다음 요청의 기록을 완료하거나 지우지 않도록 함)
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS token VARCHAR(36) NOT NULL DEFAULT '';
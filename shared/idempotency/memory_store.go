@@ -0,0 +1,89 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// MemoryStore는 메모리에 멱등성 기록을 저장하는 Store 구현체입니다.
+// 인스턴스 사이에 기록을 공유하지 않으므로 단일 인스턴스나 테스트에서만 사용합니다.
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]*Record
+}
+
+// NewMemoryStore는 새로운 MemoryStore 인스턴스를 생성합니다.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: make(map[string]*Record),
+	}
+}
+
+// Acquire는 key에 대한 처리 권한을 얻습니다.
+func (s *MemoryStore) Acquire(ctx context.Context, record *Record, now time.Time) (*Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.records[record.Key]
+	if ok && !acquirable(existing, now) {
+		copied := *existing
+		return &copied, false, nil
+	}
+
+	copied := *record
+	s.records[record.Key] = &copied
+	return record, true, nil
+}
+
+// Complete는 token으로 처리 권한을 얻은 기록에 응답을 저장합니다.
+func (s *MemoryStore) Complete(ctx context.Context, key, token string, statusCode int, header http.Header, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok || record.Status != StatusInProgress || record.Token != token {
+		return ErrRecordNotFound
+	}
+	record.Status = StatusCompleted
+	record.StatusCode = statusCode
+	record.Header = header.Clone()
+	record.Body = append([]byte(nil), body...)
+	return nil
+}
+
+// Release는 token으로 처리 권한을 얻은 기록을 지웁니다.
+func (s *MemoryStore) Release(ctx context.Context, key, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[key]; ok && record.Status == StatusInProgress && record.Token == token {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+// DeleteExpired는 before 이전에 만료된 기록을 지웁니다.
+func (s *MemoryStore) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for key, record := range s.records {
+		if record.ExpiresAt.Before(before) {
+			delete(s.records, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// acquirable은 기존 기록을 새 요청이 덮어쓸 수 있는지 확인합니다.
+// 만료된 기록과, 처리 중에 인스턴스가 종료되어 잠금이 풀린 기록은 덮어쓸 수 있습니다.
+func acquirable(record *Record, now time.Time) bool {
+	if !record.ExpiresAt.After(now) {
+		return true
+	}
+	return record.Status == StatusInProgress && !record.LockedUntil.After(now)
+}
@@ -0,0 +1,195 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// HeaderKey는 클라이언트가 재시도해도 같은 값을 보내는 멱등성 키 헤더입니다.
+	HeaderKey = "Idempotency-Key"
+	// HeaderReplayed는 저장된 응답을 재전송했음을 알리는 응답 헤더입니다.
+	HeaderReplayed = "Idempotent-Replayed"
+
+	// maxKeyLength는 허용하는 멱등성 키의 최대 길이입니다.
+	maxKeyLength = 255
+)
+
+// Config는 멱등성 미들웨어 설정을 정의합니다.
+type Config struct {
+	Store Store
+	// TTL은 응답을 저장해 두는 기간입니다. 이 기간이 지나면 같은 키를 새 요청으로 처리합니다.
+	TTL time.Duration
+	// LockTimeout은 처리 중인 요청이 키를 잠그는 최대 시간입니다.
+	// 처리 도중 인스턴스가 종료되면 이 시간이 지난 뒤 같은 키로 다시 요청할 수 있으므로 가장 오래 걸리는 핸들러보다 길어야 합니다.
+	LockTimeout time.Duration
+	// Scope는 요청의 호출자를 구별하는 값을 반환합니다. 키는 호출자별로 따로 저장되므로 다른 호출자가 같은 키를 보내도
+	// 서로의 응답을 받지 않습니다. nil이면 Authorization 헤더를, 헤더가 없으면 클라이언트 IP를 사용합니다.
+	Scope func(c echo.Context) string
+	// OnError는 저장소 오류를 기록하는 함수입니다. nil이면 기록하지 않습니다.
+	OnError func(err error, key string)
+}
+
+// Middleware는 Idempotency-Key 헤더가 있는 요청의 응답을 저장해 두고, 같은 키로 다시 온 요청에 저장된 응답을 재전송합니다.
+//   - 같은 키에 메서드, 경로, 본문이 다른 요청이 오면 422를 반환합니다.
+//   - 같은 키의 요청이 아직 처리 중이면 409를 반환합니다.
+//   - 5xx 응답이나 핸들러 오류는 저장하지 않으므로 같은 키로 다시 시도할 수 있습니다.
+//   - 키는 호출자(Scope)별로 저장되므로 다른 호출자의 같은 키와 섞이지 않습니다.
+//
+// 헤더가 없는 요청은 그대로 처리합니다.
+func Middleware(config Config) echo.MiddlewareFunc {
+	if config.TTL <= 0 {
+		config.TTL = 24 * time.Hour
+	}
+	if config.LockTimeout <= 0 {
+		config.LockTimeout = time.Minute
+	}
+	if config.Scope == nil {
+		config.Scope = callerScope
+	}
+	onError := config.OnError
+	if onError == nil {
+		onError = func(error, string) {}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderKey)
+			if key == "" {
+				return next(c)
+			}
+			if len(key) > maxKeyLength {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "Idempotency-Key is too long"})
+			}
+
+			// 요청 본문을 읽어 지문을 만들고 핸들러가 다시 읽을 수 있게 되돌립니다
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := c.Request().Context()
+			storeKey := scopedKey(config.Scope(c), key)
+			token := uuid.New().String()
+			requestFingerprint := fingerprint(c.Request().Method, c.Request().URL.Path, body)
+			now := time.Now()
+			record, acquired, err := config.Store.Acquire(ctx, &Record{
+				Key:         storeKey,
+				Token:       token,
+				Fingerprint: requestFingerprint,
+				Status:      StatusInProgress,
+				LockedUntil: now.Add(config.LockTimeout),
+				ExpiresAt:   now.Add(config.TTL),
+			}, now)
+			if err != nil {
+				onError(err, key)
+				return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to check Idempotency-Key"})
+			}
+
+			if !acquired {
+				switch {
+				case record.Fingerprint != requestFingerprint:
+					return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": "Idempotency-Key was already used for a different request"})
+				case record.Status == StatusInProgress:
+					return c.JSON(http.StatusConflict, map[string]string{"error": "A request with this Idempotency-Key is still in progress"})
+				default:
+					return replay(c, record)
+				}
+			}
+
+			// 응답을 기록하면서 핸들러 실행
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+			handlerErr := next(c)
+			c.Response().Writer = recorder.ResponseWriter
+
+			// 클라이언트가 연결을 끊어도 결과는 저장합니다
+			ctx = context.WithoutCancel(ctx)
+			status := c.Response().Status
+			if handlerErr != nil || !c.Response().Committed || status >= http.StatusInternalServerError {
+				if err := config.Store.Release(ctx, storeKey, token); err != nil {
+					onError(err, key)
+				}
+				return handlerErr
+			}
+
+			if err := config.Store.Complete(ctx, storeKey, token, status, storedHeader(c.Response().Header()), recorder.body.Bytes()); err != nil {
+				onError(err, key)
+			}
+			return nil
+		}
+	}
+}
+
+// callerScope는 Authorization 헤더로, 헤더가 없으면 클라이언트 IP로 호출자를 구별합니다.
+func callerScope(c echo.Context) string {
+	if authorization := c.Request().Header.Get(echo.HeaderAuthorization); authorization != "" {
+		return "auth:" + authorization
+	}
+	return "ip:" + c.RealIP()
+}
+
+// scopedKey는 호출자 범위와 멱등성 키로 저장 키를 만듭니다.
+// 자격 증명이 저장소에 남지 않고 길이가 일정하도록 해시로 저장합니다.
+func scopedKey(scope, key string) string {
+	hash := sha256.New()
+	hash.Write([]byte(scope))
+	hash.Write([]byte{0})
+	hash.Write([]byte(key))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// fingerprint는 메서드, 경로, 본문으로 요청 지문을 만듭니다.
+func fingerprint(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// replay는 저장된 응답을 그대로 재전송합니다.
+func replay(c echo.Context, record *Record) error {
+	for name, values := range record.Header {
+		for _, value := range values {
+			c.Response().Header().Add(name, value)
+		}
+	}
+	c.Response().Header().Set(HeaderReplayed, "true")
+	c.Response().WriteHeader(record.StatusCode)
+	_, err := c.Response().Write(record.Body)
+	return err
+}
+
+// storedHeader는 재전송할 응답 헤더만 골라냅니다. 요청마다 달라지는 헤더(요청 ID 등)는 저장하지 않습니다.
+func storedHeader(header http.Header) http.Header {
+	stored := http.Header{}
+	for _, name := range []string{echo.HeaderContentType, echo.HeaderLocation} {
+		if value := header.Get(name); value != "" {
+			stored.Set(name, value)
+		}
+	}
+	return stored
+}
+
+// responseRecorder는 클라이언트에 쓰는 응답 본문을 함께 기록합니다.
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,145 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestMiddlewareReplaysResponsesAndRejectsReusedKeys(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	status := http.StatusCreated
+
+	e := echo.New()
+	e.POST("/payments", func(c echo.Context) error {
+		n := atomic.AddInt32(&calls, 1)
+		if c.Request().Header.Get("X-Block") != "" {
+			<-release
+		}
+		return c.JSON(status, map[string]interface{}{"call": n})
+	}, Middleware(Config{Store: NewMemoryStore(), TTL: time.Hour}))
+
+	send := func(key, body string, header ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/payments", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if key != "" {
+			req.Header.Set(HeaderKey, key)
+		}
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// 같은 키의 재시도는 핸들러를 다시 실행하지 않고 저장된 응답을 재전송합니다
+	first := send("key-1", `{"amount":1000}`)
+	retry := send("key-1", `{"amount":1000}`)
+	if first.Code != http.StatusCreated || retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Errorf("retry = %d %q, want %d %q", retry.Code, retry.Body.String(), first.Code, first.Body.String())
+	}
+	if retry.Header().Get(HeaderReplayed) != "true" || retry.Header().Get(echo.HeaderContentType) != first.Header().Get(echo.HeaderContentType) {
+		t.Errorf("retry headers = %v, want replayed JSON", retry.Header())
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+
+	// 같은 키를 다른 본문에 다시 쓰면 거절합니다
+	if rec := send("key-1", `{"amount":2000}`); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("reused key status = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+
+	// 키가 없는 요청과 5xx 응답은 저장하지 않습니다
+	send("", `{"amount":1000}`)
+	status = http.StatusBadGateway
+	send("key-2", `{"amount":1000}`)
+	status = http.StatusCreated
+	if rec := send("key-2", `{"amount":1000}`); rec.Code != http.StatusCreated || rec.Header().Get(HeaderReplayed) != "" {
+		t.Errorf("retry after 5xx = %d, replayed %q, want %d, not replayed", rec.Code, rec.Header().Get(HeaderReplayed), http.StatusCreated)
+	}
+	if atomic.LoadInt32(&calls) != 4 {
+		t.Errorf("calls = %d, want 4", calls)
+	}
+
+	// 처리 중인 키로 동시에 들어온 요청은 409를 받습니다
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- send("key-3", `{}`, "X-Block", "1") }()
+	for atomic.LoadInt32(&calls) != 5 {
+		time.Sleep(time.Millisecond)
+	}
+	if rec := send("key-3", `{}`, "X-Block", "1"); rec.Code != http.StatusConflict {
+		t.Errorf("concurrent duplicate status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	close(release)
+	if rec := <-done; rec.Code != http.StatusCreated {
+		t.Errorf("blocked request status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+}
+
+func TestMiddlewareScopesKeysToCaller(t *testing.T) {
+	var calls int32
+	e := echo.New()
+	e.POST("/payments", func(c echo.Context) error {
+		n := atomic.AddInt32(&calls, 1)
+		return c.JSON(http.StatusCreated, map[string]interface{}{"call": n})
+	}, Middleware(Config{Store: NewMemoryStore(), TTL: time.Hour}))
+
+	send := func(authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/payments", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, authorization)
+		req.Header.Set(HeaderKey, "key-1")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// 다른 호출자가 같은 키를 보내도 서로의 응답을 재전송하지 않습니다
+	first := send("Bearer client-a")
+	other := send("Bearer client-b")
+	if other.Header().Get(HeaderReplayed) != "" || other.Body.String() == first.Body.String() {
+		t.Errorf("other caller = %q replayed %q, want a new response", other.Body.String(), other.Header().Get(HeaderReplayed))
+	}
+	if retry := send("Bearer client-a"); retry.Header().Get(HeaderReplayed) != "true" || retry.Body.String() != first.Body.String() {
+		t.Errorf("retry = %q replayed %q, want %q replayed", retry.Body.String(), retry.Header().Get(HeaderReplayed), first.Body.String())
+	}
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestMemoryStoreIgnoresRequestsThatLostTheLock(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	now := time.Now()
+
+	if _, acquired, _ := store.Acquire(ctx, &Record{Key: "key", Token: "stale", Status: StatusInProgress, LockedUntil: now, ExpiresAt: now.Add(time.Hour)}, now); !acquired {
+		t.Fatal("first Acquire() did not acquire the key")
+	}
+	// 잠금이 풀린 뒤 다른 요청이 키를 가져갑니다
+	later := now.Add(time.Second)
+	if _, acquired, _ := store.Acquire(ctx, &Record{Key: "key", Token: "owner", Status: StatusInProgress, LockedUntil: later.Add(time.Minute), ExpiresAt: later.Add(time.Hour)}, later); !acquired {
+		t.Fatal("Acquire() after the lock expired did not acquire the key")
+	}
+
+	// 늦게 끝난 요청은 새 요청의 기록을 지우거나 완료하지 못합니다
+	if err := store.Release(ctx, "key", "stale"); err != nil {
+		t.Fatalf("Release(stale) error = %v", err)
+	}
+	if err := store.Complete(ctx, "key", "stale", http.StatusCreated, http.Header{}, []byte(`{"stale":true}`)); !errors.Is(err, ErrRecordNotFound) {
+		t.Errorf("Complete(stale) error = %v, want %v", err, ErrRecordNotFound)
+	}
+	if err := store.Complete(ctx, "key", "owner", http.StatusCreated, http.Header{}, []byte(`{}`)); err != nil {
+		t.Errorf("Complete(owner) error = %v", err)
+	}
+}
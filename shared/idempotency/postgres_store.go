@@ -0,0 +1,152 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"example.com/myapp/shared/db"
+	"github.com/jackc/pgx/v4"
+)
+
+// acquireAttempts는 기존 기록을 읽는 사이에 기록이 지워졌을 때 Acquire를 다시 시도하는 횟수입니다.
+const acquireAttempts = 3
+
+// PostgresStore는 PostgreSQL에 멱등성 기록을 저장하는 Store 구현체입니다.
+type PostgresStore struct {
+	db *db.Database
+}
+
+// NewPostgresStore는 새로운 PostgresStore 인스턴스를 생성합니다.
+func NewPostgresStore(database *db.Database) *PostgresStore {
+	return &PostgresStore{
+		db: database,
+	}
+}
+
+// Acquire는 key에 대한 처리 권한을 얻습니다.
+// INSERT ... ON CONFLICT 한 문장으로 기록을 만들거나 덮어쓰므로, 같은 키로 동시에 들어온 요청 중 하나만 권한을 얻습니다.
+func (s *PostgresStore) Acquire(ctx context.Context, record *Record, now time.Time) (*Record, bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (key, token, fingerprint, status, status_code, header, body, locked_until, expires_at, created_at)
+		VALUES ($1, $7, $2, $3, 0, '{}', '', $4, $5, $6)
+		ON CONFLICT (key) DO UPDATE
+		SET token = EXCLUDED.token, fingerprint = EXCLUDED.fingerprint, status = EXCLUDED.status, status_code = 0, header = '{}', body = '',
+			locked_until = EXCLUDED.locked_until, expires_at = EXCLUDED.expires_at, created_at = EXCLUDED.created_at
+		WHERE idempotency_keys.expires_at <= $6
+			OR (idempotency_keys.status = $3 AND idempotency_keys.locked_until <= $6)
+		RETURNING key
+	`
+
+	for attempt := 0; attempt < acquireAttempts; attempt++ {
+		var key string
+		err := s.db.Pool.QueryRow(
+			ctx,
+			query,
+			record.Key,
+			record.Fingerprint,
+			string(StatusInProgress),
+			record.LockedUntil,
+			record.ExpiresAt,
+			now,
+			record.Token,
+		).Scan(&key)
+		if err == nil {
+			return record, true, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, fmt.Errorf("failed to acquire idempotency key: %w", err)
+		}
+
+		// 다른 요청이 가진 기록 조회 (그사이 지워졌으면 다시 시도)
+		existing, err := s.find(ctx, record.Key)
+		if errors.Is(err, ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return existing, false, nil
+	}
+
+	return nil, false, fmt.Errorf("failed to acquire idempotency key %s: record kept changing", record.Key)
+}
+
+// find는 key로 기록을 조회합니다.
+func (s *PostgresStore) find(ctx context.Context, key string) (*Record, error) {
+	query := `
+		SELECT fingerprint, status, status_code, header, body, locked_until, expires_at
+		FROM idempotency_keys
+		WHERE key = $1
+	`
+
+	record := &Record{Key: key}
+	var status string
+	var headerJSON []byte
+	err := s.db.Pool.QueryRow(ctx, query, key).Scan(
+		&record.Fingerprint,
+		&status,
+		&record.StatusCode,
+		&headerJSON,
+		&record.Body,
+		&record.LockedUntil,
+		&record.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to find idempotency key: %w", err)
+	}
+
+	record.Status = Status(status)
+	if err := json.Unmarshal(headerJSON, &record.Header); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal idempotent response header: %w", err)
+	}
+	return record, nil
+}
+
+// Complete는 token으로 처리 권한을 얻은 기록에 응답을 저장합니다.
+// 잠금이 풀려 다른 요청이 같은 키를 가져갔으면 토큰이 달라 그 요청의 기록을 덮어쓰지 않습니다.
+func (s *PostgresStore) Complete(ctx context.Context, key, token string, statusCode int, header http.Header, body []byte) error {
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("failed to marshal idempotent response header: %w", err)
+	}
+
+	query := `
+		UPDATE idempotency_keys
+		SET status = $1, status_code = $2, header = $3, body = $4
+		WHERE key = $5 AND status = $6 AND token = $7
+	`
+
+	tag, err := s.db.Pool.Exec(ctx, query, string(StatusCompleted), statusCode, headerJSON, body, key, string(StatusInProgress), token)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// Release는 token으로 처리 권한을 얻은 기록을 지웁니다.
+func (s *PostgresStore) Release(ctx context.Context, key, token string) error {
+	_, err := s.db.Pool.Exec(ctx, "DELETE FROM idempotency_keys WHERE key = $1 AND status = $2 AND token = $3", key, string(StatusInProgress), token)
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired는 before 이전에 만료된 기록을 지웁니다.
+func (s *PostgresStore) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	tag, err := s.db.Pool.Exec(ctx, "DELETE FROM idempotency_keys WHERE expires_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
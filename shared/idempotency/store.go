@@ -0,0 +1,51 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrRecordNotFound = errors.New("idempotency record not found")
+)
+
+// Status는 멱등성 키로 받은 요청의 처리 상태를 정의합니다.
+type Status string
+
+const (
+	// StatusInProgress는 요청을 처리 중인 상태이며, LockedUntil까지 같은 키의 다른 요청을 막습니다.
+	StatusInProgress Status = "in_progress"
+	// StatusCompleted는 응답이 저장되어 같은 키의 요청에 그대로 재전송하는 상태입니다.
+	StatusCompleted Status = "completed"
+)
+
+// Record는 Idempotency-Key 하나에 대해 저장된 요청 지문과 응답입니다.
+// Key는 호출자 범위가 붙은 저장 키이며, Token은 처리 권한을 얻은 요청을 구별합니다.
+type Record struct {
+	Key         string
+	Token       string
+	Fingerprint string
+	Status      Status
+	StatusCode  int
+	Header      http.Header
+	Body        []byte
+	LockedUntil time.Time
+	ExpiresAt   time.Time
+}
+
+// Store는 멱등성 기록 저장소를 정의합니다.
+type Store interface {
+	// Acquire는 key에 대한 처리 권한을 얻습니다. 기록이 없거나 만료되었거나, 처리 중이던 요청의 잠금이 풀렸으면
+	// record로 기록을 만들고 (record, true)를 반환합니다. 그 밖에는 기존 기록과 false를 반환합니다.
+	Acquire(ctx context.Context, record *Record, now time.Time) (*Record, bool, error)
+	// Complete는 token으로 처리 권한을 얻은 기록에 응답을 저장합니다.
+	// 잠금이 풀려 다른 요청이 권한을 가져갔으면 기록을 바꾸지 않고 ErrRecordNotFound를 반환합니다.
+	Complete(ctx context.Context, key, token string, statusCode int, header http.Header, body []byte) error
+	// Release는 token으로 처리 권한을 얻은 기록을 지워 같은 키로 다시 요청할 수 있게 합니다.
+	// 다른 요청이 권한을 가져간 기록은 지우지 않습니다.
+	Release(ctx context.Context, key, token string) error
+	// DeleteExpired는 before 이전에 만료된 기록을 지우고 지운 건수를 반환합니다.
+	DeleteExpired(ctx context.Context, before time.Time) (int, error)
+}